@@ -18,14 +18,18 @@
 package cmd
 
 import (
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
 )
 
 var adminAccesskeyListFlags = []cli.Flag{
@@ -49,6 +53,10 @@ var adminAccesskeyListFlags = []cli.Flag{
 		Name:  "all",
 		Usage: "list all access keys for all builtin users",
 	},
+	cli.BoolFlag{
+		Name:  "table",
+		Usage: "print one access key per row",
+	},
 }
 
 var adminAccesskeyListCmd = cli.Command{
@@ -57,7 +65,7 @@ var adminAccesskeyListCmd = cli.Command{
 	Usage:        "list access key pairs for builtin users",
 	Action:       mainAdminAccesskeyList,
 	Before:       setGlobalsFromContext,
-	Flags:        append(adminAccesskeyListFlags, globalFlags...),
+	Flags:        append(append(adminAccesskeyListFlags, accesskeyExpiryFlags...), globalFlags...),
 	OnUsageError: onUsageError,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -89,6 +97,12 @@ EXAMPLES:
 
   7. Get all users and access keys if admin, else get authenticated user and associated access keys
 	 {{.Prompt}} {{.HelpName}} local/
+
+  8. Get a table of all service accounts with their parent user, expiration and policy
+	 {{.Prompt}} {{.HelpName}} local/ --all --svcacc-only --table
+
+  9. Get a table of all access keys which expire within the next 30 days or never expire
+	 {{.Prompt}} {{.HelpName}} local/ --all --table --expiring-within 30d --never-expires
 `,
 }
 
@@ -139,6 +153,7 @@ func (m userAccesskeyList) JSON() string {
 
 func mainAdminAccesskeyList(ctx *cli.Context) error {
 	aliasedURL, tentativeAll, users, opts := commonAccesskeyList(ctx)
+	expiry := parseAccesskeyExpiryFilter(ctx, opts)
 
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
@@ -154,15 +169,132 @@ func mainAdminAccesskeyList(ctx *cli.Context) error {
 		fatalIf(probe.NewError(e), "Unable to list access keys.")
 	}
 
+	lists := make([]userAccesskeyList, 0, len(accessKeysMap))
 	for user, accessKeys := range accessKeysMap {
-		m := userAccesskeyList{
+		lists = append(lists, userAccesskeyList{
 			Status:          "success",
 			User:            user,
 			ServiceAccounts: accessKeys.ServiceAccounts,
 			STSKeys:         accessKeys.STSKeys,
 			LDAP:            false,
-		}
-		printMsg(m)
+		})
 	}
+	printAccesskeyLists(lists, expiry, ctx.Bool("table"))
 	return nil
 }
+
+// accesskeyRowMessage is an access key printed as a table row.
+type accesskeyRowMessage struct {
+	Status        string     `json:"status"`
+	AccessKey     string     `json:"accessKey"`
+	ParentUser    string     `json:"parentUser"`
+	STS           bool       `json:"sts"`
+	AccountStatus string     `json:"accountStatus,omitempty"`
+	ImpliedPolicy bool       `json:"impliedPolicy"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+}
+
+// Length of the columns of the access key table.
+const (
+	accesskeyTypeMaxLen   = 6
+	accesskeyStatusMaxLen = 8
+	accesskeyPolicyMaxLen = 8
+	accesskeyExpiryMaxLen = 16
+)
+
+func newAccesskeyTable(header bool) PrettyTable {
+	if header {
+		return newPrettyTable(" | ",
+			Field{"AccessKeyHeader", accessFieldMaxLen},
+			Field{"Headers", accesskeyTypeMaxLen},
+			Field{"Headers", accesskeyStatusMaxLen},
+			Field{"Headers", accesskeyPolicyMaxLen},
+			Field{"ExpirationHeader", accesskeyExpiryMaxLen},
+			Field{"Headers", -1},
+		)
+	}
+	return newPrettyTable(" | ",
+		Field{"AccessKey", accessFieldMaxLen},
+		Field{"", accesskeyTypeMaxLen},
+		Field{"", accesskeyStatusMaxLen},
+		Field{"", accesskeyPolicyMaxLen},
+		Field{"Expiration", accesskeyExpiryMaxLen},
+		Field{"", -1},
+	)
+}
+
+func (m accesskeyRowMessage) String() string {
+	keyType := "svcacc"
+	if m.STS {
+		keyType = "sts"
+	}
+	policy := "embedded"
+	if m.ImpliedPolicy {
+		policy = "implied"
+	}
+	expiration := "never"
+	if m.Expiration != nil {
+		expiration = humanize.Time(*m.Expiration)
+	}
+	return newAccesskeyTable(false).buildRow(m.AccessKey, keyType, m.AccountStatus, policy, expiration, m.ParentUser)
+}
+
+func (m accesskeyRowMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// printAccesskeyLists prints the access keys of users sorted by user,
+// grouped by user or as a table with one access key per row.
+func printAccesskeyLists(lists []userAccesskeyList, expiry accesskeyExpiryFilter, table bool) {
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].User < lists[j].User
+	})
+
+	if table && !globalJSON {
+		console.SetColor("AccessKeyHeader", color.New(color.Bold, color.FgBlue))
+		console.SetColor("ExpirationHeader", color.New(color.Bold, color.FgCyan))
+		console.SetColor("AccessKey", color.New(color.FgBlue))
+		console.SetColor("Expiration", color.New(color.FgCyan))
+	}
+
+	now := time.Now()
+	var rows int
+	for _, m := range lists {
+		if expiry.isSet() {
+			m.ServiceAccounts = expiry.filter(m.ServiceAccounts, now)
+			m.STSKeys = expiry.filter(m.STSKeys, now)
+			if len(m.ServiceAccounts) == 0 && len(m.STSKeys) == 0 {
+				continue
+			}
+		}
+		if !table {
+			printMsg(m)
+			continue
+		}
+		keys := make([]madmin.ServiceAccountInfo, 0, len(m.STSKeys)+len(m.ServiceAccounts))
+		keys = append(keys, m.STSKeys...)
+		keys = append(keys, m.ServiceAccounts...)
+		for i, k := range keys {
+			if rows == 0 && !globalJSON {
+				console.Println(console.Colorize("Headers", newAccesskeyTable(true).buildRow(
+					"Access Key", "Type", "Status", "Policy", "Expiry", "User")))
+			}
+			rows++
+			printMsg(accesskeyRowMessage{
+				Status:        "success",
+				AccessKey:     k.AccessKey,
+				ParentUser:    m.User,
+				STS:           i < len(m.STSKeys),
+				AccountStatus: k.AccountStatus,
+				ImpliedPolicy: k.ImpliedPolicy,
+				Expiration:    nilExpiry(k.Expiration),
+			})
+		}
+	}
+	if table && rows == 0 && !globalJSON {
+		console.Println("No access keys found")
+	}
+}
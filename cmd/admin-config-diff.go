@@ -0,0 +1,201 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminConfigDiffCmd = cli.Command{
+	Name:         "diff",
+	Usage:        "show the config keys changed by importing a config file",
+	Before:       setGlobalsFromContext,
+	Action:       mainAdminConfigDiff,
+	OnUsageError: onUsageError,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET FILE
+
+FILE:
+  Config file in the format of 'mc admin config export', use '-' to read from STDIN.
+  Keys only present on the server are left unchanged by an import, they are not shown.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the config changes of 'config.txt' exported from a staging cluster compared to the production cluster.
+     {{.Prompt}} mc admin config export staging/ > config.txt
+     {{.Prompt}} {{.HelpName}} production/ config.txt
+`,
+}
+
+// configDiffEntry is a config key changed or added by a config file.
+type configDiffEntry struct {
+	SubSystem string `json:"subSystem"`
+	Target    string `json:"target,omitempty"`
+	Key       string `json:"key"`
+	OldValue  string `json:"oldValue,omitempty"`
+	NewValue  string `json:"newValue"`
+	Added     bool   `json:"added,omitempty"`
+}
+
+func (d configDiffEntry) subSystem() string {
+	if d.Target != "" {
+		return d.SubSystem + madmin.SubSystemSeparator + d.Target
+	}
+	return d.SubSystem
+}
+
+// configDiffMessage container for the config keys changed by a config file.
+type configDiffMessage struct {
+	Status  string            `json:"status"`
+	Changes []configDiffEntry `json:"changes"`
+	DryRun  bool              `json:"dryRun,omitempty"`
+}
+
+// String colorized config diff message.
+func (u configDiffMessage) String() string {
+	if len(u.Changes) == 0 {
+		return console.Colorize("ConfigDiffNone", "No config changes.")
+	}
+	lines := make([]string, 0, len(u.Changes))
+	for _, d := range u.Changes {
+		if d.Added {
+			lines = append(lines, console.Colorize("ConfigDiffAdded",
+				fmt.Sprintf("+ %s %s=%s", d.subSystem(), d.Key, d.NewValue)))
+			continue
+		}
+		lines = append(lines, console.Colorize("ConfigDiffChanged",
+			fmt.Sprintf("~ %s %s=%s (was %s)", d.subSystem(), d.Key, d.NewValue, d.OldValue)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified config diff message.
+func (u configDiffMessage) JSON() string {
+	u.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statusJSONBytes)
+}
+
+// diffServerConfig returns the keys of the desired config which are
+// missing from or have a different value in the current config.
+func diffServerConfig(current, desired []madmin.SubsysConfig) (changes []configDiffEntry) {
+	currentKVs := make(map[string]map[string]string, len(current))
+	for _, c := range current {
+		kvs := make(map[string]string, len(c.KV))
+		for _, kv := range c.KV {
+			kvs[kv.Key] = kv.Value
+		}
+		currentKVs[c.SubSystem+madmin.SubSystemSeparator+c.Target] = kvs
+	}
+	for _, c := range desired {
+		kvs := currentKVs[c.SubSystem+madmin.SubSystemSeparator+c.Target]
+		for _, kv := range c.KV {
+			oldValue, ok := kvs[kv.Key]
+			if ok && oldValue == kv.Value {
+				continue
+			}
+			changes = append(changes, configDiffEntry{
+				SubSystem: c.SubSystem,
+				Target:    c.Target,
+				Key:       kv.Key,
+				OldValue:  oldValue,
+				NewValue:  kv.Value,
+				Added:     !ok,
+			})
+		}
+	}
+	return changes
+}
+
+// configDiff parses the config file and returns its changes
+// compared to the current server config.
+func configDiff(client *madmin.AdminClient, config []byte) ([]configDiffEntry, *probe.Error) {
+	desired, e := madmin.ParseServerConfigOutput(string(config))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	buf, e := client.GetConfig(globalContext)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	current, e := madmin.ParseServerConfigOutput(string(buf))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return diffServerConfig(current, desired), nil
+}
+
+func setConfigDiffColors() {
+	console.SetColor("ConfigDiffNone", color.New(color.FgGreen))
+	console.SetColor("ConfigDiffAdded", color.New(color.FgGreen))
+	console.SetColor("ConfigDiffChanged", color.New(color.FgYellow))
+}
+
+// checkAdminConfigDiffSyntax - validate all the passed arguments
+func checkAdminConfigDiffSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+func mainAdminConfigDiff(ctx *cli.Context) error {
+	checkAdminConfigDiffSyntax(ctx)
+
+	setConfigDiffColors()
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	file := args.Get(1)
+
+	var config []byte
+	var e error
+	if file == "-" {
+		config, e = io.ReadAll(os.Stdin)
+	} else {
+		config, e = os.ReadFile(file)
+	}
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read the config file.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	changes, err := configDiff(client, config)
+	fatalIf(err.Trace(args...), "Unable to compare the server config")
+
+	printMsg(configDiffMessage{Changes: changes})
+	return nil
+}
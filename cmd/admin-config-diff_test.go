@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestDiffServerConfig(t *testing.T) {
+	current, e := madmin.ParseServerConfigOutput(`site name=us-east region=us-east-1
+# MINIO_API_REQUESTS_MAX=1000
+api requests_max=0 cors_allow_origin=*
+notify_webhook:1 endpoint=http://localhost:8080 queue_limit=0`)
+	if e != nil {
+		t.Fatal(e)
+	}
+	desired, e := madmin.ParseServerConfigOutput(`site name=us-east region=us-west-1
+api requests_max=0 cors_allow_origin=*
+notify_webhook:1 endpoint=http://localhost:9090 queue_limit=0
+notify_webhook:2 endpoint=http://localhost:8080`)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	expected := []configDiffEntry{
+		{SubSystem: "site", Key: "region", OldValue: "us-east-1", NewValue: "us-west-1"},
+		{SubSystem: "notify_webhook", Target: "1", Key: "endpoint", OldValue: "http://localhost:8080", NewValue: "http://localhost:9090"},
+		{SubSystem: "notify_webhook", Target: "2", Key: "endpoint", NewValue: "http://localhost:8080", Added: true},
+	}
+	if changes := diffServerConfig(current, desired); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, changes)
+	}
+
+	if changes := diffServerConfig(current, current); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %#v", changes)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
@@ -136,6 +137,9 @@ func mainAdminConfigHistory(ctx *cli.Context) error {
 	}
 
 	chEntries, e := client.ListConfigHistoryKV(globalContext, ctx.Int("count"))
+	if madmin.ToErrorResponse(e).Code == "NotImplemented" {
+		fatalIf(probe.NewError(e), "Config history is not supported by the server.")
+	}
 	fatalIf(probe.NewError(e), "Unable to list server history configuration.")
 
 	hentries := make([]historyEntry, len(chEntries))
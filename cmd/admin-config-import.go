@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/fatih/color"
@@ -47,6 +49,9 @@ FLAGS:
 EXAMPLES:
   1. Import the new local config and apply to the MinIO server
      {{.Prompt}} {{.HelpName}} play/ < config.txt
+
+  2. Show the config keys which would be changed by importing the local config, without applying it
+     {{.Prompt}} {{.HelpName}} play/ --dry-run < config.txt
 `,
 }
 
@@ -96,8 +101,19 @@ func mainAdminConfigImport(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	config, e := io.ReadAll(os.Stdin)
+	fatalIf(probe.NewError(e), "Unable to read the config from STDIN")
+
+	if globalDryRun {
+		setConfigDiffColors()
+		changes, err := configDiff(client, config)
+		fatalIf(err.Trace(args...), "Unable to compare the server config")
+		printMsg(configDiffMessage{Changes: changes, DryRun: true})
+		return nil
+	}
+
 	// Call set config API
-	fatalIf(probe.NewError(client.SetConfig(globalContext, os.Stdin)), "Unable to set server config")
+	fatalIf(probe.NewError(client.SetConfig(globalContext, bytes.NewReader(config))), "Unable to set server config")
 
 	// Print
 	printMsg(configImportMessage{
@@ -19,10 +19,12 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
@@ -46,18 +48,32 @@ FLAGS:
 EXAMPLES:
   1. Restore 'restore-id' history key value on MinIO server.
      {{.Prompt}} {{.HelpName}} play/ <restore-id>
+
+  2. Show the config keys which would be changed by restoring 'restore-id', without restoring it.
+     {{.Prompt}} {{.HelpName}} play/ <restore-id> --dry-run
 `,
 }
 
 // configRestoreMessage container to hold locks information.
 type configRestoreMessage struct {
-	Status      string `json:"status"`
-	RestoreID   string `json:"restoreID"`
+	Status      string            `json:"status"`
+	RestoreID   string            `json:"restoreID"`
+	Changes     []configDiffEntry `json:"changes,omitempty"`
+	DryRun      bool              `json:"dryRun,omitempty"`
 	targetAlias string
 }
 
 // String colorized service status message.
 func (u configRestoreMessage) String() (msg string) {
+	if len(u.Changes) > 0 {
+		msg += configDiffMessage{Changes: u.Changes}.String() + "\n"
+	}
+	if u.DryRun {
+		if len(u.Changes) == 0 {
+			msg += console.Colorize("ConfigRestoreMessage", "Restoring "+u.RestoreID+" does not change any config key.")
+		}
+		return strings.TrimSuffix(msg, "\n")
+	}
 	suggestion := fmt.Sprintf("mc admin service restart %s", u.targetAlias)
 	msg += console.Colorize("ConfigRestoreMessage",
 		fmt.Sprintf("Please restart your server with `%s`.\n", suggestion))
@@ -76,15 +92,35 @@ func (u configRestoreMessage) JSON() string {
 
 // checkAdminConfigRestoreSyntax - validate all the passed arguments
 func checkAdminConfigRestoreSyntax(ctx *cli.Context) {
-	if !ctx.Args().Present() || len(ctx.Args()) > 2 {
+	if len(ctx.Args()) != 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 }
 
+// configHistoryChanges returns the config keys changed by restoring
+// the config history entry with the given restore id.
+func configHistoryChanges(client *madmin.AdminClient, restoreID string) ([]configDiffEntry, *probe.Error) {
+	// A negative count lists all the history entries.
+	entries, e := client.ListConfigHistoryKV(globalContext, -1)
+	if e != nil {
+		if madmin.ToErrorResponse(e).Code == "NotImplemented" {
+			return nil, probe.NewError(fmt.Errorf("config history is not supported by the server"))
+		}
+		return nil, probe.NewError(e)
+	}
+	for _, entry := range entries {
+		if entry.RestoreID == restoreID {
+			return configDiff(client, []byte(entry.Data))
+		}
+	}
+	return nil, probe.NewError(fmt.Errorf("restore id %s not found in the config history", restoreID))
+}
+
 func mainAdminConfigRestore(ctx *cli.Context) error {
 	checkAdminConfigRestoreSyntax(ctx)
 
 	console.SetColor("ConfigRestoreMessage", color.New(color.FgGreen))
+	setConfigDiffColors()
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -94,12 +130,26 @@ func mainAdminConfigRestore(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	restoreID := args.Get(1)
+	changes, err := configHistoryChanges(client, restoreID)
+	if globalDryRun {
+		fatalIf(err.Trace(restoreID), "Unable to compare the server configuration.")
+		printMsg(configRestoreMessage{
+			RestoreID: restoreID,
+			Changes:   changes,
+			DryRun:    true,
+		})
+		return nil
+	}
+	errorIf(err.Trace(restoreID), "Unable to summarize the restored configuration keys.")
+
 	// Call get config API
-	fatalIf(probe.NewError(client.RestoreConfigHistoryKV(globalContext, args.Get(1))), "Unable to restore server configuration.")
+	fatalIf(probe.NewError(client.RestoreConfigHistoryKV(globalContext, restoreID)), "Unable to restore server configuration.")
 
 	// Print
 	printMsg(configRestoreMessage{
-		RestoreID:   args.Get(1),
+		RestoreID:   restoreID,
+		Changes:     changes,
 		targetAlias: aliasedURL,
 	})
 
@@ -18,8 +18,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -35,12 +41,13 @@ var adminConfigSetCmd = cli.Command{
 	Before:       setGlobalsFromContext,
 	Action:       mainAdminConfigSet,
 	OnUsageError: onUsageError,
-	Flags:        append(adminConfigEnvFlags, globalFlags...),
+	Flags:        append(append(adminConfigEnvFlags, adminConfigSetFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [SUBSYSTEM KEY=VALUE...]
+  {{.HelpName}} TARGET --from-file FILE
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -54,9 +61,128 @@ EXAMPLES:
 
   3. Change healing settings on a distributed MinIO server setup.
      {{.Prompt}} {{.HelpName}} mydist/ heal max_delay=300ms max_io=50
+
+  4. Validate and apply several settings at once, one sub-system per line.
+     {{.Prompt}} {{.HelpName}} myminio/ --from-file - <<EOF
+     region name=us-west-1
+     heal max_delay=300ms max_io=50
+     EOF
 `,
 }
 
+var adminConfigSetFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "from-file",
+		Usage: "read the settings from a file with one 'SUBSYSTEM KEY=VALUE...' line per sub-system, use '-' to read from STDIN",
+	},
+}
+
+// configSetKeyMessage container for the result of setting a single config key.
+type configSetKeyMessage struct {
+	Status    string `json:"status"`
+	SubSystem string `json:"subSystem"`
+	Target    string `json:"target,omitempty"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Error     string `json:"error,omitempty"`
+}
+
+// String colorized config key result message.
+func (u configSetKeyMessage) String() string {
+	subSys := u.SubSystem
+	if u.Target != "" {
+		subSys += madmin.SubSystemSeparator + u.Target
+	}
+	if u.Error != "" {
+		return console.Colorize("SetConfigFailed", fmt.Sprintf("%s %s=%s: %s", subSys, u.Key, u.Value, u.Error))
+	}
+	return console.Colorize("SetConfigKey", fmt.Sprintf("%s %s=%s", subSys, u.Key, u.Value))
+}
+
+// JSON jsonified config key result message.
+func (u configSetKeyMessage) JSON() string {
+	statusJSONBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statusJSONBytes)
+}
+
+// validateConfigKV validates a config key and its value against the help
+// of its sub-system, as returned by the server.
+func validateConfigKV(help madmin.Help, kv madmin.ConfigKV) error {
+	if kv.Key == madmin.CommentKey {
+		return nil
+	}
+	var keyHelp *madmin.HelpKV
+	for i := range help.KeysHelp {
+		if help.KeysHelp[i].Key == kv.Key {
+			keyHelp = &help.KeysHelp[i]
+			break
+		}
+	}
+	if keyHelp == nil {
+		return fmt.Errorf("unknown key for sub-system '%s'", help.SubSys)
+	}
+	// An empty value resets the key to its default.
+	if kv.Value == "" {
+		return nil
+	}
+	switch keyHelp.Type {
+	case "on|off":
+		if kv.Value != madmin.EnableOn && kv.Value != madmin.EnableOff {
+			return errors.New("expected 'on' or 'off'")
+		}
+	case "duration":
+		if _, e := time.ParseDuration(kv.Value); e != nil {
+			return fmt.Errorf("expected a duration such as '300ms' or '1h'")
+		}
+	case "number":
+		if _, e := strconv.ParseFloat(kv.Value, 64); e != nil {
+			return fmt.Errorf("expected a number")
+		}
+	case "url":
+		if u, e := url.Parse(kv.Value); e != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("expected a URL such as 'http://localhost:8080'")
+		}
+	}
+	return nil
+}
+
+// validateConfigSettings validates the settings against the config help
+// of their sub-systems and returns the result of each key.
+func validateConfigSettings(client *madmin.AdminClient, settings []madmin.SubsysConfig) (results []configSetKeyMessage, valid bool) {
+	helps := make(map[string]madmin.Help)
+	valid = true
+	for _, c := range settings {
+		help, ok := helps[c.SubSystem]
+		if !ok {
+			var e error
+			help, e = client.HelpConfigKV(globalContext, c.SubSystem, "", false)
+			fatalIf(probe.NewError(e).Trace(c.SubSystem), "Unable to get help for the sub-system")
+			helps[c.SubSystem] = help
+		}
+		if c.Target != "" && !help.MultipleTargets {
+			fatalIf(errInvalidArgument().Trace(c.SubSystem), "Sub-system '%s' does not support named targets.", c.SubSystem)
+		}
+		for _, kv := range c.KV {
+			result := configSetKeyMessage{
+				Status:    "success",
+				SubSystem: c.SubSystem,
+				Target:    c.Target,
+				Key:       kv.Key,
+				Value:     kv.Value,
+			}
+			if e := validateConfigKV(help, kv); e != nil {
+				valid = false
+				result.Status = "error"
+				result.Error = e.Error()
+			}
+			results = append(results, result)
+		}
+	}
+	return results, valid
+}
+
 // configSetMessage container to hold locks information.
 type configSetMessage struct {
 	Status      string `json:"status"`
@@ -90,6 +216,9 @@ func checkAdminConfigSetSyntax(ctx *cli.Context) {
 	if !ctx.Args().Present() && len(ctx.Args()) < 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.IsSet("from-file") && len(ctx.Args()) > 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Settings cannot be passed as arguments with --from-file.")
+	}
 }
 
 // main config set function
@@ -99,6 +228,8 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 
 	// Set color preference of command outputs
 	console.SetColor("SetConfigSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SetConfigKey", color.New(color.FgGreen))
+	console.SetColor("SetConfigFailed", color.New(color.FgRed))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -108,6 +239,10 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	if file := ctx.String("from-file"); file != "" {
+		return adminConfigSetFromFile(client, aliasedURL, file)
+	}
+
 	input := strings.Join(args.Tail(), " ")
 
 	if !strings.Contains(input, madmin.KvSeparator) {
@@ -137,3 +272,43 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 
 	return nil
 }
+
+// adminConfigSetFromFile validates all the settings of the file, then
+// applies them to the server in a single call.
+func adminConfigSetFromFile(client *madmin.AdminClient, aliasedURL, file string) error {
+	var input []byte
+	var e error
+	if file == "-" {
+		input, e = io.ReadAll(os.Stdin)
+	} else {
+		input, e = os.ReadFile(file)
+	}
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read the settings file.")
+
+	settings, e := madmin.ParseServerConfigOutput(string(input))
+	fatalIf(probe.NewError(e).Trace(file), "Unable to parse the settings file.")
+	if len(settings) == 0 {
+		fatalIf(errInvalidArgument().Trace(file), "No settings found in the settings file.")
+	}
+
+	results, valid := validateConfigSettings(client, settings)
+	for _, result := range results {
+		printMsg(result)
+	}
+	if !valid {
+		errorIf(errInvalidArgument().Trace(file), "Invalid settings, no settings were applied.")
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	// Call set config API
+	restart, e := client.SetConfigKV(globalContext, string(input))
+	fatalIf(probe.NewError(e), "Unable to set the settings of '%s' to server", file)
+
+	// Print set config result
+	printMsg(configSetMessage{
+		targetAlias: aliasedURL,
+		restart:     restart,
+	})
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestValidateConfigKV(t *testing.T) {
+	help := madmin.Help{
+		SubSys: "heal",
+		KeysHelp: madmin.HelpKVS{
+			{Key: "bitrotscan", Type: "on|off"},
+			{Key: "max_sleep", Type: "duration"},
+			{Key: "max_io", Type: "number"},
+			{Key: "endpoint", Type: "url"},
+			{Key: "comment", Type: "sentence"},
+		},
+	}
+	testCases := []struct {
+		key, value string
+		valid      bool
+	}{
+		{"bitrotscan", "on", true},
+		{"bitrotscan", "yes", false},
+		{"max_sleep", "300ms", true},
+		{"max_sleep", "300", false},
+		{"max_io", "50", true},
+		{"max_io", "many", false},
+		{"endpoint", "http://localhost:8080", true},
+		{"endpoint", "localhost", false},
+		{"max_io", "", true},
+		{"comment", "anything goes", true},
+		{"unknown", "value", false},
+	}
+	for i, testCase := range testCases {
+		e := validateConfigKV(help, madmin.ConfigKV{Key: testCase.key, Value: testCase.value})
+		if (e == nil) != testCase.valid {
+			t.Errorf("Test %d: %s=%s expected valid=%v, got %v", i+1, testCase.key, testCase.value, testCase.valid, e)
+		}
+	}
+}
@@ -27,6 +27,7 @@ var adminConfigSubcommands = []cli.Command{
 	adminConfigRestoreCmd,
 	adminConfigExportCmd,
 	adminConfigImportCmd,
+	adminConfigDiffCmd,
 }
 
 var adminConfigCmd = cli.Command{
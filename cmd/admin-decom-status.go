@@ -26,6 +26,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
@@ -54,6 +55,49 @@ EXAMPLES:
 `,
 }
 
+// decomProgress is the progress of an ongoing decommission.
+type decomProgress struct {
+	ObjectsMigrated int64         `json:"objectsMigrated"`
+	ObjectsFailed   int64         `json:"objectsFailed"`
+	BytesMigrated   uint64        `json:"bytesMigrated"`
+	BytesRemaining  uint64        `json:"bytesRemaining"`
+	Speed           uint64        `json:"speed,omitempty"`
+	ETA             time.Duration `json:"eta,omitempty"`
+}
+
+// decomPoolStatus is the status of a pool along with its decommission progress.
+type decomPoolStatus struct {
+	madmin.PoolStatus
+	Progress *decomProgress `json:"progress,omitempty"`
+}
+
+// decommissionProgress computes the progress of a decommission from the
+// pool usage at its start and now, speed and ETA are only known after a
+// few seconds of progress.
+func decommissionProgress(info *madmin.PoolDecommissionInfo, now time.Time) *decomProgress {
+	if info == nil || info.StartTime.IsZero() || info.Complete || info.Failed || info.Canceled {
+		return nil
+	}
+	usedStart := info.TotalSize - info.StartSize
+	usedCurrent := info.TotalSize - info.CurrentSize
+	p := &decomProgress{
+		ObjectsMigrated: info.ObjectsDecommissioned,
+		ObjectsFailed:   info.ObjectsDecommissionFailed,
+		BytesMigrated:   uint64(info.BytesDone),
+	}
+	if usedCurrent > 0 {
+		p.BytesRemaining = uint64(usedCurrent)
+	}
+	duration := now.Sub(info.StartTime).Seconds()
+	if usedStart > usedCurrent && duration > 10 {
+		p.Speed = uint64(float64(usedStart-usedCurrent) / duration)
+		if p.Speed > 0 {
+			p.ETA = time.Duration(p.BytesRemaining/p.Speed) * time.Second
+		}
+	}
+	return p
+}
+
 // checkAdminDecommissionStatusSyntax - validate all the passed arguments
 func checkAdminDecommissionStatusSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 2 || len(ctx.Args()) == 0 {
@@ -79,7 +123,10 @@ func mainAdminDecommissionStatus(ctx *cli.Context) error {
 		fatalIf(probe.NewError(e).Trace(args...), "Unable to get status per pool")
 
 		if globalJSON {
-			statusJSONBytes, e := json.MarshalIndent(poolStatus, "", "    ")
+			statusJSONBytes, e := json.MarshalIndent(decomPoolStatus{
+				PoolStatus: poolStatus,
+				Progress:   decommissionProgress(poolStatus.Decommission, time.Now()),
+			}, "", "    ")
 			fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 			console.Println(string(statusJSONBytes))
 			return nil
@@ -93,16 +140,16 @@ func mainAdminDecommissionStatus(ctx *cli.Context) error {
 		} else if poolStatus.Decommission.Canceled {
 			msg = color.GreenString(fmt.Sprintf("Decommission of pool %s was canceled, you may start again", poolStatus.CmdLine))
 		} else if !poolStatus.Decommission.StartTime.IsZero() {
-			usedStart := (poolStatus.Decommission.TotalSize - poolStatus.Decommission.StartSize)
 			usedCurrent := (poolStatus.Decommission.TotalSize - poolStatus.Decommission.CurrentSize)
 
-			duration := float64(time.Since(poolStatus.Decommission.StartTime)) / float64(time.Second)
-			if usedStart > usedCurrent && duration > 10 {
-				copied := uint64(usedStart - usedCurrent)
-				speed := uint64(float64(copied) / duration)
-				msg = "Decommissioning rate at " + humanize.IBytes(speed) + "/sec " + "[" + humanize.IBytes(
+			progress := decommissionProgress(poolStatus.Decommission, time.Now())
+			if progress.Speed > 0 {
+				msg = "Decommissioning rate at " + humanize.IBytes(progress.Speed) + "/sec " + "[" + humanize.IBytes(
 					uint64(usedCurrent)) + "/" + humanize.IBytes(uint64(poolStatus.Decommission.TotalSize)) + "]"
 				msg += "\nStarted: " + humanize.RelTime(time.Now().UTC(), poolStatus.Decommission.StartTime, "", "ago")
+				msg += "\nObjects: " + humanize.Comma(progress.ObjectsMigrated) + " migrated, " +
+					humanize.Comma(progress.ObjectsFailed) + " failed"
+				msg += "\nRemaining: " + humanize.IBytes(progress.BytesRemaining) + " (" + progress.ETA.String() + " to completion)"
 			} else {
 				msg = "Decommissioning is starting..."
 			}
@@ -118,7 +165,14 @@ func mainAdminDecommissionStatus(ctx *cli.Context) error {
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to get status for all pools")
 
 	if globalJSON {
-		statusJSONBytes, e := json.MarshalIndent(poolStatuses, "", "    ")
+		statuses := make([]decomPoolStatus, 0, len(poolStatuses))
+		for _, pool := range poolStatuses {
+			statuses = append(statuses, decomPoolStatus{
+				PoolStatus: pool,
+				Progress:   decommissionProgress(pool.Decommission, time.Now()),
+			})
+		}
+		statusJSONBytes, e := json.MarshalIndent(statuses, "", "    ")
 		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 		console.Println(string(statusJSONBytes))
 		return nil
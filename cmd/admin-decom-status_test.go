@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestDecommissionProgress(t *testing.T) {
+	now := time.Now()
+	info := &madmin.PoolDecommissionInfo{
+		StartTime:             now.Add(-100 * time.Second),
+		TotalSize:             10000,
+		StartSize:             2000, // 8000 bytes used at start
+		CurrentSize:           6000, // 4000 bytes still used
+		ObjectsDecommissioned: 42,
+		BytesDone:             4000,
+	}
+	p := decommissionProgress(info, now)
+	if p == nil {
+		t.Fatal("expected progress for an ongoing decommission")
+	}
+	if p.BytesRemaining != 4000 || p.Speed != 40 || p.ETA != 100*time.Second || p.ObjectsMigrated != 42 {
+		t.Fatalf("unexpected progress %+v", *p)
+	}
+
+	// No speed nor ETA is known right after the start.
+	info.StartTime = now.Add(-5 * time.Second)
+	if p = decommissionProgress(info, now); p.Speed != 0 || p.ETA != 0 {
+		t.Fatalf("expected no ETA, got %+v", *p)
+	}
+
+	info.Complete = true
+	if p = decommissionProgress(info, now); p != nil {
+		t.Fatalf("expected no progress for a complete decommission, got %+v", *p)
+	}
+}
@@ -18,7 +18,10 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/fatih/color"
@@ -29,18 +32,25 @@ import (
 	"github.com/minio/pkg/v3/console"
 )
 
+var adminGroupMembersFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "file",
+		Usage: "read the members from a file with one user per line, use '-' to read from stdin",
+	},
+}
+
 var adminGroupAddCmd = cli.Command{
 	Name:         "add",
 	Usage:        "add users to a new or existing group",
 	Action:       mainAdminGroupAdd,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminGroupMembersFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET GROUPNAME MEMBERS...
+  {{.HelpName}} TARGET GROUPNAME [MEMBERS...] [--file FILE]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -52,16 +62,63 @@ EXAMPLES:
   2. Add user "james" to group "staff", then add the "readwrite" policy to the group "staff".
      {{.Prompt}} {{.HelpName}} myminio staff james
      {{.Prompt}} mc admin policy attach myminio readwrite --group staff
+
+  3. Add the users listed in 'members.txt' to the group 'staff'.
+     {{.Prompt}} {{.HelpName}} myminio staff --file members.txt
+
+  4. Show the users of 'members.txt' which would be added to the group 'staff', without adding them.
+     {{.Prompt}} {{.HelpName}} myminio staff --file members.txt --dry-run
 `,
 }
 
 // checkAdminGroupAddSyntax - validate all the passed arguments
 func checkAdminGroupAddSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) < 3 {
+	if len(ctx.Args()) < 3 && !(len(ctx.Args()) == 2 && ctx.IsSet("file")) {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 }
 
+// parseGroupMembers reads the members from a file with one user per
+// line, empty lines and lines starting with '#' are ignored.
+func parseGroupMembers(r io.Reader) (members []string, e error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		member := strings.TrimSpace(scanner.Text())
+		if member == "" || strings.HasPrefix(member, "#") {
+			continue
+		}
+		members = append(members, member)
+	}
+	return members, scanner.Err()
+}
+
+// groupMembersFromContext returns the members passed as arguments
+// after GROUPNAME, followed by the members listed in --file.
+func groupMembersFromContext(ctx *cli.Context) []string {
+	members := []string{}
+	for i := 2; i < ctx.NArg(); i++ {
+		members = append(members, ctx.Args().Get(i))
+	}
+	file := ctx.String("file")
+	if file == "" {
+		return members
+	}
+
+	in := io.Reader(os.Stdin)
+	if file != "-" {
+		f, e := os.Open(file)
+		fatalIf(probe.NewError(e).Trace(file), "Unable to open the members file.")
+		defer f.Close()
+		in = f
+	}
+	fileMembers, e := parseGroupMembers(in)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read the members file.")
+	if len(members)+len(fileMembers) == 0 {
+		fatalIf(errInvalidArgument().Trace(file), "No members found in the members file.")
+	}
+	return append(members, fileMembers...)
+}
+
 // groupMessage container for content message structure
 type groupMessage struct {
 	op          string
@@ -71,6 +128,7 @@ type groupMessage struct {
 	Members     []string `json:"members,omitempty"`
 	GroupStatus string   `json:"groupStatus,omitempty"`
 	GroupPolicy string   `json:"groupPolicy,omitempty"`
+	DryRun      bool     `json:"dryRun,omitempty"`
 }
 
 func (u groupMessage) String() string {
@@ -87,8 +145,18 @@ func (u groupMessage) String() string {
 		return console.Colorize("GroupMessage", "Enabled group `"+u.GroupName+"` successfully.")
 	case "add":
 		membersStr := fmt.Sprintf("`%s`", strings.Join(u.Members, ","))
+		if u.DryRun {
+			return console.Colorize("GroupMessage", "Would add members "+membersStr+" to group `"+u.GroupName+"`.")
+		}
 		return console.Colorize("GroupMessage", "Added members "+membersStr+" to group `"+u.GroupName+"` successfully.")
 	case "remove":
+		if u.DryRun {
+			if len(u.Members) > 0 {
+				membersStr := fmt.Sprintf("{%s}", strings.Join(u.Members, ","))
+				return console.Colorize("GroupMessage", "Would remove members "+membersStr+" from group "+u.GroupName+".")
+			}
+			return console.Colorize("GroupMessage", "Would remove group "+u.GroupName+".")
+		}
 		if len(u.Members) > 0 {
 			membersStr := fmt.Sprintf("{%s}", strings.Join(u.Members, ","))
 			return console.Colorize("GroupMessage", "Removed members "+membersStr+" from group "+u.GroupName+" successfully.")
@@ -128,21 +196,21 @@ func mainAdminGroupAdd(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	members := []string{}
-	for i := 2; i < ctx.NArg(); i++ {
-		members = append(members, args.Get(i))
-	}
+	members := groupMembersFromContext(ctx)
 	gAddRemove := madmin.GroupAddRemove{
 		Group:    args.Get(1),
 		Members:  members,
 		IsRemove: false,
 	}
-	fatalIf(probe.NewError(client.UpdateGroupMembers(globalContext, gAddRemove)).Trace(args...), "Unable to add new group")
+	if !globalDryRun {
+		fatalIf(probe.NewError(client.UpdateGroupMembers(globalContext, gAddRemove)).Trace(args...), "Unable to add new group")
+	}
 
 	printMsg(groupMessage{
 		op:        ctx.Command.Name,
 		GroupName: args.Get(1),
 		Members:   members,
+		DryRun:    globalDryRun,
 	})
 
 	return nil
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminGroupMembershipsCmd = cli.Command{
+	Name:         "memberships",
+	Usage:        "list the groups of a user with their attached policies",
+	Action:       mainAdminGroupMemberships,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET USERNAME
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the groups of the user 'james' with their policies.
+     {{.Prompt}} {{.HelpName}} myminio james
+`,
+}
+
+// groupMembership is a group of a user with its attached policies.
+type groupMembership struct {
+	Group    string   `json:"group"`
+	Status   string   `json:"status"`
+	Policies []string `json:"policies,omitempty"`
+}
+
+// groupMembershipsMessage container for the groups of a user
+type groupMembershipsMessage struct {
+	Status      string            `json:"status"`
+	User        string            `json:"user"`
+	Memberships []groupMembership `json:"memberships"`
+}
+
+func (m groupMembershipsMessage) String() string {
+	if len(m.Memberships) == 0 {
+		return console.Colorize("GroupMessage", fmt.Sprintf("User `%s` is not a member of any group.", m.User))
+	}
+	lines := []string{console.Colorize("GroupMessage", fmt.Sprintf("User `%s` is a member of:", m.User))}
+	for _, g := range m.Memberships {
+		line := fmt.Sprintf("  %s (%s)", console.Colorize("GroupName", g.Group), g.Status)
+		if len(g.Policies) > 0 {
+			line += ": " + strings.Join(g.Policies, ", ")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m groupMembershipsMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminGroupMembershipsSyntax - validate all the passed arguments
+func checkAdminGroupMembershipsSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminGroupMemberships is the handle for "mc admin group memberships" command.
+func mainAdminGroupMemberships(ctx *cli.Context) error {
+	checkAdminGroupMembershipsSyntax(ctx)
+
+	console.SetColor("GroupMessage", color.New(color.FgGreen))
+	console.SetColor("GroupName", color.New(color.Bold))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	user := args.Get(1)
+	info, e := client.GetUserInfo(globalContext, user)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get user info")
+
+	groups := append([]string{}, info.MemberOf...)
+	sort.Strings(groups)
+
+	memberships := make([]groupMembership, 0, len(groups))
+	for _, group := range groups {
+		gd, e := client.GetGroupDescription(globalContext, group)
+		fatalIf(probe.NewError(e).Trace(group), "Unable to fetch group info")
+		memberships = append(memberships, groupMembership{
+			Group:    group,
+			Status:   gd.Status,
+			Policies: splitPolicyNames(gd.Policy),
+		})
+	}
+
+	printMsg(groupMembershipsMessage{
+		Status:      "success",
+		User:        user,
+		Memberships: memberships,
+	})
+	return nil
+}
@@ -32,12 +32,12 @@ var adminGroupRemoveCmd = cli.Command{
 	Action:       mainAdminGroupRemove,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminGroupMembersFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET GROUPNAME [USERNAMES...]
+  {{.HelpName}} TARGET GROUPNAME [USERNAMES...] [--file FILE]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -48,6 +48,12 @@ EXAMPLES:
 
   2. Remove group 'allcents'.
      {{.Prompt}} {{.HelpName}} myminio allcents
+
+  3. Remove the users listed in 'members.txt' from group 'allcents'.
+     {{.Prompt}} {{.HelpName}} myminio allcents --file members.txt
+
+  4. Show the users of 'members.txt' which would be removed from group 'allcents', without removing them.
+     {{.Prompt}} {{.HelpName}} myminio allcents --file members.txt --dry-run
 `,
 }
 
@@ -72,23 +78,23 @@ func mainAdminGroupRemove(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	members := []string{}
-	for i := 2; i < ctx.NArg(); i++ {
-		members = append(members, args.Get(i))
-	}
+	members := groupMembersFromContext(ctx)
 	gAddRemove := madmin.GroupAddRemove{
 		Group:    args.Get(1),
 		Members:  members,
 		IsRemove: true,
 	}
 
-	e := client.UpdateGroupMembers(globalContext, gAddRemove)
-	fatalIf(probe.NewError(e).Trace(args...), "Could not perform remove operation")
+	if !globalDryRun {
+		e := client.UpdateGroupMembers(globalContext, gAddRemove)
+		fatalIf(probe.NewError(e).Trace(args...), "Could not perform remove operation")
+	}
 
 	printMsg(groupMessage{
 		op:        ctx.Command.Name,
 		GroupName: args.Get(1),
 		Members:   members,
+		DryRun:    globalDryRun,
 	})
 
 	return nil
@@ -26,6 +26,7 @@ var adminGroupSubcommands = []cli.Command{
 	adminGroupListCmd,
 	adminGroupEnableCmd,
 	adminGroupDisableCmd,
+	adminGroupMembershipsCmd,
 }
 
 var adminGroupCmd = cli.Command{
@@ -0,0 +1,304 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go/v3"
+	"github.com/olekukonko/tablewriter"
+)
+
+// healDryRunMaxItems is the maximum number of damaged items
+// remembered to be reported at the end of a dry-run.
+const healDryRunMaxItems = 10000
+
+// healSetStats holds the heal counters of an erasure set.
+type healSetStats struct {
+	Scanned, Healed, Failed int64
+}
+
+// healSetProgress is the heal progress of an erasure set.
+type healSetProgress struct {
+	setIndex
+	healSetStats
+}
+
+// healProgress is a snapshot of the heal statistics sent to the UI.
+type healProgress struct {
+	Summary      string
+	Duration     time.Duration
+	ItemsScanned int64
+	ItemsHealed  int64
+	ItemsFailed  int64
+	BytesScanned int64
+	LastItem     string
+	Sets         []healSetProgress
+}
+
+// healItemDamaged returns whether the item had missing or corrupted
+// drives before healing, and whether it is still damaged after healing.
+// In dry-run mode nothing is healed, so all damaged items are failed.
+func healItemDamaged(i madmin.HealResultItem, dryRun bool) (damaged, failed bool) {
+	missingBefore, missingAfter := i.GetMissingCounts()
+	corruptedBefore, corruptedAfter := i.GetCorruptedCounts()
+	damaged = missingBefore+corruptedBefore > 0
+	if dryRun {
+		return damaged, damaged
+	}
+	return damaged, missingAfter+corruptedAfter > 0
+}
+
+// loadEndpointSets fetches the erasure set of every drive, this is
+// used to compute per set heal statistics.
+func (ui *uiData) loadEndpointSets(ctx context.Context) {
+	info, e := ui.Client.ServerInfo(ctx)
+	if e != nil {
+		return
+	}
+	ui.EndpointSets = make(map[string]setIndex)
+	for _, srv := range info.Servers {
+		for _, disk := range srv.Disks {
+			if disk.Endpoint != "" {
+				ui.EndpointSets[disk.Endpoint] = setIndex{pool: disk.PoolIndex, set: disk.SetIndex}
+			}
+		}
+	}
+}
+
+// itemSet returns the erasure set of a heal result item.
+func (ui *uiData) itemSet(i madmin.HealResultItem) (setIndex, bool) {
+	for _, drive := range i.Before.Drives {
+		if index, ok := ui.EndpointSets[drive.Endpoint]; ok {
+			return index, true
+		}
+	}
+	return setIndex{}, false
+}
+
+// progress returns a snapshot of the current heal statistics.
+func (ui *uiData) progress(s *madmin.HealTaskStatus) healProgress {
+	p := healProgress{
+		Summary:      s.Summary,
+		Duration:     ui.HealDuration,
+		ItemsScanned: ui.ItemsScanned,
+		ItemsHealed:  ui.ItemsHealed,
+		ItemsFailed:  ui.ItemsFailed,
+		BytesScanned: ui.BytesScanned,
+	}
+	if ui.LastItem != nil {
+		p.LastItem = ui.LastItem.makeHealEntityString()
+	}
+	for index, st := range ui.Sets {
+		p.Sets = append(p.Sets, healSetProgress{setIndex: index, healSetStats: *st})
+	}
+	sort.Slice(p.Sets, func(i, j int) bool {
+		if p.Sets[i].pool != p.Sets[j].pool {
+			return p.Sets[i].pool < p.Sets[j].pool
+		}
+		return p.Sets[i].set < p.Sets[j].set
+	})
+	return p
+}
+
+// followHealStatusUI follows the heal sequence and displays its
+// progress in an interactive terminal UI.
+func (ui *uiData) followHealStatusUI(aliasedURL string) (res madmin.HealTaskStatus, err error) {
+	ctx, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	if ui.EndpointSets == nil {
+		ui.loadEndpointSets(ctx)
+	}
+
+	type healResult struct {
+		res madmin.HealTaskStatus
+		err error
+	}
+	doneCh := make(chan healResult, 1)
+
+	model := newHealProgressUI(ui.HealOpts.DryRun)
+	p := tea.NewProgram(model)
+	go func() {
+		var r healResult
+		defer func() {
+			doneCh <- r
+			p.Quit()
+		}()
+		for {
+			_, r.res, r.err = ui.Client.Heal(ctx, ui.Bucket, ui.Prefix, *ui.HealOpts,
+				ui.ClientToken, ui.ForceStart, false)
+			if r.err != nil {
+				return
+			}
+			ui.updateDuration(&r.res)
+			for _, i := range r.res.Items {
+				ui.updateStats(i)
+			}
+			if n := len(r.res.Items); n > 0 {
+				ui.LastItem = newHRI(&r.res.Items[n-1])
+			}
+			p.Send(ui.progress(&r.res))
+
+			switch r.res.Summary {
+			case "finished":
+				return
+			case "stopped":
+				r.err = fmt.Errorf("Heal had an error - %s", r.res.FailureDetail)
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				r.err = ctx.Err()
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+
+	if _, e := p.Run(); e != nil {
+		return res, e
+	}
+	if model.aborted {
+		cancel()
+		<-doneCh
+		return res, errors.New(ui.healResumeMsg(aliasedURL))
+	}
+	r := <-doneCh
+	if r.err != nil && errors.Is(r.err, context.Canceled) {
+		r.err = errors.New(ui.healResumeMsg(aliasedURL))
+	}
+	return r.res, r.err
+}
+
+type healProgressUI struct {
+	spinner  spinner.Model
+	progress healProgress
+	dryRun   bool
+	quitting bool
+	aborted  bool
+}
+
+func newHealProgressUI(dryRun bool) *healProgressUI {
+	s := spinner.New()
+	s.Spinner = spinner.Points
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return &healProgressUI{
+		spinner: s,
+		dryRun:  dryRun,
+	}
+}
+
+func (m *healProgressUI) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m *healProgressUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.quitting = true
+			m.aborted = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case healProgress:
+		m.progress = msg
+		if msg.Summary == "finished" || msg.Summary == "stopped" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *healProgressUI) View() string {
+	var s strings.Builder
+
+	if !m.quitting {
+		s.WriteString(m.spinner.View())
+	} else if m.progress.Summary == "finished" {
+		s.WriteString(m.spinner.Style.Render(tickCell + tickCell + tickCell))
+	} else if m.progress.Summary == "stopped" {
+		s.WriteString(m.spinner.Style.Render(crossTickCell + crossTickCell + crossTickCell))
+	}
+	s.WriteString("\n")
+
+	lastItem := m.progress.LastItem
+	if lastItem == "" {
+		lastItem = "** waiting for status from server **"
+	}
+	healedLabel, failedLabel := "Healed", "Failed"
+	if m.dryRun {
+		failedLabel = "Need heal"
+	}
+	s.WriteString(fmt.Sprintf("Scanned: %s\n", lineTrunc(lastItem, lineWidth-len("Scanned: "))))
+	s.WriteString(fmt.Sprintf("Items: %s scanned, %s %s, %s %s; %s in %s\n\n",
+		humanize.Comma(m.progress.ItemsScanned),
+		humanize.Comma(m.progress.ItemsHealed), strings.ToLower(healedLabel),
+		humanize.Comma(m.progress.ItemsFailed), strings.ToLower(failedLabel),
+		humanize.IBytes(uint64(m.progress.BytesScanned)),
+		m.progress.Duration.Round(time.Second)))
+
+	if len(m.progress.Sets) > 0 {
+		table := tablewriter.NewWriter(&s)
+		table.SetAutoWrapText(false)
+		table.SetAutoFormatHeaders(false)
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("")
+		table.SetRowSeparator("")
+		table.SetHeaderLine(false)
+		table.SetBorder(false)
+		table.SetTablePadding("\t") // pad with tabs
+		table.SetNoWhiteSpace(true)
+		table.SetHeader([]string{"Pool", "Set", "Scanned", healedLabel, failedLabel})
+		for _, st := range m.progress.Sets {
+			table.Append([]string{
+				fmt.Sprint(st.pool + 1),
+				fmt.Sprint(st.set + 1),
+				whiteStyle.Render(humanize.Comma(st.Scanned)),
+				whiteStyle.Render(humanize.Comma(st.Healed)),
+				whiteStyle.Render(humanize.Comma(st.Failed)),
+			})
+		}
+		table.Render()
+	}
+
+	if m.quitting {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
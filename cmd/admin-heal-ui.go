@@ -87,6 +87,18 @@ type uiData struct {
 	// Counters for healed objects and all kinds of healed items
 	ObjectsHealed, ItemsHealed int64
 
+	// Counter for items that are still damaged after heal, or
+	// that need healing in dry-run mode
+	ItemsFailed int64
+
+	// Per erasure set statistics, the set of an item is found
+	// using the endpoints of its drives
+	EndpointSets map[string]setIndex
+	Sets         map[setIndex]*healSetStats
+
+	// Items which need healing, only collected in dry-run mode
+	DamagedItems []madmin.HealResultItem
+
 	// Map from online drives to number of objects with that many
 	// online drives.
 	ObjectsByOnlineDrives map[int]int64
@@ -120,6 +132,31 @@ func (ui *uiData) updateStats(i madmin.HealResultItem) error {
 	}
 	ui.ObjectsByOnlineDrives[afterUp]++
 
+	damaged, failed := healItemDamaged(i, ui.HealOpts.DryRun)
+	if failed {
+		ui.ItemsFailed++
+	}
+	if damaged && ui.HealOpts.DryRun && len(ui.DamagedItems) < healDryRunMaxItems {
+		ui.DamagedItems = append(ui.DamagedItems, i)
+	}
+	if index, ok := ui.itemSet(i); ok {
+		if ui.Sets == nil {
+			ui.Sets = make(map[setIndex]*healSetStats)
+		}
+		st := ui.Sets[index]
+		if st == nil {
+			st = &healSetStats{}
+			ui.Sets[index] = st
+		}
+		st.Scanned++
+		if afterUp > beforeUp {
+			st.Healed++
+		}
+		if failed {
+			st.Failed++
+		}
+	}
+
 	// Update health color stats:
 
 	// Fetch health color after heal:
@@ -320,6 +357,7 @@ func (ui *uiData) printStatsJSON(_ *madmin.HealTaskStatus) {
 		ObjectsHealed  int64  `json:"objects_healed"`
 		ItemsScanned   int64  `json:"items_scanned"`
 		ItemsHealed    int64  `json:"items_healed"`
+		ItemsFailed    int64  `json:"items_failed"`
 		Size           int64  `json:"size"`
 		ElapsedTime    int64  `json:"duration"`
 	}
@@ -331,6 +369,7 @@ func (ui *uiData) printStatsJSON(_ *madmin.HealTaskStatus) {
 	summary.ObjectsHealed = ui.ObjectsHealed
 	summary.ItemsScanned = ui.ItemsScanned
 	summary.ItemsHealed = ui.ItemsHealed
+	summary.ItemsFailed = ui.ItemsFailed
 	summary.Size = ui.BytesScanned
 	summary.ElapsedTime = int64(ui.HealDuration.Round(time.Second).Seconds())
 
@@ -413,6 +452,10 @@ func (ui *uiData) healResumeMsg(aliasedURL string) string {
 }
 
 func (ui *uiData) DisplayAndFollowHealStatus(aliasedURL string) (res madmin.HealTaskStatus, err error) {
+	if !globalJSON && !globalQuiet && isTerminal() {
+		return ui.followHealStatusUI(aliasedURL)
+	}
+
 	quitMsg := ui.healResumeMsg(aliasedURL)
 
 	firstIter := true
@@ -44,14 +44,12 @@ const (
 
 var adminHealFlags = []cli.Flag{
 	cli.IntFlag{
-		Name:   "pool",
-		Usage:  "heal only the given pool",
-		Hidden: true,
+		Name:  "pool",
+		Usage: "heal only the given pool",
 	},
 	cli.IntFlag{
-		Name:   "set",
-		Usage:  "heal only the given set",
-		Hidden: true,
+		Name:  "set",
+		Usage: "heal only the given set",
 	},
 	cli.StringFlag{
 		Name:   "scan",
@@ -60,15 +58,18 @@ var adminHealFlags = []cli.Flag{
 		Hidden: true,
 	},
 	cli.BoolFlag{
-		Name:   "recursive, r",
-		Usage:  "heal recursively",
-		Hidden: true,
+		Name:  "recursive, r",
+		Usage: "heal recursively",
 	},
 	cli.BoolFlag{
-		Name:   "dry-run, n",
-		Usage:  "only inspect data, but do not mutate",
+		Name:   "n",
+		Usage:  "shorthand for the global --dry-run flag, only report corrupted or missing objects",
 		Hidden: true,
 	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "heal the bucket or prefix again after the given duration until interrupted (e.g. `24h`)",
+	},
 	cli.BoolFlag{
 		Name:   "force-start, f",
 		Usage:  "force start a new heal sequence",
@@ -128,6 +129,15 @@ FLAGS:
 EXAMPLES:
   1. Monitor healing status on a running server at alias 'myminio':
      {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Report the corrupted or missing objects under 'mybucket/photos/' without healing them:
+     {{.Prompt}} {{.HelpName}} --recursive --dry-run myminio/mybucket/photos/
+
+  3. Heal all objects of 'mybucket' in the first set of the second pool:
+     {{.Prompt}} {{.HelpName}} --recursive --pool 2 --set 1 myminio/mybucket
+
+  4. Heal all objects under 'mybucket/logs/' every 24 hours until interrupted:
+     {{.Prompt}} {{.HelpName}} --recursive --interval 24h myminio/mybucket/logs/
 `,
 }
 
@@ -136,6 +146,10 @@ func checkAdminHealSyntax(ctx *cli.Context) {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 
+	if ctx.Duration("interval") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "--interval cannot be negative.")
+	}
+
 	// Check for scan argument
 	scanArg := ctx.String("scan")
 	scanArg = strings.ToLower(scanArg)
@@ -711,7 +725,7 @@ func mainAdminHeal(ctx *cli.Context) error {
 		ScanMode:  transformScanArg(ctx.String("scan")),
 		Remove:    ctx.Bool("remove"),
 		Recursive: ctx.Bool("recursive"),
-		DryRun:    ctx.Bool("dry-run"),
+		DryRun:    globalDryRun || ctx.Bool("n"),
 		Recreate:  ctx.Bool("rewrite"),
 	}
 
@@ -752,30 +766,102 @@ func mainAdminHeal(ctx *cli.Context) error {
 		}
 	}
 
-	healStart, _, e := adminClnt.Heal(globalContext, bucket, prefix, opts, "", forceStart, false)
-	fatalIf(probe.NewError(e), "Unable to start healing.")
+	interval := ctx.Duration("interval")
+	for {
+		healStart, _, e := adminClnt.Heal(globalContext, bucket, prefix, opts, "", forceStart, false)
+		fatalIf(probe.NewError(e), "Unable to start healing.")
+
+		ui := uiData{
+			Bucket:                bucket,
+			Prefix:                prefix,
+			Client:                adminClnt,
+			ClientToken:           healStart.ClientToken,
+			ForceStart:            forceStart,
+			HealOpts:              &opts,
+			ObjectsByOnlineDrives: make(map[int]int64),
+			HealthCols:            make(map[col]int64),
+			CurChan:               cursorAnimate(),
+		}
 
-	ui := uiData{
-		Bucket:                bucket,
-		Prefix:                prefix,
-		Client:                adminClnt,
-		ClientToken:           healStart.ClientToken,
-		ForceStart:            forceStart,
-		HealOpts:              &opts,
-		ObjectsByOnlineDrives: make(map[int]int64),
-		HealthCols:            make(map[col]int64),
-		CurChan:               cursorAnimate(),
-	}
+		res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
+		if e != nil {
+			if res.FailureDetail != "" {
+				data, _ := json.MarshalIndent(res, "", " ")
+				traceStr := string(data)
+				fatalIf(probe.NewError(e).Trace(aliasedURL, traceStr), "Unable to display heal status.")
+			} else {
+				fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to display heal status.")
+			}
+		}
 
-	res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
-	if e != nil {
-		if res.FailureDetail != "" {
-			data, _ := json.MarshalIndent(res, "", " ")
-			traceStr := string(data)
-			fatalIf(probe.NewError(e).Trace(aliasedURL, traceStr), "Unable to display heal status.")
-		} else {
-			fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to display heal status.")
+		if opts.DryRun {
+			ui.printDryRunReport()
 		}
+
+		if interval <= 0 {
+			return nil
+		}
+		if !globalJSON && !globalQuiet {
+			console.Infof("Next heal of '%s' scheduled at %s\n", aliasedURL, time.Now().Add(interval).Format(printDate))
+		}
+		select {
+		case <-globalContext.Done():
+			return nil
+		case <-time.After(interval):
+		}
+		// A new heal sequence is started at each interval.
+		forceStart = false
+	}
+}
+
+// healDryRunMessage is container for an item found damaged during a dry-run.
+type healDryRunMessage struct {
+	Status    string `json:"status"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Missing   int    `json:"missing"`
+	Corrupted int    `json:"corrupted"`
+}
+
+// String colorized heal dry-run message.
+func (s healDryRunMessage) String() string {
+	return fmt.Sprintf("%s %s (%s missing, %s corrupted)",
+		console.Colorize("DiskFailed", "Need heal:"),
+		console.Colorize("HealBackground", s.Name),
+		humanize.Comma(int64(s.Missing)), humanize.Comma(int64(s.Corrupted)))
+}
+
+// JSON jsonified heal dry-run message.
+func (s healDryRunMessage) JSON() string {
+	healJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(healJSONBytes)
+}
+
+// printDryRunReport prints the items that need healing found during a dry-run.
+func (ui *uiData) printDryRunReport() {
+	for _, item := range ui.DamagedItems {
+		h := newHRI(&item)
+		typ, name := h.getHRTypeAndName()
+		missing, _ := h.GetMissingCounts()
+		corrupted, _ := h.GetCorruptedCounts()
+		printMsg(healDryRunMessage{
+			Status:    "success",
+			Type:      typ,
+			Name:      name,
+			Missing:   missing,
+			Corrupted: corrupted,
+		})
+	}
+	// The number of items needing heal is part of the JSON summary.
+	if globalJSON {
+		return
+	}
+	if n := ui.ItemsFailed - int64(len(ui.DamagedItems)); n > 0 {
+		console.Infof("%s more item(s) need healing.\n", humanize.Comma(n))
+	}
+	if ui.ItemsFailed == 0 {
+		console.Infoln("No item needs healing.")
 	}
-	return nil
 }
@@ -0,0 +1,382 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+const (
+	healthConditionNodeOffline    = "node-offline"
+	healthConditionDriveOffline   = "drive-offline"
+	healthConditionQuorumLost     = "quorum-lost"
+	healthConditionReadQuorumLost = "read-quorum-lost"
+	healthConditionUnreachable    = "unreachable"
+)
+
+var healthConditions = []string{
+	healthConditionNodeOffline,
+	healthConditionDriveOffline,
+	healthConditionQuorumLost,
+	healthConditionReadQuorumLost,
+	healthConditionUnreachable,
+}
+
+var adminHealthFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch, w",
+		Usage: "keep monitoring the cluster and print state transitions",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between two health checks in watch mode",
+		Value: 10 * time.Second,
+	},
+	cli.StringSliceFlag{
+		Name:  "fail-on",
+		Usage: "exit with an error when a condition is met: " + strings.Join(healthConditions, ", "),
+	},
+}
+
+var adminHealthCmd = cli.Command{
+	Name:            "health",
+	Usage:           "monitor the health of nodes, drives and quorum",
+	Action:          mainAdminHealth,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(adminHealthFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+CONDITIONS:
+  node-offline      a node is offline
+  drive-offline     a drive is offline, missing or faulty
+  quorum-lost       the cluster lost write quorum
+  read-quorum-lost  the cluster lost read quorum
+  unreachable       the cluster or the state of its nodes cannot be reached
+
+NOTE:
+  '{{.HelpName}}' used to be a deprecated alias of 'mc admin subnet health', use
+  'mc support diag' to generate a health report for SUBNET instead.
+
+EXAMPLES:
+  1. Show the unhealthy nodes and drives of the cluster 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Monitor the cluster 'myminio' every 30 seconds and print state transitions.
+     {{.Prompt}} {{.HelpName}} --watch --interval 30s myminio
+
+  3. Monitor the cluster 'myminio' and exit with an error as soon as a node goes offline or quorum is lost.
+     {{.Prompt}} {{.HelpName}} --watch --fail-on node-offline --fail-on quorum-lost myminio
+`,
+}
+
+// healthState is the state of the cluster components at a point in time.
+type healthState struct {
+	Reachable bool
+	// Error is the reason the cluster is unreachable.
+	Error       string
+	WriteQuorum bool
+	ReadQuorum  bool
+	Nodes       map[string]string
+	Drives      map[string]string
+}
+
+// healthy returns true if the cluster is reachable with write quorum
+// and all its nodes and drives are online.
+func (s healthState) healthy() bool {
+	if !s.Reachable || !s.WriteQuorum {
+		return false
+	}
+	for _, state := range s.Nodes {
+		if !healthyState(state) {
+			return false
+		}
+	}
+	for _, state := range s.Drives {
+		if !healthyState(state) {
+			return false
+		}
+	}
+	return true
+}
+
+// healthEventMessage is container for a cluster state transition.
+type healthEventMessage struct {
+	Status    string    `json:"status"`
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	Condition string    `json:"condition,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// JSON jsonified health event message.
+func (m healthEventMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// String colorized health event message.
+func (m healthEventMessage) String() string {
+	clr := "HealthOK"
+	if m.Condition != "" {
+		clr = "HealthFail"
+	}
+	change := console.Colorize(clr, m.To)
+	if m.From != "" {
+		change = m.From + " -> " + change
+	}
+	if m.Error != "" {
+		change += " (" + m.Error + ")"
+	}
+	return fmt.Sprintf("%s %s %s %s",
+		console.Colorize("Time", "["+m.Time.Format(printDate)+"]"),
+		console.Colorize("HealthType", fmt.Sprintf("%-7s", m.Type)),
+		m.Name, change)
+}
+
+// healthyState returns true if the state of a node or a drive is healthy.
+func healthyState(state string) bool {
+	switch state {
+	case string(madmin.ItemOnline), madmin.DriveStateOk, madmin.DriveStateUnformatted:
+		return true
+	}
+	return false
+}
+
+func quorumState(ok bool) string {
+	if ok {
+		return "ok"
+	}
+	return "lost"
+}
+
+// healthTransitions returns the state transitions between two states, when
+// prev is nil only the unhealthy components of the current state are returned.
+func healthTransitions(prev *healthState, curr healthState, now time.Time) (events []healthEventMessage) {
+	add := func(typ, name, from, to, condition string) {
+		events = append(events, healthEventMessage{
+			Status:    "success",
+			Time:      now,
+			Type:      typ,
+			Name:      name,
+			From:      from,
+			To:        to,
+			Condition: condition,
+		})
+	}
+
+	if prev == nil {
+		prev = &healthState{Reachable: true, WriteQuorum: true, ReadQuorum: true}
+	}
+
+	if prev.Reachable != curr.Reachable {
+		if curr.Reachable {
+			add("cluster", "cluster", "unreachable", "reachable", "")
+		} else {
+			add("cluster", "cluster", "reachable", "unreachable", healthConditionUnreachable)
+			events[len(events)-1].Error = curr.Error
+		}
+	}
+	if !curr.Reachable {
+		return events
+	}
+
+	if prev.WriteQuorum != curr.WriteQuorum {
+		condition := ""
+		if !curr.WriteQuorum {
+			condition = healthConditionQuorumLost
+		}
+		add("quorum", "write", quorumState(prev.WriteQuorum), quorumState(curr.WriteQuorum), condition)
+	}
+	if prev.ReadQuorum != curr.ReadQuorum {
+		condition := ""
+		if !curr.ReadQuorum {
+			condition = healthConditionReadQuorumLost
+		}
+		add("quorum", "read", quorumState(prev.ReadQuorum), quorumState(curr.ReadQuorum), condition)
+	}
+
+	compare := func(typ, condition string, prevStates, currStates map[string]string) {
+		names := make([]string, 0, len(currStates))
+		for name := range currStates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			from, to := prevStates[name], currStates[name]
+			if from == to || (from == "" && healthyState(to)) {
+				continue
+			}
+			if healthyState(to) {
+				add(typ, name, from, to, "")
+			} else {
+				add(typ, name, from, to, condition)
+			}
+		}
+	}
+	compare("node", healthConditionNodeOffline, prev.Nodes, curr.Nodes)
+	compare("drive", healthConditionDriveOffline, prev.Drives, curr.Drives)
+	return events
+}
+
+// fetchHealthState fetches the state of the nodes, drives and quorum of the
+// cluster. The cluster is unreachable when the state of its nodes cannot be
+// fetched, so that offline nodes are never missed.
+func fetchHealthState(ctx context.Context, client *madmin.AdminClient, anonClient *madmin.AnonymousClient) healthState {
+	state := healthState{
+		Nodes:  make(map[string]string),
+		Drives: make(map[string]string),
+	}
+
+	health, e := anonClient.Healthy(ctx, madmin.HealthOpts{})
+	if e != nil {
+		state.Error = e.Error()
+		return state
+	}
+	state.WriteQuorum = health.Healthy
+
+	health, e = anonClient.Healthy(ctx, madmin.HealthOpts{ClusterRead: true})
+	state.ReadQuorum = e == nil && health.Healthy
+
+	info, e := client.ServerInfo(ctx)
+	if e != nil {
+		state.Error = e.Error()
+		return state
+	}
+	state.Reachable = true
+	for _, srv := range info.Servers {
+		state.Nodes[srv.Endpoint] = srv.State
+		for _, disk := range srv.Disks {
+			state.Drives[disk.Endpoint] = disk.State
+		}
+	}
+	return state
+}
+
+func checkAdminHealthSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.Duration("interval") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--interval must be greater than zero.")
+	}
+	for _, condition := range ctx.StringSlice("fail-on") {
+		var found bool
+		for _, c := range healthConditions {
+			if c == condition {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fatalIf(errInvalidArgument().Trace(condition), "Unknown condition, supported conditions are: "+strings.Join(healthConditions, ", ")+".")
+		}
+	}
+}
+
+// mainAdminHealth is the handle for "mc admin health" command.
+func mainAdminHealth(ctx *cli.Context) error {
+	checkAdminHealthSyntax(ctx)
+
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("HealthType", color.New(color.FgCyan, color.Bold))
+	console.SetColor("HealthOK", color.New(color.FgGreen, color.Bold))
+	console.SetColor("HealthFail", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin connection.")
+
+	anonClient, err := newAnonymousClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize anonymous client.")
+
+	failOn := make(map[string]bool)
+	for _, condition := range ctx.StringSlice("fail-on") {
+		failOn[condition] = true
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var prev *healthState
+	for {
+		select {
+		case <-globalContext.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		curr := fetchHealthState(globalContext, client, anonClient)
+		if globalContext.Err() != nil {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		events := healthTransitions(prev, curr, now)
+		if prev == nil && len(events) == 0 {
+			events = append(events, healthEventMessage{
+				Status: "success",
+				Time:   now,
+				Type:   "cluster",
+				Name:   "cluster",
+				To:     "healthy",
+			})
+		}
+
+		var failed bool
+		for _, event := range events {
+			printMsg(event)
+			if failOn[event.Condition] {
+				failed = true
+			}
+		}
+		if failed {
+			return exitStatus(globalErrorExitStatus)
+		}
+		if !ctx.Bool("watch") {
+			return nil
+		}
+
+		prev = &curr
+		timer.Reset(ctx.Duration("interval"))
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthTransitions(t *testing.T) {
+	healthy := healthState{
+		Reachable:   true,
+		WriteQuorum: true,
+		ReadQuorum:  true,
+		Nodes:       map[string]string{"node1": "online", "node2": "online"},
+		Drives:      map[string]string{"node1/d1": "ok", "node2/d1": "ok"},
+	}
+	degraded := healthState{
+		Reachable:   true,
+		WriteQuorum: false,
+		ReadQuorum:  true,
+		Nodes:       map[string]string{"node1": "online", "node2": "offline"},
+		Drives:      map[string]string{"node1/d1": "ok", "node2/d1": "offline"},
+	}
+
+	if !healthy.healthy() || degraded.healthy() || (healthState{}).healthy() {
+		t.Fatal("unexpected cluster health")
+	}
+
+	if events := healthTransitions(nil, healthy, time.Now()); len(events) != 0 {
+		t.Fatalf("expected no events for a healthy cluster, got %v", events)
+	}
+
+	events := healthTransitions(&healthy, degraded, time.Now())
+	expected := []struct{ typ, name, condition string }{
+		{"quorum", "write", healthConditionQuorumLost},
+		{"node", "node2", healthConditionNodeOffline},
+		{"drive", "node2/d1", healthConditionDriveOffline},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %v", len(expected), events)
+	}
+	for i, e := range expected {
+		if events[i].Type != e.typ || events[i].Name != e.name || events[i].Condition != e.condition {
+			t.Errorf("event %d: expected %v, got %+v", i, e, events[i])
+		}
+	}
+
+	events = healthTransitions(&degraded, healthy, time.Now())
+	for _, event := range events {
+		if event.Condition != "" {
+			t.Errorf("expected recovery events only, got %+v", event)
+		}
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 recovery events, got %v", events)
+	}
+
+	events = healthTransitions(&healthy, healthState{}, time.Now())
+	if len(events) != 1 || events[0].Condition != healthConditionUnreachable {
+		t.Fatalf("expected unreachable event, got %v", events)
+	}
+
+	// Failing to fetch the state of the nodes must not look healthy.
+	noServerInfo := healthState{WriteQuorum: true, ReadQuorum: true, Error: "Access Denied."}
+	events = healthTransitions(nil, noServerInfo, time.Now())
+	if len(events) != 1 || events[0].Condition != healthConditionUnreachable || events[0].Error != noServerInfo.Error {
+		t.Fatalf("expected unreachable event with its error, got %v", events)
+	}
+}
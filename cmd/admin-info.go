@@ -41,6 +41,14 @@ var adminInfoFlags = []cli.Flag{
 		Name:  "offline",
 		Usage: "show only offline nodes/drives",
 	},
+	cli.BoolFlag{
+		Name:  "sets",
+		Usage: "show drives and usage of each erasure set",
+	},
+	cli.StringFlag{
+		Name:  "output",
+		Usage: "output format, 'prometheus' prints the key metrics in Prometheus exposition format",
+	},
 }
 
 var adminInfoCmd = cli.Command{
@@ -62,6 +70,15 @@ FLAGS:
 EXAMPLES:
   1. Get server information of the 'play' MinIO server.
      {{.Prompt}} {{.HelpName}} play/
+
+  2. Show only the unhealthy nodes and drives of the 'myminio' cluster.
+     {{.Prompt}} {{.HelpName}} --offline myminio/
+
+  3. Show the drives and usage of each erasure set of the 'myminio' cluster.
+     {{.Prompt}} {{.HelpName}} --sets myminio/
+
+  4. Print the key metrics of the 'myminio' cluster in Prometheus exposition format.
+     {{.Prompt}} {{.HelpName}} --output prometheus myminio/
 `,
 }
 
@@ -119,6 +136,151 @@ func clusterSummaryInfo(info madmin.InfoMessage) clusterInfo {
 	return summary
 }
 
+type setSummary struct {
+	pool, set          int
+	drivesOnline       int
+	drivesTotal        int
+	drivesTotalSpace   uint64
+	drivesUsedSpace    uint64
+	drivesHealingCount int
+}
+
+// setsSummaryInfo returns the drives summary of each erasure set,
+// sorted by pool and set index.
+func setsSummaryInfo(info madmin.InfoMessage) []*setSummary {
+	sets := make(map[[2]int]*setSummary)
+	for _, srv := range info.Servers {
+		for _, disk := range srv.Disks {
+			if disk.PoolIndex < 0 || disk.SetIndex < 0 {
+				continue
+			}
+			key := [2]int{disk.PoolIndex, disk.SetIndex}
+			s := sets[key]
+			if s == nil {
+				s = &setSummary{pool: disk.PoolIndex, set: disk.SetIndex}
+				sets[key] = s
+			}
+			s.drivesTotal++
+			switch disk.State {
+			case madmin.DriveStateOk, madmin.DriveStateUnformatted:
+				s.drivesOnline++
+			}
+			if disk.Healing {
+				s.drivesHealingCount++
+			}
+			s.drivesTotalSpace += disk.TotalSpace
+			s.drivesUsedSpace += disk.UsedSpace
+		}
+	}
+
+	summary := make([]*setSummary, 0, len(sets))
+	for _, s := range sets {
+		summary = append(summary, s)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].pool != summary[j].pool {
+			return summary[i].pool < summary[j].pool
+		}
+		return summary[i].set < summary[j].set
+	})
+	return summary
+}
+
+// setsSummaryTable returns the per erasure set table.
+func setsSummaryTable(info madmin.InfoMessage) string {
+	sets := setsSummaryInfo(info)
+
+	printColors := []*color.Color{getPrintCol(colGreen)}
+	for range sets {
+		printColors = append(printColors, getPrintCol(colGrey))
+	}
+	tbl := console.NewTable(printColors, []bool{false, false, false, false, false}, 0)
+
+	cellText := make([][]string, 0, len(sets)+1)
+	cellText = append(cellText, []string{
+		"Pool",
+		"Set",
+		"Drives Online",
+		"Drives Healing",
+		"Drives Usage",
+	})
+	for _, s := range sets {
+		usage := "0% (total: 0B)"
+		if s.drivesTotalSpace > 0 {
+			usage = fmt.Sprintf("%.1f%% (total: %s)", 100*float64(s.drivesUsedSpace)/float64(s.drivesTotalSpace), humanize.IBytes(s.drivesTotalSpace))
+		}
+		cellText = append(cellText, []string{
+			humanize.Ordinal(s.pool + 1),
+			humanize.Ordinal(s.set + 1),
+			fmt.Sprintf("%d/%d", s.drivesOnline, s.drivesTotal),
+			strconv.Itoa(s.drivesHealingCount),
+			usage,
+		})
+	}
+
+	var builder strings.Builder
+	e := tbl.PopulateTable(&builder, cellText)
+	fatalIf(probe.NewError(e), "unable to populate the table")
+	return builder.String()
+}
+
+// clusterInfoPrometheus returns the key metrics of the cluster
+// in Prometheus text exposition format.
+func clusterInfoPrometheus(info madmin.InfoMessage) string {
+	var b strings.Builder
+	metric := func(name, help string, samples ...func() (labels string, value float64)) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, sample := range samples {
+			labels, value := sample()
+			fmt.Fprintf(&b, "%s%s %s\n", name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+	value := func(v float64) func() (string, float64) {
+		return func() (string, float64) { return "", v }
+	}
+
+	var nodesOnline, nodesOffline int
+	for _, srv := range info.Servers {
+		if srv.State == string(madmin.ItemOnline) {
+			nodesOnline++
+		} else {
+			nodesOffline++
+		}
+	}
+
+	metric("minio_cluster_nodes_online_total", "Total number of MinIO nodes online", value(float64(nodesOnline)))
+	metric("minio_cluster_nodes_offline_total", "Total number of MinIO nodes offline", value(float64(nodesOffline)))
+	metric("minio_cluster_drives_online_total", "Total number of drives online", value(float64(info.Backend.OnlineDisks)))
+	metric("minio_cluster_drives_offline_total", "Total number of drives offline", value(float64(info.Backend.OfflineDisks)))
+	metric("minio_cluster_usage_total_bytes", "Total cluster usage in bytes", value(float64(info.Usage.Size)))
+	metric("minio_cluster_buckets_total", "Total number of buckets in the cluster", value(float64(info.Buckets.Count)))
+	metric("minio_cluster_objects_total", "Total number of objects in the cluster", value(float64(info.Objects.Count)))
+
+	sets := setsSummaryInfo(info)
+	setSamples := func(f func(s *setSummary) float64) (samples []func() (string, float64)) {
+		for _, s := range sets {
+			s := s
+			samples = append(samples, func() (string, float64) {
+				return fmt.Sprintf("{pool=\"%d\",set=\"%d\"}", s.pool+1, s.set+1), f(s)
+			})
+		}
+		return samples
+	}
+	if len(sets) > 0 {
+		metric("minio_cluster_set_drives_online_total", "Number of drives online in the erasure set",
+			setSamples(func(s *setSummary) float64 { return float64(s.drivesOnline) })...)
+		metric("minio_cluster_set_drives_total", "Number of drives in the erasure set",
+			setSamples(func(s *setSummary) float64 { return float64(s.drivesTotal) })...)
+		metric("minio_cluster_set_drives_healing_total", "Number of drives healing in the erasure set",
+			setSamples(func(s *setSummary) float64 { return float64(s.drivesHealingCount) })...)
+		metric("minio_cluster_set_capacity_total_bytes", "Total capacity of the drives in the erasure set",
+			setSamples(func(s *setSummary) float64 { return float64(s.drivesTotalSpace) })...)
+		metric("minio_cluster_set_capacity_used_bytes", "Used capacity of the drives in the erasure set",
+			setSamples(func(s *setSummary) float64 { return float64(s.drivesUsedSpace) })...)
+	}
+	return b.String()
+}
+
 func endpointToPools(endpoint string, c clusterInfo) (pools []int) {
 	for poolNumber, poolSummary := range c {
 		if poolSummary.endpoints.Contains(endpoint) {
@@ -136,6 +298,7 @@ type clusterStruct struct {
 	Info   madmin.InfoMessage `json:"info,omitempty"`
 
 	onlyOffline bool
+	showSets    bool
 }
 
 // String provides colorized info messages
@@ -212,6 +375,22 @@ func (u clusterStruct) String() (msg string) {
 		}
 
 		if u.onlyOffline {
+			var offlineDrives []string
+			for _, disk := range srv.Disks {
+				switch disk.State {
+				case madmin.DriveStateOk, madmin.DriveStateUnformatted:
+				default:
+					offlineDrives = append(offlineDrives, fmt.Sprintf("%s (%s)", disk.DrivePath, disk.State))
+				}
+			}
+			if len(offlineDrives) == 0 {
+				continue
+			}
+			msg += fmt.Sprintf("%s  %s\n", console.Colorize("InfoWarning", dot), console.Colorize("PrintB", srv.Endpoint))
+			for _, drive := range offlineDrives {
+				msg += fmt.Sprintf("   Drive: %s\n", console.Colorize("InfoFail", drive))
+			}
+			msg += "\n"
 			continue
 		}
 
@@ -326,6 +505,10 @@ func (u clusterStruct) String() (msg string) {
 		fatalIf(probe.NewError(e), "unable to populate the table")
 
 		msg += builder.String() + "\n"
+
+		if u.showSets {
+			msg += setsSummaryTable(u.Info) + "\n"
+		}
 	}
 
 	// Summary on used space, total no of buckets and
@@ -374,6 +557,15 @@ func checkAdminInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	switch ctx.String("output") {
+	case "":
+	case "prometheus":
+		if globalJSON {
+			fatalIf(errInvalidArgument().Trace(), "--output prometheus cannot be used with --json.")
+		}
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("output")), "Unsupported output format, only 'prometheus' is supported.")
+	}
 }
 
 func mainAdminInfo(ctx *cli.Context) error {
@@ -389,6 +581,7 @@ func mainAdminInfo(ctx *cli.Context) error {
 
 	clusterInfo := clusterStruct{
 		onlyOffline: ctx.Bool("offline"),
+		showSets:    ctx.Bool("sets"),
 	}
 
 	// Fetch info of all servers (cluster or single server)
@@ -402,6 +595,11 @@ func mainAdminInfo(ctx *cli.Context) error {
 	}
 
 	clusterInfo.Info = admInfo
+	if ctx.String("output") == "prometheus" {
+		fatalIf(probe.NewError(e), "Unable to get service info")
+		fmt.Print(clusterInfoPrometheus(admInfo))
+		return nil
+	}
 	printMsg(clusterInfo)
 
 	return nil
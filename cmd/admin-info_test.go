@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestClusterInfoPrometheus(t *testing.T) {
+	info := madmin.InfoMessage{
+		Servers: []madmin.ServerProperties{
+			{
+				State: string(madmin.ItemOnline),
+				Disks: []madmin.Disk{
+					{PoolIndex: 0, SetIndex: 0, State: madmin.DriveStateOk, TotalSpace: 100, UsedSpace: 10},
+					{PoolIndex: 0, SetIndex: 1, State: madmin.DriveStateOffline, TotalSpace: 100},
+				},
+			},
+			{
+				State: string(madmin.ItemOffline),
+				Disks: []madmin.Disk{
+					{PoolIndex: 0, SetIndex: 0, State: madmin.DriveStateOk, TotalSpace: 100, UsedSpace: 30, Healing: true},
+				},
+			},
+		},
+	}
+	info.Buckets.Count = 3
+
+	out := clusterInfoPrometheus(info)
+	for _, line := range []string{
+		"# TYPE minio_cluster_nodes_online_total gauge",
+		"minio_cluster_nodes_online_total 1",
+		"minio_cluster_nodes_offline_total 1",
+		"minio_cluster_buckets_total 3",
+		`minio_cluster_set_drives_online_total{pool="1",set="1"} 2`,
+		`minio_cluster_set_drives_online_total{pool="1",set="2"} 0`,
+		`minio_cluster_set_drives_healing_total{pool="1",set="1"} 1`,
+		`minio_cluster_set_capacity_used_bytes{pool="1",set="1"} 40`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("expected %q in output:\n%s", line, out)
+		}
+	}
+}
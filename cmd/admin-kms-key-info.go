@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2023 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminKMSKeyInfoCmd = cli.Command{
+	Name:         "info",
+	Usage:        "display KMS master key information and test an encrypt/decrypt round trip",
+	Action:       mainAdminKMSKeyInfo,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [KEY_NAME]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Display information about the default master key of a MinIO server/cluster.
+     $ {{.HelpName}} play
+  2. Display information about the master key 'my-master-key' of a MinIO server/cluster.
+     $ {{.HelpName}} play my-master-key
+`,
+}
+
+type kmsKeyInfoMsg struct {
+	Status        string     `json:"status"`
+	KMS           string     `json:"kms,omitempty"`
+	KeyID         string     `json:"keyId"`
+	Default       bool       `json:"default"`
+	CreatedAt     *time.Time `json:"createdAt,omitempty"`
+	CreatedBy     string     `json:"createdBy,omitempty"`
+	EncryptionErr string     `json:"encryptionError,omitempty"`
+	DecryptionErr string     `json:"decryptionError,omitempty"`
+}
+
+func (k kmsKeyInfoMsg) JSON() string {
+	k.Status = "success"
+	kmsBytes, e := json.MarshalIndent(k, "", "    ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(kmsBytes)
+}
+
+func (k kmsKeyInfoMsg) String() string {
+	msg := fmt.Sprintf("Key: %s\n", console.Colorize("KeyName", k.KeyID))
+	if k.KMS != "" {
+		msg += fmt.Sprintf("   - KMS: %s\n", k.KMS)
+	}
+	if k.Default {
+		msg += "   - Default: yes\n"
+	}
+	if k.CreatedAt != nil {
+		msg += fmt.Sprintf("   - Created: %s\n", k.CreatedAt.Format(printDate))
+	}
+	if k.CreatedBy != "" {
+		msg += fmt.Sprintf("   - Created by: %s\n", k.CreatedBy)
+	}
+	return msg + kmsKeyStatusMsg{
+		KeyID:         k.KeyID,
+		EncryptionErr: k.EncryptionErr,
+		DecryptionErr: k.DecryptionErr,
+	}.roundTrip()
+}
+
+// mainAdminKMSKeyInfo is the handle for the "mc admin kms key info" command.
+func mainAdminKMSKeyInfo(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+
+	console.SetColor("KeyName", color.New(color.FgBlue))
+	console.SetColor("StatusSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("StatusError", color.New(color.FgRed, color.Bold))
+	console.SetColor("StatusUnknown", color.New(color.FgYellow, color.Bold))
+
+	client, err := newAdminClient(ctx.Args().Get(0))
+	fatalIf(err, "Unable to get a configured admin connection.")
+
+	kmsStatus, e := client.KMSStatus(globalContext)
+	fatalIf(probe.NewError(e), "Unable to get KMS status")
+
+	keyID := ctx.Args().Get(1)
+	if keyID == "" {
+		keyID = kmsStatus.DefaultKeyID
+	}
+
+	// The status request encrypts and decrypts a test value with the key.
+	status, e := client.GetKeyStatus(globalContext, keyID)
+	fatalIf(probe.NewError(e).Trace(keyID), "Failed to get status information")
+
+	msg := kmsKeyInfoMsg{
+		KMS:           kmsStatus.Name,
+		KeyID:         status.KeyID,
+		Default:       status.KeyID == kmsStatus.DefaultKeyID,
+		EncryptionErr: status.EncryptionErr,
+		DecryptionErr: status.DecryptionErr,
+	}
+
+	// Not all KMS support listing keys, the metadata is optional.
+	if keys, e := client.ListKeys(globalContext, status.KeyID); e == nil {
+		for _, key := range keys {
+			if key.Name == status.KeyID {
+				if !key.CreatedAt.IsZero() {
+					msg.CreatedAt = &key.CreatedAt
+				}
+				msg.CreatedBy = key.CreatedBy
+				break
+			}
+		}
+	}
+
+	printMsg(msg)
+	return nil
+}
@@ -41,7 +41,7 @@ var adminKMSKeyListCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [PATTERN]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -49,12 +49,14 @@ FLAGS:
 EXAMPLES:
   1. Get list of master keys from a MinIO server/cluster.
      $ {{.HelpName}} play
+  2. Get list of master keys starting with 'app-' from a MinIO server/cluster.
+     $ {{.HelpName}} play 'app-*'
 `,
 }
 
 // adminKMSKeyCmd is the handle for the "mc admin kms key" command.
 func mainAdminKMSKeyList(ctx *cli.Context) error {
-	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 
@@ -68,13 +70,21 @@ func mainAdminKMSKeyList(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	keys, e := client.ListKeys(globalContext, "*")
+	pattern := "*"
+	if len(args) == 2 {
+		pattern = args.Get(1)
+	}
+	keys, e := client.ListKeys(globalContext, pattern)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to list KMS keys")
 
 	var rows []table.Row
 	kmsKeys := []string{}
 	for idx, k := range keys {
-		rows = append(rows, table.Row{idx + 1, k.Name})
+		var createdAt string
+		if !k.CreatedAt.IsZero() {
+			createdAt = k.CreatedAt.Format(printDate)
+		}
+		rows = append(rows, table.Row{idx + 1, k.Name, createdAt, k.CreatedBy})
 		kmsKeys = append(kmsKeys, k.Name)
 	}
 
@@ -91,7 +101,7 @@ func mainAdminKMSKeyList(ctx *cli.Context) error {
 	t.SetOutputMirror(os.Stdout)
 	t.SetColumnConfigs([]table.ColumnConfig{{Align: text.AlignCenter}})
 	t.SetTitle("KMS Keys")
-	t.AppendHeader(table.Row{"S N", "Name"})
+	t.AppendHeader(table.Row{"S N", "Name", "Created", "Created By"})
 	t.AppendRows(rows)
 	t.SetStyle(table.StyleLight)
 	t.Render()
@@ -95,8 +95,11 @@ func (s kmsKeyStatusMsg) JSON() string {
 }
 
 func (s kmsKeyStatusMsg) String() string {
-	msg := fmt.Sprintf("Key: %s\n", s.KeyID)
+	return fmt.Sprintf("Key: %s\n", s.KeyID) + s.roundTrip()
+}
 
+// roundTrip returns the result of the encryption and decryption tests.
+func (s kmsKeyStatusMsg) roundTrip() (msg string) {
 	success := console.Colorize("StatusSuccess", "✔")
 	failure := console.Colorize("StatusError", "✗")
 	dunno := console.Colorize("StatusUnknown", "?")
@@ -23,6 +23,7 @@ var adminKMSKeySubcommands = []cli.Command{
 	adminKMSCreateKeyCmd,
 	adminKMSKeyStatusCmd,
 	adminKMSKeyListCmd,
+	adminKMSKeyInfoCmd,
 }
 
 var adminKMSKeyCmd = cli.Command{
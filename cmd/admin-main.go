@@ -43,7 +43,8 @@ var adminCmdSubcommands = []cli.Command{
 	adminHealCmd,
 	adminPrometheusCmd,
 	adminKMSCmd,
-	adminHealthCmd(),
+	adminHealthCmd,
+	adminUsageCmd,
 	adminSubnetCmd,
 	adminBucketCmd,
 	adminTierCmd,
@@ -96,9 +96,11 @@ func userAttachOrDetachPolicy(ctx *cli.Context, attach bool) error {
 
 	var e error
 	var res madmin.PolicyAssociationResp
-	if attach {
+	switch {
+	case globalDryRun:
+	case attach:
 		res, e = client.AttachPolicy(globalContext, req)
-	} else {
+	default:
 		res, e = client.DetachPolicy(globalContext, req)
 	}
 
@@ -125,6 +127,7 @@ func userAttachOrDetachPolicy(ctx *cli.Context, attach bool) error {
 		PoliciesDetached: res.PoliciesDetached,
 		User:             user,
 		Group:            group,
+		DryRun:           globalDryRun,
 	}
 	printMsg(m)
 	return nil
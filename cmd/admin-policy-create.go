@@ -35,7 +35,12 @@ var adminPolicyCreateCmd = cli.Command{
 	Action:       mainAdminPolicyCreate,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags: append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "create the policy without validating it locally",
+		},
+	}, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -54,6 +59,9 @@ FLAGS:
 EXAMPLES:
   1. Create a new canned policy 'writeonly'.
      {{.Prompt}} {{.HelpName}} myminio writeonly /tmp/writeonly.json
+
+  2. Create a new canned policy 'custom' without validating it locally.
+     {{.Prompt}} {{.HelpName}} myminio custom /tmp/custom.json --force
  `,
 }
 
@@ -72,6 +80,7 @@ type userPolicyMessage struct {
 	PolicyInfo  madmin.PolicyInfo `json:"policyInfo,omitempty"`
 	UserOrGroup string            `json:"userOrGroup,omitempty"`
 	IsGroup     bool              `json:"isGroup"`
+	DryRun      bool              `json:"dryRun,omitempty"`
 }
 
 func (u userPolicyMessage) accountType() string {
@@ -94,8 +103,14 @@ func (u userPolicyMessage) String() string {
 	case "list":
 		return console.Colorize("PolicyName", u.Policy)
 	case "remove":
+		if u.DryRun {
+			return console.Colorize("PolicyMessage", "Would remove policy `"+u.Policy+"`.")
+		}
 		return console.Colorize("PolicyMessage", "Removed policy `"+u.Policy+"` successfully.")
 	case "create":
+		if u.DryRun {
+			return console.Colorize("PolicyMessage", "Would create policy `"+u.Policy+"`.")
+		}
 		return console.Colorize("PolicyMessage", "Created policy `"+u.Policy+"` successfully.")
 	case "detach":
 		return console.Colorize("PolicyMessage",
@@ -129,15 +144,29 @@ func mainAdminPolicyCreate(ctx *cli.Context) error {
 	policy, e := os.ReadFile(args.Get(2))
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to get policy")
 
+	if !ctx.Bool("force") {
+		warnings, e := validatePolicy(policy)
+		fatalIf(probe.NewError(e).Trace(args.Get(2)), "Invalid policy, use --force to create it anyway")
+		if !globalJSON {
+			setPolicyValidateColors()
+			for _, warning := range warnings {
+				console.Println(console.Colorize("PolicyWarning", "Warning: "+warning))
+			}
+		}
+	}
+
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	fatalIf(probe.NewError(client.AddCannedPolicy(globalContext, args.Get(1), policy)).Trace(args...), "Unable to create new policy")
+	if !globalDryRun {
+		fatalIf(probe.NewError(client.AddCannedPolicy(globalContext, args.Get(1), policy)).Trace(args...), "Unable to create new policy")
+	}
 
 	printMsg(userPolicyMessage{
 		op:     ctx.Command.Name,
 		Policy: args.Get(1),
+		DryRun: globalDryRun,
 	})
 
 	return nil
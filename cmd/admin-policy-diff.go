@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/policy"
+)
+
+var adminPolicyDiffCmd = cli.Command{
+	Name:         "diff",
+	Usage:        "show the statement differences between two IAM policies",
+	Action:       mainAdminPolicyDiff,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET POLICYA POLICYB
+
+POLICYA, POLICYB:
+  Name of a policy on MinIO server, or 'file:PATH' for a local policy file.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the differences between the canned policies 'readonly' and 'readwrite'.
+     {{.Prompt}} {{.HelpName}} myminio readonly readwrite
+
+  2. Show the changes of the local file '/tmp/writeonly.json' compared to the policy 'writeonly'.
+     {{.Prompt}} {{.HelpName}} myminio writeonly file:/tmp/writeonly.json
+`,
+}
+
+// policyDiffMessage container for policy diff results
+type policyDiffMessage struct {
+	Status  string             `json:"status"`
+	PolicyA string             `json:"policyA"`
+	PolicyB string             `json:"policyB"`
+	Removed []policy.Statement `json:"removed,omitempty"`
+	Added   []policy.Statement `json:"added,omitempty"`
+}
+
+func (m policyDiffMessage) String() string {
+	if len(m.Removed) == 0 && len(m.Added) == 0 {
+		return console.Colorize("PolicyMessage", fmt.Sprintf("Policies `%s` and `%s` have the same statements.", m.PolicyA, m.PolicyB))
+	}
+	var lines []string
+	lines = append(lines, console.Colorize("PolicyDiffRemoved", "--- "+m.PolicyA))
+	lines = append(lines, console.Colorize("PolicyDiffAdded", "+++ "+m.PolicyB))
+	for _, st := range m.Removed {
+		lines = append(lines, console.Colorize("PolicyDiffRemoved", "- "+policyStatementString(st)))
+	}
+	for _, st := range m.Added {
+		lines = append(lines, console.Colorize("PolicyDiffAdded", "+ "+policyStatementString(st)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m policyDiffMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// policyStatementString returns the statement as a single line of JSON.
+func policyStatementString(st policy.Statement) string {
+	buf, e := json.Marshal(st)
+	if e != nil {
+		return fmt.Sprintf("%v", st)
+	}
+	return string(buf)
+}
+
+// diffPolicyStatements returns the statements of a missing from b,
+// and the statements of b missing from a.
+func diffPolicyStatements(a, b policy.Policy) (removed, added []policy.Statement) {
+	contains := func(statements []policy.Statement, st policy.Statement) bool {
+		for _, s := range statements {
+			if s.Equals(st) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, st := range a.Statements {
+		if !contains(b.Statements, st) {
+			removed = append(removed, st)
+		}
+	}
+	for _, st := range b.Statements {
+		if !contains(a.Statements, st) {
+			added = append(added, st)
+		}
+	}
+	return removed, added
+}
+
+// policyFilePrefix marks a policy argument as a local policy file.
+const policyFilePrefix = "file:"
+
+// loadPolicy reads a local policy file given as 'file:PATH', or fetches
+// the policy with the given name from the server.
+func loadPolicy(client *madmin.AdminClient, nameOrFile string) (*policy.Policy, *probe.Error) {
+	file, ok := strings.CutPrefix(nameOrFile, policyFilePrefix)
+	if !ok {
+		return fetchPolicy(client, nameOrFile)
+	}
+	data, e := os.ReadFile(file)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return parsePolicy(data)
+}
+
+// fetchPolicy fetches the policy with the given name from the server.
+func fetchPolicy(client *madmin.AdminClient, name string) (*policy.Policy, *probe.Error) {
+	info, e := client.InfoCannedPolicyV2(globalContext, name)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return parsePolicy(info.Policy)
+}
+
+// parsePolicy parses a policy document.
+func parsePolicy(data []byte) (*policy.Policy, *probe.Error) {
+	p, e := policy.ParseConfig(bytes.NewReader(data))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return p, nil
+}
+
+// checkAdminPolicyDiffSyntax - validate all the passed arguments
+func checkAdminPolicyDiffSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 3 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminPolicyDiff is the handle for "mc admin policy diff" command.
+func mainAdminPolicyDiff(ctx *cli.Context) error {
+	checkAdminPolicyDiffSyntax(ctx)
+
+	console.SetColor("PolicyMessage", color.New(color.FgGreen))
+	console.SetColor("PolicyDiffRemoved", color.New(color.FgRed))
+	console.SetColor("PolicyDiffAdded", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	policyA, err := loadPolicy(client, args.Get(1))
+	fatalIf(err.Trace(args.Get(1)), "Unable to get policy")
+	policyB, err := loadPolicy(client, args.Get(2))
+	fatalIf(err.Trace(args.Get(2)), "Unable to get policy")
+
+	removed, added := diffPolicyStatements(*policyA, *policyB)
+	printMsg(policyDiffMessage{
+		Status:  "success",
+		PolicyA: args.Get(1),
+		PolicyB: args.Get(2),
+		Removed: removed,
+		Added:   added,
+	})
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy"
+)
+
+func mustParsePolicy(t *testing.T, s string) policy.Policy {
+	t.Helper()
+	p, e := policy.ParseConfig(bytes.NewReader([]byte(s)))
+	if e != nil {
+		t.Fatal(e)
+	}
+	return *p
+}
+
+func TestDiffPolicyStatements(t *testing.T) {
+	a := mustParsePolicy(t, `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]},
+		{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::mybucket"]}]}`)
+	b := mustParsePolicy(t, `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::mybucket"]},
+		{"Effect":"Allow","Action":["s3:PutObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`)
+
+	removed, added := diffPolicyStatements(a, b)
+	if len(removed) != 1 || !removed[0].Actions.Contains(policy.GetObjectAction) {
+		t.Fatalf("unexpected removed statements: %v", removed)
+	}
+	if len(added) != 1 || !added[0].Actions.Contains(policy.PutObjectAction) {
+		t.Fatalf("unexpected added statements: %v", added)
+	}
+
+	removed, added = diffPolicyStatements(a, a)
+	if len(removed) != 0 || len(added) != 0 {
+		t.Fatalf("expected no differences, got %v %v", removed, added)
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "readonly.json")
+	data := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`
+	if e := os.WriteFile(file, []byte(data), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	p, err := loadPolicy(nil, policyFilePrefix+file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Statements) != 1 || !p.Statements[0].Actions.Contains(policy.GetObjectAction) {
+		t.Fatalf("unexpected policy: %v", p)
+	}
+}
+
+func TestEvaluatePolicies(t *testing.T) {
+	policies := []namedPolicy{
+		{Name: "readwrite", Policy: mustParsePolicy(t, `{"Version":"2012-10-17","Statement":[
+			{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`)},
+		{Name: "denydelete", Policy: mustParsePolicy(t, `{"Version":"2012-10-17","Statement":[
+			{"Effect":"Deny","Action":["s3:DeleteObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`)},
+		{Name: "iprestricted", Policy: mustParsePolicy(t, `{"Version":"2012-10-17","Statement":[
+			{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::other/*"],
+			 "Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}]}`)},
+	}
+
+	testCases := []struct {
+		action     string
+		resource   string
+		conditions []string
+		allowed    bool
+		policy     string
+	}{
+		{"s3:PutObject", "mybucket/object", nil, true, "readwrite"},
+		{"s3:DeleteObject", "mybucket/object", nil, false, "denydelete"},
+		{"s3:PutObject", "otherbucket/object", nil, false, ""},
+		{"s3:GetObject", "other/object", []string{"aws:SourceIp=10.0.0.1"}, true, "iprestricted"},
+		{"s3:GetObject", "other/object", []string{"aws:SourceIp=192.168.0.1"}, false, ""},
+	}
+	for i, testCase := range testCases {
+		args, e := policyTestArgs(testCase.action, testCase.resource, testCase.conditions)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		allowed, name, _ := evaluatePolicies(policies, args)
+		if allowed != testCase.allowed || name != testCase.policy {
+			t.Fatalf("Test %d: expected %v/%q, got %v/%q", i+1, testCase.allowed, testCase.policy, allowed, name)
+		}
+	}
+
+	if _, e := policyTestArgs("s3:GetObject", "mybucket", []string{"invalid"}); e == nil {
+		t.Fatal("expected an error for an invalid condition")
+	}
+}
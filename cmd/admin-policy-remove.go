@@ -71,11 +71,14 @@ func mainAdminPolicyRemove(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	fatalIf(probe.NewError(client.RemoveCannedPolicy(globalContext, args.Get(1))).Trace(args...), "Unable to remove policy")
+	if !globalDryRun {
+		fatalIf(probe.NewError(client.RemoveCannedPolicy(globalContext, args.Get(1))).Trace(args...), "Unable to remove policy")
+	}
 
 	printMsg(userPolicyMessage{
 		op:     ctx.Command.Name,
 		Policy: args.Get(1),
+		DryRun: globalDryRun,
 	})
 
 	return nil
@@ -0,0 +1,332 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/policy"
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+var adminPolicyTestFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "user",
+		Usage: "test the policies of a user, LDAP user or access key, including the ones of its groups and parent user",
+	},
+	cli.StringFlag{
+		Name:  "group",
+		Usage: "test the policies attached to a group",
+	},
+	cli.StringFlag{
+		Name:  "action",
+		Usage: "action to test, e.g. 's3:PutObject'",
+	},
+	cli.StringFlag{
+		Name:  "resource",
+		Usage: "resource to test as BUCKET[/OBJECT], e.g. 'mybucket/prefix/object'",
+	},
+	cli.StringSliceFlag{
+		Name:  "condition",
+		Usage: "condition value used to evaluate the policy conditions as KEY=VALUE, e.g. 'aws:SourceIp=10.0.0.1'",
+	},
+}
+
+var adminPolicyTestCmd = cli.Command{
+	Name:         "test",
+	Usage:        "test if a user or a group is allowed to perform an action",
+	Action:       mainAdminPolicyTest,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminPolicyTestFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET --user USER|--group GROUP --action ACTION [--resource RESOURCE]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Check if the user 'bob' can upload objects under 'mybucket/prefix/'.
+     {{.Prompt}} {{.HelpName}} myminio --user bob --action s3:PutObject --resource mybucket/prefix/object
+
+  2. Check if the members of the group 'devs' can list the bucket 'mybucket'.
+     {{.Prompt}} {{.HelpName}} myminio --group devs --action s3:ListBucket --resource mybucket
+
+  3. Check if the user 'bob' can download objects from the IP address '10.0.0.1'.
+     {{.Prompt}} {{.HelpName}} myminio --user bob --action s3:GetObject --resource mybucket/object --condition aws:SourceIp=10.0.0.1
+
+  4. Check if the LDAP user 'uid=bob,dc=min,dc=io' can delete objects in 'mybucket'.
+     {{.Prompt}} {{.HelpName}} myminio --user uid=bob,dc=min,dc=io --action s3:DeleteObject --resource mybucket/object
+
+  5. Check if the access key 'Q3AM3UQ867SPQQA43P2F' can upload objects, with the policies it implies from its parent user.
+     {{.Prompt}} {{.HelpName}} myminio --user Q3AM3UQ867SPQQA43P2F --action s3:PutObject --resource mybucket/object
+`,
+}
+
+// namedPolicy is a policy document with the name it is attached with.
+type namedPolicy struct {
+	Name   string
+	Policy policy.Policy
+}
+
+// policyTestMessage container for policy test results
+type policyTestMessage struct {
+	Status    string            `json:"status"`
+	User      string            `json:"user,omitempty"`
+	Group     string            `json:"group,omitempty"`
+	Action    string            `json:"action"`
+	Resource  string            `json:"resource,omitempty"`
+	Allowed   bool              `json:"allowed"`
+	Policy    string            `json:"policy,omitempty"`
+	Statement *policy.Statement `json:"statement,omitempty"`
+}
+
+func (m policyTestMessage) String() string {
+	who := "user `" + m.User + "`"
+	if m.Group != "" {
+		who = "group `" + m.Group + "`"
+	}
+	what := "`" + m.Action + "`"
+	if m.Resource != "" {
+		what += " on `" + m.Resource + "`"
+	}
+
+	var msg string
+	switch {
+	case m.Allowed:
+		msg = console.Colorize("PolicyAllowed", fmt.Sprintf("Allowed: %s can perform %s", who, what))
+	case m.Statement != nil:
+		msg = console.Colorize("PolicyDenied", fmt.Sprintf("Denied: %s cannot perform %s", who, what))
+	default:
+		msg = console.Colorize("PolicyDenied", fmt.Sprintf("Denied: %s cannot perform %s, no statement allows it", who, what))
+	}
+	if m.Statement != nil {
+		msg += fmt.Sprintf("\nPolicy: %s\nStatement: %s", m.Policy, policyStatementString(*m.Statement))
+	}
+	return msg
+}
+
+func (m policyTestMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// evaluatePolicies evaluates the policies for the given request, an explicit
+// deny takes precedence over an allow. The matching statement is returned,
+// or nil if the request is implicitly denied.
+func evaluatePolicies(policies []namedPolicy, args policy.Args) (allowed bool, policyName string, statement *policy.Statement) {
+	for _, p := range policies {
+		for i, st := range p.Policy.Statements {
+			if st.Effect == policy.Deny && !st.IsAllowed(args) {
+				return false, p.Name, &p.Policy.Statements[i]
+			}
+		}
+	}
+	for _, p := range policies {
+		for i, st := range p.Policy.Statements {
+			if st.Effect == policy.Allow && st.IsAllowed(args) {
+				return true, p.Name, &p.Policy.Statements[i]
+			}
+		}
+	}
+	return false, "", nil
+}
+
+// policyTestArgs returns the policy arguments of the request to test.
+func policyTestArgs(action, resource string, conditions []string) (policy.Args, error) {
+	resource = strings.TrimPrefix(resource, "arn:aws:s3:::")
+	bucket, object, _ := strings.Cut(resource, "/")
+	args := policy.Args{
+		Action:          policy.Action(action),
+		BucketName:      bucket,
+		ObjectName:      object,
+		ConditionValues: make(map[string][]string),
+	}
+	for _, c := range conditions {
+		key, value, ok := strings.Cut(c, "=")
+		if !ok || key == "" {
+			return args, fmt.Errorf("invalid condition '%s', expected KEY=VALUE", c)
+		}
+		// Condition values are looked up without the 'aws:' or 's3:' prefix.
+		key = condition.KeyName(key).Name()
+		args.ConditionValues[key] = append(args.ConditionValues[key], value)
+	}
+	return args, nil
+}
+
+// checkAdminPolicyTestSyntax - validate all the passed arguments
+func checkAdminPolicyTestSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if (ctx.String("user") == "") == (ctx.String("group") == "") {
+		fatalIf(errInvalidArgument().Trace(), "Exactly one of --user or --group must be specified.")
+	}
+	if ctx.String("action") == "" {
+		fatalIf(errInvalidArgument().Trace(), "--action must be specified.")
+	}
+}
+
+// mainAdminPolicyTest is the handle for "mc admin policy test" command.
+func mainAdminPolicyTest(ctx *cli.Context) error {
+	checkAdminPolicyTestSyntax(ctx)
+
+	console.SetColor("PolicyAllowed", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyDenied", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	user, group := ctx.String("user"), ctx.String("group")
+
+	args, e := policyTestArgs(ctx.String("action"), ctx.String("resource"), ctx.StringSlice("condition"))
+	fatalIf(probe.NewError(e), "Unable to parse the request to test.")
+	if user != "" {
+		args.AccountName = user
+		if _, ok := args.ConditionValues["username"]; !ok {
+			args.ConditionValues["username"] = []string{user}
+		}
+	}
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	var subject policyTestSubject
+	if user != "" {
+		subject, err = policyTestUser(client, user)
+		fatalIf(err.Trace(user), "Unable to get user info")
+		args.Groups = subject.groups
+	} else {
+		subject.groups = []string{group}
+	}
+	// The policies of LDAP groups are returned along with the user.
+	if !subject.ldap {
+		for _, g := range subject.groups {
+			desc, e := client.GetGroupDescription(globalContext, g)
+			fatalIf(probe.NewError(e).Trace(g), "Unable to get group info")
+			subject.policyNames = append(subject.policyNames, splitPolicyNames(desc.Policy)...)
+		}
+	}
+
+	var policies []namedPolicy
+	seen := make(map[string]bool)
+	for _, name := range subject.policyNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		p, err := fetchPolicy(client, name)
+		fatalIf(err.Trace(name), "Unable to get policy")
+		policies = append(policies, namedPolicy{Name: name, Policy: *p})
+	}
+
+	allowed, policyName, statement := evaluatePolicies(policies, args)
+	if allowed && subject.session != nil {
+		// The policy of the access key restricts the ones of its parent.
+		allowed, policyName, statement = evaluatePolicies([]namedPolicy{*subject.session}, args)
+	}
+	printMsg(policyTestMessage{
+		Status:    "success",
+		User:      user,
+		Group:     group,
+		Action:    ctx.String("action"),
+		Resource:  ctx.String("resource"),
+		Allowed:   allowed,
+		Policy:    policyName,
+		Statement: statement,
+	})
+	return nil
+}
+
+// policyTestSubject holds the policies applying to the tested user or group.
+type policyTestSubject struct {
+	policyNames []string
+	groups      []string
+	// ldap is set when the policies of the groups are already in policyNames.
+	ldap bool
+	// session is the policy of an access key without an implied policy,
+	// restricting the policies of its parent user.
+	session *namedPolicy
+}
+
+// policyTestUser returns the policies of a user, looked up as a MinIO user,
+// then as an access key inheriting the policies of its parent user, then as
+// an LDAP user.
+func policyTestUser(client *madmin.AdminClient, user string) (subject policyTestSubject, err *probe.Error) {
+	info, e := client.GetUserInfo(globalContext, user)
+	if e == nil {
+		subject.policyNames = splitPolicyNames(info.PolicyName)
+		subject.groups = info.MemberOf
+		return subject, nil
+	}
+	err = probe.NewError(e)
+
+	if sa, e := client.InfoServiceAccount(globalContext, user); e == nil {
+		subject, err = policyTestUser(client, sa.ParentUser)
+		if err != nil {
+			return subject, err.Trace(sa.ParentUser)
+		}
+		if !sa.ImpliedPolicy {
+			p, err := parsePolicy([]byte(sa.Policy))
+			if err != nil {
+				return subject, err.Trace(user)
+			}
+			subject.session = &namedPolicy{Name: user, Policy: *p}
+		}
+		return subject, nil
+	}
+
+	res, e := client.GetLDAPPolicyEntities(globalContext, madmin.PolicyEntitiesQuery{Users: []string{user}})
+	if e != nil {
+		return subject, err
+	}
+	for _, mapping := range res.UserMappings {
+		if !strings.EqualFold(mapping.User, user) {
+			continue
+		}
+		subject.ldap = true
+		subject.policyNames = mapping.Policies
+		for _, g := range mapping.MemberOfMappings {
+			subject.groups = append(subject.groups, g.Group)
+			subject.policyNames = append(subject.policyNames, g.Policies...)
+		}
+		return subject, nil
+	}
+	return subject, err
+}
+
+// splitPolicyNames splits a comma separated list of policy names.
+func splitPolicyNames(s string) (names []string) {
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
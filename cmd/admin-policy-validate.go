@@ -0,0 +1,186 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/policy"
+)
+
+var adminPolicyValidateCmd = cli.Command{
+	Name:         "validate",
+	Usage:        "validate an IAM policy file",
+	Action:       mainAdminPolicyValidate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} POLICYFILE
+
+POLICYFILE:
+  Name of the policy file to validate.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Validate the policy file '/tmp/writeonly.json'.
+     {{.Prompt}} {{.HelpName}} /tmp/writeonly.json
+`,
+}
+
+// policyValidateMessage container for policy validation results
+type policyValidateMessage struct {
+	Status   string   `json:"status"`
+	File     string   `json:"file"`
+	Valid    bool     `json:"valid"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (m policyValidateMessage) String() string {
+	var msg string
+	if m.Valid {
+		msg = console.Colorize("PolicyMessage", fmt.Sprintf("Policy `%s` is valid.", m.File))
+	} else {
+		msg = console.Colorize("PolicyInvalid", fmt.Sprintf("Policy `%s` is invalid: %s", m.File, m.Error))
+	}
+	for _, warning := range m.Warnings {
+		msg += "\n" + console.Colorize("PolicyWarning", "Warning: "+warning)
+	}
+	return msg
+}
+
+func (m policyValidateMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// isAllResources returns true if the resource matches all buckets and objects.
+func isAllResources(r policy.Resource) bool {
+	return r.Pattern == "*" || r.Pattern == "*/*"
+}
+
+// lintPolicy returns warnings about overly broad grants of a valid policy.
+func lintPolicy(p policy.Policy) (warnings []string) {
+	for i, st := range p.Statements {
+		if st.Effect != policy.Allow {
+			continue
+		}
+		name := fmt.Sprintf("statement %d", i+1)
+		if st.SID != "" {
+			name = fmt.Sprintf("statement '%s'", st.SID)
+		}
+
+		if len(st.NotActions) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s allows all actions except the NotAction list", name))
+		}
+		if len(st.NotResources) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s allows access to all resources except the NotResource list", name))
+		}
+
+		var allResources bool
+		for r := range st.Resources {
+			if isAllResources(r) {
+				allResources = true
+				break
+			}
+		}
+		for action := range st.Actions {
+			switch action {
+			case policy.AllActions, "*":
+				if allResources && len(st.Conditions) == 0 {
+					warnings = append(warnings, fmt.Sprintf("%s allows '%s' on all buckets without conditions", name, action))
+				} else {
+					warnings = append(warnings, fmt.Sprintf("%s allows all S3 actions '%s'", name, action))
+				}
+			case policy.AllAdminActions:
+				warnings = append(warnings, fmt.Sprintf("%s allows all admin actions '%s'", name, action))
+			}
+		}
+	}
+	return warnings
+}
+
+// validatePolicy parses a policy document, returns an error if the policy
+// is invalid and warnings about overly broad grants otherwise.
+func validatePolicy(data []byte) ([]string, error) {
+	p, e := policy.ParseConfig(bytes.NewReader(data))
+	if e != nil {
+		return nil, e
+	}
+	if len(p.Statements) == 0 {
+		return nil, fmt.Errorf("policy has no statements")
+	}
+	return lintPolicy(*p), nil
+}
+
+// checkAdminPolicyValidateSyntax - validate all the passed arguments
+func checkAdminPolicyValidateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+func setPolicyValidateColors() {
+	console.SetColor("PolicyMessage", color.New(color.FgGreen))
+	console.SetColor("PolicyInvalid", color.New(color.FgRed, color.Bold))
+	console.SetColor("PolicyWarning", color.New(color.FgYellow))
+}
+
+// mainAdminPolicyValidate is the handle for "mc admin policy validate" command.
+func mainAdminPolicyValidate(ctx *cli.Context) error {
+	checkAdminPolicyValidateSyntax(ctx)
+
+	setPolicyValidateColors()
+
+	file := ctx.Args().Get(0)
+	data, e := os.ReadFile(file)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read the policy file.")
+
+	warnings, e := validatePolicy(data)
+	msg := policyValidateMessage{
+		Status:   "success",
+		File:     file,
+		Valid:    e == nil,
+		Warnings: warnings,
+	}
+	if e != nil {
+		msg.Status = "error"
+		msg.Error = strings.TrimSpace(e.Error())
+	}
+	printMsg(msg)
+	if e != nil {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
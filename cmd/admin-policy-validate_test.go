@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		valid    bool
+		warnings []string
+	}{
+		{
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`,
+			valid:  true,
+		},
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::*"]}]}`,
+			valid:    true,
+			warnings: []string{"on all buckets without conditions"},
+		},
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Sid":"admin","Effect":"Allow","Action":["admin:*"]}]}`,
+			valid:    true,
+			warnings: []string{"statement 'admin' allows all admin actions"},
+		},
+		{
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":["s3:*"],"Resource":["arn:aws:s3:::*"]}]}`,
+			valid:  true,
+		},
+		{
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObjekt"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`,
+		},
+		{
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"],"Condition":{"StringEquals":{"aws:unknownKey":"x"}}}]}`,
+		},
+		{
+			policy: `{"Version":"2012-10-17","Statement":[]}`,
+		},
+		{
+			policy: `{"Version":`,
+		},
+	}
+
+	for i, tc := range testCases {
+		warnings, e := validatePolicy([]byte(tc.policy))
+		if tc.valid != (e == nil) {
+			t.Errorf("case %d: expected valid=%v, got error %v", i+1, tc.valid, e)
+			continue
+		}
+		if len(warnings) != len(tc.warnings) {
+			t.Errorf("case %d: expected warnings %v, got %v", i+1, tc.warnings, warnings)
+			continue
+		}
+		for j := range warnings {
+			if !strings.Contains(warnings[j], tc.warnings[j]) {
+				t.Errorf("case %d: expected warning containing %q, got %q", i+1, tc.warnings[j], warnings[j])
+			}
+		}
+	}
+}
@@ -31,6 +31,9 @@ var adminPolicySubcommands = []cli.Command{
 	adminPolicySetCmd,
 	adminPolicyUnsetCmd,
 	adminPolicyUpdateCmd,
+	adminPolicyValidateCmd,
+	adminPolicyDiffCmd,
+	adminPolicyTestCmd,
 }
 
 var adminPolicyCmd = cli.Command{
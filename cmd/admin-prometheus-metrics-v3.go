@@ -102,7 +102,7 @@ func printPrometheusMetricsV3(ctx *cli.Context, req prometheusMetricsReq) error
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusOK {
-		printMsg(prometheusMetricsReader{Reader: resp.Body})
+		printMsg(prometheusMetricsReader{Reader: resp.Body, filter: req.filter})
 		return nil
 	}
 
@@ -18,10 +18,13 @@
 package cmd
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -39,6 +42,11 @@ var metricsFlags = append(metricsV3Flags,
 		Value: "v2",
 	})
 
+var metricsFilterFlag = cli.StringFlag{
+	Name:  "filter",
+	Usage: "print only the metrics with a name matching the regular expression",
+}
+
 var metricsV2SubSystems = set.CreateStringSet("node", "bucket", "cluster", "resource")
 
 var adminPrometheusMetricsCmd = cli.Command{
@@ -47,7 +55,7 @@ var adminPrometheusMetricsCmd = cli.Command{
 	OnUsageError: onUsageError,
 	Action:       mainSupportMetrics,
 	Before:       setGlobalsFromContext,
-	Flags:        append(globalFlags, metricsFlags...),
+	Flags:        append(append(globalFlags, metricsFlags...), metricsFilterFlag),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 USAGE:
@@ -110,6 +118,9 @@ EXAMPLES (v2):
 
   4. Resource metrics.
      {{.Prompt}} {{.HelpName}} play resource
+
+  5. Node metrics about drives only.
+     {{.Prompt}} {{.HelpName}} play node --filter 'drive'
 `,
 }
 
@@ -119,6 +130,23 @@ type prometheusMetricsReq struct {
 	aliasURL  string
 	token     string
 	subsystem string
+	filter    *regexp.Regexp
+}
+
+// prometheusMetricName returns the metric name of a line in the
+// Prometheus text format, including the '# HELP' and '# TYPE' lines.
+func prometheusMetricName(line string) string {
+	if strings.HasPrefix(line, "#") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && (fields[1] == "HELP" || fields[1] == "TYPE") {
+			return fields[2]
+		}
+		return ""
+	}
+	if i := strings.IndexAny(line, "{ "); i >= 0 {
+		return line[:i]
+	}
+	return line
 }
 
 // checkSupportMetricsSyntax - validate arguments passed by a user
@@ -171,7 +199,7 @@ func printPrometheusMetricsV2(ctx *cli.Context, req prometheusMetricsReq) error
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusOK {
-		printMsg(prometheusMetricsReader{Reader: resp.Body})
+		printMsg(prometheusMetricsReader{Reader: resp.Body, filter: req.filter})
 		return nil
 	}
 
@@ -182,6 +210,15 @@ func printPrometheusMetricsV2(ctx *cli.Context, req prometheusMetricsReq) error
 func (pm prometheusMetricsReader) JSON() string {
 	results, e := madmin.ParsePrometheusResults(pm.Reader)
 	fatalIf(probe.NewError(e), "Unable to parse Prometheus metrics.")
+	if pm.filter != nil {
+		filtered := results[:0]
+		for _, family := range results {
+			if pm.filter.MatchString(family.Name) {
+				filtered = append(filtered, family)
+			}
+		}
+		results = filtered
+	}
 
 	jsonMessageBytes, e := json.MarshalIndent(results, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
@@ -190,6 +227,18 @@ func (pm prometheusMetricsReader) JSON() string {
 
 // String - returns the string representation of the prometheus metrics
 func (pm prometheusMetricsReader) String() string {
+	if pm.filter != nil {
+		scanner := bufio.NewScanner(pm.Reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if name := prometheusMetricName(line); name != "" && pm.filter.MatchString(name) {
+				fmt.Println(line)
+			}
+		}
+		fatalIf(probe.NewError(scanner.Err()), "Unable to read Prometheus metrics.")
+		return ""
+	}
 	_, e := io.Copy(os.Stdout, pm.Reader)
 
 	fatalIf(probe.NewError(e), "Unable to read Prometheus metrics.")
@@ -200,6 +249,7 @@ func (pm prometheusMetricsReader) String() string {
 // prometheusMetricsReader mirrors the MetricFamily proto message.
 type prometheusMetricsReader struct {
 	Reader io.Reader
+	filter *regexp.Regexp
 }
 
 func mainSupportMetrics(ctx *cli.Context) error {
@@ -232,6 +282,11 @@ func mainSupportMetrics(ctx *cli.Context) error {
 		token:     token,
 		subsystem: metricsSubSystem,
 	}
+	if filter := ctx.String("filter"); filter != "" {
+		re, e := regexp.Compile(filter)
+		fatalIf(probe.NewError(e).Trace(filter), "Invalid --filter regular expression.")
+		metricsReq.filter = re
+	}
 
 	switch apiVer {
 	case "v2":
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestPrometheusMetricName(t *testing.T) {
+	testCases := []struct {
+		line string
+		name string
+	}{
+		{"# HELP minio_node_drive_total Total number of drives", "minio_node_drive_total"},
+		{"# TYPE minio_node_drive_total gauge", "minio_node_drive_total"},
+		{`minio_node_drive_total{server="127.0.0.1:9000"} 4`, "minio_node_drive_total"},
+		{"minio_cluster_health_status 1", "minio_cluster_health_status"},
+		{"# some comment", ""},
+		{"", ""},
+	}
+	for i, testCase := range testCases {
+		if name := prometheusMetricName(testCase.line); name != testCase.name {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.name, name)
+		}
+	}
+}
@@ -43,7 +43,7 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
-xEXAMPLES:
+EXAMPLES:
   1. Start rebalance on a MinIO deployment with alias myminio
      {{.Prompt}} {{.HelpName}} myminio
 `,
@@ -0,0 +1,177 @@
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go/v3"
+	"github.com/olekukonko/tablewriter"
+)
+
+// rebalanceRunning returns true if the rebalance is in progress in any pool.
+func rebalanceRunning(rInfo madmin.RebalanceStatus) bool {
+	for _, pool := range rInfo.Pools {
+		if pool.Status == "Started" {
+			return true
+		}
+	}
+	return false
+}
+
+// rebalancePoolRows returns the progress of the rebalance of each pool.
+func rebalancePoolRows(rInfo madmin.RebalanceStatus) [][]string {
+	rows := make([][]string, 0, len(rInfo.Pools))
+	for idx, pool := range rInfo.Pools {
+		status := pool.Status
+		if status == "" {
+			status = "-"
+		}
+		eta := "-"
+		if pool.Status == "Started" && pool.Progress.ETA > 0 {
+			eta = pool.Progress.ETA.Round(time.Second).String()
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("Pool-%d", idx),
+			status,
+			fmt.Sprintf("%.2f%%", pool.Used*100),
+			humanize.IBytes(pool.Progress.Bytes),
+			humanize.Comma(int64(pool.Progress.NumObjects)),
+			humanize.Comma(int64(pool.Progress.NumVersions)),
+			pool.Progress.Elapsed.Round(time.Second).String(),
+			eta,
+		})
+	}
+	return rows
+}
+
+// renderRebalancePools renders the progress of each pool as a table.
+func renderRebalancePools(s *strings.Builder, rInfo madmin.RebalanceStatus) {
+	table := tablewriter.NewWriter(s)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+	table.SetHeader([]string{"Pool", "Status", "Used", "Moved", "Objects", "Versions", "Elapsed", "ETA"})
+	table.AppendBulk(rebalancePoolRows(rInfo))
+	table.Render()
+}
+
+// rebalanceStatusErr is sent to the UI when the status cannot be fetched.
+type rebalanceStatusErr struct {
+	err error
+}
+
+type rebalanceStatusUI struct {
+	spinner  spinner.Model
+	status   madmin.RebalanceStatus
+	err      error
+	quitting bool
+}
+
+func newRebalanceStatusUI() *rebalanceStatusUI {
+	s := spinner.New()
+	s.Spinner = spinner.Points
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return &rebalanceStatusUI{spinner: s}
+}
+
+func (m *rebalanceStatusUI) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m *rebalanceStatusUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case madmin.RebalanceStatus:
+		m.status = msg
+		if !rebalanceRunning(msg) {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case rebalanceStatusErr:
+		m.err = msg.err
+		m.quitting = true
+		return m, tea.Quit
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *rebalanceStatusUI) View() string {
+	var s strings.Builder
+
+	switch {
+	case !m.quitting:
+		s.WriteString(m.spinner.View())
+	case m.err != nil:
+		s.WriteString(m.spinner.Style.Render(crossTickCell + crossTickCell + crossTickCell))
+	case !rebalanceRunning(m.status) && len(m.status.Pools) > 0:
+		s.WriteString(m.spinner.Style.Render(tickCell + tickCell + tickCell))
+	}
+	s.WriteString("\n")
+
+	if len(m.status.Pools) == 0 {
+		s.WriteString("** waiting for status from server **\n")
+		return s.String()
+	}
+
+	var bytes, objects uint64
+	var eta time.Duration
+	for _, pool := range m.status.Pools {
+		bytes += pool.Progress.Bytes
+		objects += pool.Progress.NumObjects
+		if pool.Progress.ETA > eta {
+			eta = pool.Progress.ETA
+		}
+	}
+	s.WriteString(fmt.Sprintf("Moved: %s (%s objects)", humanize.IBytes(bytes), humanize.Comma(int64(objects))))
+	if rebalanceRunning(m.status) {
+		s.WriteString(fmt.Sprintf(", %s to completion", eta.Round(time.Second)))
+	}
+	s.WriteString("\n\n")
+	renderRebalancePools(&s, m.status)
+
+	if m.quitting {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
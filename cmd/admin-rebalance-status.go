@@ -18,25 +18,40 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
 
+var adminRebalanceStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch, w",
+		Usage: "follow the rebalance progress until it completes",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between status updates with --watch",
+		Value: 2 * time.Second,
+	},
+}
+
 var adminRebalanceStatusCmd = cli.Command{
 	Name:         "status",
 	Usage:        "summarize an ongoing rebalance operation",
 	Action:       mainAdminRebalanceStatus,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminRebalanceStatusFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -49,6 +64,9 @@ FLAGS:
 EXAMPLES:
   1. Summarize ongoing rebalance on a MinIO deployment with alias myminio
      {{.Prompt}} {{.HelpName}} myminio
+
+  2. Follow the progress of the ongoing rebalance on a MinIO deployment with alias myminio
+     {{.Prompt}} {{.HelpName}} myminio --watch
 `,
 }
 
@@ -66,6 +84,14 @@ func mainAdminRebalanceStatus(ctx *cli.Context) error {
 		return err.ToGoError()
 	}
 
+	if ctx.Bool("watch") {
+		if ctx.Duration("interval") <= 0 {
+			fatalIf(errInvalidArgument().Trace(ctx.String("interval")), "--interval must be positive.")
+		}
+		watchRebalanceStatus(client, ctx.Duration("interval"))
+		return nil
+	}
+
 	rInfo, e := client.RebalanceStatus(globalContext)
 	fatalIf(probe.NewError(e), "Unable to get rebalance status")
 
@@ -120,7 +146,57 @@ func mainAdminRebalanceStatus(ctx *cli.Context) error {
 	var b strings.Builder
 	fmt.Fprintf(&b, "Summary: \n")
 	fmt.Fprintf(&b, "Data: %s (%d objects, %d versions) \n", humanize.IBytes(totalBytes), totalObjects, totalVersions)
-	fmt.Fprintf(&b, "Time: %s (%s to completion)", maxElapsed, maxETA)
-	console.Println(b.String())
+	fmt.Fprintf(&b, "Time: %s (%s to completion)\n\n", maxElapsed, maxETA)
+	renderRebalancePools(&b, rInfo)
+	console.Print(b.String())
 	return nil
 }
+
+// watchRebalanceStatus displays the rebalance progress at every interval
+// until the rebalance completes, as a JSON line per update with --json.
+func watchRebalanceStatus(client *madmin.AdminClient, interval time.Duration) {
+	ctx, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	if globalJSON {
+		for {
+			rInfo, e := client.RebalanceStatus(ctx)
+			fatalIf(probe.NewError(e), "Unable to get rebalance status")
+			b, e := json.Marshal(rInfo)
+			fatalIf(probe.NewError(e), "Unable to marshal json")
+			console.Println(string(b))
+			if !rebalanceRunning(rInfo) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}
+
+	ui := newRebalanceStatusUI()
+	p := tea.NewProgram(ui)
+	go func() {
+		for {
+			rInfo, e := client.RebalanceStatus(ctx)
+			if e != nil {
+				p.Send(rebalanceStatusErr{err: e})
+				return
+			}
+			p.Send(rInfo)
+			if !rebalanceRunning(rInfo) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+	_, e := p.Run()
+	fatalIf(probe.NewError(e), "Unable to display rebalance status")
+	fatalIf(probe.NewError(ui.err), "Unable to get rebalance status")
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestRebalancePoolRows(t *testing.T) {
+	rInfo := madmin.RebalanceStatus{
+		Pools: []madmin.RebalancePoolStatus{
+			{ID: 0, Status: "Started", Used: 0.5, Progress: madmin.RebalPoolProgress{
+				NumObjects: 1200, NumVersions: 1300, Bytes: 2 << 30,
+				Elapsed: 90 * time.Second, ETA: 150 * time.Second,
+			}},
+			{ID: 1, Used: 0.1},
+		},
+	}
+	if !rebalanceRunning(rInfo) {
+		t.Fatal("expected rebalance to be running")
+	}
+
+	expected := [][]string{
+		{"Pool-0", "Started", "50.00%", "2.0 GiB", "1,200", "1,300", "1m30s", "2m30s"},
+		{"Pool-1", "-", "10.00%", "0 B", "0", "0", "0s", "-"},
+	}
+	if rows := rebalancePoolRows(rInfo); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+
+	rInfo.Pools[0].Status = "Completed"
+	if rebalanceRunning(rInfo) {
+		t.Fatal("expected rebalance to be completed")
+	}
+}
@@ -36,10 +36,6 @@ import (
 )
 
 var serviceRestartFlag = []cli.Flag{
-	cli.BoolFlag{
-		Name:  "dry-run",
-		Usage: "do not attempt a restart, however verify the peer status",
-	},
 	cli.BoolFlag{
 		Name:  "wait, w",
 		Usage: "wait for background initializations to complete",
@@ -265,7 +261,7 @@ func mainAdminServiceRestart(ctx *cli.Context) error {
 		// Restart the specified MinIO server
 		result, e := client.ServiceAction(ctxt, madmin.ServiceActionOpts{
 			Action: madmin.ServiceActionRestart,
-			DryRun: ctx.Bool("dry-run"),
+			DryRun: globalDryRun,
 		})
 		if e != nil {
 			// Attempt an older API server might be old
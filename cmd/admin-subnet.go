@@ -42,9 +42,3 @@ func mainAdminSubnet(_ *cli.Context) error {
 	return nil
 	// Sub-commands like "health", "register" have their own main.
 }
-
-func adminHealthCmd() cli.Command {
-	cmd := adminSubnetHealthCmd
-	cmd.Hidden = true
-	return cmd
-}
@@ -0,0 +1,230 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// traceFileWriter saves traces as JSON lines to a file, rotating
+// the file when it grows beyond maxSize bytes or gets older than maxAge.
+type traceFileWriter struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	maxAge  time.Duration
+
+	f       *os.File
+	size    int64
+	created time.Time
+}
+
+func newTraceFileWriter(path string, maxSize int64, maxAge time.Duration) (*traceFileWriter, *probe.Error) {
+	w := &traceFileWriter{
+		path:    path,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *traceFileWriter) open() *probe.Error {
+	f, e := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	fi, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return probe.NewError(e)
+	}
+	w.f = f
+	w.size = fi.Size()
+	w.created = time.Now()
+	return nil
+}
+
+// rotate renames the current file with a timestamp suffix and starts a new one.
+func (w *traceFileWriter) rotate() *probe.Error {
+	if e := w.f.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	suffix := time.Now().UTC().Format("20060102T150405.000")
+	rotated := w.path + "." + suffix
+	// Do not overwrite a file rotated within the same millisecond.
+	for i := 1; ; i++ {
+		if _, e := os.Stat(rotated); os.IsNotExist(e) {
+			break
+		}
+		rotated = fmt.Sprintf("%s.%s-%d", w.path, suffix, i)
+	}
+	if e := os.Rename(w.path, rotated); e != nil {
+		return probe.NewError(e)
+	}
+	return w.open()
+}
+
+// Write saves a single trace to the file.
+func (w *traceFileWriter) Write(traceInfo madmin.ServiceTraceInfo) *probe.Error {
+	data, e := json.Marshal(traceInfo.Trace)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	data = append(data, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size > 0 && ((w.maxSize > 0 && w.size+int64(len(data)) > w.maxSize) ||
+		(w.maxAge > 0 && time.Since(w.created) > w.maxAge)) {
+		if err := w.rotate(); err != nil {
+			return err.Trace(w.path)
+		}
+	}
+	n, e := w.f.Write(data)
+	w.size += int64(n)
+	return probe.NewError(e)
+}
+
+// Close closes the current file.
+func (w *traceFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
+
+// replayTraceMatches returns true if a saved trace would have
+// been sent by the server when tracing with the given options.
+func replayTraceMatches(opts madmin.ServiceTraceOpts, trace madmin.TraceInfo) bool {
+	if !opts.TraceTypes().Overlaps(trace.TraceType) {
+		return false
+	}
+	if opts.Threshold > 0 && trace.Duration < opts.Threshold {
+		return false
+	}
+	if opts.OnlyErrors {
+		failed := trace.Error != ""
+		if trace.HTTP != nil && trace.HTTP.RespInfo.StatusCode >= 400 {
+			failed = true
+		}
+		return failed
+	}
+	return true
+}
+
+// readTraceFile reads traces from a file of JSON lines, compressed files
+// with a .zst extension are supported. decode returns the trace of a line,
+// or false to skip the line.
+func readTraceFile(ctx context.Context, file string, decode func(line []byte) (madmin.TraceInfo, bool)) <-chan madmin.ServiceTraceInfo {
+	ch := make(chan madmin.ServiceTraceInfo, 1000)
+	go func() {
+		defer close(ch)
+
+		send := func(traceInfo madmin.ServiceTraceInfo) bool {
+			select {
+			case ch <- traceInfo:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		f, e := os.Open(file)
+		if e != nil {
+			send(madmin.ServiceTraceInfo{Err: e})
+			return
+		}
+		defer f.Close()
+
+		in := io.Reader(f)
+		if strings.HasSuffix(file, ".zst") {
+			zr, e := zstd.NewReader(in)
+			if e != nil {
+				send(madmin.ServiceTraceInfo{Err: e})
+				return
+			}
+			defer zr.Close()
+			in = zr
+		}
+
+		rd := bufio.NewReader(in)
+		for ctx.Err() == nil {
+			b, e := rd.ReadBytes('\n')
+			if len(b) > 0 {
+				if trace, ok := decode(b); ok && !send(madmin.ServiceTraceInfo{Trace: trace}) {
+					return
+				}
+			}
+			if e != nil {
+				if e != io.EOF {
+					send(madmin.ServiceTraceInfo{Err: e})
+				}
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// replayTraces reads traces saved with --out from a file, keeping the
+// ones the server would have sent with the given options.
+func replayTraces(ctx context.Context, file string, opts madmin.ServiceTraceOpts) <-chan madmin.ServiceTraceInfo {
+	return readTraceFile(ctx, file, func(line []byte) (trace madmin.TraceInfo, ok bool) {
+		if json.Unmarshal(line, &trace) != nil {
+			return trace, false
+		}
+		return trace, replayTraceMatches(opts, trace)
+	})
+}
+
+// decodeShortTrace decodes a trace printed by 'mc admin trace --json',
+// as read with --in.
+func decodeShortTrace(line []byte) (madmin.TraceInfo, bool) {
+	var t shortTraceMsg
+	if e := json.Unmarshal(line, &t); e != nil || t.Type == "Bootstrap" {
+		// Ignore bootstrap, since their times skews averages.
+		return madmin.TraceInfo{}, false
+	}
+	return madmin.TraceInfo{
+		TraceType: t.trcType, // TODO: Grab from string, once we can.
+		NodeName:  t.Host,
+		FuncName:  t.FuncName,
+		Time:      t.Time,
+		Path:      t.Path,
+		Duration:  t.Duration,
+		Bytes:     t.Size,
+		Message:   t.StatusMsg,
+		Error:     t.Error,
+		Custom:    t.Extra,
+	}, true
+}
@@ -18,25 +18,25 @@
 package cmd
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"hash/fnv"
-	"io"
+	"math"
+	"math/rand"
 	"net/http"
 	"net/url"
-	"os"
 	"path"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
-	"github.com/klauspost/compress/zstd"
+	"github.com/juju/ratelimit"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/madmin-go/v3"
@@ -77,6 +77,26 @@ var adminTraceFlags = []cli.Flag{
 		Name:  "node",
 		Usage: "trace only matching servers",
 	},
+	cli.StringSliceFlag{
+		Name:  "bucket",
+		Usage: "trace only calls on matching buckets",
+	},
+	cli.StringSliceFlag{
+		Name:  "request-id",
+		Usage: "trace only calls with matching request id",
+	},
+	cli.StringSliceFlag{
+		Name:  "user",
+		Usage: "trace only calls signed with matching access key",
+	},
+	cli.StringSliceFlag{
+		Name:  "not-path",
+		Usage: "exclude calls on matching path",
+	},
+	cli.IntSliceFlag{
+		Name:  "not-status-code",
+		Usage: "exclude calls with matching status code",
+	},
 	cli.StringSliceFlag{
 		Name:  "request-header",
 		Usage: "trace only matching request headers",
@@ -91,7 +111,7 @@ var adminTraceFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:  "stats",
-		Usage: "print statistical summary of all the traced calls",
+		Usage: "print statistical summary of all the traced calls, with error rates and latency percentiles",
 	},
 	cli.IntFlag{
 		Name:   "stats-n",
@@ -119,6 +139,27 @@ var adminTraceFlags = []cli.Flag{
 		Name:  "in",
 		Usage: "read previously saved json from file and replay",
 	},
+	cli.StringFlag{
+		Name:  "out",
+		Usage: "save matching traces to a file, which can be replayed with 'replay FILE'",
+	},
+	cli.StringFlag{
+		Name:  "out-max-size",
+		Usage: "rotate the --out file when it grows beyond this size (see UNITS)",
+	},
+	cli.DurationFlag{
+		Name:  "out-max-age",
+		Usage: "rotate the --out file when it gets older than this duration (e.g. `1h`)",
+	},
+	cli.Float64Flag{
+		Name:  "sample-rate",
+		Usage: "fraction of the matching calls to trace, between 0 and 1",
+		Value: 1,
+	},
+	cli.IntFlag{
+		Name:  "max-events-per-second",
+		Usage: "maximum number of traced calls per second, calls over the limit are dropped",
+	},
 }
 
 // traceCallTypes contains all call types and flags to apply when selected.
@@ -201,6 +242,7 @@ var adminTraceCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] TARGET
+  {{.HelpName}} [FLAGS] replay FILE
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -209,7 +251,7 @@ CALL TYPES:
 ` + traceCallsHelp() + `
 
 UNITS
-  --filter-size flags use with --filter-response or --filter-request accept human-readable case-insensitive number
+  --out-max-size and --filter-size flags use with --filter-response or --filter-request accept human-readable case-insensitive number
   suffixes such as "k", "m", "g" and "t" referring to the metric units KB,
   MB, GB and TB respectively. Adding an "i" to these prefixes, uses the IEC
   units, so that "gi" refers to "gibibyte" or "GiB". A "b" at the end is
@@ -239,6 +281,24 @@ EXAMPLES:
   
   8. Show trace only for requests operations duration greater than 5ms
      {{.Prompt}} {{.HelpName}} --response-duration 5ms myminio
+
+  9. Show trace only for calls of a single tenant on a specific bucket
+     {{.Prompt}} {{.HelpName}} --user tenant1-key --bucket tenant1-bucket myminio
+
+  10. Show trace for a single request id
+     {{.Prompt}} {{.HelpName}} -v --request-id 17A1B2C3D4E5F607 myminio
+
+  11. Show trace excluding health checks and successful calls
+     {{.Prompt}} {{.HelpName}} --not-path "minio/health/*" --not-status-code 200 myminio
+
+  12. Save all calls to a file rotated every 100MiB or every hour
+     {{.Prompt}} {{.HelpName}} -a --out trace.json --out-max-size 100MiB --out-max-age 1h myminio
+
+  13. Replay the failed calls saved in a file with verbose output
+     {{.Prompt}} {{.HelpName}} -v -e replay trace.json
+
+  14. Show trace for 1% of the calls, at most 100 calls per second
+     {{.Prompt}} {{.HelpName}} --sample-rate 0.01 --max-events-per-second 100 myminio
 `,
 }
 
@@ -246,10 +306,24 @@ const traceTimeFormat = "2006-01-02T15:04:05.000"
 
 var colors = []color.Attribute{color.FgCyan, color.FgWhite, color.FgYellow, color.FgGreen}
 
+// isTraceReplay returns true if the command replays traces saved with --out.
+func isTraceReplay(ctx *cli.Context) bool {
+	return len(ctx.Args()) == 2 && ctx.Args().First() == "replay"
+}
+
 func checkAdminTraceSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 && len(ctx.String("in")) == 0 {
+	if len(ctx.Args()) != 1 && len(ctx.String("in")) == 0 && !isTraceReplay(ctx) {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if isTraceReplay(ctx) && ctx.String("out") != "" {
+		fatalIf(errDummy().Trace(), "You cannot specify --out when replaying traces.")
+	}
+	if rate := ctx.Float64("sample-rate"); rate <= 0 || rate > 1 {
+		fatalIf(errInvalidArgument().Trace(), "--sample-rate must be greater than 0 and less than or equal to 1.")
+	}
+	if ctx.Int("max-events-per-second") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "--max-events-per-second cannot be negative.")
+	}
 	filterFlag := ctx.Bool("filter-request") || ctx.Bool("filter-response")
 	if filterFlag && ctx.String("filter-size") == "" {
 		// filter must use with filter-size flags
@@ -261,6 +335,79 @@ func checkAdminTraceSyntax(ctx *cli.Context) {
 	}
 }
 
+// traceSampler drops traced calls to limit the amount of output,
+// either randomly or when exceeding a maximum rate.
+type traceSampler struct {
+	rate   float64
+	bucket *ratelimit.Bucket
+
+	total      atomic.Uint64
+	sampledOut atomic.Uint64
+	dropped    atomic.Uint64
+}
+
+// newTraceSampler returns a sampler keeping the given fraction of calls and
+// at most maxPerSecond calls per second, or nil if no sampling is required.
+func newTraceSampler(rate float64, maxPerSecond int) *traceSampler {
+	if rate >= 1 && maxPerSecond <= 0 {
+		return nil
+	}
+	s := &traceSampler{rate: rate}
+	if maxPerSecond > 0 {
+		s.bucket = ratelimit.NewBucketWithRate(float64(maxPerSecond), int64(maxPerSecond))
+	}
+	return s
+}
+
+// keep returns true if the traced call must be displayed.
+func (s *traceSampler) keep() bool {
+	if s == nil {
+		return true
+	}
+	s.total.Add(1)
+	if s.rate < 1 && rand.Float64() >= s.rate {
+		s.sampledOut.Add(1)
+		return false
+	}
+	if s.bucket != nil && s.bucket.TakeAvailable(1) == 0 {
+		s.dropped.Add(1)
+		return false
+	}
+	return true
+}
+
+// summary returns the number of traced calls kept and discarded.
+func (s *traceSampler) summary() traceSampleMessage {
+	total, sampledOut, dropped := s.total.Load(), s.sampledOut.Load(), s.dropped.Load()
+	return traceSampleMessage{
+		Total:      total,
+		Displayed:  total - sampledOut - dropped,
+		SampledOut: sampledOut,
+		Dropped:    dropped,
+	}
+}
+
+// traceSampleMessage container for the summary of sampled traces
+type traceSampleMessage struct {
+	Status     string `json:"status"`
+	Total      uint64 `json:"total"`
+	Displayed  uint64 `json:"displayed"`
+	SampledOut uint64 `json:"sampledOut"`
+	Dropped    uint64 `json:"dropped"`
+}
+
+func (m traceSampleMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m traceSampleMessage) String() string {
+	return console.Colorize("Stat", fmt.Sprintf("Matched %d calls: %d displayed, %d sampled out, %d dropped by rate limit.",
+		m.Total, m.Displayed, m.SampledOut, m.Dropped))
+}
+
 func printTrace(verbose bool, traceInfo madmin.ServiceTraceInfo) {
 	if verbose {
 		printMsg(traceMessage{ServiceTraceInfo: traceInfo})
@@ -275,18 +422,71 @@ type matchString struct {
 }
 
 type matchOpts struct {
-	statusCodes  []int
-	methods      []string
-	funcNames    []string
-	apiPaths     []string
-	nodes        []string
-	reqHeaders   []matchString
-	reqQueries   []matchString
-	requestSize  uint64
-	responseSize uint64
+	statusCodes    []int
+	notStatusCodes []int
+	methods        []string
+	funcNames      []string
+	apiPaths       []string
+	notAPIPaths    []string
+	nodes          []string
+	buckets        []string
+	requestIDs     []string
+	users          []string
+	reqHeaders     []matchString
+	reqQueries     []matchString
+	requestSize    uint64
+	responseSize   uint64
+}
+
+// traceBucket returns the bucket name of a traced S3 call.
+func traceBucket(traceInfo madmin.ServiceTraceInfo) string {
+	bucket, _, _ := strings.Cut(strings.TrimPrefix(traceInfo.Trace.Path, "/"), "/")
+	return bucket
+}
+
+// traceAccessKey returns the access key used to sign a traced call,
+// extracted from the signature V4/V2 authorization header or the presigned query.
+func traceAccessKey(traceInfo madmin.ServiceTraceInfo) string {
+	if traceInfo.Trace.HTTP == nil {
+		return ""
+	}
+	reqInfo := traceInfo.Trace.HTTP.ReqInfo
+	auth := reqInfo.Headers.Get("Authorization")
+	if _, credential, ok := strings.Cut(auth, "Credential="); ok {
+		accessKey, _, _ := strings.Cut(credential, "/")
+		return accessKey
+	}
+	if v2, ok := strings.CutPrefix(auth, "AWS "); ok {
+		accessKey, _, _ := strings.Cut(v2, ":")
+		return accessKey
+	}
+	if query, e := url.ParseQuery(reqInfo.RawQuery); e == nil {
+		if credential := query.Get("X-Amz-Credential"); credential != "" {
+			accessKey, _, _ := strings.Cut(credential, "/")
+			return accessKey
+		}
+		return query.Get("AWSAccessKeyId")
+	}
+	return ""
 }
 
 func (opts matchOpts) matches(traceInfo madmin.ServiceTraceInfo) bool {
+	// Exclude request paths if passed by the user
+	for _, apiPath := range opts.notAPIPaths {
+		if pathMatch(path.Join("/", apiPath), traceInfo.Trace.Path) {
+			return false
+		}
+	}
+
+	// Exclude response status codes if passed by the user
+	if traceInfo.Trace.HTTP != nil {
+		for _, code := range opts.notStatusCodes {
+			if traceInfo.Trace.HTTP.RespInfo.StatusCode == code {
+				return false
+			}
+		}
+	}
+
 	// Filter request path if passed by the user
 	if len(opts.apiPaths) > 0 {
 		matched := false
@@ -359,6 +559,53 @@ func (opts matchOpts) matches(traceInfo madmin.ServiceTraceInfo) bool {
 		}
 	}
 
+	if len(opts.buckets) > 0 {
+		matched := false
+		// Filter request by bucket if passed by the user.
+		bucket := traceBucket(traceInfo)
+		for _, b := range opts.buckets {
+			if pathMatch(b, bucket) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(opts.requestIDs) > 0 {
+		matched := false
+		// Filter request by request id if passed by the user.
+		if traceInfo.Trace.HTTP != nil {
+			requestID := traceInfo.Trace.HTTP.RespInfo.Headers.Get("X-Amz-Request-Id")
+			for _, id := range opts.requestIDs {
+				if id == requestID {
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(opts.users) > 0 {
+		matched := false
+		// Filter request by access key if passed by the user.
+		accessKey := traceAccessKey(traceInfo)
+		for _, user := range opts.users {
+			if accessKey != "" && pathMatch(user, accessKey) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
 	if len(opts.reqHeaders) > 0 && traceInfo.Trace.HTTP != nil {
 		matched := false
 		for _, hdr := range opts.reqHeaders {
@@ -424,10 +671,15 @@ func (opts matchOpts) matches(traceInfo madmin.ServiceTraceInfo) bool {
 
 func matchingOpts(ctx *cli.Context) (opts matchOpts) {
 	opts.statusCodes = ctx.IntSlice("status-code")
+	opts.notStatusCodes = ctx.IntSlice("not-status-code")
 	opts.methods = ctx.StringSlice("method")
 	opts.funcNames = ctx.StringSlice("funcname")
 	opts.apiPaths = ctx.StringSlice("path")
+	opts.notAPIPaths = ctx.StringSlice("not-path")
 	opts.nodes = ctx.StringSlice("node")
+	opts.buckets = ctx.StringSlice("bucket")
+	opts.requestIDs = ctx.StringSlice("request-id")
+	opts.users = ctx.StringSlice("user")
 	for _, s := range ctx.StringSlice("request-header") {
 		opts.reqHeaders = append(opts.reqHeaders, matchString{
 			reverse: strings.HasPrefix(s, "!"),
@@ -498,6 +750,12 @@ func mainAdminTrace(ctx *cli.Context) error {
 	verbose := ctx.Bool("verbose")
 	stats := ctx.Bool("stats")
 
+	sampler := newTraceSampler(ctx.Float64("sample-rate"), ctx.Int("max-events-per-second"))
+	if sampler != nil {
+		// Print the sampling summary when interrupted.
+		defer holdSignalExit()()
+	}
+
 	console.SetColor("Stat", color.New(color.FgYellow))
 
 	console.SetColor("Request", color.New(color.FgCyan))
@@ -525,52 +783,12 @@ func mainAdminTrace(ctx *cli.Context) error {
 
 	if inFile := ctx.String("in"); inFile != "" {
 		stats = true
-		ch := make(chan madmin.ServiceTraceInfo, 1000)
-		traceCh = ch
-		go func() {
-			f, e := os.Open(inFile)
-			fatalIf(probe.NewError(e), "Unable to open input")
-			defer f.Close()
-			in := io.Reader(f)
-			if strings.HasSuffix(inFile, ".zst") {
-				zr, e := zstd.NewReader(in)
-				fatalIf(probe.NewError(e), "Unable to open input")
-				defer zr.Close()
-				in = zr
-			}
-			sc := bufio.NewReader(in)
-			for ctxt.Err() == nil {
-				b, e := sc.ReadBytes('\n')
-				if e == io.EOF {
-					break
-				}
-				var t shortTraceMsg
-				e = json.Unmarshal(b, &t)
-				if e != nil || t.Type == "Bootstrap" {
-					// Ignore bootstrap, since their times skews averages.
-					continue
-				}
-				ch <- madmin.ServiceTraceInfo{
-					Trace: madmin.TraceInfo{
-						TraceType:  t.trcType, // TODO: Grab from string, once we can.
-						NodeName:   t.Host,
-						FuncName:   t.FuncName,
-						Time:       t.Time,
-						Path:       t.Path,
-						Duration:   t.Duration,
-						Bytes:      t.Size,
-						Message:    t.StatusMsg,
-						Error:      t.Error,
-						Custom:     t.Extra,
-						HTTP:       nil,
-						HealResult: nil,
-					},
-					Err: nil,
-				}
-			}
-			close(ch)
-			select {}
-		}()
+		traceCh = readTraceFile(ctxt, inFile, decodeShortTrace)
+	} else if isTraceReplay(ctx) {
+		opts, e := tracingOpts(ctx, ctx.StringSlice("call"))
+		fatalIf(probe.NewError(e), "Unable to replay traces")
+
+		traceCh = replayTraces(ctxt, ctx.Args().Get(1), opts)
 	} else {
 		// Create a new MinIO Admin Client
 		aliasedURL := ctx.Args().Get(0)
@@ -588,6 +806,20 @@ func mainAdminTrace(ctx *cli.Context) error {
 		traceCh = client.ServiceTrace(ctxt, opts)
 	}
 
+	var out *traceFileWriter
+	if outFile := ctx.String("out"); outFile != "" {
+		var maxSize uint64
+		if ctx.String("out-max-size") != "" {
+			var e error
+			maxSize, e = humanize.ParseBytes(ctx.String("out-max-size"))
+			fatalIf(probe.NewError(e).Trace(ctx.String("out-max-size")), "Unable to parse input bytes.")
+		}
+		var err *probe.Error
+		out, err = newTraceFileWriter(outFile, int64(maxSize), ctx.Duration("out-max-age"))
+		fatalIf(err.Trace(outFile), "Unable to open the output file.")
+		defer out.Close()
+	}
+
 	mopts := matchingOpts(ctx)
 	if stats {
 		filteredTraces := make(chan madmin.ServiceTraceInfo, 1)
@@ -600,7 +832,10 @@ func mainAdminTrace(ctx *cli.Context) error {
 					ui.Kill()
 					return
 				}
-				if mopts.matches(t) {
+				if mopts.matches(t) && sampler.keep() {
+					if out != nil {
+						errorIf(out.Write(t), "Unable to save trace.")
+					}
 					filteredTraces <- t
 				}
 			}
@@ -614,17 +849,26 @@ func mainAdminTrace(ctx *cli.Context) error {
 			aliasedURL := ctx.Args().Get(0)
 			fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to fetch http trace statistics")
 		}
+		if sampler != nil {
+			printMsg(sampler.summary())
+		}
 		return nil
 	}
 	for traceInfo := range traceCh {
 		if traceInfo.Err != nil {
 			fatalIf(probe.NewError(traceInfo.Err), "Unable to listen to http trace")
 		}
-		if mopts.matches(traceInfo) {
+		if mopts.matches(traceInfo) && sampler.keep() {
+			if out != nil {
+				errorIf(out.Write(traceInfo), "Unable to save trace.")
+			}
 			printTrace(verbose, traceInfo)
 		}
 	}
 
+	if sampler != nil {
+		printMsg(sampler.summary())
+	}
 	return nil
 }
 
@@ -975,7 +1219,45 @@ type statItem struct {
 	MaxTTFB        time.Duration `json:"maxTTFB,omitempty"`
 	MaxDur         time.Duration `json:"maxDuration"`
 	MinDur         time.Duration `json:"minDuration"`
+	P50            time.Duration `json:"p50Duration"`
+	P99            time.Duration `json:"p99Duration"`
 	Size           int64         `json:"size"`
+
+	// durations holds a uniform sample of the call durations,
+	// used to estimate latency percentiles with bounded memory.
+	durations []time.Duration
+}
+
+// traceStatsMaxSamples is the maximum number of durations sampled per call.
+const traceStatsMaxSamples = 1000
+
+// sampleDuration adds the duration of a call to the sample,
+// Count must already include the call.
+func (s *statItem) sampleDuration(d time.Duration) {
+	if len(s.durations) < traceStatsMaxSamples {
+		s.durations = append(s.durations, d)
+		return
+	}
+	// Reservoir sampling, keep each call with equal probability.
+	if i := rand.Intn(s.Count); i < traceStatsMaxSamples {
+		s.durations[i] = d
+	}
+}
+
+// percentiles returns the estimated durations at the given percentiles (0-100).
+func (s statItem) percentiles(ps ...float64) []time.Duration {
+	res := make([]time.Duration, len(ps))
+	if len(s.durations) == 0 {
+		return res
+	}
+	sorted := make([]time.Duration, len(s.durations))
+	copy(sorted, s.durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i, p := range ps {
+		idx := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		res[i] = sorted[min(max(idx, 0), len(sorted)-1)]
+	}
+	return res
 }
 
 type statTrace struct {
@@ -988,12 +1270,23 @@ type statTrace struct {
 func (s *statTrace) JSON() string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	// Percentiles are only estimated when printed.
+	calls := make(map[string]statItem, len(s.Calls))
+	for id, item := range s.Calls {
+		p := item.percentiles(50, 99)
+		item.P50, item.P99 = p[0], p[1]
+		calls[id] = item
+	}
 	buf := &bytes.Buffer{}
 	enc := json.NewEncoder(buf)
 	enc.SetIndent("", " ")
 	// Disable escaping special chars to display XML tags correctly
 	enc.SetEscapeHTML(false)
-	fatalIf(probe.NewError(enc.Encode(s)), "Unable to marshal into JSON.")
+	fatalIf(probe.NewError(enc.Encode(struct {
+		Calls  map[string]statItem `json:"calls"`
+		Oldest time.Time
+		Latest time.Time
+	}{Calls: calls, Oldest: s.Oldest, Latest: s.Latest})), "Unable to marshal into JSON.")
 
 	// strip off extra newline added by json encoder
 	return strings.TrimSuffix(buf.String(), "\n")
@@ -1032,6 +1325,7 @@ func (s *statTrace) add(t madmin.ServiceTraceInfo) {
 	}
 	got.Count++
 	got.Duration += t.Trace.Duration
+	got.sampleDuration(t.Trace.Duration)
 	if t.Trace.Error != "" {
 		got.Errors++
 	}
@@ -0,0 +1,253 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func newTestTraceInfo(path, auth, rawQuery, requestID string, statusCode int) madmin.ServiceTraceInfo {
+	reqHeaders := http.Header{}
+	if auth != "" {
+		reqHeaders.Set("Authorization", auth)
+	}
+	respHeaders := http.Header{}
+	respHeaders.Set("X-Amz-Request-Id", requestID)
+	return madmin.ServiceTraceInfo{
+		Trace: madmin.TraceInfo{
+			Path: path,
+			HTTP: &madmin.TraceHTTPStats{
+				ReqInfo:  madmin.TraceRequestInfo{Headers: reqHeaders, RawQuery: rawQuery},
+				RespInfo: madmin.TraceResponseInfo{Headers: respHeaders, StatusCode: statusCode},
+			},
+		},
+	}
+}
+
+func TestTraceAccessKey(t *testing.T) {
+	testCases := []struct {
+		auth     string
+		rawQuery string
+		expected string
+	}{
+		{"AWS4-HMAC-SHA256 Credential=tenant1/20240101/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc", "", "tenant1"},
+		{"AWS tenant2:signature", "", "tenant2"},
+		{"", "X-Amz-Credential=tenant3%2F20240101%2Fus-east-1%2Fs3%2Faws4_request", "tenant3"},
+		{"", "", ""},
+	}
+
+	for i, testCase := range testCases {
+		traceInfo := newTestTraceInfo("/bucket/object", testCase.auth, testCase.rawQuery, "", 200)
+		if got := traceAccessKey(traceInfo); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestTraceMatchOpts(t *testing.T) {
+	traceInfo := newTestTraceInfo("/tenant1-bucket/photos/a.jpg",
+		"AWS4-HMAC-SHA256 Credential=tenant1/20240101/us-east-1/s3/aws4_request", "", "17A1B2C3D4E5F607", 200)
+
+	testCases := []struct {
+		opts    matchOpts
+		matches bool
+	}{
+		{matchOpts{}, true},
+		{matchOpts{buckets: []string{"tenant1-*"}}, true},
+		{matchOpts{buckets: []string{"tenant2-bucket"}}, false},
+		{matchOpts{users: []string{"tenant1"}}, true},
+		{matchOpts{users: []string{"tenant2"}}, false},
+		{matchOpts{requestIDs: []string{"17A1B2C3D4E5F607"}}, true},
+		{matchOpts{requestIDs: []string{"other"}}, false},
+		{matchOpts{notAPIPaths: []string{"tenant1-bucket/*"}}, false},
+		{matchOpts{notAPIPaths: []string{"minio/health/*"}}, true},
+		{matchOpts{notStatusCodes: []int{200}}, false},
+		{matchOpts{notStatusCodes: []int{404}}, true},
+	}
+
+	for i, testCase := range testCases {
+		if got := testCase.opts.matches(traceInfo); got != testCase.matches {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.matches, got)
+		}
+	}
+}
+
+func TestTraceFileWriterReplay(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "trace.json")
+
+	// Rotate after every trace.
+	w, err := newTraceFileWriter(file, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	traces := []madmin.ServiceTraceInfo{
+		{Trace: madmin.TraceInfo{TraceType: madmin.TraceS3, FuncName: "s3.PutObject", Duration: time.Second}},
+		{Trace: madmin.TraceInfo{TraceType: madmin.TraceS3, FuncName: "s3.GetObject", Duration: time.Millisecond}},
+		{Trace: madmin.TraceInfo{TraceType: madmin.TraceOS, FuncName: "os.Rename"}},
+	}
+	for _, traceInfo := range traces {
+		if err = w.Write(traceInfo); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if e := w.Close(); e != nil {
+		t.Fatal(e)
+	}
+
+	entries, e := os.ReadDir(dir)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(entries) != len(traces) {
+		t.Fatalf("expected %d files after rotation, got %d", len(traces), len(entries))
+	}
+
+	// The current file holds the last trace, which is filtered out unless OS calls are traced.
+	var replayed []string
+	for traceInfo := range replayTraces(context.Background(), file, madmin.ServiceTraceOpts{S3: true}) {
+		replayed = append(replayed, traceInfo.Trace.FuncName)
+	}
+	if len(replayed) != 0 {
+		t.Fatalf("expected no replayed traces, got %v", replayed)
+	}
+	for traceInfo := range replayTraces(context.Background(), file, madmin.ServiceTraceOpts{OS: true}) {
+		replayed = append(replayed, traceInfo.Trace.FuncName)
+	}
+	if len(replayed) != 1 || replayed[0] != "os.Rename" {
+		t.Fatalf("expected os.Rename to be replayed, got %v", replayed)
+	}
+}
+
+func TestReadTraceFileShortTraces(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "trace.json")
+	data := `{"type":"Bootstrap","api":"bootstrap"}
+{"type":"S3","host":"node1","api":"s3.GetObject","path":"/bucket/object","duration":1000}
+`
+	if e := os.WriteFile(file, []byte(data), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	var read []madmin.TraceInfo
+	for traceInfo := range readTraceFile(context.Background(), file, decodeShortTrace) {
+		if traceInfo.Err != nil {
+			t.Fatal(traceInfo.Err)
+		}
+		read = append(read, traceInfo.Trace)
+	}
+	if len(read) != 1 || read[0].FuncName != "s3.GetObject" || read[0].NodeName != "node1" {
+		t.Fatalf("unexpected traces %+v", read)
+	}
+}
+
+func TestReplayTraceMatches(t *testing.T) {
+	testCases := []struct {
+		opts    madmin.ServiceTraceOpts
+		trace   madmin.TraceInfo
+		matches bool
+	}{
+		{madmin.ServiceTraceOpts{S3: true}, madmin.TraceInfo{TraceType: madmin.TraceS3}, true},
+		{madmin.ServiceTraceOpts{S3: true}, madmin.TraceInfo{TraceType: madmin.TraceOS}, false},
+		{madmin.ServiceTraceOpts{S3: true, Threshold: time.Second}, madmin.TraceInfo{TraceType: madmin.TraceS3, Duration: time.Millisecond}, false},
+		{madmin.ServiceTraceOpts{S3: true, OnlyErrors: true}, madmin.TraceInfo{TraceType: madmin.TraceS3}, false},
+		{madmin.ServiceTraceOpts{S3: true, OnlyErrors: true}, madmin.TraceInfo{TraceType: madmin.TraceS3, Error: "failed"}, true},
+	}
+
+	for i, testCase := range testCases {
+		if got := replayTraceMatches(testCase.opts, testCase.trace); got != testCase.matches {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.matches, got)
+		}
+	}
+}
+
+func TestStatItemPercentiles(t *testing.T) {
+	var item statItem
+	for i := 1; i <= 2*traceStatsMaxSamples; i++ {
+		item.Count++
+		item.sampleDuration(time.Duration(i) * time.Millisecond)
+	}
+	if len(item.durations) != traceStatsMaxSamples {
+		t.Fatalf("expected %d samples, got %d", traceStatsMaxSamples, len(item.durations))
+	}
+
+	item = statItem{}
+	for i := 1; i <= 100; i++ {
+		item.Count++
+		item.sampleDuration(time.Duration(i) * time.Millisecond)
+	}
+	got := item.percentiles(50, 99, 100)
+	expected := []time.Duration{50 * time.Millisecond, 99 * time.Millisecond, 100 * time.Millisecond}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("percentile %d: expected %v, got %v", i, expected[i], got[i])
+		}
+	}
+
+	if got := (statItem{}).percentiles(50); got[0] != 0 {
+		t.Errorf("expected zero percentile without samples, got %v", got[0])
+	}
+
+	stats := &statTrace{Calls: map[string]statItem{"s3.GetObject": item}}
+	var decoded struct {
+		Calls map[string]statItem `json:"calls"`
+	}
+	if e := json.Unmarshal([]byte(stats.JSON()), &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if call := decoded.Calls["s3.GetObject"]; call.P50 != 50*time.Millisecond || call.P99 != 99*time.Millisecond {
+		t.Errorf("expected p50 and p99 in the JSON stats, got %v and %v", call.P50, call.P99)
+	}
+}
+
+func TestTraceSampler(t *testing.T) {
+	if s := newTraceSampler(1, 0); s != nil {
+		t.Fatal("expected no sampler when sampling is disabled")
+	}
+	var s *traceSampler
+	if !s.keep() {
+		t.Fatal("nil sampler must keep all calls")
+	}
+
+	s = newTraceSampler(1, 10)
+	for i := 0; i < 100; i++ {
+		s.keep()
+	}
+	m := s.summary()
+	if m.Total != 100 || m.SampledOut != 0 || m.Displayed+m.Dropped != 100 {
+		t.Fatalf("unexpected summary %+v", m)
+	}
+	if m.Displayed < 10 || m.Displayed > 11 {
+		t.Fatalf("expected about 10 displayed calls, got %d", m.Displayed)
+	}
+
+	s = newTraceSampler(0.5, 0)
+	for i := 0; i < 1000; i++ {
+		s.keep()
+	}
+	m = s.summary()
+	if m.Dropped != 0 || m.SampledOut == 0 || m.Displayed == 0 || m.Displayed+m.SampledOut != 1000 {
+		t.Fatalf("unexpected summary %+v", m)
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -38,6 +40,11 @@ var adminUpdateFlags = []cli.Flag{
 		Name:  "yes, y",
 		Usage: "Confirms the server update",
 	},
+	cli.DurationFlag{
+		Name:  "verify-timeout",
+		Usage: "time to wait for the cluster to be healthy after the update, 0 disables the verification",
+		Value: 5 * time.Minute,
+	},
 }
 
 var adminServerUpdateCmd = cli.Command{
@@ -51,7 +58,10 @@ var adminServerUpdateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [UPDATE-URL]
+
+UPDATE-URL:
+  URL of the sha256sum file of a specific MinIO server release, the latest release is used when omitted.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -62,6 +72,12 @@ EXAMPLES:
 
   2. Update all MinIO servers in a distributed setup, represented by its alias 'mydist'.
      {{.Prompt}} {{.HelpName}} mydist/
+
+  3. Update all MinIO servers of 'mydist' to a specific release.
+     {{.Prompt}} {{.HelpName}} mydist/ https://dl.min.io/server/minio/release/linux-amd64/archive/minio.RELEASE.2024-10-13T13-34-11Z.sha256sum
+
+  4. Show the versions the servers of 'mydist' would be updated to, without updating them.
+     {{.Prompt}} {{.HelpName}} mydist/ --dry-run
 `,
 }
 
@@ -108,6 +124,139 @@ func (s serverUpdateMessage) JSON() string {
 	return string(serverUpdateJSONBytes)
 }
 
+// serverUpdateVerifyMessage is container for the cluster health after an update.
+type serverUpdateVerifyMessage struct {
+	Status   string            `json:"status"`
+	Healthy  bool              `json:"healthy"`
+	Elapsed  time.Duration     `json:"elapsed"`
+	Versions map[string]string `json:"versions,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// String colorized server update verification message.
+func (s serverUpdateVerifyMessage) String() string {
+	if !s.Healthy {
+		return console.Colorize("ServerUpdateFailed", "Cluster is not healthy after the update: "+s.Error)
+	}
+	msg := fmt.Sprintf("Cluster is healthy after the update (%s).", s.Elapsed.Round(time.Second))
+	versions := make([]string, 0, len(s.Versions))
+	for host, version := range s.Versions {
+		versions = append(versions, host+": "+version)
+	}
+	sort.Strings(versions)
+	for _, version := range versions {
+		msg += "\n  " + version
+	}
+	return console.Colorize("ServerUpdate", msg)
+}
+
+// JSON jsonified server update verification message.
+func (s serverUpdateVerifyMessage) JSON() string {
+	serverUpdateJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(serverUpdateJSONBytes)
+}
+
+// serverVersionTime parses a server version, either a release time
+// e.g. `2024-10-13T13:34:11Z` or a release tag, e.g. `RELEASE.2024-10-13T13-34-11Z`.
+func serverVersionTime(version string) (time.Time, bool) {
+	if t, err := mcVersionToReleaseTime(version); err == nil {
+		return t, true
+	}
+	if t, err := releaseTagToReleaseTime(version); err == nil {
+		return t, true
+	}
+	if t, e := time.Parse(mcReleaseTagTimeLayout, version); e == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// isServerVersion returns true if a server version reported by server
+// info is the same release as the expected version.
+func isServerVersion(version, expected string) bool {
+	t1, ok1 := serverVersionTime(version)
+	t2, ok2 := serverVersionTime(expected)
+	if ok1 && ok2 {
+		return t1.Equal(t2)
+	}
+	return version == expected
+}
+
+// updatedServerVersions returns the version each server was updated to,
+// keyed by host, and the version common to all servers if any.
+func updatedServerVersions(us madmin.ServerUpdateStatusV2) (versions map[string]string, common string) {
+	versions = make(map[string]string, len(us.Results))
+	for i, peerRes := range us.Results {
+		versions[peerRes.Host] = peerRes.UpdatedVersion
+		if i == 0 {
+			common = peerRes.UpdatedVersion
+		} else if common != peerRes.UpdatedVersion {
+			common = ""
+		}
+	}
+	return versions, common
+}
+
+// verifyServerUpdate waits for the cluster to be healthy with all its
+// nodes running the version they were updated to.
+func verifyServerUpdate(aliasedURL string, us madmin.ServerUpdateStatusV2, timeout time.Duration) serverUpdateVerifyMessage {
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+	anonClient, err := newAnonymousClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize anonymous client.")
+
+	expected, common := updatedServerVersions(us)
+
+	start := time.Now()
+	msg := serverUpdateVerifyMessage{Status: "error"}
+	for {
+		state := fetchHealthState(globalContext, client, anonClient)
+		if state.healthy() {
+			info, e := client.ServerInfo(globalContext)
+			if e == nil {
+				msg.Versions = make(map[string]string, len(info.Servers))
+				msg.Error = ""
+				for _, srv := range info.Servers {
+					msg.Versions[srv.Endpoint] = srv.Version
+					want, ok := expected[srv.Endpoint]
+					if !ok {
+						want = common
+					}
+					switch {
+					case want == "":
+						msg.Error = fmt.Sprintf("unable to find the version %s was updated to", srv.Endpoint)
+					case !isServerVersion(srv.Version, want):
+						msg.Error = fmt.Sprintf("%s is running %s, expected %s", srv.Endpoint, srv.Version, want)
+					}
+					if msg.Error != "" {
+						break
+					}
+				}
+				if msg.Error == "" {
+					msg.Status = "success"
+					msg.Healthy = true
+					msg.Elapsed = time.Since(start)
+					return msg
+				}
+			}
+		} else {
+			msg.Error = "cluster is not reachable or has offline nodes or drives"
+		}
+		if time.Since(start) > timeout {
+			msg.Elapsed = time.Since(start)
+			return msg
+		}
+		select {
+		case <-globalContext.Done():
+			msg.Error = globalContext.Err().Error()
+			return msg
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
 // checkAdminServerUpdateSyntax - validate all the passed arguments
 func checkAdminServerUpdateSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
@@ -121,6 +270,7 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 
 	// Set color.
 	console.SetColor("ServerUpdate", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ServerUpdateFailed", color.New(color.FgRed, color.Bold))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -133,7 +283,7 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 
 	autoConfirm := ctx.Bool("yes")
 
-	if isTerminal() && !autoConfirm {
+	if isTerminal() && !autoConfirm && !globalDryRun {
 		fmt.Printf("You are about to upgrade *MinIO Server*, please confirm [y/N]: ")
 		answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
 		fatalIf(probe.NewError(e), "Unable to parse user input.")
@@ -147,7 +297,7 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 	// Update the specified MinIO server, optionally also
 	// with the provided update URL.
 	us, e := client.ServerUpdateV2(globalContext, madmin.ServerUpdateOpts{
-		DryRun:    ctx.Bool("dry-run"),
+		DryRun:    globalDryRun,
 		UpdateURL: updateURL,
 	})
 	fatalIf(probe.NewError(e), "Unable to update the server.")
@@ -158,5 +308,21 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 		ServerURL:          aliasedURL,
 		ServerUpdateStatus: us,
 	})
+
+	if us.DryRun || ctx.Duration("verify-timeout") <= 0 {
+		return nil
+	}
+	for _, peerRes := range us.Results {
+		if peerRes.Err != "" {
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
+
+	// Servers restart after the update, wait for the cluster to be healthy again.
+	verifyMsg := verifyServerUpdate(aliasedURL, us, ctx.Duration("verify-timeout"))
+	printMsg(verifyMsg)
+	if !verifyMsg.Healthy {
+		return exitStatus(globalErrorExitStatus)
+	}
 	return nil
 }
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestIsServerVersion(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected string
+		match    bool
+	}{
+		{"2024-10-13T13:34:11Z", "2024-10-13T13:34:11Z", true},
+		{"2024-10-13T13:34:11Z", "RELEASE.2024-10-13T13-34-11Z", true},
+		{"2024-10-13T13:34:11Z", "2024-10-13T13-34-11Z", true},
+		{"2024-10-02T17:50:41Z", "RELEASE.2024-10-13T13-34-11Z", false},
+		{"DEVELOPMENT.GOGET", "DEVELOPMENT.GOGET", true},
+		{"DEVELOPMENT.GOGET", "RELEASE.2024-10-13T13-34-11Z", false},
+	}
+	for i, testCase := range testCases {
+		if got := isServerVersion(testCase.version, testCase.expected); got != testCase.match {
+			t.Errorf("Test %d: expected %v for %s and %s, got %v", i+1, testCase.match, testCase.version, testCase.expected, got)
+		}
+	}
+}
+
+func TestUpdatedServerVersions(t *testing.T) {
+	us := madmin.ServerUpdateStatusV2{Results: []madmin.ServerPeerUpdateStatus{
+		{Host: "node1:9000", CurrentVersion: "2024-10-02T17:50:41Z", UpdatedVersion: "2024-10-13T13:34:11Z"},
+		{Host: "node2:9000", CurrentVersion: "2024-10-02T17:50:41Z", UpdatedVersion: "2024-10-13T13:34:11Z"},
+	}}
+	versions, common := updatedServerVersions(us)
+	if common != "2024-10-13T13:34:11Z" || versions["node2:9000"] != common {
+		t.Fatalf("unexpected updated versions %v, common %q", versions, common)
+	}
+
+	us.Results[1].UpdatedVersion = "2024-10-02T17:50:41Z"
+	if _, common = updatedServerVersions(us); common != "" {
+		t.Fatalf("expected no common version, got %q", common)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+	"github.com/olekukonko/tablewriter"
+)
+
+var adminUsageFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "state",
+		Usage: "file keeping the usage of the previous run, to report the growth since then",
+	},
+	cli.BoolFlag{
+		Name:  "csv",
+		Usage: "print the report in CSV format",
+	},
+}
+
+var adminUsageCmd = cli.Command{
+	Name:         "usage",
+	Usage:        "report the data usage of each bucket",
+	Action:       mainAdminUsage,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminUsageFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+  The usage is computed by the MinIO object scanner, it may lag behind recent writes.
+  MinIO does not track the owner of objects, the usage is reported per bucket only.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Report the usage of each bucket of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Report the usage of each bucket and its growth since the previous run, in CSV format.
+     {{.Prompt}} {{.HelpName}} myminio --state ~/.mc/myminio-usage.json --csv > usage.csv
+`,
+}
+
+// bucketUsage is the usage of a bucket.
+type bucketUsage struct {
+	Bucket   string `json:"bucket"`
+	Objects  uint64 `json:"objects"`
+	Versions uint64 `json:"versions"`
+	Size     uint64 `json:"size"`
+}
+
+// usageState is the usage saved between two runs.
+type usageState struct {
+	Time    time.Time              `json:"time"`
+	Buckets map[string]bucketUsage `json:"buckets"`
+}
+
+// bucketUsageMessage is the usage of a bucket and its growth since the previous run.
+type bucketUsageMessage struct {
+	Status string `json:"status"`
+	bucketUsage
+	Since        *time.Time `json:"since,omitempty"`
+	ObjectsDelta int64      `json:"objectsDelta"`
+	SizeDelta    int64      `json:"sizeDelta"`
+}
+
+func (m bucketUsageMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (m bucketUsageMessage) String() string {
+	return fmt.Sprintf("%s: %s objects, %s", m.Bucket, humanize.Comma(int64(m.Objects)), humanize.IBytes(m.Size))
+}
+
+// signedBytes formats a growth in bytes with its sign.
+func signedBytes(n int64) string {
+	if n < 0 {
+		return "-" + humanize.IBytes(uint64(-n))
+	}
+	return "+" + humanize.IBytes(uint64(n))
+}
+
+// bucketUsageReport returns the usage of each bucket sorted by name,
+// along with its growth since the previous state when present.
+func bucketUsageReport(info madmin.DataUsageInfo, prev *usageState) []bucketUsageMessage {
+	report := make([]bucketUsageMessage, 0, len(info.BucketsUsage))
+	for bucket, usage := range info.BucketsUsage {
+		msg := bucketUsageMessage{
+			bucketUsage: bucketUsage{
+				Bucket:   bucket,
+				Objects:  usage.ObjectsCount,
+				Versions: usage.VersionsCount,
+				Size:     usage.Size,
+			},
+		}
+		if prev != nil {
+			since := prev.Time
+			msg.Since = &since
+			old := prev.Buckets[bucket]
+			msg.ObjectsDelta = int64(usage.ObjectsCount) - int64(old.Objects)
+			msg.SizeDelta = int64(usage.Size) - int64(old.Size)
+		}
+		report = append(report, msg)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Bucket < report[j].Bucket
+	})
+	return report
+}
+
+// readUsageState reads the usage of the previous run, a missing file is not an error.
+func readUsageState(file string) (*usageState, error) {
+	data, e := os.ReadFile(file)
+	if errors.Is(e, os.ErrNotExist) {
+		return nil, nil
+	}
+	if e != nil {
+		return nil, e
+	}
+	var state usageState
+	if e = json.Unmarshal(data, &state); e != nil {
+		return nil, e
+	}
+	return &state, nil
+}
+
+// writeUsageState saves the usage of this run for the next one.
+func writeUsageState(file string, report []bucketUsageMessage, now time.Time) error {
+	state := usageState{
+		Time:    now,
+		Buckets: make(map[string]bucketUsage, len(report)),
+	}
+	for _, m := range report {
+		state.Buckets[m.Bucket] = m.bucketUsage
+	}
+	data, e := json.MarshalIndent(state, "", " ")
+	if e != nil {
+		return e
+	}
+	return os.WriteFile(file, data, 0o600)
+}
+
+// writeUsageCSV writes the usage report in CSV format.
+func writeUsageCSV(w io.Writer, report []bucketUsageMessage) error {
+	cw := csv.NewWriter(w)
+	if e := cw.Write([]string{"bucket", "objects", "versions", "size", "objectsDelta", "sizeDelta"}); e != nil {
+		return e
+	}
+	for _, m := range report {
+		e := cw.Write([]string{
+			m.Bucket,
+			strconv.FormatUint(m.Objects, 10),
+			strconv.FormatUint(m.Versions, 10),
+			strconv.FormatUint(m.Size, 10),
+			strconv.FormatInt(m.ObjectsDelta, 10),
+			strconv.FormatInt(m.SizeDelta, 10),
+		})
+		if e != nil {
+			return e
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// renderUsageTable renders the usage report as a table.
+func renderUsageTable(w io.Writer, report []bucketUsageMessage, growth bool) {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	header := []string{"Bucket", "Objects", "Versions", "Size"}
+	if growth {
+		header = append(header, "Objects Growth", "Size Growth")
+	}
+	table.SetHeader(header)
+	for _, m := range report {
+		row := []string{
+			m.Bucket,
+			humanize.Comma(int64(m.Objects)),
+			humanize.Comma(int64(m.Versions)),
+			humanize.IBytes(m.Size),
+		}
+		if growth {
+			row = append(row, fmt.Sprintf("%+d", m.ObjectsDelta), signedBytes(m.SizeDelta))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func checkAdminUsageSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.Bool("csv") && globalJSON {
+		fatalIf(errInvalidArgument().Trace(), "--csv and --json cannot be used together.")
+	}
+}
+
+// mainAdminUsage is the handle for "mc admin usage" command.
+func mainAdminUsage(ctx *cli.Context) error {
+	checkAdminUsageSyntax(ctx)
+
+	console.SetColor("UsageSince", color.New(color.FgCyan))
+
+	aliasedURL := ctx.Args().Get(0)
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin connection.")
+
+	stateFile := ctx.String("state")
+	var prev *usageState
+	if stateFile != "" {
+		var e error
+		prev, e = readUsageState(stateFile)
+		fatalIf(probe.NewError(e).Trace(stateFile), "Unable to read the usage state file.")
+	}
+
+	info, e := client.DataUsageInfo(globalContext)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get data usage info.")
+
+	report := bucketUsageReport(info, prev)
+	switch {
+	case globalJSON:
+		for _, m := range report {
+			printMsg(m)
+		}
+	case ctx.Bool("csv"):
+		fatalIf(probe.NewError(writeUsageCSV(os.Stdout, report)), "Unable to write the usage report.")
+	default:
+		var s strings.Builder
+		if prev != nil {
+			s.WriteString(console.Colorize("UsageSince", "Growth since "+prev.Time.Format(printDate)) + "\n")
+		}
+		renderUsageTable(&s, report, prev != nil)
+		console.Print(s.String())
+	}
+
+	if stateFile != "" {
+		fatalIf(probe.NewError(writeUsageState(stateFile, report, time.Now().UTC())).Trace(stateFile),
+			"Unable to write the usage state file.")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestBucketUsageReport(t *testing.T) {
+	info := madmin.DataUsageInfo{
+		BucketsUsage: map[string]madmin.BucketUsageInfo{
+			"photos": {Size: 3000, ObjectsCount: 30, VersionsCount: 35},
+			"logs":   {Size: 1000, ObjectsCount: 10, VersionsCount: 10},
+		},
+	}
+
+	report := bucketUsageReport(info, nil)
+	if len(report) != 2 || report[0].Bucket != "logs" || report[1].Bucket != "photos" {
+		t.Fatalf("expected buckets sorted by name, got %+v", report)
+	}
+	if report[0].Since != nil || report[0].SizeDelta != 0 {
+		t.Fatalf("expected no growth without a previous state, got %+v", report[0])
+	}
+
+	stateFile := filepath.Join(t.TempDir(), "usage.json")
+	if state, e := readUsageState(stateFile); e != nil || state != nil {
+		t.Fatalf("expected no state for a missing file, got %v, %v", state, e)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if e := writeUsageState(stateFile, report, now); e != nil {
+		t.Fatal(e)
+	}
+	prev, e := readUsageState(stateFile)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	info.BucketsUsage["logs"] = madmin.BucketUsageInfo{Size: 1500, ObjectsCount: 12, VersionsCount: 12}
+	info.BucketsUsage["new"] = madmin.BucketUsageInfo{Size: 100, ObjectsCount: 1, VersionsCount: 1}
+	report = bucketUsageReport(info, prev)
+	expected := map[string][2]int64{"logs": {2, 500}, "new": {1, 100}, "photos": {0, 0}}
+	for _, m := range report {
+		if m.Since == nil || !m.Since.Equal(now) {
+			t.Fatalf("%s: expected growth since %v, got %v", m.Bucket, now, m.Since)
+		}
+		if d := expected[m.Bucket]; m.ObjectsDelta != d[0] || m.SizeDelta != d[1] {
+			t.Errorf("%s: expected growth %v, got %d %d", m.Bucket, d, m.ObjectsDelta, m.SizeDelta)
+		}
+	}
+
+	var buf bytes.Buffer
+	if e = writeUsageCSV(&buf, report[:1]); e != nil {
+		t.Fatal(e)
+	}
+	if csv := "bucket,objects,versions,size,objectsDelta,sizeDelta\nlogs,12,12,1500,2,500\n"; buf.String() != csv {
+		t.Fatalf("expected CSV %q, got %q", csv, buf.String())
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminUserExportCmd = cli.Command{
+	Name:         "export",
+	Usage:        "export users to a CSV file",
+	Action:       mainAdminUserExport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [FILE]
+
+FILE:
+  CSV file with the columns 'accessKey,secretKey,policy,group', written to stdout when omitted.
+  Secret keys are never exported, they must be filled in before importing the users in another cluster.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Export the users of 'myminio' to 'users.csv'.
+     {{.Prompt}} {{.HelpName}} myminio users.csv
+
+  2. Print the users of 'myminio' in CSV format.
+     {{.Prompt}} {{.HelpName}} myminio
+`,
+}
+
+// writeUserExportCSV writes the users in CSV format sorted by access key,
+// in the format read by 'mc admin user import'.
+func writeUserExportCSV(w io.Writer, users map[string]madmin.UserInfo) error {
+	accessKeys := make([]string, 0, len(users))
+	for accessKey := range users {
+		accessKeys = append(accessKeys, accessKey)
+	}
+	sort.Strings(accessKeys)
+
+	cw := csv.NewWriter(w)
+	if e := cw.Write(userCSVHeader); e != nil {
+		return e
+	}
+	for _, accessKey := range accessKeys {
+		user := users[accessKey]
+		policies := splitUserCSVList(strings.ReplaceAll(user.PolicyName, ",", userCSVListSep))
+		record := []string{
+			accessKey,
+			"",
+			strings.Join(policies, userCSVListSep),
+			strings.Join(user.MemberOf, userCSVListSep),
+		}
+		if e := cw.Write(record); e != nil {
+			return e
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// checkAdminUserExportSyntax - validate all the passed arguments
+func checkAdminUserExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) < 1 || len(ctx.Args()) > 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserExport is the handle for "mc admin user export" command.
+func mainAdminUserExport(ctx *cli.Context) error {
+	checkAdminUserExportSyntax(ctx)
+
+	console.SetColor("UserMessage", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	file := args.Get(1)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	users, e := client.ListUsers(globalContext)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to list users")
+
+	if file == "" || file == "-" {
+		fatalIf(probe.NewError(writeUserExportCSV(os.Stdout, users)), "Unable to export users.")
+		return nil
+	}
+
+	f, e := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to create the users file.")
+	fatalIf(probe.NewError(writeUserExportCSV(f, users)).Trace(file), "Unable to export users.")
+	fatalIf(probe.NewError(f.Close()).Trace(file), "Unable to export users.")
+
+	printMsg(userExportMessage{Status: "success", File: file, Count: len(users)})
+	return nil
+}
+
+// userExportMessage is container for the result of a user export.
+type userExportMessage struct {
+	Status string `json:"status"`
+	File   string `json:"file"`
+	Count  int    `json:"count"`
+}
+
+func (u userExportMessage) String() string {
+	return console.Colorize("UserMessage", fmt.Sprintf("Exported %d user(s) to `%s` successfully.", u.Count, u.File))
+}
+
+func (u userExportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// userCSVHeader is the list of columns of the users CSV file.
+var userCSVHeader = []string{"accessKey", "secretKey", "policy", "group"}
+
+// userCSVListSep separates multiple policies or groups in a CSV column.
+const userCSVListSep = ";"
+
+var adminUserImportCmd = cli.Command{
+	Name:         "import",
+	Usage:        "create or update users from a CSV file",
+	Action:       mainAdminUserImport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET FILE
+
+FILE:
+  CSV file with the columns 'accessKey,secretKey,policy,group', use '-' to read from stdin.
+  The header line is optional. Multiple policies or groups are separated by ';'.
+  An empty secretKey keeps the secret key of an existing user unchanged. Existing users
+  keep their status, the listed policies and groups are added to the ones they already have.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Create or update the users listed in 'users.csv'.
+     {{.Prompt}} {{.HelpName}} myminio users.csv
+
+  2. Create or update users from the standard input.
+     {{.Prompt}} cat users.csv | {{.HelpName}} myminio -
+`,
+}
+
+// userImportRow is a user read from the CSV file.
+type userImportRow struct {
+	Line      int
+	AccessKey string
+	SecretKey string
+	Policies  []string
+	Groups    []string
+}
+
+// userImportMessage is container for the result of a user import,
+// Policies and Groups are the ones attached by the import.
+type userImportMessage struct {
+	Status    string   `json:"status"`
+	Line      int      `json:"line"`
+	AccessKey string   `json:"accessKey"`
+	Result    string   `json:"result,omitempty"`
+	Policies  []string `json:"policies,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func (u userImportMessage) String() string {
+	if u.Error != "" {
+		return console.Colorize("UserImportFailed",
+			fmt.Sprintf("Line %d: unable to import user `%s`: %s", u.Line, u.AccessKey, u.Error))
+	}
+	msg := fmt.Sprintf("Line %d: %s user `%s`", u.Line, u.Result, u.AccessKey)
+	if len(u.Policies) > 0 {
+		msg += fmt.Sprintf(", attached policies: %s", strings.Join(u.Policies, ", "))
+	}
+	if len(u.Groups) > 0 {
+		msg += fmt.Sprintf(", added to groups: %s", strings.Join(u.Groups, ", "))
+	}
+	return console.Colorize("UserMessage", msg)
+}
+
+func (u userImportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// splitUserCSVList splits a list of policies or groups.
+func splitUserCSVList(s string) (list []string) {
+	for _, v := range strings.Split(s, userCSVListSep) {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// parseUserImportCSV reads the users from a CSV file, the header
+// line is skipped when present.
+func parseUserImportCSV(r io.Reader) ([]userImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []userImportRow
+	for {
+		record, e := reader.Read()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, e
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), userCSVHeader[0]) {
+			continue
+		}
+		if len(record) > len(userCSVHeader) {
+			return nil, fmt.Errorf("line %d: expected at most %d columns, got %d", line, len(userCSVHeader), len(record))
+		}
+		record = append(record, make([]string, len(userCSVHeader)-len(record))...)
+		row := userImportRow{
+			Line:      line,
+			AccessKey: strings.TrimSpace(record[0]),
+			SecretKey: record[1],
+			Policies:  splitUserCSVList(record[2]),
+			Groups:    splitUserCSVList(record[3]),
+		}
+		if row.AccessKey == "" {
+			return nil, fmt.Errorf("line %d: access key cannot be empty", line)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// missingUserItems returns the policies or groups of want not in have.
+func missingUserItems(want, have []string) (missing []string) {
+	for _, v := range want {
+		if !slices.Contains(have, v) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// userImportResult is the outcome of importing a single user.
+type userImportResult struct {
+	result   string
+	policies []string
+	groups   []string
+}
+
+// importUser creates or updates a user, then attaches its missing policies
+// and groups. An existing user keeps its account status.
+func importUser(client *madmin.AdminClient, row userImportRow, existing *madmin.UserInfo) (res userImportResult, e error) {
+	res.policies, res.groups = row.Policies, row.Groups
+	switch {
+	case existing == nil && row.SecretKey == "":
+		return res, errors.New("secret key is required to create a user")
+	case existing == nil:
+		res.result = "created"
+		e = client.AddUser(globalContext, row.AccessKey, row.SecretKey)
+	default:
+		res.result = "unchanged"
+		res.policies = missingUserItems(row.Policies, strings.Split(existing.PolicyName, ","))
+		res.groups = missingUserItems(row.Groups, existing.MemberOf)
+		if row.SecretKey != "" {
+			// AddUser would enable a disabled user, keep its status.
+			e = client.SetUser(globalContext, row.AccessKey, row.SecretKey, existing.Status)
+		}
+		if row.SecretKey != "" || len(res.policies) > 0 || len(res.groups) > 0 {
+			res.result = "updated"
+		}
+	}
+	if e != nil {
+		return res, e
+	}
+	if len(res.policies) > 0 {
+		_, e = client.AttachPolicy(globalContext, madmin.PolicyAssociationReq{
+			User:     row.AccessKey,
+			Policies: res.policies,
+		})
+		if e != nil && madmin.ToErrorResponse(e).Code != errCodeChangeAlreadyApplied {
+			return res, e
+		}
+	}
+	for _, group := range res.groups {
+		e = client.UpdateGroupMembers(globalContext, madmin.GroupAddRemove{
+			Group:   group,
+			Members: []string{row.AccessKey},
+		})
+		if e != nil {
+			return res, e
+		}
+	}
+	return res, nil
+}
+
+// checkAdminUserImportSyntax - validate all the passed arguments
+func checkAdminUserImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserImport is the handle for "mc admin user import" command.
+func mainAdminUserImport(ctx *cli.Context) error {
+	checkAdminUserImportSyntax(ctx)
+
+	console.SetColor("UserMessage", color.New(color.FgGreen))
+	console.SetColor("UserImportFailed", color.New(color.FgRed))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	file := args.Get(1)
+
+	in := io.Reader(os.Stdin)
+	if file != "-" {
+		f, e := os.Open(file)
+		fatalIf(probe.NewError(e).Trace(file), "Unable to open the users file.")
+		defer f.Close()
+		in = f
+	}
+	rows, e := parseUserImportCSV(in)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to parse the users file.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	users, e := client.ListUsers(globalContext)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to list users")
+
+	var failed bool
+	for _, row := range rows {
+		var existing *madmin.UserInfo
+		if info, ok := users[row.AccessKey]; ok {
+			existing = &info
+		}
+		res, e := importUser(client, row, existing)
+		msg := userImportMessage{
+			Status:    "success",
+			Line:      row.Line,
+			AccessKey: row.AccessKey,
+			Result:    res.result,
+			Policies:  res.policies,
+			Groups:    res.groups,
+		}
+		if e != nil {
+			failed = true
+			msg.Status = "error"
+			msg.Result = ""
+			msg.Policies = nil
+			msg.Groups = nil
+			msg.Error = e.Error()
+		}
+		printMsg(msg)
+	}
+	if failed {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
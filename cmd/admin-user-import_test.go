@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestParseUserImportCSV(t *testing.T) {
+	input := `accessKey,secretKey,policy,group
+alice,alice-secret,readwrite,
+# comment
+bob,,readonly;diagnostics,devs; ops
+carol,carol-secret
+`
+	rows, e := parseUserImportCSV(strings.NewReader(input))
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := []userImportRow{
+		{Line: 2, AccessKey: "alice", SecretKey: "alice-secret", Policies: []string{"readwrite"}},
+		{Line: 4, AccessKey: "bob", Policies: []string{"readonly", "diagnostics"}, Groups: []string{"devs", "ops"}},
+		{Line: 5, AccessKey: "carol", SecretKey: "carol-secret"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, rows)
+	}
+
+	for _, input := range []string{",secret\n", "a,b,c,d,e\n"} {
+		if _, e := parseUserImportCSV(strings.NewReader(input)); e == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestWriteUserExportCSV(t *testing.T) {
+	users := map[string]madmin.UserInfo{
+		"bob":   {PolicyName: "readonly,diagnostics", MemberOf: []string{"devs", "ops"}},
+		"alice": {PolicyName: "readwrite"},
+	}
+	var buf bytes.Buffer
+	if e := writeUserExportCSV(&buf, users); e != nil {
+		t.Fatal(e)
+	}
+	expected := "accessKey,secretKey,policy,group\nalice,,readwrite,\nbob,,readonly;diagnostics,devs;ops\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+
+	rows, e := parseUserImportCSV(&buf)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(rows) != 2 || rows[1].AccessKey != "bob" || len(rows[1].Groups) != 2 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+}
+
+func TestMissingUserItems(t *testing.T) {
+	testCases := []struct {
+		want, have []string
+		expected   []string
+	}{
+		{[]string{"readwrite"}, []string{""}, []string{"readwrite"}},
+		{[]string{"readwrite", "diagnostics"}, []string{"readwrite"}, []string{"diagnostics"}},
+		{[]string{"devs"}, []string{"devs", "ops"}, nil},
+		{nil, []string{"ops"}, nil},
+	}
+	for i, testCase := range testCases {
+		if got := missingUserItems(testCase.want, testCase.have); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -29,6 +29,8 @@ var adminUserSubcommands = []cli.Command{
 	adminUserPolicyCmd,
 	adminUserSvcAcctCmd,
 	adminUserSTSAcctCmd,
+	adminUserImportCmd,
+	adminUserExportCmd,
 }
 
 var adminUserCmd = cli.Command{
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net"
 	"net/url"
 	"os"
 	"strings"
@@ -29,6 +30,8 @@ import (
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/pkg/v3/console"
 )
 
@@ -37,6 +40,18 @@ var anonymousFlags = []cli.Flag{
 		Name:  "recursive, r",
 		Usage: "list recursively",
 	},
+	cli.StringSliceFlag{
+		Name:  "source-ip",
+		Usage: "only allow anonymous access from these IP addresses or CIDR ranges (aws:SourceIp)",
+	},
+	cli.StringSliceFlag{
+		Name:  "referer",
+		Usage: "only allow anonymous access for these HTTP referers, wildcards allowed (aws:Referer)",
+	},
+	cli.BoolFlag{
+		Name:  "show-effective",
+		Usage: "print the generated bucket policy before applying it",
+	},
 }
 
 // Manage anonymous access to buckets and objects.
@@ -93,6 +108,13 @@ EXAMPLES:
 
   9. List public object URLs recursively.
      {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. Allow anonymous downloads of a prefix only from a private network.
+      {{.Prompt}} {{.HelpName}} set download --source-ip 10.0.0.0/8 s3/public-commons/images
+
+  11. Allow anonymous downloads only for requests referred by your website, and print the
+      generated bucket policy before applying it.
+      {{.Prompt}} {{.HelpName}} set download --referer "https://example.com/*" --show-effective s3/shared
 `,
 }
 
@@ -121,10 +143,19 @@ type anonymousMessage struct {
 	Bucket    string                 `json:"bucket"`
 	Perms     accessPerms            `json:"permission"`
 	Anonymous map[string]interface{} `json:"anonymous,omitempty"`
+	DryRun    bool                   `json:"dryRun,omitempty"`
 }
 
 // String colorized access message.
 func (s anonymousMessage) String() string {
+	if s.DryRun && s.Operation == "set" {
+		return console.Colorize("Anonymous",
+			"Would set access permission for `"+s.Bucket+"` to `"+string(s.Perms)+"`")
+	}
+	if s.DryRun && s.Operation == "set-json" {
+		return console.Colorize("Anonymous",
+			"Would set access permission for `"+s.Bucket+"` from `"+string(s.Perms)+"`")
+	}
 	if s.Operation == "set" {
 		return console.Colorize("Anonymous",
 			"Access permission for `"+s.Bucket+"` is set to `"+string(s.Perms)+"`")
@@ -173,6 +204,31 @@ func (s anonymousLinksMessage) JSON() string {
 	return string(anonymousJSONBytes)
 }
 
+// anonymousPolicyMessage is the bucket policy generated by 'anonymous set --show-effective'
+type anonymousPolicyMessage struct {
+	Status string                 `json:"status"`
+	Bucket string                 `json:"bucket"`
+	Policy map[string]interface{} `json:"policy"`
+}
+
+// String colorized generated policy.
+func (s anonymousPolicyMessage) String() string {
+	if len(s.Policy) == 0 {
+		return console.Colorize("Anonymous", "Effective policy for `"+s.Bucket+"` is empty")
+	}
+	policyBytes, e := json.MarshalIndent(s.Policy, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return console.Colorize("Anonymous", "Effective policy for `"+s.Bucket+"`:\n") + string(policyBytes)
+}
+
+// JSON jsonified generated policy.
+func (s anonymousPolicyMessage) JSON() string {
+	policyJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(policyJSONBytes)
+}
+
 // checkAnonymousSyntax check for incoming syntax.
 func checkAnonymousSyntax(ctx *cli.Context) {
 	argsLength := len(ctx.Args())
@@ -188,6 +244,11 @@ func checkAnonymousSyntax(ctx *cli.Context) {
 	firstArg := ctx.Args().Get(0)
 	secondArg := ctx.Args().Get(1)
 
+	if firstArg != "set" && (ctx.IsSet("source-ip") || ctx.IsSet("referer") || ctx.IsSet("show-effective")) {
+		fatalIf(errInvalidArgument().Trace(firstArg),
+			"--source-ip, --referer and --show-effective are only supported with `set`.")
+	}
+
 	// More syntax checking
 	switch accessPerms(firstArg) {
 	case "set":
@@ -203,6 +264,12 @@ func checkAnonymousSyntax(ctx *cli.Context) {
 			fatalIf(errDummy().Trace(),
 				"Unrecognized permission `"+secondArg+"`. Allowed values are [private, public, download, upload].")
 		}
+		cond, err := parseAnonymousConditions(ctx.StringSlice("source-ip"), ctx.StringSlice("referer"))
+		fatalIf(err, "Unable to parse anonymous access conditions.")
+		if !cond.isEmpty() && (accessPerms(secondArg) == accessNone || accessPerms(secondArg) == accessPrivate) {
+			fatalIf(errInvalidArgument().Trace(secondArg),
+				"--source-ip and --referer cannot be used with permission `"+secondArg+"`.")
+		}
 
 	case "set-json":
 		// Always expect three arguments when setting a anonymous permission.
@@ -247,14 +314,142 @@ func accessPermToString(perm accessPerms) string {
 	return anonymous
 }
 
+// anonymousConditions restricts generated anonymous statements to the
+// given source addresses and referers.
+type anonymousConditions struct {
+	sourceIPs []string
+	referers  []string
+}
+
+func (c anonymousConditions) isEmpty() bool {
+	return len(c.sourceIPs) == 0 && len(c.referers) == 0
+}
+
+// parseAnonymousConditions validates --source-ip and --referer values, a
+// bare IP address is turned into a single host CIDR range.
+func parseAnonymousConditions(sourceIPs, referers []string) (cond anonymousConditions, err *probe.Error) {
+	for _, ip := range sourceIPs {
+		ip = strings.TrimSpace(ip)
+		if _, _, e := net.ParseCIDR(ip); e == nil {
+			cond.sourceIPs = append(cond.sourceIPs, ip)
+			continue
+		}
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			return cond, errInvalidArgument().Trace(ip)
+		}
+		if addr.To4() != nil {
+			cond.sourceIPs = append(cond.sourceIPs, addr.String()+"/32")
+		} else {
+			cond.sourceIPs = append(cond.sourceIPs, addr.String()+"/128")
+		}
+	}
+	for _, referer := range referers {
+		referer = strings.TrimSpace(referer)
+		if referer == "" {
+			return cond, errInvalidArgument().Trace(referer)
+		}
+		cond.referers = append(cond.referers, referer)
+	}
+	return cond, nil
+}
+
+// anonymousConditionalSid identifies the conditional statements generated
+// for bucket/prefix, they are kept apart from the canned statements which
+// minio-go would otherwise merge with them.
+const anonymousConditionalSid = "mc-anonymous:"
+
+// generateAnonymousPolicy returns the bucket policy obtained by granting perms
+// on bucket/prefix on top of the current policy. Without conditions this is
+// the same policy the canned permissions produce, otherwise the statements of
+// bucket/prefix are replaced by conditional ones.
+func generateAnonymousPolicy(policyStr string, perms accessPerms, bucket, prefix string, cond anonymousConditions) (string, *probe.Error) {
+	p := policy.BucketAccessPolicy{Version: "2012-10-17"}
+	if policyStr != "" {
+		if e := json.Unmarshal([]byte(policyStr), &p); e != nil {
+			return "", probe.NewError(e)
+		}
+	}
+
+	sid := anonymousConditionalSid + bucket + "/" + prefix
+	var statements, conditional []policy.Statement
+	for _, statement := range p.Statements {
+		switch {
+		case statement.Sid == sid:
+			// Replaced by the new permission below.
+		case strings.HasPrefix(statement.Sid, anonymousConditionalSid):
+			conditional = append(conditional, statement)
+		default:
+			statements = append(statements, statement)
+		}
+	}
+
+	bucketPolicy := policy.BucketPolicy(accessPermToString(perms))
+	if cond.isEmpty() {
+		statements = policy.SetPolicy(statements, bucketPolicy, bucket, prefix)
+	} else {
+		statements = policy.SetPolicy(statements, policy.BucketPolicyNone, bucket, prefix)
+		for _, statement := range policy.SetPolicy(nil, bucketPolicy, bucket, prefix) {
+			statement.Sid = sid
+			if statement.Conditions == nil {
+				statement.Conditions = make(policy.ConditionMap)
+			}
+			if len(cond.sourceIPs) > 0 {
+				statement.Conditions.Add("IpAddress", policy.ConditionKeyMap{
+					"aws:SourceIp": set.CreateStringSet(cond.sourceIPs...),
+				})
+			}
+			if len(cond.referers) > 0 {
+				statement.Conditions.Add("StringLike", policy.ConditionKeyMap{
+					"aws:Referer": set.CreateStringSet(cond.referers...),
+				})
+			}
+			conditional = append(conditional, statement)
+		}
+	}
+
+	p.Statements = append(statements, conditional...)
+	if len(p.Statements) == 0 {
+		return "", nil
+	}
+	policyBytes, e := json.Marshal(p)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return string(policyBytes), nil
+}
+
 // doSetAccess do set access.
-func doSetAccess(ctx context.Context, targetURL string, targetPERMS accessPerms) *probe.Error {
+func doSetAccess(ctx context.Context, targetURL string, targetPERMS accessPerms, cond anonymousConditions, showEffective bool) *probe.Error {
 	clnt, err := newClient(targetURL)
 	if err != nil {
 		return err.Trace(targetURL)
 	}
-	anonymous := accessPermToString(targetPERMS)
-	if err = clnt.SetAccess(ctx, anonymous, false); err != nil {
+	_, policyStr, err := clnt.GetAccess(ctx)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	clntURL := clnt.GetURL()
+	bucket, prefix := url2BucketAndObject(&clntURL)
+	if policyStr, err = generateAnonymousPolicy(policyStr, targetPERMS, bucket, prefix, cond); err != nil {
+		return err.Trace(targetURL, string(targetPERMS))
+	}
+	if showEffective {
+		policyJSON := map[string]interface{}{}
+		if policyStr != "" {
+			e := json.Unmarshal([]byte(policyStr), &policyJSON)
+			fatalIf(probe.NewError(e), "Unable to unmarshal generated anonymous policy.")
+		}
+		printMsg(anonymousPolicyMessage{
+			Status: "success",
+			Bucket: targetURL,
+			Policy: policyJSON,
+		})
+	}
+	if globalDryRun {
+		return nil
+	}
+	if err = clnt.SetAccess(ctx, policyStr, true); err != nil {
 		return err.Trace(targetURL, string(targetPERMS))
 	}
 	return nil
@@ -284,6 +479,9 @@ func doSetAccessJSON(ctx context.Context, targetURL string, targetPERMS accessPe
 	}
 
 	configBytes := configBuf[:n]
+	if globalDryRun {
+		return nil
+	}
 	if err = clnt.SetAccess(ctx, string(configBytes), true); err != nil {
 		return err.Trace(targetURL, string(targetPERMS))
 	}
@@ -421,7 +619,7 @@ func runAnonymousLinksCmd(args cli.Args, recursive bool) {
 }
 
 // Run anonymous cmd to fetch set permission
-func runAnonymousCmd(args cli.Args) {
+func runAnonymousCmd(args cli.Args, cond anonymousConditions, showEffective bool) {
 	ctx, cancelAnonymous := context.WithCancel(globalContext)
 	defer cancelAnonymous()
 
@@ -437,8 +635,8 @@ func runAnonymousCmd(args cli.Args) {
 			fatalIf(errDummy().Trace(), "Invalid access permission: `"+string(perms)+"`.")
 		}
 		targetURL = args.Get(2)
-		probeErr = doSetAccess(ctx, targetURL, perms)
-		if probeErr == nil {
+		probeErr = doSetAccess(ctx, targetURL, perms, cond, showEffective)
+		if probeErr == nil && !globalDryRun {
 			perms, _, probeErr = doGetAccess(ctx, targetURL)
 		}
 	case "set-json":
@@ -475,6 +673,7 @@ func runAnonymousCmd(args cli.Args) {
 		Bucket:    targetURL,
 		Perms:     perms,
 		Anonymous: anonymousJSON,
+		DryRun:    globalDryRun && strings.HasPrefix(operation, "set"),
 	})
 }
 
@@ -491,7 +690,9 @@ func mainAnonymous(ctx *cli.Context) error {
 		// anonymous set-json path-to-anonymous-json-file alias/bucket/prefix
 		// anonymous get alias/bucket/prefix
 		// anonymous get-json alias/bucket/prefix
-		runAnonymousCmd(ctx.Args())
+		cond, err := parseAnonymousConditions(ctx.StringSlice("source-ip"), ctx.StringSlice("referer"))
+		fatalIf(err, "Unable to parse anonymous access conditions.")
+		runAnonymousCmd(ctx.Args(), cond, ctx.Bool("show-effective"))
 	case "list":
 		// anonymous list alias/bucket/prefix
 		runAnonymousListCmd(ctx.Args().Tail())
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/policy"
+)
+
+func TestParseAnonymousConditions(t *testing.T) {
+	testCases := []struct {
+		sourceIPs []string
+		referers  []string
+		expected  []string
+		success   bool
+	}{
+		{[]string{"10.0.0.0/8"}, nil, []string{"10.0.0.0/8"}, true},
+		{[]string{"192.168.1.10"}, nil, []string{"192.168.1.10/32"}, true},
+		{[]string{"::1"}, nil, []string{"::1/128"}, true},
+		{[]string{"not-an-ip"}, nil, nil, false},
+		{nil, []string{"https://example.com/*"}, nil, true},
+		{nil, []string{" "}, nil, false},
+	}
+	for i, testCase := range testCases {
+		cond, err := parseAnonymousConditions(testCase.sourceIPs, testCase.referers)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		if len(cond.sourceIPs) != len(testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, cond.sourceIPs)
+		}
+		for j := range testCase.expected {
+			if cond.sourceIPs[j] != testCase.expected[j] {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, cond.sourceIPs)
+			}
+		}
+	}
+}
+
+func TestGenerateAnonymousPolicy(t *testing.T) {
+	// Existing public download access on another prefix must be preserved.
+	existing, err := generateAnonymousPolicy("", accessDownload, "bucket", "public/", anonymousConditions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cond := anonymousConditions{sourceIPs: []string{"10.0.0.0/8"}, referers: []string{"https://example.com/*"}}
+	policyStr, err := generateAnonymousPolicy(existing, accessDownload, "bucket", "images/", cond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p policy.BucketAccessPolicy
+	if e := json.Unmarshal([]byte(policyStr), &p); e != nil {
+		t.Fatal(e)
+	}
+	if got := policy.GetPolicies(p.Statements, "bucket", "public/"); got["bucket/public/*"] != policy.BucketPolicyReadOnly {
+		t.Fatalf("expected existing prefix to stay readonly, got %v", got)
+	}
+
+	var conditional int
+	for _, statement := range p.Statements {
+		if !statement.Resources.Contains("arn:aws:s3:::bucket/images/*") {
+			continue
+		}
+		conditional++
+		if !statement.Conditions["IpAddress"]["aws:SourceIp"].Contains("10.0.0.0/8") {
+			t.Fatalf("expected aws:SourceIp condition, got %v", statement.Conditions)
+		}
+		if !statement.Conditions["StringLike"]["aws:Referer"].Contains("https://example.com/*") {
+			t.Fatalf("expected aws:Referer condition, got %v", statement.Conditions)
+		}
+	}
+	if conditional == 0 {
+		t.Fatalf("expected a conditional statement for images/, got %s", policyStr)
+	}
+
+	// Making both prefixes private removes the policy entirely.
+	policyStr, err = generateAnonymousPolicy(policyStr, accessPrivate, "bucket", "images/", anonymousConditions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policyStr, err = generateAnonymousPolicy(policyStr, accessPrivate, "bucket", "public/", anonymousConditions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policyStr != "" {
+		t.Fatalf("expected an empty policy, got %s", policyStr)
+	}
+}
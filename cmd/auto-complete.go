@@ -18,10 +18,13 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/posener/complete"
@@ -80,34 +83,144 @@ func completeAdminConfigKeys(aliasPath, keyPrefix string) (prediction []string)
 	return prediction
 }
 
-// Complete S3 path. If the prediction result is only one directory,
-// then recursively scans it. This is needed to satisfy posener/complete
-// (look at posener/complete.PredictFiles)
-func completeS3Path(s3Path string) (prediction []string) {
-	// Convert alias/bucket/incompl to alias/bucket/ to list its contents
-	parentDirPath := filepath.Dir(s3Path) + "/"
-	clnt, err := newClient(parentDirPath)
+const (
+	// completionCacheTTL is how long the listings done for completion
+	// are reused, can be changed with MC_COMPLETION_CACHE_TTL, 0 disables
+	// the cache.
+	completionCacheTTL = 30 * time.Second
+	// completionListTimeout bounds a listing so that an unreachable
+	// server never blocks the shell.
+	completionListTimeout = 3 * time.Second
+	// completionMaxEntries is the maximum number of entries of a folder
+	// matching the completed path listed for completion.
+	completionMaxEntries = 1000
+)
+
+// completionCacheEntry is the cached listing of a single folder.
+type completionCacheEntry struct {
+	Time    time.Time `json:"time"`
+	Entries []string  `json:"entries"`
+}
+
+func completionCacheFile() string {
+	return filepath.Join(mustGetMcConfigDir(), "completion-cache.json")
+}
+
+func getCompletionCacheTTL() time.Duration {
+	if v, ok := os.LookupEnv(envPrefix + "COMPLETION_CACHE_TTL"); ok {
+		if ttl, e := time.ParseDuration(v); e == nil && ttl >= 0 {
+			return ttl
+		}
+	}
+	return completionCacheTTL
+}
+
+// loadCompletionCache returns the cached folder listings, a missing or
+// corrupted cache is empty.
+func loadCompletionCache(filename string) map[string]completionCacheEntry {
+	cache := make(map[string]completionCacheEntry)
+	data, e := os.ReadFile(filename)
+	if e != nil {
+		return cache
+	}
+	if e = json.Unmarshal(data, &cache); e != nil {
+		return make(map[string]completionCacheEntry)
+	}
+	return cache
+}
+
+// saveCompletionCache writes the listings which are not expired yet.
+func saveCompletionCache(filename string, cache map[string]completionCacheEntry, ttl time.Duration, now time.Time) {
+	for dir, entry := range cache {
+		if now.Sub(entry.Time) >= ttl {
+			delete(cache, dir)
+		}
+	}
+	data, e := json.Marshal(cache)
+	if e != nil {
+		return
+	}
+	if e = os.MkdirAll(filepath.Dir(filename), 0o700); e != nil {
+		return
+	}
+	// Write to a temporary file first, completions may run concurrently.
+	tmpFile := filename + ".tmp"
+	if e = os.WriteFile(tmpFile, data, 0o600); e != nil {
+		return
+	}
+	os.Rename(tmpFile, filename)
+}
+
+// listCompletionDir returns the entries of an alias/bucket/prefix/ folder
+// starting with prefix, the path being completed, prefixed with the folder
+// path. Entries are filtered before the listing is truncated so that any
+// entry of a large folder can be completed. Listings are cached by prefix
+// and reused while they are recent enough.
+func listCompletionDir(dirPath, prefix string) []string {
+	ttl := getCompletionCacheTTL()
+	cacheFile := completionCacheFile()
+	now := time.Now()
+
+	var cache map[string]completionCacheEntry
+	if ttl > 0 {
+		cache = loadCompletionCache(cacheFile)
+		if entry, ok := cache[prefix]; ok && now.Sub(entry.Time) < ttl {
+			return entry.Entries
+		}
+	}
+
+	clnt, err := newClient(dirPath)
 	if err != nil {
 		return nil
 	}
 
 	// Calculate alias from the path
-	alias := splitStr(s3Path, "/", 3)[0]
+	alias := splitStr(dirPath, "/", 3)[0]
 
-	// List dirPath content and only pick elements that corresponds
-	// to the path that we want to complete
-	for content := range clnt.List(globalContext, ListOptions{Recursive: false, ShowDir: DirFirst}) {
-		cmplS3Path := alias + getKey(content)
+	ctx, cancel := context.WithTimeout(globalContext, completionListTimeout)
+	defer cancel()
+
+	var entries []string
+	listed := true
+	for content := range clnt.List(ctx, ListOptions{Recursive: false, ShowDir: DirFirst}) {
+		if content.Err != nil {
+			listed = false
+			continue
+		}
+		entry := alias + getKey(content)
 		if content.Type.IsDir() {
-			if !strings.HasSuffix(cmplS3Path, "/") {
-				cmplS3Path += "/"
+			if !strings.HasSuffix(entry, "/") {
+				entry += "/"
 			}
 		}
-		if strings.HasPrefix(cmplS3Path, s3Path) {
-			prediction = append(prediction, cmplS3Path)
+		if !strings.HasPrefix(entry, prefix) {
+			continue
+		}
+		entries = append(entries, entry)
+		if len(entries) >= completionMaxEntries {
+			break
 		}
 	}
 
+	// Only cache listings which did not fail or time out.
+	if ttl > 0 && listed && ctx.Err() == nil {
+		cache[prefix] = completionCacheEntry{Time: now, Entries: entries}
+		saveCompletionCache(cacheFile, cache, ttl, now)
+	}
+	return entries
+}
+
+// Complete S3 path. If the prediction result is only one directory,
+// then recursively scans it. This is needed to satisfy posener/complete
+// (look at posener/complete.PredictFiles)
+func completeS3Path(s3Path string) (prediction []string) {
+	// Convert alias/bucket/incompl to alias/bucket/ to list its contents
+	parentDirPath := filepath.Dir(s3Path) + "/"
+
+	// Only pick elements of the listing that corresponds
+	// to the path that we want to complete
+	prediction = append(prediction, listCompletionDir(parentDirPath, s3Path)...)
+
 	// If completion found only one directory, recursively scan it.
 	if len(prediction) == 1 && strings.HasSuffix(prediction[0], "/") {
 		prediction = append(prediction, completeS3Path(prediction[0])...)
@@ -244,6 +357,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/stat":      complete.PredictOr(s3Completer, fsCompleter),
 	"/watch":     complete.PredictOr(s3Completer, fsCompleter),
 	"/anonymous": complete.PredictOr(s3Completer, fsCompleter),
+	"/browse":    complete.PredictOr(s3Completer, fsCompleter),
 	"/tree":      complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 	"/du":        complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 
@@ -259,6 +373,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/mb":  aliasCompleter,
 
 	"/event/add":    s3Complete{deepLevel: 2},
+	"/event/edit":   s3Complete{deepLevel: 2},
 	"/event/list":   s3Complete{deepLevel: 2},
 	"/event/remove": s3Complete{deepLevel: 2},
 
@@ -303,10 +418,13 @@ var completeCmds = map[string]complete.Predictor{
 	"/ilm/export":  s3Complete{deepLevel: 2},
 	"/ilm/import":  s3Complete{deepLevel: 2},
 	"/ilm/restore": s3Completer,
+	"/ilm/preview": s3Complete{deepLevel: 2},
 
 	"/ilm/rule/list":    s3Complete{deepLevel: 2},
 	"/ilm/rule/add":     s3Complete{deepLevel: 2},
 	"/ilm/rule/edit":    s3Complete{deepLevel: 2},
+	"/ilm/rule/enable":  s3Complete{deepLevel: 2},
+	"/ilm/rule/disable": s3Complete{deepLevel: 2},
 	"/ilm/rule/remove":  s3Complete{deepLevel: 2},
 	"/ilm/rule/export":  s3Complete{deepLevel: 2},
 	"/ilm/rule/import":  s3Complete{deepLevel: 2},
@@ -315,7 +433,9 @@ var completeCmds = map[string]complete.Predictor{
 	"/undo": s3Completer,
 
 	// Admin API commands MinIO only.
-	"/admin/heal": s3Completer,
+	"/admin/heal":   s3Completer,
+	"/admin/health": aliasCompleter,
+	"/admin/usage":  aliasCompleter,
 
 	"/admin/info": aliasCompleter,
 	"/admin/logs": aliasCompleter,
@@ -325,6 +445,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/config/reset":   adminConfigCompleter,
 	"/admin/config/import":  aliasCompleter,
 	"/admin/config/export":  aliasCompleter,
+	"/admin/config/diff":    aliasCompleter,
 	"/admin/config/history": aliasCompleter,
 	"/admin/config/restore": aliasCompleter,
 
@@ -391,6 +512,15 @@ var completeCmds = map[string]complete.Predictor{
 	"/idp/ldap/accesskey/edit":              aliasCompleter,
 	"/idp/ldap/accesskey/enable":            aliasCompleter,
 	"/idp/ldap/accesskey/disable":           aliasCompleter,
+	"/idp/openid/accesskey/create":          aliasCompleter,
+	"/idp/openid/accesskey/list":            aliasCompleter,
+	"/idp/openid/accesskey/ls":              aliasCompleter,
+	"/idp/openid/accesskey/remove":          aliasCompleter,
+	"/idp/openid/accesskey/rm":              aliasCompleter,
+	"/idp/openid/accesskey/info":            aliasCompleter,
+	"/idp/openid/accesskey/edit":            aliasCompleter,
+	"/idp/openid/accesskey/enable":          aliasCompleter,
+	"/idp/openid/accesskey/disable":         aliasCompleter,
 
 	"/admin/accesskey/create":  aliasCompleter,
 	"/admin/accesskey/list":    aliasCompleter,
@@ -411,6 +541,9 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/policy/attach":   aliasCompleter,
 	"/admin/policy/detach":   aliasCompleter,
 	"/admin/policy/entities": aliasCompleter,
+	"/admin/policy/validate": fsCompleter,
+	"/admin/policy/diff":     aliasCompleter,
+	"/admin/policy/test":     aliasCompleter,
 
 	"/admin/user/add":     aliasCompleter,
 	"/admin/user/disable": aliasCompleter,
@@ -419,6 +552,8 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/user/remove":  aliasCompleter,
 	"/admin/user/info":    aliasCompleter,
 	"/admin/user/policy":  aliasCompleter,
+	"/admin/user/import":  aliasCompleter,
+	"/admin/user/export":  aliasCompleter,
 
 	"/admin/user/svcacct/add":     aliasCompleter,
 	"/admin/user/svcacct/list":    aliasCompleter,
@@ -431,12 +566,13 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/admin/user/sts/info": aliasCompleter,
 
-	"/admin/group/add":     aliasCompleter,
-	"/admin/group/disable": aliasCompleter,
-	"/admin/group/enable":  aliasCompleter,
-	"/admin/group/list":    aliasCompleter,
-	"/admin/group/remove":  aliasCompleter,
-	"/admin/group/info":    aliasCompleter,
+	"/admin/group/add":         aliasCompleter,
+	"/admin/group/disable":     aliasCompleter,
+	"/admin/group/enable":      aliasCompleter,
+	"/admin/group/list":        aliasCompleter,
+	"/admin/group/remove":      aliasCompleter,
+	"/admin/group/info":        aliasCompleter,
+	"/admin/group/memberships": aliasCompleter,
 
 	"/admin/bucket/remote/add":    aliasCompleter,
 	"/admin/bucket/remote/edit":   aliasCompleter,
@@ -447,6 +583,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/kms/key/create": aliasCompleter,
 	"/admin/kms/key/status": aliasCompleter,
 	"/admin/kms/key/list":   aliasCompleter,
+	"/admin/kms/key/info":   aliasCompleter,
 
 	"/admin/subnet/health":   aliasCompleter,
 	"/admin/subnet/register": aliasCompleter,
@@ -515,6 +652,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/od":             nil,
 	"/batch/generate": aliasCompleter,
 	"/batch/start":    aliasCompleter,
+	"/batch/validate": aliasCompleter,
 	"/batch/list":     aliasCompleter,
 	"/batch/status":   aliasCompleter,
 	"/batch/describe": aliasCompleter,
@@ -19,9 +19,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 )
 
 func TestAutoCompletionCompletness(t *testing.T) {
@@ -58,3 +63,68 @@ func TestAutoCompletionCompletness(t *testing.T) {
 
 	}
 }
+
+func TestListCompletionDirCache(t *testing.T) {
+	configDir := t.TempDir()
+	oldConfigDir := mcCustomConfigDir
+	setMcConfigDir(configDir)
+	defer setMcConfigDir(oldConfigDir)
+
+	oldLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = oldLoadMcConfig }()
+
+	dataDir := t.TempDir()
+	if e := os.WriteFile(filepath.Join(dataDir, "a.txt"), nil, 0o600); e != nil {
+		t.Fatal(e)
+	}
+	if e := os.Mkdir(filepath.Join(dataDir, "sub"), 0o700); e != nil {
+		t.Fatal(e)
+	}
+	dirPath := filepath.ToSlash(dataDir) + "/"
+	list := func() []string {
+		entries := listCompletionDir(dirPath, dirPath)
+		sort.Strings(entries)
+		return entries
+	}
+
+	t.Setenv("MC_COMPLETION_CACHE_TTL", "1h")
+	want := []string{dirPath + "a.txt", dirPath + "sub/"}
+	if got := list(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, e := os.Stat(completionCacheFile()); e != nil {
+		t.Fatalf("expected the completion cache to be saved: %v", e)
+	}
+
+	// A new file is not seen until the cached listing expires.
+	if e := os.WriteFile(filepath.Join(dataDir, "b.txt"), nil, 0o600); e != nil {
+		t.Fatal(e)
+	}
+	if got := list(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected cached %v, got %v", want, got)
+	}
+
+	t.Setenv("MC_COMPLETION_CACHE_TTL", "0")
+	want = []string{dirPath + "a.txt", dirPath + "b.txt", dirPath + "sub/"}
+	if got := list(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Entries past the listing limit can be completed.
+	for i := 0; i < completionMaxEntries; i++ {
+		if e := os.WriteFile(filepath.Join(dataDir, fmt.Sprintf("f%04d", i)), nil, 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := os.WriteFile(filepath.Join(dataDir, "zz.txt"), nil, 0o600); e != nil {
+		t.Fatal(e)
+	}
+	if got := listCompletionDir(dirPath, dirPath); len(got) != completionMaxEntries {
+		t.Fatalf("expected %d entries, got %d", completionMaxEntries, len(got))
+	}
+	want = []string{dirPath + "zz.txt"}
+	if got := listCompletionDir(dirPath, dirPath+"z"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
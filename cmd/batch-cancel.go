@@ -18,12 +18,17 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
@@ -33,6 +38,10 @@ var batchCancelFlags = []cli.Flag{
 		Name:  "id",
 		Usage: "job id",
 	},
+	cli.BoolFlag{
+		Name:  "yes, y",
+		Usage: "cancel the job without confirmation",
+	},
 }
 
 var batchCancelCmd = cli.Command{
@@ -46,7 +55,7 @@ var batchCancelCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET JOBFILE
+  {{.HelpName}} TARGET JOBID
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -54,18 +63,50 @@ FLAGS:
 EXAMPLES:
   1. Cancel ongoing batch job:
      {{.Prompt}} {{.HelpName}} myminio <job-id>
+
+  2. Cancel ongoing batch job without confirmation:
+     {{.Prompt}} {{.HelpName}} myminio <job-id> --yes
 `,
 }
 
 // batchCancelMessage container for file batchCancel messages
 type batchCancelMessage struct {
-	Status string `json:"status"`
-	JobID  string `json:"job-id"`
+	Status string         `json:"status"`
+	JobID  string         `json:"job-id"`
+	Job    *batchJobEntry `json:"job,omitempty"`
 }
 
 // String colorized batchCancel message
 func (c batchCancelMessage) String() string {
-	return console.Colorize("batchCancel", fmt.Sprintf("Successfully canceled batch job `%s`", c.JobID))
+	msg := console.Colorize("BatchCancel", fmt.Sprintf("Successfully canceled batch job `%s`", c.JobID))
+	if c.Job != nil {
+		msg += "\n" + batchJobSummary(*c.Job)
+	}
+	return msg
+}
+
+// batchJobSummary returns the counters of the batch job.
+func batchJobSummary(job batchJobEntry) string {
+	summary := fmt.Sprintf("%s job started %s: %s objects processed, %s failed",
+		job.Type, humanize.Time(job.Started), humanize.Comma(job.Objects), humanize.Comma(job.ObjectsFailed))
+	if job.Bytes > 0 {
+		summary += ", " + humanize.IBytes(uint64(job.Bytes)) + " transferred"
+	}
+	return summary
+}
+
+// batchJobStatusEntry returns the batch job entry of the last metric of the job.
+func batchJobStatusEntry(ctx context.Context, client *madmin.AdminClient, jobID string) (*batchJobEntry, error) {
+	status, e := client.BatchJobStatus(ctx, jobID)
+	if e != nil {
+		return nil, e
+	}
+	entry := newBatchJobEntry(madmin.BatchJobResult{
+		ID:      jobID,
+		Type:    madmin.BatchJobType(status.LastMetric.JobType),
+		Started: status.LastMetric.StartTime,
+	}, status.LastMetric)
+	return &entry, nil
 }
 
 // JSON jsonified batchCancel message
@@ -100,12 +141,32 @@ func mainBatchCancel(ctx *cli.Context) error {
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
+	if isTerminal() && !globalJSON && !ctx.Bool("yes") {
+		job, e := batchJobStatusEntry(ctxt, adminClient, jobID)
+		fatalIf(probe.NewError(e), "Unable to fetch the status of the job")
+		if job.State != batchJobStateRunning {
+			fatalIf(errInvalidArgument().Trace(jobID), fmt.Sprintf("Batch job is already %s.", job.State))
+		}
+		fmt.Println(batchJobSummary(*job))
+		fmt.Printf("You are about to cancel the batch job `%s`, please confirm [y/N]: ", jobID)
+		answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+		fatalIf(probe.NewError(e), "Unable to parse user input.")
+		if answer = strings.TrimSpace(strings.ToLower(answer)); answer != "y" && answer != "yes" {
+			fmt.Println("Cancel aborted!")
+			return nil
+		}
+	}
+
 	e := adminClient.CancelBatchJob(ctxt, jobID)
 	fatalIf(probe.NewError(e), "Unable to cancel job")
 
+	// report the counters of the job when it was canceled
+	job, _ := batchJobStatusEntry(ctxt, adminClient, jobID)
+
 	printMsg(batchCancelMessage{
 		Status: "Canceled",
 		JobID:  jobID,
+		Job:    job,
 	})
 	return nil
 }
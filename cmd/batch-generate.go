@@ -19,6 +19,9 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/minio/cli"
@@ -26,13 +29,28 @@ import (
 	"github.com/minio/mc/pkg/probe"
 )
 
+var batchGenerateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "source",
+		Usage: "prefill the source, as ALIAS/BUCKET[/PREFIX] for replicate and BUCKET[/PREFIX] for the other job types",
+	},
+	cli.StringFlag{
+		Name:  "target",
+		Usage: "prefill the replication target as ALIAS/BUCKET[/PREFIX]",
+	},
+	cli.StringFlag{
+		Name:  "prefix",
+		Usage: "prefill the prefix of the objects processed by the job",
+	},
+}
+
 var batchGenerateCmd = cli.Command{
 	Name:         "generate",
 	Usage:        "generate a new batch job definition",
 	Action:       mainBatchGenerate,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(batchGenerateFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -47,6 +65,17 @@ FLAGS:
 EXAMPLES:
   1. Generate a new batch 'replication' job definition:
      {{.Prompt}} {{.HelpName}} myminio replicate > replication.yaml
+
+  2. Generate a 'replication' job definition copying 'photos/2024' from the alias 'oldminio' to the bucket 'photos' of 'myminio',
+     the endpoints are filled from the alias configuration and the credentials reference the environment variables
+     SOURCE_ACCESS_KEY, SOURCE_SECRET_KEY, TARGET_ACCESS_KEY and TARGET_SECRET_KEY, expanded by 'mc batch start':
+     {{.Prompt}} {{.HelpName}} myminio replicate --source oldminio/photos/2024 --target myminio/photos > replication.yaml
+
+  3. Generate a 'keyrotate' job definition for the objects under 'invoices/' in the bucket 'finance':
+     {{.Prompt}} {{.HelpName}} myminio keyrotate --source finance --prefix invoices/ > keyrotate.yaml
+
+  4. Generate an 'expire' job definition for the bucket 'logs':
+     {{.Prompt}} {{.HelpName}} myminio expire --source logs > expire.yaml
 `,
 }
 
@@ -65,6 +94,137 @@ func checkBatchGenerateSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.IsSet("target") && ctx.Args().Get(1) != string(madmin.BatchJobReplicate) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args().Get(1)), "--target is only supported by replicate jobs.")
+	}
+}
+
+// batchLocation is the bucket and prefix of a batch job location, with the
+// configuration of its alias for replication jobs.
+type batchLocation struct {
+	alias    string
+	aliasCfg *aliasConfigV10
+	bucket   string
+	prefix   string
+}
+
+// parseBatchLocation parses a BUCKET[/PREFIX] location, or an ALIAS/BUCKET[/PREFIX]
+// location when withAlias is set.
+func parseBatchLocation(location string, withAlias bool) (loc batchLocation, err *probe.Error) {
+	if withAlias {
+		loc.alias, location = url2Alias(location)
+		loc.aliasCfg = mustGetHostConfig(loc.alias)
+		if loc.aliasCfg == nil {
+			return loc, errInvalidAliasedURL(loc.alias).Trace(loc.alias)
+		}
+	}
+	parts := splitStr(strings.TrimPrefix(location, "/"), "/", 2)
+	loc.bucket, loc.prefix = parts[0], parts[1]
+	if loc.bucket == "" {
+		return loc, errInvalidArgument().Trace(location)
+	}
+	return loc, nil
+}
+
+// batchEndpointType returns the replication type of an alias endpoint.
+func batchEndpointType(endpoint string) string {
+	u, e := url.Parse(endpoint)
+	if e == nil && isAmazon(u.Host) {
+		return "s3"
+	}
+	return "minio"
+}
+
+// batchTemplateValues returns the values to prefill in the job template of the
+// job type, keyed by their path in the template.
+func batchTemplateValues(jobType madmin.BatchJobType, source, target *batchLocation, prefix string) map[string]string {
+	values := make(map[string]string)
+	set := func(path string, value string) {
+		values[string(jobType)+"."+path] = strconv.Quote(value)
+	}
+
+	switch jobType {
+	case madmin.BatchJobReplicate:
+		for key, loc := range map[string]*batchLocation{"source": source, "target": target} {
+			if loc == nil {
+				continue
+			}
+			set(key+".type", batchEndpointType(loc.aliasCfg.URL))
+			set(key+".bucket", loc.bucket)
+			set(key+".prefix", loc.prefix)
+			set(key+".endpoint", loc.aliasCfg.URL)
+			// Reference the credentials instead of writing them to the
+			// job definition, they are expanded by 'mc batch start'.
+			set(key+".credentials.accessKey", "${"+strings.ToUpper(key)+"_ACCESS_KEY}")
+			set(key+".credentials.secretKey", "${"+strings.ToUpper(key)+"_SECRET_KEY}")
+		}
+		if prefix != "" {
+			set("source.prefix", prefix)
+		}
+	default:
+		if source != nil {
+			set("bucket", source.bucket)
+			set("prefix", source.prefix)
+		}
+		if prefix != "" {
+			set("prefix", prefix)
+		}
+	}
+	return values
+}
+
+// prefillBatchTemplate replaces the values of the template keys found in values,
+// keyed by their dotted path, and keeps the comments of the template. It also
+// returns the paths of values which are not found in the template.
+func prefillBatchTemplate(template string, values map[string]string) (string, []string) {
+	matched := make(map[string]bool, len(values))
+	type level struct {
+		indent int
+		key    string
+	}
+	var stack []level
+
+	lines := strings.Split(template, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, level{indent: indent, key: key})
+
+		path := make([]string, 0, len(stack))
+		for _, l := range stack {
+			path = append(path, l.key)
+		}
+		newValue, ok := values[strings.Join(path, ".")]
+		if !ok {
+			continue
+		}
+		matched[strings.Join(path, ".")] = true
+
+		var comment string
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			comment = value[idx:]
+		}
+		lines[i] = line[:indent] + key + ": " + newValue + comment
+	}
+
+	var missing []string
+	for path := range values {
+		if !matched[path] {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+	return strings.Join(lines, "\n"), missing
 }
 
 // mainBatchGenerate is the handle for "mc batch generate" command.
@@ -91,11 +251,25 @@ func mainBatchGenerate(ctx *cli.Context) error {
 		fatalIf(errInvalidArgument().Trace(jobType), "Unable to generate a job template for the specified job type")
 	}
 
+	var source, target *batchLocation
+	if ctx.IsSet("source") {
+		loc, err := parseBatchLocation(ctx.String("source"), jobType == string(madmin.BatchJobReplicate))
+		fatalIf(err, "Unable to parse the source location.")
+		source = &loc
+	}
+	if ctx.IsSet("target") {
+		loc, err := parseBatchLocation(ctx.String("target"), true)
+		fatalIf(err, "Unable to parse the target location.")
+		target = &loc
+	}
+
 	out, e := adminClient.GenerateBatchJob(globalContext, madmin.GenerateBatchJobOpts{
 		Type: madmin.BatchJobType(jobType),
 	})
 	fatalIf(probe.NewError(e), "Unable to generate %s", args.Get(1))
 
-	fmt.Println(string(out))
+	values := batchTemplateValues(madmin.BatchJobType(jobType), source, target, ctx.String("prefix"))
+	out, _ = prefillBatchTemplate(out, values)
+	fmt.Println(out)
 	return nil
 }
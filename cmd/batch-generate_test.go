@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestPrefillBatchTemplate(t *testing.T) {
+	source := &batchLocation{
+		aliasCfg: &aliasConfigV10{URL: "https://old.example.com", AccessKey: "old-access", SecretKey: "old-secret"},
+		bucket:   "photos",
+		prefix:   "2024",
+	}
+	target := &batchLocation{
+		aliasCfg: &aliasConfigV10{URL: "https://s3.amazonaws.com", AccessKey: "new-access", SecretKey: "new-secret"},
+		bucket:   "archive",
+	}
+
+	out, missing := prefillBatchTemplate(madmin.BatchJobReplicateTemplate,
+		batchTemplateValues(madmin.BatchJobReplicate, source, target, ""))
+	if len(missing) != 0 {
+		t.Fatalf("unexpected missing values %v", missing)
+	}
+	for _, expected := range []string{
+		`    type: "minio" # valid values are "s3" or "minio"`,
+		`    bucket: "photos"`,
+		`    prefix: "2024" # 'PREFIX' is optional`,
+		`    endpoint: "https://old.example.com"`,
+		`      accessKey: "${SOURCE_ACCESS_KEY}" # Required`,
+		`    type: "s3" # valid values are "s3" or "minio"`,
+		`    bucket: "archive"`,
+		`    prefix: "" # 'PREFIX' is optional`,
+		`      secretKey: "${TARGET_SECRET_KEY}"`,
+		`      attempts: 10 # number of retries for the job before giving up`,
+	} {
+		if !strings.Contains(out, expected+"\n") {
+			t.Errorf("expected %q in the generated template:\n%s", expected, out)
+		}
+	}
+
+	if strings.Contains(out, "old-secret") || strings.Contains(out, "new-access") {
+		t.Errorf("expected no alias credentials in the generated template:\n%s", out)
+	}
+
+	out, _ = prefillBatchTemplate(madmin.BatchJobExpireTemplate,
+		batchTemplateValues(madmin.BatchJobExpire, &batchLocation{bucket: "logs"}, nil, "app/"))
+	for _, expected := range []string{
+		`  bucket: "logs" # Bucket where this job will expire matching objects from`,
+		`  prefix: "app/" # (Optional) Prefix under which this job will expire objects matching the rules below.`,
+		`      name: NAME # match object names that satisfy the wildcard expression.`,
+	} {
+		if !strings.Contains(out, expected+"\n") {
+			t.Errorf("expected %q in the generated template:\n%s", expected, out)
+		}
+	}
+
+	if out, _ = prefillBatchTemplate(madmin.BatchJobKeyRotateTemplate, nil); out != madmin.BatchJobKeyRotateTemplate {
+		t.Errorf("expected the template to be unchanged, got:\n%s", out)
+	}
+
+	_, missing = prefillBatchTemplate(madmin.BatchJobKeyRotateTemplate, map[string]string{
+		"keyrotate.bucket":  `"finance"`,
+		"keyrotate.unknown": `"value"`,
+	})
+	if len(missing) != 1 || missing[0] != "keyrotate.unknown" {
+		t.Errorf("expected keyrotate.unknown to be missing, got %v", missing)
+	}
+}
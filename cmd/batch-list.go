@@ -19,7 +19,11 @@ package cmd
 
 import (
 	"context"
+	gojson "encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
@@ -34,6 +38,22 @@ var batchListFlags = []cli.Flag{
 		Name:  "type",
 		Usage: "list all current batch jobs via job type",
 	},
+	cli.StringFlag{
+		Name:  "state",
+		Usage: "list only the jobs in the given state [running, completed, failed, unknown]",
+	},
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "list only the jobs started after the given date or duration (e.g. 2024.01.01, 7d)",
+	},
+	cli.StringFlag{
+		Name:  "until",
+		Usage: "list only the jobs started before the given date or duration (e.g. 2024.01.31, 1h)",
+	},
+	cli.BoolFlag{
+		Name:  "all, a",
+		Usage: "include the finished jobs previously listed or started from this client",
+	},
 }
 
 var batchListCmd = cli.Command{
@@ -59,19 +79,108 @@ EXAMPLES:
 
   2. List all current batch jobs of type 'replicate':
      {{.Prompt}} {{.HelpName}} myminio/ --type "replicate"
+
+  3. List the failed batch jobs started in the last 7 days, including the finished ones:
+     {{.Prompt}} {{.HelpName}} myminio/ --all --state failed --since 7d
+
+  4. Stream all the batch jobs as JSON lines, one job per line:
+     {{.Prompt}} {{.HelpName}} myminio/ --all --json
 `,
 }
 
+const (
+	batchJobStateRunning   = "running"
+	batchJobStateCompleted = "completed"
+	batchJobStateFailed    = "failed"
+	batchJobStateUnknown   = "unknown"
+)
+
+// batchJobEntry is a batch job with its state and counters.
+type batchJobEntry struct {
+	madmin.BatchJobResult
+	State         string `json:"state"`
+	Objects       int64  `json:"objects"`
+	ObjectsFailed int64  `json:"objectsFailed"`
+	Bytes         int64  `json:"bytesTransferred,omitempty"`
+}
+
+// newBatchJobEntry returns the batch job entry with the state and counters of its last metric.
+func newBatchJobEntry(job madmin.BatchJobResult, metric madmin.JobMetric) batchJobEntry {
+	entry := batchJobEntry{BatchJobResult: job, State: batchJobStateRunning}
+	switch {
+	case metric.Failed:
+		entry.State = batchJobStateFailed
+	case metric.Complete:
+		entry.State = batchJobStateCompleted
+	}
+	switch {
+	case metric.Replicate != nil:
+		entry.Objects = metric.Replicate.Objects
+		entry.ObjectsFailed = metric.Replicate.ObjectsFailed
+		entry.Bytes = metric.Replicate.BytesTransferred
+	case metric.KeyRotate != nil:
+		entry.Objects = metric.KeyRotate.Objects
+		entry.ObjectsFailed = metric.KeyRotate.ObjectsFailed
+	case metric.Expired != nil:
+		entry.Objects = metric.Expired.Objects
+		entry.ObjectsFailed = metric.Expired.ObjectsFailed
+	}
+	return entry
+}
+
+// batchJobFilter selects the batch jobs to list.
+type batchJobFilter struct {
+	jobType      string
+	state        string
+	since, until time.Time
+}
+
+func (f batchJobFilter) match(entry batchJobEntry) bool {
+	if f.jobType != "" && string(entry.Type) != f.jobType {
+		return false
+	}
+	if f.state != "" && entry.State != f.state {
+		return false
+	}
+	if !f.since.IsZero() && entry.Started.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && entry.Started.After(f.until) {
+		return false
+	}
+	return true
+}
+
+// batchListJobMessage container for a single batch job, used to stream the jobs in JSON.
+type batchListJobMessage struct {
+	Status string `json:"status"`
+	batchJobEntry
+}
+
+// String colorized batch job message
+func (c batchListJobMessage) String() string {
+	return c.ID
+}
+
+// JSON jsonified batch job message
+func (c batchListJobMessage) JSON() string {
+	c.Status = "success"
+	batchListJobMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(batchListJobMessageBytes)
+}
+
 // batchListMessage container for file batchList messages
 type batchListMessage struct {
-	Status string                  `json:"status"`
-	Jobs   []madmin.BatchJobResult `json:"jobs"`
+	Status string          `json:"status"`
+	Jobs   []batchJobEntry `json:"jobs"`
 }
 
 // String colorized batchList message
 func (c batchListMessage) String() string {
 	if len(c.Jobs) == 0 {
-		return "currently no jobs are running"
+		return "no batch jobs found"
 	}
 
 	var s strings.Builder
@@ -90,8 +199,8 @@ func (c batchListMessage) String() string {
 	table.SetTablePadding("\t") // pad with tabs
 	table.SetNoWhiteSpace(true)
 
-	table.SetHeader([]string{"ID", "TYPE", "USER", "STARTED"})
-	data := make([][]string, 0, 4)
+	table.SetHeader([]string{"ID", "TYPE", "USER", "STARTED", "STATE", "OBJECTS", "FAILED"})
+	data := make([][]string, 0, 7)
 
 	for _, job := range c.Jobs {
 		data = append(data, []string{
@@ -99,6 +208,9 @@ func (c batchListMessage) String() string {
 			string(job.Type),
 			job.User,
 			humanize.Time(job.Started),
+			job.State,
+			humanize.Comma(job.Objects),
+			humanize.Comma(job.ObjectsFailed),
 		})
 	}
 
@@ -117,11 +229,105 @@ func (c batchListMessage) JSON() string {
 	return string(batchListMessageBytes)
 }
 
+// batchJobsHistoryFile returns the file where the batch jobs seen by this client are kept.
+func batchJobsHistoryFile(alias string) string {
+	return filepath.Join(mustGetMcConfigDir(), "batch-history", filepath.Clean(alias)+".json")
+}
+
+// loadBatchJobsHistory returns the batch jobs kept in the history file.
+func loadBatchJobsHistory(filename string) ([]madmin.BatchJobResult, error) {
+	data, e := os.ReadFile(filename)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil, nil
+		}
+		return nil, e
+	}
+	var jobs []madmin.BatchJobResult
+	return jobs, gojson.Unmarshal(data, &jobs)
+}
+
+// saveBatchJobsHistory saves the batch jobs to the history file.
+func saveBatchJobsHistory(filename string, jobs []madmin.BatchJobResult) error {
+	if e := os.MkdirAll(filepath.Dir(filename), 0o700); e != nil {
+		return e
+	}
+	data, e := gojson.Marshal(jobs)
+	if e != nil {
+		return e
+	}
+	return os.WriteFile(filename, data, 0o600)
+}
+
+// mergeBatchJobs returns the jobs followed by the jobs of history not found in jobs.
+func mergeBatchJobs(jobs, history []madmin.BatchJobResult) []madmin.BatchJobResult {
+	seen := make(map[string]bool, len(jobs))
+	merged := make([]madmin.BatchJobResult, 0, len(jobs)+len(history))
+	for _, job := range jobs {
+		seen[job.ID] = true
+		merged = append(merged, job)
+	}
+	for _, job := range history {
+		if !seen[job.ID] {
+			seen[job.ID] = true
+			merged = append(merged, job)
+		}
+	}
+	return merged
+}
+
+// recordBatchJobs adds the jobs to the history of the alias, it returns
+// the jobs of the history.
+func recordBatchJobs(alias string, jobs ...madmin.BatchJobResult) []madmin.BatchJobResult {
+	filename := batchJobsHistoryFile(alias)
+	history, e := loadBatchJobsHistory(filename)
+	errorIf(probe.NewError(e), "Unable to read the batch jobs history")
+
+	history = mergeBatchJobs(jobs, history)
+	e = saveBatchJobsHistory(filename, history)
+	errorIf(probe.NewError(e), "Unable to save the batch jobs history")
+	return history
+}
+
+// forgetBatchJobs removes the given job IDs from the history of the alias.
+func forgetBatchJobs(alias string, history []madmin.BatchJobResult, ids []string) {
+	forget := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		forget[id] = true
+	}
+	kept := history[:0]
+	for _, job := range history {
+		if !forget[job.ID] {
+			kept = append(kept, job)
+		}
+	}
+	e := saveBatchJobsHistory(batchJobsHistoryFile(alias), kept)
+	errorIf(probe.NewError(e), "Unable to save the batch jobs history")
+}
+
+// parseBatchListTime parses the --since and --until flags.
+func parseBatchListTime(ctx *cli.Context, flag string) time.Time {
+	value := ctx.String(flag)
+	if value == "" {
+		return time.Time{}
+	}
+	t := parseRewindFlag(value)
+	if t.IsZero() {
+		fatalIf(errInvalidArgument().Trace(value), "Unable to parse --"+flag+" argument.")
+	}
+	return t
+}
+
 // checkBatchListSyntax - validate all the passed arguments
 func checkBatchListSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	switch ctx.String("state") {
+	case "", batchJobStateRunning, batchJobStateCompleted, batchJobStateFailed, batchJobStateUnknown:
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("state")), "Invalid job state, valid values are running, completed, failed and unknown.")
+	}
 }
 
 // mainBatchList is the handle for "mc batch create" command.
@@ -131,6 +337,14 @@ func mainBatchList(ctx *cli.Context) error {
 	// Get the alias parameter from cli
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
+	alias, _ := url2Alias(aliasedURL)
+
+	filter := batchJobFilter{
+		jobType: ctx.String("type"),
+		state:   ctx.String("state"),
+		since:   parseBatchListTime(ctx, "since"),
+		until:   parseBatchListTime(ctx, "until"),
+	}
 
 	// Start a new MinIO Admin Client
 	adminClient, err := newAdminClient(aliasedURL)
@@ -144,9 +358,48 @@ func mainBatchList(ctx *cli.Context) error {
 	})
 	fatalIf(probe.NewError(e), "Unable to list jobs")
 
-	printMsg(batchListMessage{
-		Status: "success",
-		Jobs:   res.Jobs,
-	})
+	history := recordBatchJobs(alias, res.Jobs...)
+	jobs := res.Jobs
+	if ctx.Bool("all") {
+		jobs = mergeBatchJobs(jobs, history)
+	}
+
+	current := len(res.Jobs)
+	var entries []batchJobEntry
+	var forgotten []string
+	for i, job := range jobs {
+		status, e := adminClient.BatchJobStatus(ctxt, job.ID)
+		if e != nil && i >= current {
+			// the server does not know about this finished job anymore
+			if madmin.ToErrorResponse(e).Code != "" {
+				forgotten = append(forgotten, job.ID)
+			}
+			continue
+		}
+		entry := newBatchJobEntry(job, status.LastMetric)
+		if e != nil {
+			// the state of the job is not known without its status
+			entry = batchJobEntry{BatchJobResult: job, State: batchJobStateUnknown}
+		}
+		if !filter.match(entry) {
+			continue
+		}
+		if globalJSON {
+			printMsg(batchListJobMessage{batchJobEntry: entry})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(forgotten) > 0 {
+		forgetBatchJobs(alias, history, forgotten)
+	}
+
+	if !globalJSON {
+		printMsg(batchListMessage{
+			Status: "success",
+			Jobs:   entries,
+		})
+	}
 	return nil
 }
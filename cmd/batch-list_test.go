@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestBatchJobFilter(t *testing.T) {
+	now := time.Now()
+	running := newBatchJobEntry(madmin.BatchJobResult{ID: "1", Type: madmin.BatchJobReplicate, Started: now.Add(-time.Hour)},
+		madmin.JobMetric{Replicate: &madmin.ReplicateInfo{Objects: 10, ObjectsFailed: 1, BytesTransferred: 100}})
+	failed := newBatchJobEntry(madmin.BatchJobResult{ID: "2", Type: madmin.BatchJobExpire, Started: now.Add(-48 * time.Hour)},
+		madmin.JobMetric{Failed: true, Complete: true, Expired: &madmin.ExpirationInfo{Objects: 5, ObjectsFailed: 5}})
+	completed := newBatchJobEntry(madmin.BatchJobResult{ID: "3", Type: madmin.BatchJobKeyRotate, Started: now.Add(-24 * time.Hour)},
+		madmin.JobMetric{Complete: true, KeyRotate: &madmin.KeyRotationInfo{Objects: 7}})
+
+	if running.State != batchJobStateRunning || running.Objects != 10 || running.ObjectsFailed != 1 || running.Bytes != 100 {
+		t.Fatalf("unexpected running job %+v", running)
+	}
+	if failed.State != batchJobStateFailed || failed.ObjectsFailed != 5 {
+		t.Fatalf("unexpected failed job %+v", failed)
+	}
+	if completed.State != batchJobStateCompleted || completed.Objects != 7 {
+		t.Fatalf("unexpected completed job %+v", completed)
+	}
+
+	testCases := []struct {
+		filter   batchJobFilter
+		expected []string
+	}{
+		{batchJobFilter{}, []string{"1", "2", "3"}},
+		{batchJobFilter{state: batchJobStateCompleted}, []string{"3"}},
+		{batchJobFilter{jobType: string(madmin.BatchJobExpire)}, []string{"2"}},
+		{batchJobFilter{since: now.Add(-30 * time.Hour)}, []string{"1", "3"}},
+		{batchJobFilter{until: now.Add(-2 * time.Hour)}, []string{"2", "3"}},
+		{batchJobFilter{since: now.Add(-30 * time.Hour), until: now.Add(-2 * time.Hour)}, []string{"3"}},
+	}
+	for i, testCase := range testCases {
+		var ids []string
+		for _, entry := range []batchJobEntry{running, failed, completed} {
+			if testCase.filter.match(entry) {
+				ids = append(ids, entry.ID)
+			}
+		}
+		if len(ids) != len(testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, ids)
+			continue
+		}
+		for j := range ids {
+			if ids[j] != testCase.expected[j] {
+				t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, ids)
+				break
+			}
+		}
+	}
+}
+
+func TestBatchJobsHistory(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "batch-history", "myminio.json")
+
+	history, e := loadBatchJobsHistory(filename)
+	if e != nil || len(history) != 0 {
+		t.Fatalf("expected an empty history, got %v, %v", history, e)
+	}
+
+	history = mergeBatchJobs([]madmin.BatchJobResult{{ID: "1"}, {ID: "2"}}, history)
+	if e = saveBatchJobsHistory(filename, history); e != nil {
+		t.Fatal(e)
+	}
+	history, e = loadBatchJobsHistory(filename)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	merged := mergeBatchJobs([]madmin.BatchJobResult{{ID: "3"}, {ID: "1"}}, history)
+	if len(merged) != 3 || merged[0].ID != "3" || merged[1].ID != "1" || merged[2].ID != "2" {
+		t.Fatalf("unexpected merged jobs %v", merged)
+	}
+}
@@ -22,6 +22,7 @@ import "github.com/minio/cli"
 var batchSubcommands = []cli.Command{
 	batchGenerateCmd,
 	batchStartCmd,
+	batchValidateCmd,
 	batchListCmd,
 	batchStatusCmd,
 	batchDescribeCmd,
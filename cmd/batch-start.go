@@ -21,6 +21,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -30,13 +33,20 @@ import (
 	"github.com/minio/pkg/v3/console"
 )
 
+var batchJobFileFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "set",
+		Usage: "override a value of the job definition as PATH=VALUE, e.g. replicate.target.credentials.secretKey=SECRET",
+	},
+}
+
 var batchStartCmd = cli.Command{
 	Name:         "start",
 	Usage:        "start a new batch job",
 	Action:       mainBatchStart,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(batchJobFileFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -49,6 +59,14 @@ FLAGS:
 EXAMPLES:
   1. Start a new batch 'replication' job:
      {{.Prompt}} {{.HelpName}} myminio ./replication.yaml
+
+  2. Start a new batch 'replication' job whose file references the target credentials as
+     ${TARGET_ACCESS_KEY} and ${TARGET_SECRET_KEY}:
+     {{.Prompt}} export TARGET_ACCESS_KEY=minio TARGET_SECRET_KEY=minio123
+     {{.Prompt}} {{.HelpName}} myminio ./replication.yaml
+
+  3. Start a new batch 'replication' job overriding its target endpoint:
+     {{.Prompt}} {{.HelpName}} myminio ./replication.yaml --set replicate.target.endpoint=https://minio.example.com
 `,
 }
 
@@ -72,6 +90,145 @@ func (c batchStartMessage) JSON() string {
 	return string(batchStartMessageBytes)
 }
 
+// batchJobEnvVar matches the ${NAME} environment variable references of a job definition.
+var batchJobEnvVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandBatchJobEnv replaces the ${NAME} references in the values of the job
+// definition with the value of the environment variables, it fails on references
+// to undefined variables. Values with references are quoted once expanded, so
+// characters such as '#', ': ' or newlines in a variable do not change the
+// structure of the job definition. References in keys and comments are ignored.
+func expandBatchJobEnv(job string, lookup func(string) (string, bool)) (string, error) {
+	var undefined []string
+	expand := func(value string) string {
+		return batchJobEnvVar.ReplaceAllStringFunc(value, func(ref string) string {
+			name := batchJobEnvVar.FindStringSubmatch(ref)[1]
+			value, ok := lookup(name)
+			if !ok {
+				undefined = append(undefined, name)
+				return ref
+			}
+			return value
+		})
+	}
+
+	lines := strings.Split(job, "\n")
+	for i, line := range lines {
+		if !batchJobEnvVar.MatchString(line) {
+			continue
+		}
+		start := batchJobValueStart(line)
+		if start < 0 {
+			continue
+		}
+		value, rest := splitBatchJobScalar(line[start:])
+		if !batchJobEnvVar.MatchString(value) {
+			continue
+		}
+		lines[i] = line[:start] + strconv.Quote(expand(value)) + rest
+	}
+	if len(undefined) > 0 {
+		return "", fmt.Errorf("undefined environment variables: %s", strings.Join(undefined, ", "))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// batchJobValueStart returns the offset of the value of a 'key: value' or
+// '- value' line of a job definition, -1 if the line has no value.
+func batchJobValueStart(line string) int {
+	start := len(line) - len(strings.TrimLeft(line, " "))
+	if strings.HasPrefix(line[start:], "#") {
+		return -1
+	}
+	if strings.HasPrefix(line[start:], "- ") {
+		start += 2
+	}
+	for i := start; i < len(line); i++ {
+		if line[i] == '"' || line[i] == '\'' {
+			// A quoted list item, not a key.
+			break
+		}
+		if line[i] == ':' && (i+1 == len(line) || line[i+1] == ' ') {
+			start = i + 1
+			break
+		}
+	}
+	for start < len(line) && line[start] == ' ' {
+		start++
+	}
+	if start == len(line) {
+		return -1
+	}
+	return start
+}
+
+// splitBatchJobScalar splits a YAML flow scalar, quoted or not, from what
+// follows it, e.g. a comment. It returns the unquoted value of the scalar.
+func splitBatchJobScalar(s string) (value, rest string) {
+	switch s[0] {
+	case '"':
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				if value, e := strconv.Unquote(s[:i+1]); e == nil {
+					return value, s[i+1:]
+				}
+				return s[1:i], s[i+1:]
+			}
+		}
+	case '\'':
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			return strings.ReplaceAll(s[1:i], "''", "'"), s[i+1:]
+		}
+	}
+	end := len(s)
+	if idx := strings.Index(s, " #"); idx >= 0 {
+		end = idx
+	}
+	value = strings.TrimRight(s[:end], " ")
+	return value, s[len(value):]
+}
+
+// setBatchJobValues applies the PATH=VALUE overrides to the job definition.
+func setBatchJobValues(job string, overrides []string) (string, error) {
+	values := make(map[string]string, len(overrides))
+	for _, override := range overrides {
+		path, value, found := strings.Cut(override, "=")
+		if !found || path == "" {
+			return "", fmt.Errorf("invalid value '%s', expected PATH=VALUE", override)
+		}
+		values[path] = strconv.Quote(value)
+	}
+	job, missing := prefillBatchTemplate(job, values)
+	if len(missing) > 0 {
+		return "", fmt.Errorf("not found in the job definition: %s", strings.Join(missing, ", "))
+	}
+	return job, nil
+}
+
+// readBatchJobFile reads the job definition, with its environment variable
+// references expanded and the --set overrides applied.
+func readBatchJobFile(ctx *cli.Context, filename string) string {
+	buf, e := os.ReadFile(filename)
+	fatalIf(probe.NewError(e), "Unable to read %s", filename)
+
+	job, e := expandBatchJobEnv(string(buf), os.LookupEnv)
+	fatalIf(probe.NewError(e), "Unable to expand the environment variables of %s", filename)
+
+	job, e = setBatchJobValues(job, ctx.StringSlice("set"))
+	fatalIf(probe.NewError(e), "Unable to apply --set to %s", filename)
+	return job
+}
+
 // checkBatchStartSyntax - validate all the passed arguments
 func checkBatchStartSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
@@ -93,15 +250,17 @@ func mainBatchStart(ctx *cli.Context) error {
 	adminClient, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	buf, e := os.ReadFile(args.Get(1))
-	fatalIf(probe.NewError(e), "Unable to read %s", args.Get(1))
+	job := readBatchJobFile(ctx, args.Get(1))
 
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
-	res, e := adminClient.StartBatchJob(ctxt, string(buf))
+	res, e := adminClient.StartBatchJob(ctxt, job)
 	fatalIf(probe.NewError(e), "Unable to start job")
 
+	alias, _ := url2Alias(aliasedURL)
+	recordBatchJobs(alias, res)
+
 	printMsg(batchStartMessage{
 		Status: "success",
 		Result: res,
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestBatchJobFileSubstitution(t *testing.T) {
+	env := map[string]string{"ACCESS_KEY": "minio", "SECRET_KEY": "minio123"}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	job := `replicate:
+  apiVersion: v1
+  target:
+    bucket: archive
+    endpoint: https://minio.example.com # remote
+    credentials:
+      accessKey: ${ACCESS_KEY}
+      secretKey: "${SECRET_KEY}"
+`
+	expanded, e := expandBatchJobEnv(job, lookup)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `replicate:
+  apiVersion: v1
+  target:
+    bucket: archive
+    endpoint: https://minio.example.com # remote
+    credentials:
+      accessKey: "minio"
+      secretKey: "minio123"
+`
+	if expanded != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, expanded)
+	}
+
+	if _, e = expandBatchJobEnv("bucket: ${BUCKET}\nprefix: ${PREFIX}\n", lookup); e == nil || e.Error() != "undefined environment variables: BUCKET, PREFIX" {
+		t.Fatalf("expected undefined variables error, got %v", e)
+	}
+	if unchanged, e := expandBatchJobEnv("token: $TOKEN\n", lookup); e != nil || unchanged != "token: $TOKEN\n" {
+		t.Fatalf("expected $NAME to be left unchanged, got %q, %v", unchanged, e)
+	}
+
+	// Values are quoted once expanded, the characters of the variables
+	// cannot change the job definition.
+	env["SECRET_KEY"] = "a#b: c\n*d"
+	env["PREFIX"] = "'logs'"
+	special := `replicate:
+  source:
+    prefix: ${PREFIX} # optional
+    bucket: 'in-${PREFIX}'
+  target:
+    credentials:
+      secretKey: ${SECRET_KEY}
+  tags:
+    - ${PREFIX}
+# ${UNDEFINED} is not expanded in comments
+`
+	expected = `replicate:
+  source:
+    prefix: "'logs'" # optional
+    bucket: "in-'logs'"
+  target:
+    credentials:
+      secretKey: "a#b: c\n*d"
+  tags:
+    - "'logs'"
+# ${UNDEFINED} is not expanded in comments
+`
+	got, e := expandBatchJobEnv(special, lookup)
+	if e != nil || got != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s (%v)", expected, got, e)
+	}
+	var def batchJobDef
+	if e = yaml.Unmarshal([]byte(got), &def); e != nil {
+		t.Fatal(e)
+	}
+	if def.Replicate.Target.Credentials.SecretKey != "a#b: c\n*d" || def.Replicate.Source.Prefix != "'logs'" {
+		t.Fatalf("unexpected parsed job definition %+v", def.Replicate)
+	}
+	env["SECRET_KEY"] = "minio123"
+
+	set, e := setBatchJobValues(expanded, []string{"replicate.target.endpoint=https://other.example.com", "replicate.target.bucket=backup"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected = `replicate:
+  apiVersion: v1
+  target:
+    bucket: "backup"
+    endpoint: "https://other.example.com" # remote
+    credentials:
+      accessKey: "minio"
+      secretKey: "minio123"
+`
+	if set != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, set)
+	}
+
+	for _, override := range []string{"replicate.source.bucket=photos", "novalue", "=value"} {
+		if _, e = setBatchJobValues(expanded, []string{override}); e == nil {
+			t.Errorf("expected an error for --set %s", override)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// fetchBatchJobsStatus returns the status of the jobs, jobs whose status
+// cannot be fetched are reported in the unknown state.
+func fetchBatchJobsStatus(ctx context.Context, client *madmin.AdminClient, jobIDs []string) []batchJobEntry {
+	jobs := make([]batchJobEntry, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		job, e := batchJobStatusEntry(ctx, client, jobID)
+		if e != nil {
+			jobs = append(jobs, batchJobEntry{
+				BatchJobResult: madmin.BatchJobResult{ID: jobID},
+				State:          batchJobStateUnknown,
+			})
+			continue
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// batchJobsFinished returns true if none of the jobs is running anymore.
+func batchJobsFinished(jobs []batchJobEntry) bool {
+	for _, job := range jobs {
+		if job.State == batchJobStateRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// batchJobsSummary returns the number of jobs in each state, jobs in the
+// unknown state are counted apart from the finished ones.
+func batchJobsSummary(jobs []batchJobEntry) string {
+	counts := make(map[string]int)
+	for _, job := range jobs {
+		counts[job.State]++
+	}
+	var summary []string
+	for _, state := range []string{batchJobStateRunning, batchJobStateCompleted, batchJobStateFailed, batchJobStateUnknown} {
+		if counts[state] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+	return strings.Join(summary, ", ")
+}
+
+// batchJobsUnknown returns the number of jobs whose state is not known.
+func batchJobsUnknown(jobs []batchJobEntry) int {
+	var n int
+	for _, job := range jobs {
+		if job.State == batchJobStateUnknown {
+			n++
+		}
+	}
+	return n
+}
+
+// writeBatchJobsCSV appends the status of the jobs at the given time as CSV
+// records, the header is written when header is set.
+func writeBatchJobsCSV(w io.Writer, now time.Time, jobs []batchJobEntry, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if e := cw.Write([]string{"time", "id", "type", "state", "objects", "objectsFailed", "bytesTransferred"}); e != nil {
+			return e
+		}
+	}
+	for _, job := range jobs {
+		e := cw.Write([]string{
+			now.Format(time.RFC3339),
+			job.ID,
+			string(job.Type),
+			job.State,
+			strconv.FormatInt(job.Objects, 10),
+			strconv.FormatInt(job.ObjectsFailed, 10),
+			strconv.FormatInt(job.Bytes, 10),
+		})
+		if e != nil {
+			return e
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// appendBatchJobsCSV appends the status of the jobs to the CSV file.
+func appendBatchJobsCSV(filename string, now time.Time, jobs []batchJobEntry) error {
+	f, e := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if e != nil {
+		return e
+	}
+	defer f.Close()
+
+	st, e := f.Stat()
+	if e != nil {
+		return e
+	}
+	return writeBatchJobsCSV(f, now, jobs, st.Size() == 0)
+}
+
+// batchJobsStatus displays the status of the jobs in a single table, updated
+// at every interval until all the jobs are finished.
+func batchJobsStatus(ctx context.Context, client *madmin.AdminClient, jobIDs []string, interval time.Duration, csvFile string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var ui *tea.Program
+	uiDone := make(chan struct{})
+	if !globalJSON {
+		ui = tea.NewProgram(initBatchJobsStatusUI())
+		go func() {
+			defer close(uiDone)
+			if _, e := ui.Run(); e != nil {
+				fatalIf(probe.NewError(e), "Unable to get current batch status")
+			}
+			cancel()
+		}()
+	}
+
+	for {
+		jobs := fetchBatchJobsStatus(ctx, client, jobIDs)
+		if ctx.Err() != nil {
+			break
+		}
+		if csvFile != "" {
+			e := appendBatchJobsCSV(csvFile, UTCNow(), jobs)
+			fatalIf(probe.NewError(e), "Unable to write the status of the jobs to "+csvFile)
+		}
+
+		finished := batchJobsFinished(jobs)
+		if globalJSON {
+			printMsg(batchListMessage{Status: "success", Jobs: jobs})
+		} else {
+			ui.Send(batchJobsStatusUpdate{jobs: jobs, finished: finished})
+		}
+		if finished {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if ui != nil {
+		ui.Quit()
+		<-uiDone
+	}
+	return nil
+}
+
+// batchJobsStatusUpdate is the status of the jobs sent to the UI.
+type batchJobsStatusUpdate struct {
+	jobs     []batchJobEntry
+	finished bool
+}
+
+type batchJobsStatusUI struct {
+	spinner  spinner.Model
+	status   batchJobsStatusUpdate
+	quitting bool
+}
+
+func initBatchJobsStatusUI() *batchJobsStatusUI {
+	s := spinner.New()
+	s.Spinner = spinner.Points
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return &batchJobsStatusUI{
+		spinner: s,
+	}
+}
+
+func (m *batchJobsStatusUI) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m *batchJobsStatusUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		default:
+			return m, nil
+		}
+	case batchJobsStatusUpdate:
+		m.status = msg
+		if msg.finished {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	default:
+		return m, nil
+	}
+}
+
+func (m *batchJobsStatusUI) View() string {
+	var s strings.Builder
+
+	if !m.quitting {
+		s.WriteString(m.spinner.View())
+	} else if m.status.finished {
+		if batchJobsUnknown(m.status.jobs) > 0 {
+			s.WriteString(m.spinner.Style.Render(crossTickCell + crossTickCell + crossTickCell))
+		} else {
+			s.WriteString(m.spinner.Style.Render(tickCell + tickCell + tickCell))
+		}
+	}
+	s.WriteString("\n")
+
+	if m.status.jobs != nil {
+		s.WriteString(batchListMessage{Jobs: m.status.jobs}.String())
+		s.WriteString("\nJobs: " + batchJobsSummary(m.status.jobs) + "\n")
+	}
+	if m.quitting {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
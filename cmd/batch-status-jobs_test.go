@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestBatchJobsStatusCSV(t *testing.T) {
+	jobs := []batchJobEntry{
+		{BatchJobResult: madmin.BatchJobResult{ID: "1", Type: madmin.BatchJobReplicate}, State: batchJobStateRunning, Objects: 10, ObjectsFailed: 1, Bytes: 1024},
+		{BatchJobResult: madmin.BatchJobResult{ID: "2", Type: madmin.BatchJobExpire}, State: batchJobStateCompleted, Objects: 5},
+	}
+	if batchJobsFinished(jobs) {
+		t.Fatal("expected the jobs to be still running")
+	}
+	jobs[0].State = batchJobStateFailed
+	if !batchJobsFinished(jobs) {
+		t.Fatal("expected the jobs to be finished")
+	}
+	unknown := append(jobs[:2:2], batchJobEntry{BatchJobResult: madmin.BatchJobResult{ID: "3"}, State: batchJobStateUnknown})
+	if summary := batchJobsSummary(unknown); summary != "1 completed, 1 failed, 1 unknown" {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+	if n := batchJobsUnknown(unknown); n != 1 {
+		t.Fatalf("expected 1 unknown job, got %d", n)
+	}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var buf bytes.Buffer
+	if e := writeBatchJobsCSV(&buf, now, jobs, true); e != nil {
+		t.Fatal(e)
+	}
+	if e := writeBatchJobsCSV(&buf, now, jobs[1:], false); e != nil {
+		t.Fatal(e)
+	}
+	expected := "time,id,type,state,objects,objectsFailed,bytesTransferred\n" +
+		"2024-01-02T03:04:05Z,1,replicate,failed,10,1,1024\n" +
+		"2024-01-02T03:04:05Z,2,expire,completed,5,0,0\n" +
+		"2024-01-02T03:04:05Z,2,expire,completed,5,0,0\n"
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -19,19 +19,35 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
+var batchStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "all, a",
+		Usage: "display the status of all the current jobs",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between two status updates",
+		Value: time.Second,
+	},
+	cli.StringFlag{
+		Name:  "csv",
+		Usage: "append the status of the jobs to a CSV file at every update",
+	},
+}
+
 var batchStatusCmd = cli.Command{
 	Name:            "status",
 	Usage:           "summarize job events on MinIO server in real-time",
 	Action:          mainBatchStatus,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(batchStatusFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET JOBID
+  {{.HelpName}} TARGET [JOBID...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -39,6 +55,12 @@ FLAGS:
 EXAMPLES:
    1. Display current in-progress JOB events.
       {{.Prompt}} {{.HelpName}} myminio/ KwSysDpxcBU9FNhGkn2dCf
+
+   2. Display the status of two jobs in a single table.
+      {{.Prompt}} {{.HelpName}} myminio/ KwSysDpxcBU9FNhGkn2dCf 7bCqZxwSfWPzRkq2h5Jn3a
+
+   3. Monitor all the current jobs every minute and record their status in a CSV file.
+      {{.Prompt}} {{.HelpName}} myminio/ --all --interval 1m --csv jobs.csv
 `,
 }
 
@@ -62,9 +84,12 @@ func (c batchJobStatusMessage) String() string {
 
 // checkBatchStatusSyntax - validate all the passed arguments
 func checkBatchStatusSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 2 {
+	if len(ctx.Args()) < 1 || (len(ctx.Args()) == 1) != ctx.Bool("all") {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Duration("interval") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--interval must be greater than zero.")
+	}
 }
 
 func mainBatchStatus(ctx *cli.Context) error {
@@ -80,6 +105,18 @@ func mainBatchStatus(ctx *cli.Context) error {
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
+	if len(ctx.Args()) > 2 || ctx.Bool("all") || ctx.IsSet("csv") {
+		jobIDs := ctx.Args().Tail()
+		if ctx.Bool("all") {
+			res, e := client.ListBatchJobs(ctxt, &madmin.ListBatchJobsFilter{})
+			fatalIf(probe.NewError(e), "Unable to list jobs")
+			for _, job := range res.Jobs {
+				jobIDs = append(jobIDs, job.ID)
+			}
+		}
+		return batchJobsStatus(ctxt, client, jobIDs, ctx.Duration("interval"), ctx.String("csv"))
+	}
+
 	_, e := client.DescribeBatchJob(ctxt, jobID)
 	nosuchJob := madmin.ToErrorResponse(e).Code == "XMinioAdminNoSuchJob"
 	if nosuchJob {
@@ -93,6 +130,7 @@ func mainBatchStatus(ctx *cli.Context) error {
 	ui := tea.NewProgram(initBatchJobMetricsUI(jobID))
 	if nosuchJob {
 		go func() {
+			// the job is not active anymore, display its final metrics
 			res, e := client.BatchJobStatus(ctxt, jobID)
 			fatalIf(probe.NewError(e), "Unable to lookup job status")
 			if globalJSON {
@@ -100,12 +138,9 @@ func mainBatchStatus(ctx *cli.Context) error {
 					Status: "success",
 					Metric: res.LastMetric,
 				})
-				if res.LastMetric.Complete || res.LastMetric.Failed {
-					cancel()
-					return
-				}
+				cancel()
 			} else {
-				ui.Send(res.LastMetric)
+				ui.Send(batchJobFinalMetric(res.LastMetric))
 			}
 		}()
 	} else {
@@ -113,7 +148,7 @@ func mainBatchStatus(ctx *cli.Context) error {
 			opts := madmin.MetricsOptions{
 				Type:     madmin.MetricsBatchJobs,
 				ByJobID:  jobID,
-				Interval: time.Second,
+				Interval: ctx.Duration("interval"),
 			}
 			e := client.Metrics(ctxt, opts, func(metrics madmin.RealtimeMetrics) {
 				if globalJSON {
@@ -177,6 +212,9 @@ func initBatchJobMetricsUI(jobID string) *batchJobMetricsUI {
 	}
 }
 
+// batchJobFinalMetric is the last metric of a job which is not active anymore.
+type batchJobFinalMetric madmin.JobMetric
+
 type batchJobMetricsUI struct {
 	metric   madmin.JobMetric
 	spinner  spinner.Model
@@ -205,6 +243,10 @@ func (m *batchJobMetricsUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 		return m, nil
+	case batchJobFinalMetric:
+		m.metric = madmin.JobMetric(msg)
+		m.quitting = true
+		return m, tea.Quit
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -277,7 +319,16 @@ func (m *batchJobMetricsUI) View() string {
 			accElapsedTime := m.metric.LastUpdate.Sub(m.metric.StartTime)
 			addLine("Elapsed: ", accElapsedTime.String())
 		}
+	case string(madmin.BatchJobKeyRotate):
+		addLine("JobType: ", m.metric.JobType)
+		addLine("Objects: ", m.metric.KeyRotate.Objects)
+		addLine("FailedObjects: ", m.metric.KeyRotate.ObjectsFailed)
+		addLine("CurrObjName: ", m.metric.KeyRotate.Object)
 
+		if !m.metric.LastUpdate.IsZero() {
+			accElapsedTime := m.metric.LastUpdate.Sub(m.metric.StartTime)
+			addLine("Elapsed: ", accElapsedTime.String())
+		}
 	}
 
 	table.AppendBulk(data)
@@ -0,0 +1,656 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var batchValidateFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "sample",
+		Usage: "maximum number of objects listed to estimate the number of objects processed by the job",
+		Value: 1000,
+	},
+}
+
+var batchValidateCmd = cli.Command{
+	Name:         "validate",
+	Usage:        "validate a job definition before starting it",
+	Action:       mainBatchValidate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(batchValidateFlags, batchJobFileFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET JOBFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Validate the batch job definition 'replication.yaml' for the cluster 'myminio':
+     {{.Prompt}} {{.HelpName}} myminio ./replication.yaml
+
+  2. Validate the batch job definition 'expire.yaml' and list up to 10000 objects to estimate its size:
+     {{.Prompt}} {{.HelpName}} myminio ./expire.yaml --sample 10000
+`,
+}
+
+type batchJobKVDef struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+type batchJobFilterDef struct {
+	NewerThan     string          `yaml:"newerThan"`
+	OlderThan     string          `yaml:"olderThan"`
+	CreatedAfter  string          `yaml:"createdAfter"`
+	CreatedBefore string          `yaml:"createdBefore"`
+	Tags          []batchJobKVDef `yaml:"tags"`
+	Metadata      []batchJobKVDef `yaml:"metadata"`
+	KMSKey        string          `yaml:"kmskey"`
+	KMSKeyID      string          `yaml:"kmskeyid"`
+}
+
+type batchJobNotifyDef struct {
+	Endpoint string `yaml:"endpoint"`
+	Token    string `yaml:"token"`
+}
+
+type batchJobRetryDef struct {
+	Attempts int    `yaml:"attempts"`
+	Delay    string `yaml:"delay"`
+}
+
+type batchJobFlagsDef struct {
+	Filter batchJobFilterDef `yaml:"filter"`
+	Notify batchJobNotifyDef `yaml:"notify"`
+	Retry  batchJobRetryDef  `yaml:"retry"`
+}
+
+type batchJobCredentialsDef struct {
+	AccessKey    string `yaml:"accessKey"`
+	SecretKey    string `yaml:"secretKey"`
+	SessionToken string `yaml:"sessionToken"`
+}
+
+type batchJobSnowballDef struct {
+	Disable     bool   `yaml:"disable"`
+	Batch       int    `yaml:"batch"`
+	InMemory    bool   `yaml:"inmemory"`
+	Compress    bool   `yaml:"compress"`
+	SmallerThan string `yaml:"smallerThan"`
+	SkipErrs    bool   `yaml:"skipErrs"`
+}
+
+type batchJobLocationDef struct {
+	Type        string                 `yaml:"type"`
+	Bucket      string                 `yaml:"bucket"`
+	Prefix      interface{}            `yaml:"prefix"`
+	Endpoint    string                 `yaml:"endpoint"`
+	Path        string                 `yaml:"path"`
+	Credentials batchJobCredentialsDef `yaml:"credentials"`
+	Snowball    *batchJobSnowballDef   `yaml:"snowball"`
+}
+
+type batchJobReplicateDef struct {
+	APIVersion string              `yaml:"apiVersion"`
+	Source     batchJobLocationDef `yaml:"source"`
+	Target     batchJobLocationDef `yaml:"target"`
+	Flags      batchJobFlagsDef    `yaml:"flags"`
+}
+
+type batchJobEncryptionDef struct {
+	Type    string `yaml:"type"`
+	Key     string `yaml:"key"`
+	Context string `yaml:"context"`
+}
+
+type batchJobKeyRotateDef struct {
+	APIVersion string                `yaml:"apiVersion"`
+	Bucket     string                `yaml:"bucket"`
+	Prefix     string                `yaml:"prefix"`
+	Encryption batchJobEncryptionDef `yaml:"encryption"`
+	Flags      batchJobFlagsDef      `yaml:"flags"`
+}
+
+type batchJobExpireRuleDef struct {
+	Type          string          `yaml:"type"`
+	Name          string          `yaml:"name"`
+	OlderThan     string          `yaml:"olderThan"`
+	CreatedBefore string          `yaml:"createdBefore"`
+	Tags          []batchJobKVDef `yaml:"tags"`
+	Metadata      []batchJobKVDef `yaml:"metadata"`
+	Size          struct {
+		LessThan    string `yaml:"lessThan"`
+		GreaterThan string `yaml:"greaterThan"`
+	} `yaml:"size"`
+	Purge struct {
+		RetainVersions int `yaml:"retainVersions"`
+	} `yaml:"purge"`
+}
+
+type batchJobExpireDef struct {
+	APIVersion string                  `yaml:"apiVersion"`
+	Bucket     string                  `yaml:"bucket"`
+	Prefix     interface{}             `yaml:"prefix"`
+	Rules      []batchJobExpireRuleDef `yaml:"rules"`
+	Notify     batchJobNotifyDef       `yaml:"notify"`
+	Retry      batchJobRetryDef        `yaml:"retry"`
+}
+
+// batchJobDef is the definition of a batch job as accepted by 'mc batch start'.
+type batchJobDef struct {
+	Replicate *batchJobReplicateDef `yaml:"replicate"`
+	KeyRotate *batchJobKeyRotateDef `yaml:"keyrotate"`
+	Expire    *batchJobExpireDef    `yaml:"expire"`
+}
+
+// parseBatchJobDef parses a batch job definition, unknown fields are rejected.
+func parseBatchJobDef(data []byte) (def batchJobDef, e error) {
+	if e = yaml.UnmarshalStrict(data, &def); e != nil {
+		return def, e
+	}
+	var n int
+	for _, set := range []bool{def.Replicate != nil, def.KeyRotate != nil, def.Expire != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return def, errors.New("the job definition must contain exactly one of 'replicate', 'keyrotate' or 'expire'")
+	}
+	return def, nil
+}
+
+// jobType returns the type of the batch job.
+func (def batchJobDef) jobType() string {
+	switch {
+	case def.Replicate != nil:
+		return "replicate"
+	case def.KeyRotate != nil:
+		return "keyrotate"
+	default:
+		return "expire"
+	}
+}
+
+// batchJobPrefixes returns the prefixes of a prefix field, which is either a string or a list of strings.
+func batchJobPrefixes(prefix interface{}) ([]string, error) {
+	scalar := func(v interface{}) (string, bool) {
+		switch v.(type) {
+		case []interface{}, map[interface{}]interface{}:
+			return "", false
+		}
+		return fmt.Sprint(v), true
+	}
+
+	switch p := prefix.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		prefixes := make([]string, 0, len(p))
+		for _, v := range p {
+			s, ok := scalar(v)
+			if !ok {
+				return nil, fmt.Errorf("invalid prefix %v", v)
+			}
+			prefixes = append(prefixes, s)
+		}
+		return prefixes, nil
+	}
+	s, ok := scalar(prefix)
+	if !ok {
+		return nil, fmt.Errorf("invalid prefix %v", prefix)
+	}
+	return []string{s}, nil
+}
+
+// batchJobValidator collects the errors found in a batch job definition.
+type batchJobValidator struct {
+	errs []string
+}
+
+func (v *batchJobValidator) errorf(format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Sprintf(format, args...))
+}
+
+func (v *batchJobValidator) apiVersion(field, value string) {
+	if value != "v1" {
+		v.errorf("%s: unsupported value '%s', expected 'v1'", field, value)
+	}
+}
+
+func (v *batchJobValidator) required(field, value string) {
+	if value == "" {
+		v.errorf("%s: missing value", field)
+	}
+}
+
+func (v *batchJobValidator) duration(field, value string) {
+	if value == "" {
+		return
+	}
+	if d, e := ParseDuration(value); e != nil || d < 0 {
+		v.errorf("%s: invalid duration '%s'", field, value)
+	}
+}
+
+func (v *batchJobValidator) date(field, value string) {
+	if value == "" {
+		return
+	}
+	if _, e := time.Parse(time.RFC3339, value); e != nil {
+		v.errorf("%s: invalid date '%s', expected RFC3339 format e.g. 2006-01-02T15:04:05Z", field, value)
+	}
+}
+
+func (v *batchJobValidator) size(field, value string) {
+	if value == "" {
+		return
+	}
+	if _, e := humanize.ParseBytes(value); e != nil {
+		v.errorf("%s: invalid size '%s'", field, value)
+	}
+}
+
+func (v *batchJobValidator) endpoint(field, value string) {
+	if value == "" {
+		return
+	}
+	u, e := url.Parse(value)
+	if e != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.errorf("%s: invalid endpoint '%s'", field, value)
+	}
+}
+
+func (v *batchJobValidator) kvs(field string, kvs []batchJobKVDef) {
+	for i, kv := range kvs {
+		if kv.Key == "" {
+			v.errorf("%s[%d].key: missing value", field, i)
+		}
+	}
+}
+
+func (v *batchJobValidator) flags(field string, flags batchJobFlagsDef) {
+	v.duration(field+".filter.newerThan", flags.Filter.NewerThan)
+	v.duration(field+".filter.olderThan", flags.Filter.OlderThan)
+	v.date(field+".filter.createdAfter", flags.Filter.CreatedAfter)
+	v.date(field+".filter.createdBefore", flags.Filter.CreatedBefore)
+	v.kvs(field+".filter.tags", flags.Filter.Tags)
+	v.kvs(field+".filter.metadata", flags.Filter.Metadata)
+	v.notify(field+".notify", flags.Notify)
+	v.retry(field+".retry", flags.Retry)
+}
+
+func (v *batchJobValidator) notify(field string, notify batchJobNotifyDef) {
+	v.endpoint(field+".endpoint", notify.Endpoint)
+}
+
+func (v *batchJobValidator) retry(field string, retry batchJobRetryDef) {
+	if retry.Attempts < 0 {
+		v.errorf("%s.attempts: cannot be negative", field)
+	}
+	if retry.Delay != "" {
+		if d, e := time.ParseDuration(retry.Delay); e != nil || d < 0 {
+			v.errorf("%s.delay: invalid duration '%s'", field, retry.Delay)
+		}
+	}
+}
+
+func (v *batchJobValidator) location(field string, loc batchJobLocationDef) {
+	switch loc.Type {
+	case "", "s3", "minio":
+	default:
+		v.errorf("%s.type: unsupported value '%s', expected 's3' or 'minio'", field, loc.Type)
+	}
+	v.required(field+".bucket", loc.Bucket)
+	if _, e := batchJobPrefixes(loc.Prefix); e != nil {
+		v.errorf("%s.prefix: %v", field, e)
+	}
+	v.endpoint(field+".endpoint", loc.Endpoint)
+	switch loc.Path {
+	case "", "on", "off", "auto":
+	default:
+		v.errorf("%s.path: unsupported value '%s', expected 'on', 'off' or 'auto'", field, loc.Path)
+	}
+	if loc.Endpoint != "" {
+		v.required(field+".credentials.accessKey", loc.Credentials.AccessKey)
+		v.required(field+".credentials.secretKey", loc.Credentials.SecretKey)
+	}
+	if loc.Snowball != nil {
+		v.size(field+".snowball.smallerThan", loc.Snowball.SmallerThan)
+		if loc.Snowball.Batch < 0 {
+			v.errorf("%s.snowball.batch: cannot be negative", field)
+		}
+	}
+}
+
+// validateBatchJobDef returns the errors found in the values of the batch job definition.
+func validateBatchJobDef(def batchJobDef) []string {
+	v := &batchJobValidator{}
+	switch {
+	case def.Replicate != nil:
+		r := def.Replicate
+		v.apiVersion("replicate.apiVersion", r.APIVersion)
+		v.location("replicate.source", r.Source)
+		v.location("replicate.target", r.Target)
+		if r.Source.Endpoint != "" && r.Target.Endpoint != "" {
+			v.errorf("replicate: either the source or the target must be the local deployment, leave its endpoint empty")
+		}
+		if prefixes, _ := batchJobPrefixes(r.Target.Prefix); len(prefixes) > 1 {
+			v.errorf("replicate.target.prefix: only a single prefix is supported")
+		}
+		v.flags("replicate.flags", r.Flags)
+	case def.KeyRotate != nil:
+		k := def.KeyRotate
+		v.apiVersion("keyrotate.apiVersion", k.APIVersion)
+		v.required("keyrotate.bucket", k.Bucket)
+		switch k.Encryption.Type {
+		case "sse-s3":
+		case "sse-kms":
+			v.required("keyrotate.encryption.key", k.Encryption.Key)
+		default:
+			v.errorf("keyrotate.encryption.type: unsupported value '%s', expected 'sse-s3' or 'sse-kms'", k.Encryption.Type)
+		}
+		v.flags("keyrotate.flags", k.Flags)
+	case def.Expire != nil:
+		x := def.Expire
+		v.apiVersion("expire.apiVersion", x.APIVersion)
+		v.required("expire.bucket", x.Bucket)
+		if _, e := batchJobPrefixes(x.Prefix); e != nil {
+			v.errorf("expire.prefix: %v", e)
+		}
+		if len(x.Rules) == 0 {
+			v.errorf("expire.rules: at least one rule is required")
+		}
+		for i, rule := range x.Rules {
+			field := fmt.Sprintf("expire.rules[%d]", i)
+			switch rule.Type {
+			case "object":
+			case "deleted":
+				if len(rule.Tags) > 0 || len(rule.Metadata) > 0 || rule.Size.LessThan != "" || rule.Size.GreaterThan != "" {
+					v.errorf("%s: tags, metadata and size cannot be used to match delete markers", field)
+				}
+			default:
+				v.errorf("%s.type: unsupported value '%s', expected 'object' or 'deleted'", field, rule.Type)
+			}
+			v.duration(field+".olderThan", rule.OlderThan)
+			v.date(field+".createdBefore", rule.CreatedBefore)
+			v.kvs(field+".tags", rule.Tags)
+			v.kvs(field+".metadata", rule.Metadata)
+			v.size(field+".size.lessThan", rule.Size.LessThan)
+			v.size(field+".size.greaterThan", rule.Size.GreaterThan)
+			if rule.Purge.RetainVersions < 0 {
+				v.errorf("%s.purge.retainVersions: cannot be negative", field)
+			}
+		}
+		v.notify("expire.notify", x.Notify)
+		v.retry("expire.retry", x.Retry)
+	}
+	return v.errs
+}
+
+// batchValidateLocation is the result of the reachability check of a job location.
+type batchValidateLocation struct {
+	Role     string `json:"role"`
+	Endpoint string `json:"endpoint"`
+	Bucket   string `json:"bucket"`
+	Error    string `json:"error,omitempty"`
+}
+
+// batchJobLocation is a bucket accessed by a batch job and its prefixes.
+type batchJobLocation struct {
+	role     string
+	bucket   string
+	prefixes []string
+	// endpoint and credentials of a remote location, empty for the local deployment
+	endpoint string
+	path     string
+	creds    batchJobCredentialsDef
+}
+
+// locations returns the buckets accessed by the batch job, the first one holds the processed objects.
+func (def batchJobDef) locations() []batchJobLocation {
+	switch {
+	case def.Replicate != nil:
+		var locs []batchJobLocation
+		for _, l := range []struct {
+			role string
+			def  batchJobLocationDef
+		}{{"source", def.Replicate.Source}, {"target", def.Replicate.Target}} {
+			prefixes, _ := batchJobPrefixes(l.def.Prefix)
+			locs = append(locs, batchJobLocation{
+				role:     l.role,
+				bucket:   l.def.Bucket,
+				prefixes: prefixes,
+				endpoint: l.def.Endpoint,
+				path:     l.def.Path,
+				creds:    l.def.Credentials,
+			})
+		}
+		return locs
+	case def.KeyRotate != nil:
+		return []batchJobLocation{{role: "bucket", bucket: def.KeyRotate.Bucket, prefixes: []string{def.KeyRotate.Prefix}}}
+	default:
+		prefixes, _ := batchJobPrefixes(def.Expire.Prefix)
+		return []batchJobLocation{{role: "bucket", bucket: def.Expire.Bucket, prefixes: prefixes}}
+	}
+}
+
+// batchLocationClient returns a client for the path of the job location bucket,
+// the local deployment is accessed with the alias.
+func batchLocationClient(alias string, loc batchJobLocation, path string) (Client, string, *probe.Error) {
+	if loc.endpoint == "" {
+		aliasCfg := mustGetHostConfig(alias)
+		if aliasCfg == nil {
+			return nil, "", errInvalidAliasedURL(alias).Trace(alias)
+		}
+		clnt, err := newClientFromAlias(alias, urlJoinPath(aliasCfg.URL, path))
+		return clnt, aliasCfg.URL, err
+	}
+	clnt, err := S3New(NewS3Config("", urlJoinPath(loc.endpoint, path), &aliasConfigV10{
+		AccessKey:    loc.creds.AccessKey,
+		SecretKey:    loc.creds.SecretKey,
+		SessionToken: loc.creds.SessionToken,
+		API:          "S3v4",
+		Path:         loc.path,
+	}))
+	return clnt, loc.endpoint, err
+}
+
+// checkBatchLocation verifies that the bucket of the job location is reachable with its credentials.
+func checkBatchLocation(ctx context.Context, alias string, loc batchJobLocation) batchValidateLocation {
+	clnt, endpoint, err := batchLocationClient(alias, loc, loc.bucket)
+	result := batchValidateLocation{Role: loc.role, Endpoint: endpoint, Bucket: loc.bucket}
+	if err == nil {
+		_, err = clnt.Stat(ctx, StatOptions{})
+	}
+	if err != nil {
+		result.Error = err.ToGoError().Error()
+	}
+	return result
+}
+
+// countBatchObjects lists up to limit objects under the prefixes of the job
+// location, it returns the number of objects and whether the count is exact.
+func countBatchObjects(ctx context.Context, alias string, loc batchJobLocation, limit int) (int64, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prefixes := loc.prefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{""}
+	}
+	var count int64
+	for _, prefix := range prefixes {
+		clnt, _, err := batchLocationClient(alias, loc, loc.bucket+"/"+prefix)
+		if err != nil {
+			return count, false, err.ToGoError()
+		}
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				return count, false, content.Err.ToGoError()
+			}
+			count++
+			if count >= int64(limit) {
+				return count, false, nil
+			}
+		}
+	}
+	return count, true, nil
+}
+
+// batchValidateMessage container for batch job validation messages
+type batchValidateMessage struct {
+	Status       string                  `json:"status"`
+	Type         string                  `json:"type,omitempty"`
+	Errors       []string                `json:"errors,omitempty"`
+	Locations    []batchValidateLocation `json:"locations,omitempty"`
+	Objects      int64                   `json:"objects"`
+	ObjectsExact bool                    `json:"objectsExact"`
+	CountError   string                  `json:"countError,omitempty"`
+}
+
+// valid returns true if no error was found.
+func (c batchValidateMessage) valid() bool {
+	if len(c.Errors) > 0 {
+		return false
+	}
+	for _, loc := range c.Locations {
+		if loc.Error != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// String colorized batch job validation message
+func (c batchValidateMessage) String() string {
+	var s strings.Builder
+	for _, e := range c.Errors {
+		s.WriteString(console.Colorize("BatchValidateFail", crossTickCell) + " " + e + "\n")
+	}
+	for _, loc := range c.Locations {
+		if loc.Error != "" {
+			fmt.Fprintf(&s, "%s %s bucket '%s' at %s: %s\n", console.Colorize("BatchValidateFail", crossTickCell),
+				loc.Role, loc.Bucket, loc.Endpoint, loc.Error)
+			continue
+		}
+		fmt.Fprintf(&s, "%s %s bucket '%s' at %s is reachable\n", console.Colorize("BatchValidateOK", tickCell),
+			loc.Role, loc.Bucket, loc.Endpoint)
+	}
+	if len(c.Locations) > 0 {
+		switch {
+		case c.CountError != "":
+			fmt.Fprintf(&s, "Unable to estimate the number of objects: %s\n", c.CountError)
+		case c.ObjectsExact:
+			fmt.Fprintf(&s, "Objects to process before filtering: %s\n", humanize.Comma(c.Objects))
+		default:
+			fmt.Fprintf(&s, "Objects to process before filtering: more than %s\n", humanize.Comma(c.Objects))
+		}
+	}
+	if c.valid() {
+		s.WriteString(console.Colorize("BatchValidateOK", fmt.Sprintf("The %s job definition is valid", c.Type)))
+	} else {
+		s.WriteString(console.Colorize("BatchValidateFail", "The job definition is invalid"))
+	}
+	return s.String()
+}
+
+// JSON jsonified batch job validation message
+func (c batchValidateMessage) JSON() string {
+	batchValidateMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(batchValidateMessageBytes)
+}
+
+// checkBatchValidateSyntax - validate all the passed arguments
+func checkBatchValidateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.Int("sample") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--sample must be greater than zero.")
+	}
+}
+
+// mainBatchValidate is the handle for "mc batch validate" command.
+func mainBatchValidate(ctx *cli.Context) error {
+	checkBatchValidateSyntax(ctx)
+
+	console.SetColor("BatchValidateOK", color.New(color.FgGreen, color.Bold))
+	console.SetColor("BatchValidateFail", color.New(color.FgRed, color.Bold))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	alias, _ := url2Alias(args.Get(0))
+
+	job := readBatchJobFile(ctx, args.Get(1))
+
+	msg := batchValidateMessage{Status: "success"}
+	def, e := parseBatchJobDef([]byte(job))
+	if e != nil {
+		msg.Errors = []string{e.Error()}
+	} else {
+		msg.Type = def.jobType()
+		msg.Errors = validateBatchJobDef(def)
+	}
+
+	if len(msg.Errors) == 0 {
+		ctxt, cancel := context.WithCancel(globalContext)
+		defer cancel()
+
+		locations := def.locations()
+		for _, loc := range locations {
+			msg.Locations = append(msg.Locations, checkBatchLocation(ctxt, alias, loc))
+		}
+		if msg.valid() {
+			msg.Objects, msg.ObjectsExact, e = countBatchObjects(ctxt, alias, locations[0], ctx.Int("sample"))
+			if e != nil {
+				msg.CountError = e.Error()
+			}
+		}
+	}
+
+	if !msg.valid() {
+		msg.Status = "error"
+	}
+	printMsg(msg)
+	if !msg.valid() {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestParseBatchJobDef(t *testing.T) {
+	for _, template := range []string{madmin.BatchJobReplicateTemplate, madmin.BatchJobKeyRotateTemplate, madmin.BatchJobExpireTemplate} {
+		if _, e := parseBatchJobDef([]byte(template)); e != nil {
+			t.Errorf("unable to parse the job template: %v\n%s", e, template)
+		}
+	}
+
+	if _, e := parseBatchJobDef([]byte("replicate:\n  apiVersion: v1\n  unknown: field\n")); e == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+	if _, e := parseBatchJobDef([]byte("replicate:\n  apiVersion: v1\nexpire:\n  apiVersion: v1\n")); e == nil {
+		t.Error("expected several job types to be rejected")
+	}
+}
+
+func TestValidateBatchJobDef(t *testing.T) {
+	testCases := []struct {
+		job    string
+		errors []string
+	}{
+		{
+			job: `replicate:
+  apiVersion: v1
+  source:
+    type: minio
+    bucket: photos
+    prefix: [2023, 2024]
+    endpoint: https://old.example.com
+    credentials:
+      accessKey: access
+      secretKey: secret
+  target:
+    bucket: archive
+  flags:
+    filter:
+      newerThan: 7d
+      createdAfter: "2024-01-01T00:00:00Z"
+    retry:
+      attempts: 10
+      delay: 500ms
+`,
+		},
+		{
+			job: `replicate:
+  apiVersion: v2
+  source:
+    type: gcs
+    bucket: photos
+    endpoint: old.example.com
+  target:
+    bucket: archive
+    endpoint: https://new.example.com
+    credentials:
+      accessKey: access
+      secretKey: secret
+  flags:
+    filter:
+      createdAfter: date
+    retry:
+      delay: soon
+`,
+			errors: []string{
+				"replicate.apiVersion",
+				"replicate.source.type",
+				"replicate.source.endpoint",
+				"replicate.source.credentials.accessKey",
+				"replicate.source.credentials.secretKey",
+				"replicate: either the source or the target",
+				"replicate.flags.filter.createdAfter",
+				"replicate.flags.retry.delay",
+			},
+		},
+		{
+			job: `keyrotate:
+  apiVersion: v1
+  bucket: finance
+  encryption:
+    type: sse-kms
+`,
+			errors: []string{"keyrotate.encryption.key"},
+		},
+		{
+			job: `expire:
+  apiVersion: v1
+  bucket: logs
+  rules:
+    - type: deleted
+      olderThan: 10h
+      size:
+        lessThan: 10MiB
+    - type: object
+      olderThan: 7d
+      size:
+        greaterThan: big
+      purge:
+        retainVersions: -1
+`,
+			errors: []string{
+				"expire.rules[0]: tags, metadata and size",
+				"expire.rules[1].size.greaterThan",
+				"expire.rules[1].purge.retainVersions",
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		def, e := parseBatchJobDef([]byte(testCase.job))
+		if e != nil {
+			t.Fatalf("Test %d: unable to parse the job: %v", i+1, e)
+		}
+		errs := validateBatchJobDef(def)
+		if len(errs) != len(testCase.errors) {
+			t.Errorf("Test %d: expected %d errors, got %v", i+1, len(testCase.errors), errs)
+			continue
+		}
+		for j, e := range errs {
+			if !strings.HasPrefix(e, testCase.errors[j]) {
+				t.Errorf("Test %d: expected error starting with %q, got %q", i+1, testCase.errors[j], e)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/term"
+)
+
+var browseCmd = cli.Command{
+	Name:         "browse",
+	Usage:        "browse aliases, buckets and objects interactively",
+	Action:       mainBrowse,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [TARGET]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+KEYS:
+  ↑/k ↓/j       move the selection, pgup/pgdown move by page
+  enter/→/l     open a folder or show the information of an object
+  ←/h/backspace go to the parent folder
+  i             show the information of the selected object
+  d             download the selected object to the current directory
+  p             generate a presigned URL of the selected object valid for 7 days
+  x/delete      remove the selected object, after confirmation
+  r             refresh the listing
+  esc           close the information view or cancel a removal
+  q/ctrl+c      quit, the presigned URLs generated are printed when quitting
+
+EXAMPLES:
+  1. Browse all configured aliases.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Browse the buckets of the alias 'play'.
+     {{.Prompt}} {{.HelpName}} play
+
+  3. Browse the 'photos/2024/' prefix of the bucket 'mybucket'.
+     {{.Prompt}} {{.HelpName}} play/mybucket/photos/2024/
+`,
+}
+
+// mainBrowse is the handle for "mc browse" command.
+func mainBrowse(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) > 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
+	}
+	if globalJSON || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fatalIf(errInvalidArgument().Trace(), "mc browse requires an interactive terminal and does not support --json.")
+	}
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(), "Unable to load the configuration.")
+	aliases := make([]string, 0, len(conf.Aliases))
+	for alias := range conf.Aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	dir := cliCtx.Args().Get(0)
+	if dir != "" && !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	m, e := tea.NewProgram(newBrowseUI(globalContext, dir, aliases), tea.WithAltScreen()).Run()
+	fatalIf(probe.NewError(e), "Unable to run the browser.")
+
+	if ui, ok := m.(*browseUI); ok && len(ui.shared) > 0 {
+		shareSetColor()
+		for _, msg := range ui.shared {
+			printMsg(msg)
+		}
+	}
+	return nil
+}
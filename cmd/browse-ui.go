@@ -0,0 +1,645 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// browseEntry is an alias, bucket, prefix or object shown by mc browse.
+type browseEntry struct {
+	name    string
+	url     string
+	isDir   bool
+	size    int64
+	modTime time.Time
+}
+
+// browsePageSize is the number of entries listed at once, the next page
+// is listed when the cursor reaches the last listed entry.
+const browsePageSize = 1000
+
+// browseListing is the listing of a folder being paged through.
+type browseListing struct {
+	dir     string
+	dirPath string
+	seq     int
+	ch      <-chan *ClientContent
+	cancel  context.CancelFunc
+}
+
+// browseListMsg is a page of the listing of dir, listing is set when
+// more entries are left to list.
+type browseListMsg struct {
+	dir        string
+	seq        int
+	entries    []browseEntry
+	err        *probe.Error
+	listing    *browseListing
+	appendPage bool
+}
+
+type browseInfoMsg struct {
+	content *ClientContent
+	err     *probe.Error
+}
+
+type browseActionMsg struct {
+	status  string
+	shared  *shareMessage
+	refresh bool
+	err     *probe.Error
+}
+
+type browseKeyMap struct {
+	up       key.Binding
+	down     key.Binding
+	pageUp   key.Binding
+	pageDown key.Binding
+	open     key.Binding
+	back     key.Binding
+	info     key.Binding
+	download key.Binding
+	share    key.Binding
+	remove   key.Binding
+	refresh  key.Binding
+	cancel   key.Binding
+	quit     key.Binding
+}
+
+func newBrowseKeyMap() browseKeyMap {
+	return browseKeyMap{
+		up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		pageUp:   key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up")),
+		pageDown: key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdown", "page down")),
+		open:     key.NewBinding(key.WithKeys("enter", "right", "l"), key.WithHelp("enter", "open")),
+		back:     key.NewBinding(key.WithKeys("left", "h", "backspace"), key.WithHelp("←", "back")),
+		info:     key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "info")),
+		download: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "download")),
+		share:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "presign")),
+		remove:   key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "remove")),
+		refresh:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		cancel:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+		quit:     key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+// browseUI is the bubbletea model of mc browse.
+type browseUI struct {
+	ctx     context.Context
+	aliases []string
+	dir     string // current folder, "" lists the aliases
+	entries []browseEntry
+	listing *browseListing // listing of dir with entries left to list
+	listSeq int            // sequence number of the latest listing
+	cursor  int
+	cursors map[string]int // selection to restore when going back to a folder
+	height  int
+	loading bool
+	info    *ClientContent
+	confirm *browseEntry // object waiting for a removal confirmation
+	status  string
+	errMsg  string
+	shared  []shareMessage
+	spinner spinner.Model
+	help    help.Model
+	keymap  browseKeyMap
+}
+
+func newBrowseUI(ctx context.Context, dir string, aliases []string) *browseUI {
+	s := spinner.New()
+	s.Spinner = spinner.Points
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return &browseUI{
+		ctx:     ctx,
+		aliases: aliases,
+		dir:     dir,
+		cursors: make(map[string]int),
+		height:  24,
+		loading: true,
+		spinner: s,
+		help:    help.New(),
+		keymap:  newBrowseKeyMap(),
+	}
+}
+
+// browseParent returns the parent folder of dir, "" when dir is an alias
+// or a filesystem root.
+func browseParent(dir string) string {
+	trimmed := strings.TrimSuffix(dir, "/")
+	i := strings.LastIndex(trimmed, "/")
+	if i < 0 {
+		return ""
+	}
+	return trimmed[:i+1]
+}
+
+// listBrowseDir lists the aliases when dir is empty, otherwise the first
+// page of the content of the folder dir.
+func listBrowseDir(ctx context.Context, dir string, aliases []string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		msg := browseListMsg{dir: dir, seq: seq}
+		if dir == "" {
+			for _, alias := range aliases {
+				msg.entries = append(msg.entries, browseEntry{name: alias + "/", url: alias + "/", isDir: true})
+			}
+			return msg
+		}
+		clnt, err := newClient(dir)
+		if err != nil {
+			msg.err = err.Trace(dir)
+			return msg
+		}
+		dirPath := filepath.ToSlash(clnt.GetURL().Path)
+		if !strings.HasSuffix(dirPath, "/") {
+			dirPath += "/"
+		}
+		listCtx, cancel := context.WithCancel(ctx)
+		listing := &browseListing{
+			dir:     dir,
+			dirPath: dirPath,
+			seq:     seq,
+			ch:      clnt.List(listCtx, ListOptions{ShowDir: DirFirst}),
+			cancel:  cancel,
+		}
+		return listing.nextPage(false)
+	}
+}
+
+// readBrowsePage lists the next page of a folder.
+func readBrowsePage(listing *browseListing) tea.Cmd {
+	return func() tea.Msg {
+		return listing.nextPage(true)
+	}
+}
+
+// nextPage lists up to browsePageSize entries, folders first. The listing
+// is released once all the entries are listed or on error.
+func (l *browseListing) nextPage(appendPage bool) browseListMsg {
+	msg := browseListMsg{dir: l.dir, seq: l.seq, appendPage: appendPage, listing: l}
+	for len(msg.entries) < browsePageSize {
+		content, ok := <-l.ch
+		if !ok {
+			msg.listing = nil
+			break
+		}
+		if content.Err != nil {
+			msg.err = content.Err.Trace(l.dir)
+			msg.listing = nil
+			break
+		}
+		name := strings.TrimPrefix(filepath.ToSlash(content.URL.Path), l.dirPath)
+		name = strings.TrimPrefix(name, "/")
+		if content.Type.IsDir() && !strings.HasSuffix(name, "/") {
+			name += "/"
+		}
+		if name == "" || name == "/" {
+			continue
+		}
+		msg.entries = append(msg.entries, browseEntry{
+			name:    name,
+			url:     l.dir + name,
+			isDir:   content.Type.IsDir(),
+			size:    content.Size,
+			modTime: content.Time,
+		})
+	}
+	if msg.listing == nil {
+		l.cancel()
+	}
+	sort.SliceStable(msg.entries, func(i, j int) bool {
+		return msg.entries[i].isDir && !msg.entries[j].isDir
+	})
+	return msg
+}
+
+func statBrowseEntry(ctx context.Context, entry browseEntry) tea.Cmd {
+	return func() tea.Msg {
+		clnt, err := newClient(entry.url)
+		if err != nil {
+			return browseInfoMsg{err: err.Trace(entry.url)}
+		}
+		content, err := clnt.Stat(ctx, StatOptions{})
+		if err != nil {
+			return browseInfoMsg{err: err.Trace(entry.url)}
+		}
+		return browseInfoMsg{content: content}
+	}
+}
+
+// downloadBrowseEntry downloads an object to the current directory, an
+// existing file is never overwritten.
+func downloadBrowseEntry(ctx context.Context, entry browseEntry) tea.Cmd {
+	return func() tea.Msg {
+		clnt, err := newClient(entry.url)
+		if err != nil {
+			return browseActionMsg{err: err.Trace(entry.url)}
+		}
+		reader, _, err := clnt.Get(ctx, GetOptions{})
+		if err != nil {
+			return browseActionMsg{err: err.Trace(entry.url)}
+		}
+		defer reader.Close()
+
+		target := filepath.Base(entry.name)
+		f, e := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if e != nil {
+			return browseActionMsg{err: probe.NewError(e).Trace(target)}
+		}
+		n, e := io.Copy(f, reader)
+		if e == nil {
+			e = f.Close()
+		} else {
+			f.Close()
+		}
+		if e != nil {
+			os.Remove(target)
+			return browseActionMsg{err: probe.NewError(e).Trace(entry.url)}
+		}
+		return browseActionMsg{status: fmt.Sprintf("Downloaded `%s` to `%s` (%s).", entry.url, target, humanize.IBytes(uint64(n)))}
+	}
+}
+
+func shareBrowseEntry(ctx context.Context, entry browseEntry) tea.Cmd {
+	return func() tea.Msg {
+		clnt, err := newClient(entry.url)
+		if err != nil {
+			return browseActionMsg{err: err.Trace(entry.url)}
+		}
+		shareURL, err := clnt.ShareDownload(ctx, "", defaultSevenDays)
+		if err != nil {
+			return browseActionMsg{err: err.Trace(entry.url)}
+		}
+		return browseActionMsg{
+			status: "Share: " + shareURL,
+			shared: &shareMessage{ObjectURL: entry.url, ShareURL: shareURL, TimeLeft: defaultSevenDays},
+		}
+	}
+}
+
+func removeBrowseEntry(ctx context.Context, entry browseEntry) tea.Cmd {
+	return func() tea.Msg {
+		clnt, err := newClient(entry.url)
+		if err != nil {
+			return browseActionMsg{err: err.Trace(entry.url)}
+		}
+		contentCh := make(chan *ClientContent, 1)
+		contentCh <- &ClientContent{URL: clnt.GetURL()}
+		close(contentCh)
+		for result := range clnt.Remove(ctx, false, false, false, false, contentCh) {
+			if result.Err != nil {
+				return browseActionMsg{err: result.Err.Trace(entry.url)}
+			}
+		}
+		return browseActionMsg{status: fmt.Sprintf("Removed `%s`.", entry.url), refresh: true}
+	}
+}
+
+func (m *browseUI) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.startListing())
+}
+
+// startListing lists the current folder again, from its first page.
+func (m *browseUI) startListing() tea.Cmd {
+	m.stopListing()
+	m.listSeq++
+	m.loading = true
+	return listBrowseDir(m.ctx, m.dir, m.aliases, m.listSeq)
+}
+
+// stopListing releases the listing of the current folder, if any.
+func (m *browseUI) stopListing() {
+	if m.listing != nil {
+		m.listing.cancel()
+		m.listing = nil
+	}
+}
+
+// loadMore lists the next page of the current folder when the cursor
+// reaches the last listed entry.
+func (m *browseUI) loadMore() tea.Cmd {
+	if m.listing == nil || m.loading || m.cursor < len(m.entries)-1 {
+		return nil
+	}
+	m.loading = true
+	return readBrowsePage(m.listing)
+}
+
+// selected returns the entry under the cursor, nil if the folder is empty.
+func (m *browseUI) selected() *browseEntry {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return nil
+	}
+	return &m.entries[m.cursor]
+}
+
+// listHeight is the number of entries shown at once.
+func (m *browseUI) listHeight() int {
+	if h := m.height - 6; h > 1 {
+		return h
+	}
+	return 1
+}
+
+func (m *browseUI) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.entries) {
+		m.cursor = len(m.entries) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// changeDir starts listing dir, the selection of the current folder is
+// kept to be restored when coming back.
+func (m *browseUI) changeDir(dir string) tea.Cmd {
+	m.cursors[m.dir] = m.cursor
+	m.dir = dir
+	m.entries = nil
+	m.cursor = 0
+	m.info = nil
+	return m.startListing()
+}
+
+// objectAction runs an action on the selected entry, objects only.
+func (m *browseUI) objectAction(action func(context.Context, browseEntry) tea.Cmd) tea.Cmd {
+	entry := m.selected()
+	if entry == nil {
+		return nil
+	}
+	if entry.isDir {
+		m.errMsg = "This action is only supported on objects."
+		return nil
+	}
+	m.loading = true
+	return action(m.ctx, *entry)
+}
+
+func (m *browseUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		return m, nil
+	case browseListMsg:
+		if msg.dir != m.dir || msg.seq != m.listSeq {
+			// Stale listing of a folder left or refreshed in the meantime.
+			if msg.listing != nil {
+				msg.listing.cancel()
+			}
+			return m, nil
+		}
+		m.loading = false
+		m.listing = msg.listing
+		if msg.appendPage {
+			m.entries = append(m.entries, msg.entries...)
+		} else {
+			m.entries = msg.entries
+			m.cursor = m.cursors[m.dir]
+			m.moveCursor(0)
+		}
+		if msg.err != nil {
+			m.errMsg = msg.err.ToGoError().Error()
+		}
+		return m, nil
+	case browseInfoMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.errMsg = msg.err.ToGoError().Error()
+			return m, nil
+		}
+		m.info = msg.content
+		return m, nil
+	case browseActionMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.errMsg = msg.err.ToGoError().Error()
+			return m, nil
+		}
+		m.status = msg.status
+		if msg.shared != nil {
+			m.shared = append(m.shared, *msg.shared)
+		}
+		if msg.refresh {
+			m.cursors[m.dir] = m.cursor
+			return m, m.startListing()
+		}
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keymap.quit) && msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		m.errMsg = ""
+		if m.confirm != nil {
+			entry := *m.confirm
+			m.confirm = nil
+			if msg.String() == "y" || msg.String() == "Y" {
+				m.loading = true
+				return m, removeBrowseEntry(m.ctx, entry)
+			}
+			m.status = "Removal cancelled."
+			return m, nil
+		}
+		m.status = ""
+		switch {
+		case key.Matches(msg, m.keymap.quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keymap.cancel):
+			m.info = nil
+		case m.info != nil:
+			// Only closing the information view is possible.
+			if key.Matches(msg, m.keymap.back) {
+				m.info = nil
+			}
+		case key.Matches(msg, m.keymap.up):
+			m.moveCursor(-1)
+		case key.Matches(msg, m.keymap.down):
+			m.moveCursor(1)
+			return m, m.loadMore()
+		case key.Matches(msg, m.keymap.pageUp):
+			m.moveCursor(-m.listHeight())
+		case key.Matches(msg, m.keymap.pageDown):
+			m.moveCursor(m.listHeight())
+			return m, m.loadMore()
+		case key.Matches(msg, m.keymap.back):
+			if m.dir != "" {
+				return m, m.changeDir(browseParent(m.dir))
+			}
+		case key.Matches(msg, m.keymap.open):
+			if entry := m.selected(); entry != nil && entry.isDir {
+				return m, m.changeDir(entry.url)
+			}
+			return m, m.objectAction(statBrowseEntry)
+		case key.Matches(msg, m.keymap.info):
+			return m, m.objectAction(statBrowseEntry)
+		case key.Matches(msg, m.keymap.download):
+			return m, m.objectAction(downloadBrowseEntry)
+		case key.Matches(msg, m.keymap.share):
+			return m, m.objectAction(shareBrowseEntry)
+		case key.Matches(msg, m.keymap.remove):
+			if entry := m.selected(); entry != nil {
+				if entry.isDir {
+					m.errMsg = "This action is only supported on objects."
+				} else {
+					e := *entry
+					m.confirm = &e
+				}
+			}
+		case key.Matches(msg, m.keymap.refresh):
+			m.cursors[m.dir] = m.cursor
+			return m, m.startListing()
+		}
+	}
+	return m, nil
+}
+
+var (
+	browseTitleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	browseSelectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	browseDirStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	browseErrStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
+
+func (m *browseUI) View() string {
+	var sb strings.Builder
+	title := m.dir
+	if title == "" {
+		title = "aliases"
+	}
+	sb.WriteString(browseTitleStyle.Render("mc browse: "+title) + divider)
+	if m.loading {
+		sb.WriteString(m.spinner.View())
+	}
+	sb.WriteString("\n\n")
+
+	if m.info != nil {
+		sb.WriteString(m.infoView())
+	} else {
+		sb.WriteString(m.listView())
+	}
+
+	switch {
+	case m.confirm != nil:
+		sb.WriteString(browseErrStyle.Render(fmt.Sprintf("Remove `%s`? (y/n)", m.confirm.url)))
+	case m.errMsg != "":
+		sb.WriteString(browseErrStyle.Render(m.errMsg))
+	default:
+		sb.WriteString(m.status)
+	}
+	sb.WriteString("\n" + m.help.ShortHelpView([]key.Binding{
+		m.keymap.up, m.keymap.down, m.keymap.open, m.keymap.back, m.keymap.info,
+		m.keymap.download, m.keymap.share, m.keymap.remove, m.keymap.refresh, m.keymap.quit,
+	}))
+	return sb.String()
+}
+
+func (m *browseUI) listView() string {
+	var sb strings.Builder
+	height := m.listHeight()
+	if len(m.entries) == 0 && !m.loading {
+		sb.WriteString(descStyle.Render("(empty)") + "\n")
+		height--
+	}
+	if m.listing != nil {
+		// Keep a line to tell more entries are left to list.
+		height--
+	}
+	// Scroll so that the cursor is always visible.
+	start := 0
+	if m.cursor >= height {
+		start = m.cursor - height + 1
+	}
+	end := start + height
+	if end > len(m.entries) {
+		end = len(m.entries)
+	}
+	for i := start; i < end; i++ {
+		entry := m.entries[i]
+		line := entry.name
+		if !entry.isDir {
+			line = fmt.Sprintf("%-48s %10s  %s", entry.name, humanize.IBytes(uint64(entry.size)),
+				entry.modTime.Local().Format(printDate))
+		}
+		switch {
+		case i == m.cursor:
+			line = browseSelectedStyle.Render("> " + line)
+		case entry.isDir:
+			line = "  " + browseDirStyle.Render(line)
+		default:
+			line = "  " + line
+		}
+		sb.WriteString(line + "\n")
+	}
+	for i := end - start; i < height; i++ {
+		sb.WriteString("\n")
+	}
+	if m.listing != nil {
+		sb.WriteString(descStyle.Render(fmt.Sprintf("(%d entries listed, move down to list more)", len(m.entries))) + "\n")
+	}
+	return sb.String()
+}
+
+func (m *browseUI) infoView() string {
+	c := m.info
+	var sb strings.Builder
+	row := func(k, v string) {
+		if v != "" {
+			sb.WriteString(fmt.Sprintf("%-14s: %s\n", k, v))
+		}
+	}
+	row("Name", c.URL.String())
+	row("Date", c.Time.Local().Format(printDate))
+	row("Size", fmt.Sprintf("%s (%d bytes)", humanize.IBytes(uint64(c.Size)), c.Size))
+	row("ETag", c.ETag)
+	row("VersionID", c.VersionID)
+	row("StorageClass", c.StorageClass)
+	keys := make([]string, 0, len(c.Metadata))
+	for k := range c.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		sb.WriteString("Metadata      :\n")
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", k, c.Metadata[k]))
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
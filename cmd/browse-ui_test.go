@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestBrowseParent(t *testing.T) {
+	testCases := []struct {
+		dir, parent string
+	}{
+		{"", ""},
+		{"play/", ""},
+		{"play/bucket/", "play/"},
+		{"play/bucket/a/b/", "play/bucket/a/"},
+		{"/tmp/", "/"},
+		{"/", ""},
+	}
+	for i, testCase := range testCases {
+		if got := browseParent(testCase.dir); got != testCase.parent {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.parent, got)
+		}
+	}
+}
+
+func TestBrowseUINavigation(t *testing.T) {
+	oldLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = oldLoadMcConfig }()
+
+	root := t.TempDir()
+	if e := os.MkdirAll(filepath.Join(root, "dir"), 0o700); e != nil {
+		t.Fatal(e)
+	}
+	for _, name := range []string{"a.txt", filepath.Join("dir", "b.txt")} {
+		if e := os.WriteFile(filepath.Join(root, name), []byte("hello"), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	rootDir := filepath.ToSlash(root) + "/"
+
+	ctx := context.Background()
+	m := newBrowseUI(ctx, "", []string{"play", "local"})
+	run := func(cmd tea.Cmd) {
+		for cmd != nil {
+			_, cmd = m.Update(cmd())
+		}
+	}
+	press := func(k string) tea.Cmd {
+		keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			keyMsg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "down":
+			keyMsg = tea.KeyMsg{Type: tea.KeyDown}
+		case "left":
+			keyMsg = tea.KeyMsg{Type: tea.KeyLeft}
+		}
+		_, cmd := m.Update(keyMsg)
+		return cmd
+	}
+
+	run(m.startListing())
+	if len(m.entries) != 2 || m.entries[1].url != "local/" {
+		t.Fatalf("unexpected aliases %+v", m.entries)
+	}
+
+	run(m.changeDir(rootDir))
+	if len(m.entries) != 2 || m.entries[0].name != "dir/" || m.entries[1].name != "a.txt" {
+		t.Fatalf("expected folders first, got %+v", m.entries)
+	}
+
+	run(press("enter"))
+	if m.dir != rootDir+"dir/" || len(m.entries) != 1 || m.entries[0].url != rootDir+"dir/b.txt" {
+		t.Fatalf("unexpected folder %q %+v", m.dir, m.entries)
+	}
+
+	// Going back restores the selection of the parent folder.
+	run(press("left"))
+	run(press("down"))
+	if m.dir != rootDir || m.selected().name != "a.txt" {
+		t.Fatalf("unexpected selection in %q: %+v", m.dir, m.selected())
+	}
+
+	run(press("i"))
+	if m.info == nil || m.info.Size != 5 {
+		t.Fatalf("expected the information of a.txt, got %+v", m.info)
+	}
+	press("esc")
+	if m.info != nil {
+		t.Fatal("expected the information view to be closed")
+	}
+
+	// Download to the current directory, an existing file is kept.
+	wd, e := os.Getwd()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = os.Chdir(t.TempDir()); e != nil {
+		t.Fatal(e)
+	}
+	defer os.Chdir(wd)
+	run(press("d"))
+	if data, e := os.ReadFile("a.txt"); e != nil || string(data) != "hello" || m.errMsg != "" {
+		t.Fatalf("download failed: %v %q %q", e, data, m.errMsg)
+	}
+	run(press("d"))
+	if m.errMsg == "" {
+		t.Fatal("expected an error when the downloaded file exists")
+	}
+
+	// Removal asks for a confirmation.
+	if cmd := press("x"); cmd != nil || m.confirm == nil {
+		t.Fatal("expected a removal confirmation")
+	}
+	run(press("n"))
+	if _, e = os.Stat(filepath.Join(root, "a.txt")); e != nil {
+		t.Fatal("object removed without confirmation")
+	}
+	press("x")
+	run(press("y"))
+	if _, e = os.Stat(filepath.Join(root, "a.txt")); !os.IsNotExist(e) {
+		t.Fatalf("expected a.txt to be removed, got %v", e)
+	}
+	if len(m.entries) != 1 || m.entries[0].name != "dir/" {
+		t.Fatalf("expected the listing to be refreshed, got %+v", m.entries)
+	}
+	if view := m.View(); !strings.Contains(view, "dir/") || !strings.Contains(view, "Removed") {
+		t.Fatalf("unexpected view %q", view)
+	}
+}
+
+func TestBrowseUIPaging(t *testing.T) {
+	oldLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = oldLoadMcConfig }()
+
+	root := t.TempDir()
+	for i := 0; i < browsePageSize+5; i++ {
+		if e := os.WriteFile(filepath.Join(root, fmt.Sprintf("%05d.txt", i)), nil, 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	m := newBrowseUI(context.Background(), filepath.ToSlash(root)+"/", nil)
+	run := func(cmd tea.Cmd) {
+		for cmd != nil {
+			_, cmd = m.Update(cmd())
+		}
+	}
+	run(m.startListing())
+	if len(m.entries) != browsePageSize || m.listing == nil {
+		t.Fatalf("expected a first page of %d entries, got %d", browsePageSize, len(m.entries))
+	}
+	if !strings.Contains(m.View(), "list more") {
+		t.Fatal("expected the view to tell more entries are left")
+	}
+
+	// Moving past the last listed entry lists the next page.
+	m.cursor = len(m.entries) - 2
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	run(cmd)
+	if len(m.entries) != browsePageSize+5 || m.listing != nil {
+		t.Fatalf("expected %d entries, got %d", browsePageSize+5, len(m.entries))
+	}
+	if m.entries[browsePageSize].name != fmt.Sprintf("%05d.txt", browsePageSize) {
+		t.Fatalf("unexpected entry %+v after the first page", m.entries[browsePageSize])
+	}
+}
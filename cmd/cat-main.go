@@ -68,7 +68,7 @@ var catCmd = cli.Command{
 	Action:       mainCat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(catFlags, encCFlag), globalFlags...),
+	Flags:        append(append(catFlags, encCFlag, cseKeyFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -79,6 +79,9 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 
+ENVIRONMENT VARIABLES:
+  MC_CAT_PARALLEL: number of ranged readers used to stream large objects to a pipe or a file, defaults to 4.
+
 EXAMPLES:
   1. Stream an object from Amazon S3 cloud storage to mplayer standard input.
      {{.Prompt}} {{.HelpName}} s3/mysql-backups/kubecon-mysql-operator.mpv | mplayer -
@@ -101,6 +104,9 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Display the content of an object encrypted on the client side by 'mc cp --cse-key'.
+     {{.Prompt}} {{.HelpName}} --cse-key MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA play/my-bucket/private/my-object
 `,
 }
 
@@ -169,6 +175,7 @@ type catOpts struct {
 	partN     int
 	isZip     bool
 	stdinMode bool
+	cseKey    []byte
 }
 
 // parseCatSyntax performs command-line input validation for cat command.
@@ -218,6 +225,11 @@ func parseCatSyntax(ctx *cli.Context) catOpts {
 	if (o.tailO != 0 || o.startO != 0) && o.partN > 0 {
 		fatalIf(errInvalidArgument().Trace(), "You cannot use --part-number with --tail or --offset")
 	}
+	if key := ctx.String("cse-key"); key != "" {
+		var err *probe.Error
+		o.cseKey, err = parseCSEKey(key)
+		fatalIf(err, "Unable to parse --cse-key.")
+	}
 
 	return o
 }
@@ -225,6 +237,8 @@ func parseCatSyntax(ctx *cli.Context) catOpts {
 // catURL displays contents of a URL to stdout.
 func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixSSEPair, o catOpts) *probe.Error {
 	var reader io.ReadCloser
+	var cseMetadata map[string]string
+	var etag string
 	size := int64(-1)
 	switch sourceURL {
 	case "-":
@@ -267,18 +281,52 @@ func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixS
 			if o.partN != 0 {
 				size = int64(-1)
 			}
+			if o.cseKey != nil && isCSEEncrypted(content.Metadata) {
+				if o.startO != 0 || o.partN != 0 {
+					return errInvalidArgument().Trace(sourceURL)
+				}
+				cseMetadata = content.Metadata
+			}
+			etag = content.ETag
 		} else {
 			return err.Trace(sourceURL)
 		}
 		gopts := GetOptions{VersionID: versionID, Zip: o.isZip, RangeStart: o.startO, PartNumber: o.partN}
-		if reader, err = getSourceStreamFromURL(ctx, sourceURL, encKeyDB, getSourceOpts{
-			GetOptions: gopts,
-			preserve:   false,
-		}); err != nil {
-			return err.Trace(sourceURL)
+		if size >= catParallelThreshold && !o.isZip && !isTerminal() {
+			// Large object streamed to a pipe or a file, fetch ahead
+			// with several ranged readers to not be limited by the
+			// throughput of a single connection.
+			parallel, err := getCatParallel()
+			if err != nil {
+				return err.Trace(sourceURL)
+			}
+			if parallel > 1 {
+				reader = newParallelRangeReader(ctx, size, catPartSize, parallel, func(ctx context.Context, offset, length int64) (io.ReadCloser, *probe.Error) {
+					opts := gopts
+					opts.RangeStart += offset
+					opts.RangeLength = length
+					opts.MatchETag = etag
+					return getSourceStreamFromURL(ctx, sourceURL, encKeyDB, getSourceOpts{GetOptions: opts})
+				})
+			}
+		}
+		if reader == nil {
+			if reader, err = getSourceStreamFromURL(ctx, sourceURL, encKeyDB, getSourceOpts{
+				GetOptions: gopts,
+				preserve:   false,
+			}); err != nil {
+				return err.Trace(sourceURL)
+			}
 		}
 		defer reader.Close()
 	}
+	if cseMetadata != nil {
+		decrypted, plainSize, err := cseDecrypt(o.cseKey, reader, cseMetadata)
+		if err != nil {
+			return err.Trace(sourceURL)
+		}
+		return catOut(decrypted, plainSize).Trace(sourceURL)
+	}
 	return catOut(reader, size).Trace(sourceURL)
 }
 
@@ -0,0 +1,168 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/env"
+)
+
+const (
+	// envCatParallel overrides the number of ranged readers used to
+	// stream a large object, 1 disables parallel reads.
+	envCatParallel = "MC_CAT_PARALLEL"
+
+	defaultCatParallel = 4
+
+	// Objects smaller than catParallelThreshold are streamed with a
+	// single GET, larger ones are fetched in catPartSize ranges.
+	catParallelThreshold = 64 << 20
+	catPartSize          = 16 << 20
+)
+
+// getCatParallel returns the number of ranged readers to use.
+func getCatParallel() (int, *probe.Error) {
+	v := env.Get(envCatParallel, "")
+	if v == "" {
+		return defaultCatParallel, nil
+	}
+	n, e := strconv.Atoi(v)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(envCatParallel, v)
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n, nil
+}
+
+// rangeGetFunc returns a reader for length bytes starting at offset.
+type rangeGetFunc func(ctx context.Context, offset, length int64) (io.ReadCloser, *probe.Error)
+
+// rangePart is a range of the object being fetched.
+type rangePart struct {
+	data []byte
+	err  *probe.Error
+	done chan struct{}
+}
+
+// parallelRangeReader reads an object of a known size by fetching
+// consecutive ranges concurrently. Fetched ranges are held in a reorder
+// buffer and handed out in order, at most workers ranges are in flight
+// or buffered at any time.
+type parallelRangeReader struct {
+	cancel context.CancelFunc
+	parts  chan *rangePart
+	tokens chan struct{}
+	wg     sync.WaitGroup
+
+	cur *rangePart
+	buf []byte
+	err error
+}
+
+func newParallelRangeReader(ctx context.Context, size, partSize int64, workers int, get rangeGetFunc) *parallelRangeReader {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r := &parallelRangeReader{
+		cancel: cancel,
+		parts:  make(chan *rangePart, workers),
+		tokens: make(chan struct{}, workers),
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer close(r.parts)
+		for offset := int64(0); offset < size; offset += partSize {
+			select {
+			case r.tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			part := &rangePart{done: make(chan struct{})}
+			r.wg.Add(1)
+			go func(offset, length int64) {
+				defer r.wg.Done()
+				defer close(part.done)
+				part.data, part.err = fetchRange(ctx, offset, length, get)
+			}(offset, min(partSize, size-offset))
+			r.parts <- part
+		}
+	}()
+	return r
+}
+
+// fetchRange reads a whole range into memory.
+func fetchRange(ctx context.Context, offset, length int64, get rangeGetFunc) ([]byte, *probe.Error) {
+	reader, err := get(ctx, offset, length)
+	if err != nil {
+		return nil, err.Trace()
+	}
+	defer reader.Close()
+	data := make([]byte, length)
+	if n, e := io.ReadFull(reader, data); e != nil {
+		if e == io.ErrUnexpectedEOF || e == io.EOF {
+			return nil, probe.NewError(UnexpectedEOF{TotalSize: length, TotalWritten: int64(n)})
+		}
+		return nil, probe.NewError(e)
+	}
+	return data, nil
+}
+
+// Read hands out the fetched ranges in order.
+func (r *parallelRangeReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.cur != nil {
+			// Range consumed, let the next one be fetched.
+			r.cur = nil
+			<-r.tokens
+		}
+		part, ok := <-r.parts
+		if !ok {
+			r.err = io.EOF
+			continue
+		}
+		<-part.done
+		if part.err != nil {
+			r.err = part.err.ToGoError()
+			r.cancel()
+			continue
+		}
+		r.cur, r.buf = part, part.data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close stops fetching and waits for in-flight ranges to finish.
+func (r *parallelRangeReader) Close() error {
+	r.cancel()
+	r.wg.Wait()
+	return nil
+}
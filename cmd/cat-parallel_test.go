@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestParallelRangeReader(t *testing.T) {
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	testCases := []struct {
+		size      int64
+		partSize  int64
+		workers   int
+		failAt    int64
+		shortAt   int64
+		expectErr bool
+	}{
+		{size: 1000, partSize: 100, workers: 4, failAt: -1, shortAt: -1},
+		{size: 1000, partSize: 300, workers: 2, failAt: -1, shortAt: -1},
+		{size: 1000, partSize: 2000, workers: 4, failAt: -1, shortAt: -1},
+		{size: 1000, partSize: 1, workers: 8, failAt: -1, shortAt: -1},
+		{size: 0, partSize: 100, workers: 4, failAt: -1, shortAt: -1},
+		{size: 1000, partSize: 100, workers: 0, failAt: -1, shortAt: -1},
+		{size: 1000, partSize: 100, workers: 4, failAt: 500, shortAt: -1, expectErr: true},
+		{size: 1000, partSize: 100, workers: 4, failAt: -1, shortAt: 900, expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		var inflight, maxInflight int32
+		get := func(_ context.Context, offset, length int64) (io.ReadCloser, *probe.Error) {
+			n := atomic.AddInt32(&inflight, 1)
+			defer atomic.AddInt32(&inflight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInflight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInflight, m, n) {
+					break
+				}
+			}
+			if offset == testCase.failAt {
+				return nil, probe.NewError(errors.New("range failed"))
+			}
+			if offset == testCase.shortAt {
+				length--
+			}
+			return io.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+		}
+
+		r := newParallelRangeReader(context.Background(), testCase.size, testCase.partSize, testCase.workers, get)
+		got, e := io.ReadAll(r)
+		r.Close()
+		if testCase.expectErr {
+			if e == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if !bytes.Equal(got, data[:testCase.size]) {
+			t.Errorf("Test %d: read data does not match the object", i+1)
+		}
+		if workers := int32(max(testCase.workers, 1)); maxInflight > workers {
+			t.Errorf("Test %d: expected at most %d ranges in flight, got %d", i+1, workers, maxInflight)
+		}
+	}
+}
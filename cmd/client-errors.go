@@ -110,6 +110,15 @@ func (e ObjectOnGlacier) Error() string {
 	return "Object `" + e.Object + "` is on Glacier storage."
 }
 
+// ListingNotSorted - listing is not sorted by key.
+type ListingNotSorted struct {
+	Key, PrevKey string
+}
+
+func (e ListingNotSorted) Error() string {
+	return "Listing is not sorted by key, `" + e.Key + "` follows `" + e.PrevKey + "`."
+}
+
 // GenericFileError - generic file error.
 type GenericFileError struct {
 	Path string
@@ -304,11 +304,18 @@ func (f *fsClient) put(_ context.Context, reader io.Reader, size int64, progress
 	// Write to a temporary file "object.part.minio" before commit.
 	objectPartPath := objectPath + partSuffix
 
-	// We cannot resume this operation, then we
-	// should remove any partial download if any.
-	defer os.Remove(objectPartPath)
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if opts.resume {
+		// Append to the partial download, which is kept
+		// on failure to be resumed later.
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	} else {
+		// We cannot resume this operation, then we
+		// should remove any partial download if any.
+		defer os.Remove(objectPartPath)
+	}
 
-	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY, 0o666)
+	tmpFile, e := os.OpenFile(objectPartPath, flags, 0o666)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
 		return 0, err.Trace(f.PathURL.Path)
@@ -422,7 +429,7 @@ func (f *fsClient) putN(_ context.Context, reader io.Reader, size int64, progres
 	// should remove any partial download if any.
 	defer os.Remove(objectPartPath)
 
-	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY, 0o666)
+	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o666)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
 		return 0, err.Trace(f.PathURL.Path)
@@ -587,6 +594,12 @@ func (f *fsClient) Get(_ context.Context, opts GetOptions) (io.ReadCloser, *Clie
 		content.Metadata[metadataKey] = fileAttr
 	}
 
+	if opts.RangeLength > 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(fileData, opts.RangeLength), fileData}, content, nil
+	}
 	return fileData, content, nil
 }
 
@@ -765,6 +778,14 @@ func (f *fsClient) List(_ context.Context, opts ListOptions) <-chan *ClientConte
 		return filteredCh
 	}
 
+	if opts.Inventory != "" {
+		filteredCh <- &ClientContent{
+			Err: probe.NewError(errors.New("inventory listing not supported for local files")),
+		}
+		close(filteredCh)
+		return filteredCh
+	}
+
 	if opts.Recursive {
 		if opts.ShowDir == DirNone {
 			go f.listRecursiveInRoutine(contentCh)
@@ -1494,7 +1515,7 @@ func (f *fsClient) GetBucketInfo(_ context.Context) (BucketInfo, *probe.Error) {
 }
 
 // Restore object - not implemented
-func (f *fsClient) Restore(_ context.Context, _ string, _ int) *probe.Error {
+func (f *fsClient) Restore(_ context.Context, _ string, _ int, _ minio.TierType) *probe.Error {
 	return probe.NewError(APINotImplemented{
 		API:     "Restore",
 		APIType: "filesystem",
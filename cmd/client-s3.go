@@ -101,7 +101,7 @@ func newCustomDialContext(c *Config) dialContext {
 	return func(ctx context.Context, network, addr string) (net.Conn, error) {
 		dialer := &net.Dialer{
 			Timeout:   10 * time.Second,
-			KeepAlive: 15 * time.Second,
+			KeepAlive: globalTCPKeepAlive,
 		}
 
 		if ip, ok := globalResolvers[addr]; ok {
@@ -131,7 +131,7 @@ func newCustomDialTLSContext(tlsConf *tls.Config) dialContext {
 		dialer := &tls.Dialer{
 			NetDialer: &net.Dialer{
 				Timeout:   10 * time.Second,
-				KeepAlive: 15 * time.Second,
+				KeepAlive: globalTCPKeepAlive,
 			},
 			Config: tlsConf,
 		}
@@ -146,7 +146,12 @@ func newCustomDialTLSContext(tlsConf *tls.Config) dialContext {
 				addr = ip.String()
 			}
 		}
-		return dialer.DialContext(ctx, network, addr)
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		globalConnStats.addTLSConn(conn.(*tls.Conn).ConnectionState())
+		return conn, nil
 	}
 }
 
@@ -297,8 +302,43 @@ func (c *S3Client) GetURL() ClientURL {
 	return c.targetURL.Clone()
 }
 
+// notificationEventTypes maps the event names accepted on the command
+// line to bucket notification event types. Names starting with "s3:"
+// are passed through as is, to allow any event type supported by the server.
+func notificationEventTypes(events []string) ([]notification.EventType, *probe.Error) {
+	var eventTypes []notification.EventType
+	for _, event := range events {
+		switch event {
+		case "put":
+			eventTypes = append(eventTypes, notification.ObjectCreatedAll)
+		case "delete":
+			eventTypes = append(eventTypes, notification.ObjectRemovedAll)
+		case "get":
+			eventTypes = append(eventTypes, notification.ObjectAccessedAll)
+		case "replica":
+			eventTypes = append(eventTypes, notification.ObjectReplicationAll)
+		case "ilm":
+			eventTypes = append(eventTypes, notification.EventType("s3:ObjectRestore:*"), notification.ObjectTransitionAll)
+		case "restore":
+			eventTypes = append(eventTypes, notification.EventType("s3:ObjectRestore:*"))
+		case "transition":
+			eventTypes = append(eventTypes, notification.ObjectTransitionAll)
+		case "expiry":
+			eventTypes = append(eventTypes, notification.ILMDelMarkerExpirationDelete)
+		case "scanner":
+			eventTypes = append(eventTypes, notification.ObjectScannerManyVersions, notification.ObjectScannerBigPrefix)
+		default:
+			if !strings.HasPrefix(event, "s3:") {
+				return nil, errInvalidArgument().Trace(events...)
+			}
+			eventTypes = append(eventTypes, notification.EventType(event))
+		}
+	}
+	return eventTypes, nil
+}
+
 // AddNotificationConfig - Add bucket notification
-func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events []string, prefix, suffix string, ignoreExisting bool) *probe.Error {
+func (c *S3Client) AddNotificationConfig(ctx context.Context, arn, id string, events []string, prefix, suffix string, ignoreExisting bool) *probe.Error {
 	bucket, _ := c.url2BucketAndObject()
 
 	accountArn, err := notification.NewArnFromString(arn)
@@ -306,6 +346,7 @@ func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events
 		return probe.NewError(invalidArgumentErr(err)).Untrace()
 	}
 	nc := notification.NewConfig(accountArn)
+	nc.ID = id
 
 	// Get any enabled notification.
 	mb, e := c.api.GetBucketNotification(ctx, bucket)
@@ -314,26 +355,11 @@ func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events
 	}
 
 	// Configure events
-	for _, event := range events {
-		switch event {
-		case "put":
-			nc.AddEvents(notification.ObjectCreatedAll)
-		case "delete":
-			nc.AddEvents(notification.ObjectRemovedAll)
-		case "get":
-			nc.AddEvents(notification.ObjectAccessedAll)
-		case "replica":
-			nc.AddEvents(notification.EventType("s3:Replication:*"))
-		case "ilm":
-			nc.AddEvents(notification.EventType("s3:ObjectRestore:*"))
-			nc.AddEvents(notification.EventType("s3:ObjectTransition:*"))
-		case "scanner":
-			nc.AddEvents(notification.EventType("s3:Scanner:ManyVersions"))
-			nc.AddEvents(notification.EventType("s3:Scanner:BigPrefix"))
-		default:
-			return errInvalidArgument().Trace(events...)
-		}
+	eventTypes, perr := notificationEventTypes(events)
+	if perr != nil {
+		return perr
 	}
+	nc.AddEvents(eventTypes...)
 	if prefix != "" {
 		nc.AddFilterPrefix(prefix)
 	}
@@ -368,6 +394,151 @@ func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events
 	return nil
 }
 
+// EditNotificationConfigOpts holds the changes applied by EditNotificationConfig,
+// nil fields are left unchanged.
+type EditNotificationConfigOpts struct {
+	Events []string
+	Prefix *string
+	Suffix *string
+}
+
+// notificationConfigRef returns a reference to the notification rule of
+// the given ARN, selected by its ID. When no ID is given, the ARN must
+// have exactly one rule.
+func notificationConfigRef(mb *notification.Configuration, arn, id string) (*notification.Config, *probe.Error) {
+	var matches []*notification.Config
+	for i := range mb.TopicConfigs {
+		if mb.TopicConfigs[i].Topic == arn {
+			matches = append(matches, &mb.TopicConfigs[i].Config)
+		}
+	}
+	for i := range mb.QueueConfigs {
+		if mb.QueueConfigs[i].Queue == arn {
+			matches = append(matches, &mb.QueueConfigs[i].Config)
+		}
+	}
+	for i := range mb.LambdaConfigs {
+		if mb.LambdaConfigs[i].Lambda == arn {
+			matches = append(matches, &mb.LambdaConfigs[i].Config)
+		}
+	}
+
+	if id == "" {
+		if len(matches) != 1 {
+			return nil, probe.NewError(fmt.Errorf("found %d notification rules for %s, please select one with --id", len(matches), arn))
+		}
+		return matches[0], nil
+	}
+	for _, config := range matches {
+		if config.ID == id {
+			return config, nil
+		}
+	}
+	return nil, probe.NewError(fmt.Errorf("notification rule with id %s not found for %s", id, arn))
+}
+
+// EditNotificationConfig - Edit the events and filters of an existing bucket
+// notification rule in place, with a single update of the bucket configuration.
+func (c *S3Client) EditNotificationConfig(ctx context.Context, arn, id string, opts EditNotificationConfigOpts) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+
+	mb, e := c.api.GetBucketNotification(ctx, bucket)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	config, err := notificationConfigRef(&mb, arn, id)
+	if err != nil {
+		return err
+	}
+
+	if opts.Events != nil {
+		eventTypes, err := notificationEventTypes(opts.Events)
+		if err != nil {
+			return err
+		}
+		config.Events = eventTypes
+	}
+
+	prefix, suffix := notificationConfigFilters(*config)
+	if opts.Prefix != nil {
+		prefix = *opts.Prefix
+	}
+	if opts.Suffix != nil {
+		suffix = *opts.Suffix
+	}
+	config.Filter = nil
+	if prefix != "" {
+		config.AddFilterPrefix(prefix)
+	}
+	if suffix != "" {
+		config.AddFilterSuffix(suffix)
+	}
+
+	if e := c.api.SetBucketNotification(ctx, bucket, mb); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// RemoveNotificationConfigByID - Remove the bucket notification rule with the given ID,
+// only when it targets the given ARN if not empty.
+func (c *S3Client) RemoveNotificationConfigByID(ctx context.Context, arn, id string) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+
+	mb, e := c.api.GetBucketNotification(ctx, bucket)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	matches := func(config notification.Config, configArn string) bool {
+		return config.ID == id && (arn == "" || configArn == arn)
+	}
+
+	var found bool
+	topics := mb.TopicConfigs[:0]
+	for _, config := range mb.TopicConfigs {
+		if matches(config.Config, config.Topic) {
+			found = true
+			continue
+		}
+		topics = append(topics, config)
+	}
+	mb.TopicConfigs = topics
+
+	queues := mb.QueueConfigs[:0]
+	for _, config := range mb.QueueConfigs {
+		if matches(config.Config, config.Queue) {
+			found = true
+			continue
+		}
+		queues = append(queues, config)
+	}
+	mb.QueueConfigs = queues
+
+	lambdas := mb.LambdaConfigs[:0]
+	for _, config := range mb.LambdaConfigs {
+		if matches(config.Config, config.Lambda) {
+			found = true
+			continue
+		}
+		lambdas = append(lambdas, config)
+	}
+	mb.LambdaConfigs = lambdas
+
+	if !found {
+		if arn != "" {
+			return probe.NewError(fmt.Errorf("notification rule with id %s and arn %s not found", id, arn))
+		}
+		return probe.NewError(fmt.Errorf("notification rule with id %s not found", id))
+	}
+
+	if e := c.api.SetBucketNotification(ctx, bucket, mb); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
 // RemoveNotificationConfig - Remove bucket notification
 func (c *S3Client) RemoveNotificationConfig(ctx context.Context, arn, event, prefix, suffix string) *probe.Error {
 	bucket, _ := c.url2BucketAndObject()
@@ -393,27 +564,9 @@ func (c *S3Client) RemoveNotificationConfig(ctx context.Context, arn, event, pre
 	// the arguments
 	if event != "" || suffix != "" || prefix != "" {
 		// Translate events to type events for comparison
-		events := strings.Split(event, ",")
-		var eventsTyped []notification.EventType
-		for _, e := range events {
-			switch e {
-			case "put":
-				eventsTyped = append(eventsTyped, notification.ObjectCreatedAll)
-			case "delete":
-				eventsTyped = append(eventsTyped, notification.ObjectRemovedAll)
-			case "get":
-				eventsTyped = append(eventsTyped, notification.ObjectAccessedAll)
-			case "replica":
-				eventsTyped = append(eventsTyped, notification.EventType("s3:Replication:*"))
-			case "ilm":
-				eventsTyped = append(eventsTyped, notification.EventType("s3:ObjectRestore:*"))
-				eventsTyped = append(eventsTyped, notification.EventType("s3:ObjectTransition:*"))
-			case "scanner":
-				eventsTyped = append(eventsTyped, notification.EventType("s3:Scanner:ManyVersions"))
-				eventsTyped = append(eventsTyped, notification.EventType("s3:Scanner:BigPrefix"))
-			default:
-				return errInvalidArgument().Trace(events...)
-			}
+		eventsTyped, perr := notificationEventTypes(strings.Split(event, ","))
+		if perr != nil {
+			return perr
 		}
 		var err error
 		// based on the arn type, we'll look for the event in the corresponding sublist and delete it if there's a match
@@ -461,6 +614,23 @@ type NotificationConfig struct {
 	Suffix string   `json:"suffix"`
 }
 
+// notificationConfigFilters returns the prefix and suffix filters of a notification rule.
+func notificationConfigFilters(config notification.Config) (prefix, suffix string) {
+	if config.Filter == nil {
+		return
+	}
+	for _, filter := range config.Filter.S3Key.FilterRules {
+		if strings.ToLower(filter.Name) == "prefix" {
+			prefix = filter.Value
+		}
+		if strings.ToLower(filter.Name) == "suffix" {
+			suffix = filter.Value
+		}
+
+	}
+	return prefix, suffix
+}
+
 // ListNotificationConfigs - List notification configs
 func (c *S3Client) ListNotificationConfigs(ctx context.Context, arn string) ([]NotificationConfig, *probe.Error) {
 	var configs []NotificationConfig
@@ -479,27 +649,11 @@ func (c *S3Client) ListNotificationConfigs(ctx context.Context, arn string) ([]N
 		return result
 	}
 
-	getFilters := func(config notification.Config) (prefix, suffix string) {
-		if config.Filter == nil {
-			return
-		}
-		for _, filter := range config.Filter.S3Key.FilterRules {
-			if strings.ToLower(filter.Name) == "prefix" {
-				prefix = filter.Value
-			}
-			if strings.ToLower(filter.Name) == "suffix" {
-				suffix = filter.Value
-			}
-
-		}
-		return prefix, suffix
-	}
-
 	for _, config := range mb.TopicConfigs {
 		if arn != "" && config.Topic != arn {
 			continue
 		}
-		prefix, suffix := getFilters(config.Config)
+		prefix, suffix := notificationConfigFilters(config.Config)
 		configs = append(configs, NotificationConfig{
 			ID:     config.ID,
 			Arn:    config.Topic,
@@ -513,7 +667,7 @@ func (c *S3Client) ListNotificationConfigs(ctx context.Context, arn string) ([]N
 		if arn != "" && config.Queue != arn {
 			continue
 		}
-		prefix, suffix := getFilters(config.Config)
+		prefix, suffix := notificationConfigFilters(config.Config)
 		configs = append(configs, NotificationConfig{
 			ID:     config.ID,
 			Arn:    config.Queue,
@@ -527,7 +681,7 @@ func (c *S3Client) ListNotificationConfigs(ctx context.Context, arn string) ([]N
 		if arn != "" && config.Lambda != arn {
 			continue
 		}
-		prefix, suffix := getFilters(config.Config)
+		prefix, suffix := notificationConfigFilters(config.Config)
 		configs = append(configs, NotificationConfig{
 			ID:     config.ID,
 			Arn:    config.Lambda,
@@ -807,24 +961,18 @@ func (c *S3Client) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 	var events []string
 	for _, event := range options.Events {
 		switch event {
-		case "put":
-			events = append(events, string(notification.ObjectCreatedAll))
-		case "delete":
-			events = append(events, string(notification.ObjectRemovedAll))
-		case "get":
-			events = append(events, string(notification.ObjectAccessedAll))
-		case "replica":
-			events = append(events, "s3:Replication:*") // TODO: add it to minio-go as constant
-		case "ilm":
-			events = append(events, "s3:ObjectRestore:*", "s3:ObjectTransition:*") // TODO: add it to minio-go as constant
 		case "bucket-creation":
 			events = append(events, string(notification.BucketCreatedAll))
 		case "bucket-removal":
 			events = append(events, string(notification.BucketRemovedAll))
-		case "scanner":
-			events = append(events, "s3:Scanner:ManyVersions", "s3:Scanner:BigPrefix")
 		default:
-			return nil, errInvalidArgument().Trace(event)
+			eventTypes, err := notificationEventTypes([]string{event})
+			if err != nil {
+				return nil, err
+			}
+			for _, eventType := range eventTypes {
+				events = append(events, string(eventType))
+			}
 		}
 	}
 
@@ -892,12 +1040,23 @@ func (c *S3Client) Get(ctx context.Context, opts GetOptions) (io.ReadCloser, *Cl
 	if opts.Zip {
 		o.Set("x-minio-extract", "true")
 	}
-	if opts.RangeStart != 0 {
+	if opts.RangeLength > 0 {
+		err := o.SetRange(opts.RangeStart, opts.RangeStart+opts.RangeLength-1)
+		if err != nil {
+			return nil, nil, probe.NewError(err)
+		}
+	} else if opts.RangeStart != 0 {
 		err := o.SetRange(opts.RangeStart, 0)
 		if err != nil {
 			return nil, nil, probe.NewError(err)
 		}
 	}
+	if opts.MatchETag != "" {
+		err := o.SetMatchETag(opts.MatchETag)
+		if err != nil {
+			return nil, nil, probe.NewError(err)
+		}
+	}
 	// Disallow automatic decompression for some objects with content-encoding set.
 	o.Set("Accept-Encoding", "identity")
 
@@ -1895,6 +2054,17 @@ func (c *S3Client) List(ctx context.Context, opts ListOptions) <-chan *ClientCon
 	contentCh := make(chan *ClientContent)
 	go func() {
 		defer close(contentCh)
+		if opts.Inventory != "" {
+			if b, _ := c.url2BucketAndObject(); b == "" || !opts.Recursive || opts.Incomplete || opts.ListZip || !opts.TimeRef.IsZero() || opts.WithOlderVersions {
+				select {
+				case <-ctx.Done():
+				case contentCh <- &ClientContent{
+					Err: probe.NewError(errors.New("inventory listing requires a recursive listing of the latest objects of a bucket")),
+				}:
+				}
+				return
+			}
+		}
 		if !opts.TimeRef.IsZero() || opts.WithOlderVersions {
 			c.versionedList(ctx, contentCh, opts)
 		} else {
@@ -2393,8 +2563,7 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 				contentCh <- c.bucketInfo2ClientContent(bucket)
 			}
 
-			isRecursive := true
-			for object := range c.listObjectWrapper(ctx, bucket.Name, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+			for object := range c.listRecursive(ctx, bucket.Name, o, opts) {
 				if object.Err != nil {
 					contentCh <- &ClientContent{
 						Err: probe.NewError(object.Err),
@@ -2409,8 +2578,7 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 			}
 		}
 	default:
-		isRecursive := true
-		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+		for object := range c.listRecursive(ctx, b, o, opts) {
 			if object.Err != nil {
 				contentCh <- &ClientContent{
 					Err: probe.NewError(object.Err),
@@ -2422,6 +2590,168 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 	}
 }
 
+// listParallelBuffer is the number of objects a parallel listing fetches
+// ahead in each prefix.
+const listParallelBuffer = 10000
+
+// listRecursive lists the objects under prefix recursively, in parallel if
+// requested.
+func (c *S3Client) listRecursive(ctx context.Context, bucket, prefix string, opts ListOptions) <-chan minio.ObjectInfo {
+	if opts.Inventory != "" {
+		return c.listInventory(ctx, bucket, prefix, opts)
+	}
+	// The top-level listing of a parallel listing cannot request the
+	// metadata of the objects, list sequentially when it is needed.
+	if opts.Parallel > 1 && !opts.ListZip && !opts.WithMetadata && !isGoogle(c.targetURL.Host) {
+		return c.listRecursiveParallel(ctx, bucket, prefix, opts)
+	}
+	isRecursive := true
+	return c.listObjectWrapper(ctx, bucket, prefix, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip)
+}
+
+// listTopLevelMaxKeys is the page size of a top-level listing.
+const listTopLevelMaxKeys = 1000
+
+// listTopLevel lists the objects and the common prefixes right under
+// prefix, sorted by key as in a recursive listing.
+func (c *S3Client) listTopLevel(ctx context.Context, bucket, prefix string) <-chan minio.ObjectInfo {
+	objectCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectCh)
+		entryCh := c.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+			Prefix:       prefix,
+			WithMetadata: false,
+			MaxKeys:      listTopLevelMaxKeys,
+		})
+		mergeTopLevel(entryCh, listTopLevelMaxKeys, func(entry minio.ObjectInfo) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case objectCh <- entry:
+				return true
+			}
+		})
+	}()
+	return objectCh
+}
+
+// mergeTopLevel sends the entries of a delimited listing sorted by key. Each
+// page of maxKeys entries at most lists its objects then its common
+// prefixes, the objects are held back until no smaller prefix can follow.
+func mergeTopLevel(entryCh <-chan minio.ObjectInfo, maxKeys int, send func(minio.ObjectInfo) bool) {
+	var (
+		pending    []minio.ObjectInfo
+		sawPrefix  bool
+		sendFailed bool
+	)
+	// flush sends the pending objects before key, all of them if key is empty.
+	flush := func(key string) {
+		n := 0
+		for n < len(pending) && (key == "" || pending[n].Key < key) {
+			if !sendFailed && !send(pending[n]) {
+				sendFailed = true
+			}
+			n++
+		}
+		pending = pending[n:]
+	}
+	for entry := range entryCh {
+		if sendFailed {
+			continue
+		}
+		if entry.Err != nil {
+			flush("")
+			if !sendFailed {
+				send(entry)
+			}
+			sendFailed = true
+			continue
+		}
+		if entry.ETag == "" && entry.LastModified.IsZero() {
+			// A common prefix, all objects of its page are pending.
+			sawPrefix = true
+			flush(entry.Key)
+			if !sendFailed && !send(entry) {
+				sendFailed = true
+			}
+			continue
+		}
+		if sawPrefix {
+			// The first object of a new page, all pending objects
+			// are from the previous page.
+			sawPrefix = false
+			flush("")
+		}
+		pending = append(pending, entry)
+		if len(pending) > maxKeys {
+			// Only the last maxKeys objects can be in the current page.
+			flush(pending[len(pending)-maxKeys].Key)
+		}
+	}
+	if !sendFailed {
+		flush("")
+	}
+}
+
+// listRecursiveParallel lists the objects under prefix recursively, with up
+// to opts.Parallel of its top-level prefixes listed concurrently. All keys
+// of a prefix follow each other, so sending the listing of each top-level
+// entry in turn keeps the objects sorted as in a sequential listing.
+func (c *S3Client) listRecursiveParallel(ctx context.Context, bucket, prefix string, opts ListOptions) <-chan minio.ObjectInfo {
+	// The listings of the top-level entries, in order, the listings in
+	// the queue and the one being sent run concurrently.
+	listingCh := make(chan chan minio.ObjectInfo, opts.Parallel-1)
+	go func() {
+		defer close(listingCh)
+		for entry := range c.listTopLevel(ctx, bucket, prefix) {
+			isPrefix := entry.Err == nil && entry.ETag == "" && entry.Key != prefix &&
+				strings.HasSuffix(entry.Key, string(c.targetURL.Separator))
+			var listing chan minio.ObjectInfo
+			if isPrefix {
+				listing = make(chan minio.ObjectInfo, listParallelBuffer)
+			} else {
+				listing = make(chan minio.ObjectInfo, 1)
+				listing <- entry
+				close(listing)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case listingCh <- listing:
+			}
+			if !isPrefix {
+				continue
+			}
+			go func(prefix string) {
+				defer close(listing)
+				isRecursive := true
+				for object := range c.listObjectWrapper(ctx, bucket, prefix, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, false) {
+					select {
+					case <-ctx.Done():
+						return
+					case listing <- object:
+					}
+				}
+			}(entry.Key)
+		}
+	}()
+
+	objectCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectCh)
+		for listing := range listingCh {
+			for object := range listing {
+				select {
+				case <-ctx.Done():
+					return
+				case objectCh <- object:
+				}
+			}
+		}
+	}()
+	return objectCh
+}
+
 // ShareDownload - get a usable presigned object url to share.
 func (c *S3Client) ShareDownload(ctx context.Context, versionID string, expires time.Duration) (string, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
@@ -2985,7 +3315,7 @@ func (c *S3Client) GetBucketInfo(ctx context.Context) (BucketInfo, *probe.Error)
 }
 
 // Restore gets a copy of an archived object
-func (c *S3Client) Restore(ctx context.Context, versionID string, days int) *probe.Error {
+func (c *S3Client) Restore(ctx context.Context, versionID string, days int, tier minio.TierType) *probe.Error {
 	bucket, object := c.url2BucketAndObject()
 	if bucket == "" {
 		return probe.NewError(BucketNameEmpty{})
@@ -2996,13 +3326,45 @@ func (c *S3Client) Restore(ctx context.Context, versionID string, days int) *pro
 
 	req := minio.RestoreRequest{}
 	req.SetDays(days)
-	req.SetGlacierJobParameters(minio.GlacierJobParameters{Tier: minio.TierExpedited})
+	req.SetGlacierJobParameters(minio.GlacierJobParameters{Tier: tier})
 	if err := c.api.RestoreObject(ctx, bucket, object, versionID, req); err != nil {
 		return probe.NewError(err)
 	}
 	return nil
 }
 
+// GetObjectAttributes - returns the checksums and the parts of an object,
+// all the parts are fetched when the response is paginated.
+func (c *S3Client) GetObjectAttributes(ctx context.Context, versionID string, sse encrypt.ServerSide) (*minio.ObjectAttributes, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectNameEmpty{})
+	}
+
+	opts := minio.ObjectAttributesOptions{
+		VersionID:            versionID,
+		ServerSideEncryption: sse,
+	}
+	attrs, e := c.api.GetObjectAttributes(ctx, bucket, object, opts)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	for attrs.ObjectParts.IsTruncated && attrs.ObjectParts.NextPartNumberMarker > opts.PartNumberMarker {
+		opts.PartNumberMarker = attrs.ObjectParts.NextPartNumberMarker
+		next, e := c.api.GetObjectAttributes(ctx, bucket, object, opts)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		attrs.ObjectParts.Parts = append(attrs.ObjectParts.Parts, next.ObjectParts.Parts...)
+		attrs.ObjectParts.IsTruncated = next.ObjectParts.IsTruncated
+		attrs.ObjectParts.NextPartNumberMarker = next.ObjectParts.NextPartNumberMarker
+	}
+	return attrs, nil
+}
+
 // GetPart gets an object in a given number of parts
 func (c *S3Client) GetPart(ctx context.Context, part int) (io.ReadCloser, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
@@ -24,9 +24,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strconv"
+	"strings"
 
 	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
 	checkv1 "gopkg.in/check.v1"
 )
 
@@ -191,6 +194,111 @@ func (h stsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// listObjectsHandler is an http.Handler serving ListObjectsV2 requests for
+// a set of keys, in pages of three entries.
+type listObjectsHandler struct {
+	keys []string
+}
+
+func (h listObjectsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+
+	// Collect the sorted entries, grouping keys in common prefixes.
+	var entries []string
+	isPrefix := make(map[string]bool)
+	for _, key := range h.keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				key = key[:len(prefix)+i+len(delimiter)]
+				if isPrefix[key] {
+					continue
+				}
+				isPrefix[key] = true
+			}
+		}
+		entries = append(entries, key)
+	}
+	sort.Strings(entries)
+
+	start, _ := strconv.Atoi(query.Get("continuation-token"))
+	end := min(start+3, len(entries))
+	var contents, prefixes string
+	for _, entry := range entries[start:end] {
+		if isPrefix[entry] {
+			prefixes += "<CommonPrefixes><Prefix>" + entry + "</Prefix></CommonPrefixes>"
+		} else {
+			contents += "<Contents><Key>" + entry + "</Key><ETag>\"etag\"</ETag><LastModified>2015-05-21T18:24:21.097Z</LastModified><Size>1</Size></Contents>"
+		}
+	}
+	response := "<ListBucketResult xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"><Name>bucket</Name><KeyCount>" +
+		strconv.Itoa(end-start) + "</KeyCount>" + contents + prefixes
+	if end < len(entries) {
+		response += "<IsTruncated>true</IsTruncated><NextContinuationToken>" + strconv.Itoa(end) + "</NextContinuationToken>"
+	} else {
+		response += "<IsTruncated>false</IsTruncated>"
+	}
+	w.Write([]byte(response + "</ListBucketResult>"))
+}
+
+// Test a parallel listing returns the objects in the order of a sequential listing.
+func (s *TestSuite) TestListParallel(c *checkv1.C) {
+	handler := listObjectsHandler{keys: []string{
+		"a.txt", "a/1", "a/2/x", "a/2/y", "a0", "b/1", "b/2", "b/3", "b/4", "c", "d/", "d/1", "e/f/g", "z",
+	}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, checkv1.IsNil)
+
+	for _, parallel := range []int{0, 2, 8} {
+		var keys []string
+		for content := range s3c.List(globalContext, ListOptions{Recursive: true, ShowDir: DirNone, Parallel: parallel}) {
+			c.Assert(content.Err, checkv1.IsNil)
+			keys = append(keys, strings.TrimPrefix(content.URL.Path, "/bucket/"))
+		}
+		c.Assert(keys, checkv1.DeepEquals, handler.keys)
+	}
+}
+
+// Test the entries of a delimited listing are merged in key order.
+func (s *TestSuite) TestMergeTopLevel(c *checkv1.C) {
+	object := func(key string) minio.ObjectInfo {
+		return minio.ObjectInfo{Key: key, ETag: "etag"}
+	}
+	// Pages of two entries at most, objects first then prefixes.
+	entryCh := make(chan minio.ObjectInfo, 8)
+	for _, entry := range []minio.ObjectInfo{
+		object("a"), {Key: "a/"},
+		object("c"), {Key: "b/"},
+		object("d"), object("e"),
+		object("f"), {Key: "e0/"},
+	} {
+		entryCh <- entry
+	}
+	close(entryCh)
+
+	var keys []string
+	mergeTopLevel(entryCh, 2, func(entry minio.ObjectInfo) bool {
+		keys = append(keys, entry.Key)
+		return true
+	})
+	c.Assert(keys, checkv1.DeepEquals, []string{"a", "a/", "b/", "c", "d", "e", "e0/", "f"})
+}
+
 // Test bucket operations.
 func (s *TestSuite) TestBucketOperations(c *checkv1.C) {
 	bucket := bucketHandler{
@@ -299,3 +407,41 @@ func (s *TestSuite) TestSelectCompressionType(c *checkv1.C) {
 		c.Assert(cType, checkv1.DeepEquals, test.compressionType)
 	}
 }
+
+// TestNotificationEventTypes - tests the mapping of event names to notification event types
+func (s *TestSuite) TestNotificationEventTypes(c *checkv1.C) {
+	eventTypes, err := notificationEventTypes([]string{"put", "transition", "restore", "expiry", "s3:ObjectRemoved:DeleteMarkerCreated"})
+	c.Assert(err, checkv1.IsNil)
+	c.Assert(eventTypes, checkv1.DeepEquals, []notification.EventType{
+		notification.ObjectCreatedAll,
+		notification.ObjectTransitionAll,
+		"s3:ObjectRestore:*",
+		notification.ILMDelMarkerExpirationDelete,
+		notification.ObjectRemovedDeleteMarkerCreated,
+	})
+
+	_, err = notificationEventTypes([]string{"unknown"})
+	c.Assert(err, checkv1.NotNil)
+}
+
+// TestNotificationConfigRef - tests selecting a notification rule by ARN and ID
+func (s *TestSuite) TestNotificationConfigRef(c *checkv1.C) {
+	arn := "arn:minio:sqs::primary:webhook"
+	mb := notification.Configuration{
+		QueueConfigs: []notification.QueueConfig{
+			{Config: notification.Config{ID: "first"}, Queue: arn},
+			{Config: notification.Config{ID: "second"}, Queue: arn},
+		},
+	}
+
+	config, err := notificationConfigRef(&mb, arn, "second")
+	c.Assert(err, checkv1.IsNil)
+	config.Events = []notification.EventType{notification.ObjectCreatedAll}
+	c.Assert(mb.QueueConfigs[1].Events, checkv1.DeepEquals, []notification.EventType{notification.ObjectCreatedAll})
+
+	_, err = notificationConfigRef(&mb, arn, "")
+	c.Assert(err, checkv1.NotNil)
+
+	_, err = notificationConfigRef(&mb, arn, "third")
+	c.Assert(err, checkv1.NotNil)
+}
@@ -62,6 +62,12 @@ type GetOptions struct {
 	RangeStart int64
 	PartNumber int
 	Preserve   bool
+
+	// RangeLength limits the read to a number of bytes from
+	// RangeStart, 0 reads till the end of the object.
+	RangeLength int64
+	// MatchETag fails the read if the object was changed.
+	MatchETag string
 }
 
 // PutOptions holds options for PUT operation
@@ -76,6 +82,7 @@ type PutOptions struct {
 	concurrentStream      bool
 	ifNotExists           bool
 	checksum              minio.ChecksumType
+	resume                bool
 }
 
 // StatOptions holds options of the HEAD operation
@@ -108,6 +115,12 @@ type ListOptions struct {
 	TimeRef           time.Time
 	ShowDir           DirOpt
 	Count             int
+	// Parallel lists up to this many top-level prefixes concurrently in
+	// a recursive listing, supported by S3 targets only.
+	Parallel int
+	// Inventory lists the objects from the S3 Inventory report of the
+	// bucket described by this manifest instead of listing the bucket.
+	Inventory string
 }
 
 // CopyOptions holds options for copying operation
@@ -198,7 +211,7 @@ type Client interface {
 	GetBucketInfo(ctx context.Context) (BucketInfo, *probe.Error)
 
 	// Restore an object
-	Restore(ctx context.Context, versionID string, days int) *probe.Error
+	Restore(ctx context.Context, versionID string, days int, tier minio.TierType) *probe.Error
 
 	// OD operations
 	GetPart(ctx context.Context, part int) (io.ReadCloser, *probe.Error)
@@ -342,7 +355,7 @@ func (config *Config) initTransport(withS3v2 bool) {
 		tr := &http.Transport{
 			Proxy:                 http.ProxyFromEnvironment,
 			DialContext:           newCustomDialContext(config),
-			MaxIdleConnsPerHost:   1024,
+			MaxIdleConnsPerHost:   globalMaxIdleConnsPerHost,
 			WriteBufferSize:       32 << 10, // 32KiB moving up from 4KiB default
 			ReadBufferSize:        32 << 10, // 32KiB moving up from 4KiB default
 			IdleConnTimeout:       90 * time.Second,
@@ -357,20 +370,23 @@ func (config *Config) initTransport(withS3v2 bool) {
 			DisableCompression: true,
 		}
 		if useTLS {
-			tr.DialTLSContext = newCustomDialTLSContext(&tls.Config{
+			tlsConfig := &tls.Config{
 				RootCAs:            globalRootCAs,
 				MinVersion:         tls.VersionTLS12,
 				InsecureSkipVerify: config.Insecure,
-			})
+				ClientSessionCache: globalTLSSessionCache,
+			}
+			tr.DialTLSContext = newCustomDialTLSContext(tlsConfig)
 
-			// Because we create a custom TLSClientConfig, we have to opt-in to HTTP/2.
+			// Because we create a custom TLS dialer, we have to opt-in to HTTP/2.
 			// See https://github.com/golang/go/issues/14275
 			//
-			// TODO: Enable http2.0 when upstream issues related to HTTP/2 are fixed.
-			//
-			// if e = http2.ConfigureTransport(tr); e != nil {
-			// 	return nil, probe.NewError(e)
-			// }
+			// The transport adds "h2" to the protocols of tlsConfig, which is
+			// shared with the dialer, and switches to HTTP/2 if negotiated.
+			if globalHTTP2 {
+				tr.TLSClientConfig = tlsConfig
+				tr.ForceAttemptHTTP2 = true
+			}
 		}
 		transport = tr
 	}
@@ -378,6 +394,7 @@ func (config *Config) initTransport(withS3v2 bool) {
 	transport = limiter.New(config.UploadLimit, config.DownloadLimit, transport)
 
 	if config.Debug {
+		transport = connStatsTransport{transport: transport}
 		if strings.EqualFold(config.Signature, "S3v4") {
 			transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
 		} else if strings.EqualFold(config.Signature, "S3v2") && withS3v2 {
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -111,7 +112,8 @@ func getSourceStreamMetadataFromURL(ctx context.Context, aliasedURL, versionID s
 
 type getSourceOpts struct {
 	GetOptions
-	preserve bool
+	preserve     bool
+	sourceDigest *sourceDigest
 }
 
 // getSourceStreamFromURL gets a reader from URL.
@@ -150,6 +152,10 @@ func isReadAt(reader io.Reader) (ok bool) {
 
 // getSourceStream gets a reader from URL.
 func getSourceStream(ctx context.Context, alias, urlStr string, opts getSourceOpts) (reader io.ReadCloser, content *ClientContent, err *probe.Error) {
+	if alias == "" && urlRgx.MatchString(urlStr) {
+		// Plain HTTP(S) source, not an alias.
+		return getHTTPSourceStream(ctx, urlStr, opts.sourceDigest)
+	}
 	sourceClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
@@ -163,6 +169,69 @@ func getSourceStream(ctx context.Context, alias, urlStr string, opts getSourceOp
 	return reader, content, nil
 }
 
+// resumeOverlapSize is the length of the already downloaded data fetched
+// again and compared when resuming a partial download.
+const resumeOverlapSize = 1 << 20
+
+// getResumableSourceStream gets a reader for the part of the source which is
+// missing from the partial download partPath of a source of size bytes. The
+// end of the partial download is compared with the source first, the
+// download restarts from the beginning if they differ. The ranged read is
+// pinned to etag when known, so a source replaced in between is not mixed
+// with the partial download.
+func getResumableSourceStream(ctx context.Context, alias, urlStr string, opts getSourceOpts, partPath string, size int64, etag string) (reader io.ReadCloser, content *ClientContent, offset int64, err *probe.Error) {
+	if st, e := os.Stat(partPath); e == nil && st.Mode().IsRegular() {
+		offset = st.Size()
+	}
+	if offset <= 0 || offset >= size {
+		os.Remove(partPath)
+		reader, content, err = getSourceStream(ctx, alias, urlStr, opts)
+		return reader, content, 0, err
+	}
+
+	overlap := min(offset, resumeOverlapSize)
+	rangeOpts := opts
+	rangeOpts.RangeStart = offset - overlap
+	rangeOpts.MatchETag = etag
+	reader, content, err = getSourceStream(ctx, alias, urlStr, rangeOpts)
+	if err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code != "PreconditionFailed" {
+			return nil, nil, 0, err
+		}
+		// The source changed since it was listed, start over.
+		os.Remove(partPath)
+		reader, content, err = getSourceStream(ctx, alias, urlStr, opts)
+		return reader, content, 0, err
+	}
+	if !partialDownloadMatches(partPath, reader, offset-overlap, overlap) {
+		reader.Close()
+		os.Remove(partPath)
+		reader, content, err = getSourceStream(ctx, alias, urlStr, opts)
+		return reader, content, 0, err
+	}
+	return reader, content, offset, nil
+}
+
+// partialDownloadMatches consumes length bytes from reader and returns true
+// if they are the same as partPath from offset.
+func partialDownloadMatches(partPath string, reader io.Reader, offset, length int64) bool {
+	f, e := os.Open(partPath)
+	if e != nil {
+		return false
+	}
+	defer f.Close()
+
+	local := make([]byte, length)
+	if _, e = f.ReadAt(local, offset); e != nil {
+		return false
+	}
+	remote := make([]byte, length)
+	if _, e = io.ReadFull(reader, remote); e != nil {
+		return false
+	}
+	return bytes.Equal(local, remote)
+}
+
 // putTargetRetention sets retention headers if any
 func putTargetRetention(ctx context.Context, alias, urlStr string, metadata map[string]string) *probe.Error {
 	targetClnt, err := newClientFromAlias(alias, urlStr)
@@ -190,6 +259,53 @@ func putTargetRetention(ctx context.Context, alias, urlStr string, metadata map[
 	return nil
 }
 
+// isObjectLockNotConfigured returns true if the error means the object or
+// its bucket has no object lock retention or legal hold to read.
+func isObjectLockNotConfigured(err *probe.Error) bool {
+	errResp := minio.ToErrorResponse(err.ToGoError())
+	switch errResp.Code {
+	case "NoSuchObjectLockConfiguration", "ObjectLockConfigurationNotFoundError":
+		return true
+	case "InvalidRequest":
+		// MinIO reports a bucket without object lock as InvalidRequest,
+		// any other invalid request is a real error.
+		return strings.Contains(strings.ToLower(errResp.Message), "objectlockconfiguration")
+	}
+	return false
+}
+
+// getSourceObjectLock returns the retention mode, retain until date and
+// legal hold of a source object, empty values mean nothing to preserve.
+// An expired retention is not returned as it no longer protects the object.
+func getSourceObjectLock(ctx context.Context, alias, urlStr, versionID string) (mode, until, legalHold string, err *probe.Error) {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return "", "", "", err.Trace(alias, urlStr)
+	}
+	if _, ok := clnt.(*S3Client); !ok {
+		// Filesystem sources have no object lock.
+		return "", "", "", nil
+	}
+
+	m, t, err := clnt.GetObjectRetention(ctx, versionID)
+	if err != nil && !isObjectLockNotConfigured(err) {
+		return "", "", "", err.Trace(alias, urlStr)
+	}
+	if err == nil && m.IsValid() && t.After(UTCNow()) {
+		mode = string(m)
+		until = t.UTC().Format(time.RFC3339)
+	}
+
+	lh, err := clnt.GetObjectLegalHold(ctx, versionID)
+	if err != nil && !isObjectLockNotConfigured(err) {
+		return "", "", "", err.Trace(alias, urlStr)
+	}
+	if err == nil && lh == minio.LegalHoldEnabled {
+		legalHold = string(lh)
+	}
+	return mode, until, legalHold, nil
+}
+
 // putTargetStream writes to URL from Reader.
 func putTargetStream(ctx context.Context, alias, urlStr, mode, until, legalHold string, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (int64, *probe.Error) {
 	targetClnt, err := newClientFromAlias(alias, urlStr)
@@ -345,6 +461,21 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 		legalHold = uploadOpts.urls.TargetContent.LegalHold
 	}
 
+	// copy the retention and legal hold of the source object, unless
+	// they are explicitly overridden for the target.
+	if uploadOpts.preserveLock {
+		srcMode, srcUntil, srcLegalHold, err := getSourceObjectLock(ctx, sourceAlias, sourceURL.String(), sourceVersion)
+		if err != nil {
+			return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+		}
+		if !uploadOpts.urls.TargetContent.RetentionEnabled && srcMode != "" {
+			mode, until = srcMode, srcUntil
+		}
+		if !uploadOpts.urls.TargetContent.LegalHoldEnabled && srcLegalHold != "" {
+			legalHold = srcLegalHold
+		}
+	}
+
 	for k, v := range uploadOpts.urls.SourceContent.UserMetadata {
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
@@ -353,7 +484,8 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 	}
 
 	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias && !uploadOpts.isZip && !uploadOpts.urls.checksum.IsSet() {
+	isHTTPSource := sourceAlias == "" && urlRgx.MatchString(sourceURL.String())
+	if sourceAlias == targetAlias && !isHTTPSource && uploadOpts.cseKey == nil && !uploadOpts.isZip && !uploadOpts.urls.checksum.IsSet() {
 		// preserve new metadata and save existing ones.
 		if uploadOpts.preserve {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, uploadOpts.urls)
@@ -425,14 +557,32 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			reader  io.ReadCloser
 		)
 
-		reader, content, err = getSourceStream(ctx, sourceAlias, sourceURL.String(), getSourceOpts{
+		getOpts := getSourceOpts{
 			GetOptions: GetOptions{
 				VersionID: sourceVersion,
 				SSE:       srcSSE,
 				Zip:       uploadOpts.isZip,
 				Preserve:  uploadOpts.preserve,
 			},
-		})
+			sourceDigest: uploadOpts.sourceDigest,
+		}
+		// Only plain downloads of objects of a known size can be resumed.
+		resume := uploadOpts.resume && targetURL.Type == fileSystem && sourceURL.Type == objectStorage &&
+			!isHTTPSource && length > 0 && uploadOpts.cseKey == nil && !uploadOpts.isZip
+		if resume {
+			var offset int64
+			reader, content, offset, err = getResumableSourceStream(ctx, sourceAlias, sourceURL.String(), getOpts,
+				targetURL.Path+partSuffix, length, uploadOpts.urls.SourceContent.ETag)
+			if err == nil && offset > 0 {
+				length -= offset
+				if uploadOpts.progress != nil {
+					// Account for the already downloaded data.
+					io.CopyN(io.Discard, uploadOpts.progress, offset)
+				}
+			}
+		} else {
+			reader, content, err = getSourceStream(ctx, sourceAlias, sourceURL.String(), getOpts)
+		}
 		if err != nil {
 			return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
 		}
@@ -460,6 +610,29 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			metadata[http.CanonicalHeaderKey(k)] = v
 		}
 
+		// Decrypt client-side encrypted sources and encrypt what is
+		// uploaded to object storage.
+		var source io.Reader = reader
+		if uploadOpts.cseKey != nil {
+			if isCSEEncrypted(content.Metadata) {
+				source, length, err = cseDecrypt(uploadOpts.cseKey, source, content.Metadata)
+				if err != nil {
+					return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+				}
+				removeCSEMetadata(metadata)
+			}
+			if targetURL.Type == objectStorage {
+				var cseMetadata map[string]string
+				source, length, cseMetadata, err = cseEncrypt(uploadOpts.cseKey, source, length)
+				if err != nil {
+					return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+				}
+				for k, v := range cseMetadata {
+					metadata[k] = v
+				}
+			}
+		}
+
 		var e error
 		var multipartSize uint64
 		var multipartThreads int
@@ -496,14 +669,32 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			multipartThreads: uint(multipartThreads),
 			ifNotExists:      uploadOpts.ifNotExists,
 			checksum:         uploadOpts.urls.checksum,
+			resume:           resume,
 		}
 
-		if isReadAt(reader) || length == 0 {
+		switch {
+		case uploadOpts.smallFileThreshold > 0 && length >= 0 && length <= uploadOpts.smallFileThreshold && uploadOpts.cseKey == nil && !resume:
+			// Per object overhead dominates the copy of small files,
+			// read them in a pooled buffer and report their progress
+			// once uploaded.
+			var buf []byte
+			var release func()
+			buf, release, err = readSmallFile(source, length)
+			if err != nil {
+				return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+			}
+			defer release()
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
-				legalHold, reader, length, uploadOpts.progress, putOpts)
-		} else {
+				legalHold, bytes.NewReader(buf), length, nil, putOpts)
+			if err == nil && uploadOpts.progress != nil {
+				io.CopyN(io.Discard, uploadOpts.progress, length)
+			}
+		case isReadAt(source) || length <= 0:
+			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+				legalHold, source, length, uploadOpts.progress, putOpts)
+		default:
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
-				legalHold, io.LimitReader(reader, length), length, uploadOpts.progress, putOpts)
+				legalHold, io.LimitReader(source, length), length, uploadOpts.progress, putOpts)
 		}
 	}
 	if err != nil {
@@ -579,4 +770,9 @@ type uploadSourceToTargetURLOpts struct {
 	multipartThreads    string
 	updateProgressTotal bool
 	ifNotExists         bool
+	preserveLock        bool
+	sourceDigest        *sourceDigest
+	cseKey              []byte
+	resume              bool
+	smallFileThreshold  int64
 }
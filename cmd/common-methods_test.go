@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestIsObjectLockNotConfigured(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: minio.ErrorResponse{Code: "NoSuchObjectLockConfiguration"}, expected: true},
+		{err: minio.ErrorResponse{Code: "ObjectLockConfigurationNotFoundError"}, expected: true},
+		{err: minio.ErrorResponse{Code: "InvalidRequest", Message: "Bucket is missing ObjectLockConfiguration"}, expected: true},
+		{err: minio.ErrorResponse{Code: "InvalidRequest", Message: "Invalid Request"}, expected: false},
+		{err: minio.ErrorResponse{Code: "InvalidRequest"}, expected: false},
+		{err: minio.ErrorResponse{Code: "AccessDenied"}, expected: false},
+		{err: errors.New("connection reset"), expected: false},
+	}
+
+	for i, testCase := range testCases {
+		if got := isObjectLockNotConfigured(probe.NewError(testCase.err)); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestGetResumableSourceStream(t *testing.T) {
+	// Local paths only, no aliases are configured.
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	data := bytes.Repeat([]byte("0123456789abcdef"), 1<<17) // 2 MiB
+	source := filepath.Join(dir, "source")
+	if e := os.WriteFile(source, data, 0o644); e != nil {
+		t.Fatal(e)
+	}
+	corrupted := bytes.Clone(data[:1<<20])
+	corrupted[len(corrupted)-1] = 'x'
+
+	testCases := []struct {
+		partial []byte
+		offset  int64
+	}{
+		// No partial download.
+		{nil, 0},
+		// Matching partial download, resumed.
+		{data[:1<<20], 1 << 20},
+		// Partial download smaller than the overlap, resumed.
+		{data[:100], 100},
+		// Partial download different from the source, restarted.
+		{corrupted, 0},
+		// Partial download as big as the source, restarted.
+		{data, 0},
+	}
+	for i, testCase := range testCases {
+		partPath := filepath.Join(dir, "target"+partSuffix)
+		os.Remove(partPath)
+		if testCase.partial != nil {
+			if e := os.WriteFile(partPath, testCase.partial, 0o644); e != nil {
+				t.Fatal(e)
+			}
+		}
+		reader, _, offset, err := getResumableSourceStream(context.Background(), "", source, getSourceOpts{}, partPath, int64(len(data)), "")
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		rest, e := io.ReadAll(reader)
+		reader.Close()
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if offset != testCase.offset {
+			t.Fatalf("Test %d: expected offset %d, got %d", i+1, testCase.offset, offset)
+		}
+		if !bytes.Equal(rest, data[offset:]) {
+			t.Fatalf("Test %d: expected the source from offset %d, got %d bytes", i+1, offset, len(rest))
+		}
+		if _, e = os.Stat(partPath); (offset == 0) != os.IsNotExist(e) {
+			t.Fatalf("Test %d: unexpected partial download state, offset %d, stat error %v", i+1, offset, e)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+)
+
+// connStats counts the connections used by the clients of a run, they
+// are printed with --debug to help tuning the connection flags.
+type connStats struct {
+	requests      atomic.Int64
+	newConns      atomic.Int64
+	reusedConns   atomic.Int64
+	tlsHandshakes atomic.Int64
+	tlsResumed    atomic.Int64
+	http2Conns    atomic.Int64
+}
+
+var globalConnStats connStats
+
+// addTLSConn records a new TLS connection.
+func (s *connStats) addTLSConn(state tls.ConnectionState) {
+	s.tlsHandshakes.Add(1)
+	if state.DidResume {
+		s.tlsResumed.Add(1)
+	}
+	if state.NegotiatedProtocol == "h2" {
+		s.http2Conns.Add(1)
+	}
+}
+
+func (s *connStats) String() string {
+	return fmt.Sprintf("Connections: %d requests, %d new connections, %d reused connections, %d TLS handshakes (%d resumed), %d HTTP/2 connections",
+		s.requests.Load(), s.newConns.Load(), s.reusedConns.Load(), s.tlsHandshakes.Load(), s.tlsResumed.Load(), s.http2Conns.Load())
+}
+
+// connStatsTransport records whether requests got a new or an idle connection.
+type connStatsTransport struct {
+	transport http.RoundTripper
+}
+
+func (t connStatsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	globalConnStats.requests.Add(1)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				globalConnStats.reusedConns.Add(1)
+			} else {
+				globalConnStats.newConns.Add(1)
+			}
+		},
+	}
+	return t.transport.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConnStatsTransport(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Force a new connection for every HTTP/1.1 request.
+		if r.ProtoMajor == 1 {
+			w.Header().Set("Connection", "close")
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	testCases := []struct {
+		name                           string
+		http2                          bool
+		sessionCache                   tls.ClientSessionCache
+		newConns, reused               int64
+		handshakes, resumed, http2Conn int64
+	}{
+		{"http1", false, nil, 2, 0, 2, 0, 0},
+		{"http1 resumed", false, tls.NewLRUClientSessionCache(8), 2, 0, 2, 1, 0},
+		{"http2", true, nil, 1, 1, 1, 0, 1},
+	}
+
+	defer func(http2 bool, cache tls.ClientSessionCache) {
+		globalHTTP2, globalTLSSessionCache = http2, cache
+	}(globalHTTP2, globalTLSSessionCache)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			globalHTTP2, globalTLSSessionCache = tc.http2, tc.sessionCache
+			config := &Config{HostURL: server.URL, Insecure: true}
+			transport := connStatsTransport{transport: config.getTransport()}
+
+			newConns, reused := globalConnStats.newConns.Load(), globalConnStats.reusedConns.Load()
+			handshakes, resumed, http2Conns := globalConnStats.tlsHandshakes.Load(), globalConnStats.tlsResumed.Load(), globalConnStats.http2Conns.Load()
+			for i := 0; i < 2; i++ {
+				req, e := http.NewRequest(http.MethodGet, server.URL, nil)
+				if e != nil {
+					t.Fatal(e)
+				}
+				resp, e := transport.RoundTrip(req)
+				if e != nil {
+					t.Fatal(e)
+				}
+				resp.Body.Close()
+			}
+
+			if n := globalConnStats.newConns.Load() - newConns; n != tc.newConns {
+				t.Errorf("expected %d new connections, got %d", tc.newConns, n)
+			}
+			if n := globalConnStats.reusedConns.Load() - reused; n != tc.reused {
+				t.Errorf("expected %d reused connections, got %d", tc.reused, n)
+			}
+			if n := globalConnStats.tlsHandshakes.Load() - handshakes; n != tc.handshakes {
+				t.Errorf("expected %d TLS handshakes, got %d", tc.handshakes, n)
+			}
+			if n := globalConnStats.tlsResumed.Load() - resumed; n != tc.resumed {
+				t.Errorf("expected %d resumed TLS sessions, got %d", tc.resumed, n)
+			}
+			if n := globalConnStats.http2Conns.Load() - http2Conns; n != tc.http2Conn {
+				t.Errorf("expected %d HTTP/2 connections, got %d", tc.http2Conn, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// httpSourceMaxRetries is the number of times a broken download of an
+// HTTP(S) source is resumed with a Range request before giving up.
+const httpSourceMaxRetries = 5
+
+// isHTTPSourceURL returns true if urlStr is a plain HTTP(S) URL, which
+// does not refer to any configured alias.
+func isHTTPSourceURL(urlStr string) bool {
+	if !urlRgx.MatchString(urlStr) {
+		return false
+	}
+	_, _, aliasCfg := mustExpandAlias(urlStr)
+	return aliasCfg == nil
+}
+
+// sourceDigest is the expected digest of an HTTP(S) source, as
+// passed with --source-digest ALGORITHM:HEX.
+type sourceDigest struct {
+	algorithm string
+	sum       []byte
+}
+
+func parseSourceDigest(s string) (*sourceDigest, *probe.Error) {
+	algorithm, sumHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, probe.NewError(errors.New("digest must be in ALGORITHM:HEX format")).Trace(s)
+	}
+	d := &sourceDigest{algorithm: strings.ToLower(algorithm)}
+	if d.newHash() == nil {
+		return nil, probe.NewError(errors.New("unsupported digest algorithm, allowed values are [md5, sha1, sha256, sha512]")).Trace(s)
+	}
+	sum, e := hex.DecodeString(sumHex)
+	if e != nil || len(sum) != d.newHash().Size() {
+		return nil, probe.NewError(errors.New("invalid " + d.algorithm + " digest")).Trace(s)
+	}
+	d.sum = sum
+	return d, nil
+}
+
+func (d *sourceDigest) newHash() hash.Hash {
+	switch d.algorithm {
+	case "md5":
+		return md5.New()
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "sha512":
+		return sha512.New()
+	}
+	return nil
+}
+
+// statHTTPSource returns the size, modification time and content type of
+// an HTTP(S) source. Servers rejecting HEAD, such as presigned GET URLs,
+// are asked for the first byte instead.
+func statHTTPSource(ctx context.Context, urlStr string) (*ClientContent, *probe.Error) {
+	client := httpClient(0)
+	req, e := http.NewRequestWithContext(ctx, http.MethodHead, urlStr, nil)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(urlStr)
+	}
+	resp, e := client.Do(req)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(urlStr)
+	}
+	resp.Body.Close()
+	size := resp.ContentLength
+	if resp.StatusCode != http.StatusOK {
+		req, e = http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(urlStr)
+		}
+		req.Header.Set("Range", "bytes=0-0")
+		resp, e = client.Do(req)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(urlStr)
+		}
+		resp.Body.Close()
+		switch resp.StatusCode {
+		case http.StatusOK:
+			size = resp.ContentLength
+		case http.StatusPartialContent:
+			size = parseContentRangeSize(resp.Header.Get("Content-Range"))
+		default:
+			return nil, probe.NewError(errors.New("unexpected HTTP status " + resp.Status)).Trace(urlStr)
+		}
+	}
+
+	content := &ClientContent{
+		URL:      *newClientURL(urlStr),
+		Size:     size,
+		Type:     os.FileMode(0o644),
+		ETag:     strings.Trim(resp.Header.Get("ETag"), "\""),
+		Metadata: map[string]string{},
+	}
+	if t, e := http.ParseTime(resp.Header.Get("Last-Modified")); e == nil {
+		content.Time = t.UTC()
+	} else {
+		content.Time = UTCNow()
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		content.Metadata["Content-Type"] = contentType
+	}
+	return content, nil
+}
+
+// parseContentRangeSize returns the complete length from a Content-Range
+// header such as "bytes 0-0/1234", -1 if it is unknown.
+func parseContentRangeSize(contentRange string) int64 {
+	_, sizeStr, ok := strings.Cut(contentRange, "/")
+	if !ok {
+		return -1
+	}
+	size, e := strconv.ParseInt(sizeStr, 10, 64)
+	if e != nil {
+		return -1
+	}
+	return size
+}
+
+// httpSourceName returns the object name to use for an HTTP(S) source
+// copied into a folder.
+func httpSourceName(urlStr string) string {
+	u, e := url.Parse(urlStr)
+	if e != nil || path.Base(u.Path) == "/" || path.Base(u.Path) == "." {
+		return "index.html"
+	}
+	return path.Base(u.Path)
+}
+
+// prepareHTTPCopyURLs prepares the copy of an HTTP(S) source to targetURL,
+// the source name is appended to the target if it is a folder.
+func prepareHTTPCopyURLs(ctx context.Context, sourceURL, targetURL string, isTargetDir bool) URLs {
+	sourceContent, err := statHTTPSource(ctx, sourceURL)
+	if err != nil {
+		return URLs{Error: err.Trace(sourceURL)}
+	}
+	cc := copyURLsContent{sourceContent: sourceContent}
+	cc.targetAlias, cc.targetURL, _ = mustExpandAlias(targetURL)
+	if isTargetDir {
+		targetURLParse := newClientURL(cc.targetURL)
+		targetURLParse.Path = path.Join(targetURLParse.Path, httpSourceName(sourceURL))
+		cc.targetURL = targetURLParse.String()
+	}
+	return makeCopyContentTypeA(cc)
+}
+
+// httpSourceReader streams an HTTP(S) source, resuming the download with
+// a Range request when the connection breaks, and verifies its digest.
+type httpSourceReader struct {
+	ctx       context.Context
+	client    *http.Client
+	url       string
+	validator string // ETag or Last-Modified used as If-Range when resuming.
+	size      int64
+	offset    int64
+	retries   int
+	body      io.ReadCloser
+
+	digest   *sourceDigest
+	hash     hash.Hash
+	verified bool
+}
+
+// getHTTPSourceStream returns a reader for an HTTP(S) source.
+func getHTTPSourceStream(ctx context.Context, urlStr string, digest *sourceDigest) (io.ReadCloser, *ClientContent, *probe.Error) {
+	r := &httpSourceReader{
+		ctx:    ctx,
+		client: httpClient(0),
+		url:    urlStr,
+		size:   -1,
+		digest: digest,
+	}
+	if digest != nil {
+		r.hash = digest.newHash()
+	}
+	resp, e := r.open()
+	if e != nil {
+		return nil, nil, probe.NewError(e).Trace(urlStr)
+	}
+	content := &ClientContent{
+		URL:      *newClientURL(urlStr),
+		Size:     resp.ContentLength,
+		Type:     os.FileMode(0o644),
+		ETag:     strings.Trim(resp.Header.Get("ETag"), "\""),
+		Metadata: map[string]string{},
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		content.Metadata["Content-Type"] = contentType
+	}
+	r.size = resp.ContentLength
+	if resp.Header.Get("Accept-Ranges") == "bytes" {
+		r.validator = resp.Header.Get("ETag")
+		if r.validator == "" || strings.HasPrefix(r.validator, "W/") {
+			r.validator = resp.Header.Get("Last-Modified")
+		}
+	}
+	return r, content, nil
+}
+
+// open sends a GET request from the current offset.
+func (r *httpSourceReader) open() (*http.Response, error) {
+	req, e := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if e != nil {
+		return nil, e
+	}
+	if r.offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(r.offset, 10)+"-")
+		req.Header.Set("If-Range", r.validator)
+	}
+	resp, e := r.client.Do(req)
+	if e != nil {
+		return nil, e
+	}
+	if (r.offset == 0 && resp.StatusCode != http.StatusOK) ||
+		(r.offset > 0 && resp.StatusCode != http.StatusPartialContent) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	r.body = resp.Body
+	return resp, nil
+}
+
+func (r *httpSourceReader) Read(p []byte) (n int, e error) {
+	for {
+		if r.body == nil {
+			if _, e = r.open(); e != nil {
+				return 0, e
+			}
+		}
+		n, e = r.body.Read(p)
+		r.offset += int64(n)
+		if r.hash != nil {
+			r.hash.Write(p[:n])
+		}
+		if e == io.EOF && r.size >= 0 && r.offset < r.size {
+			e = io.ErrUnexpectedEOF
+		}
+		if e == nil || e == io.EOF {
+			if e == io.EOF || (r.size >= 0 && r.offset >= r.size) {
+				if ve := r.verify(); ve != nil {
+					return n, ve
+				}
+			}
+			return n, e
+		}
+
+		// The connection broke, resume from the current offset if possible.
+		r.body.Close()
+		r.body = nil
+		if r.validator == "" || r.retries >= httpSourceMaxRetries || r.ctx.Err() != nil {
+			return n, e
+		}
+		r.retries++
+		if n > 0 {
+			return n, nil
+		}
+		select {
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		case <-time.After(time.Duration(r.retries) * time.Second):
+		}
+	}
+}
+
+// verify compares the digest of the downloaded data with the expected one.
+func (r *httpSourceReader) verify() error {
+	if r.hash == nil || r.verified {
+		return nil
+	}
+	r.verified = true
+	if sum := r.hash.Sum(nil); !bytes.Equal(sum, r.digest.sum) {
+		return fmt.Errorf("%s digest mismatch for `%s`: expected %x, got %x", r.digest.algorithm, r.url, r.digest.sum, sum)
+	}
+	return nil
+}
+
+func (r *httpSourceReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
+
+// prepareCopyHTTPSources prepares the copy of HTTP(S) sources, followed by
+// the remaining alias and filesystem sources of the command.
+func prepareCopyHTTPSources(ctx context.Context, httpSourceURLs []string, o prepareCopyURLsOpts) <-chan URLs {
+	copyURLsCh := make(chan URLs)
+	go func() {
+		defer close(copyURLsCh)
+		isTargetDir, _ := isAliasURLDir(ctx, o.targetURL, o.encKeyDB, time.Time{}, false)
+		if !isTargetDir && len(httpSourceURLs)+len(o.sourceURLs) > 1 {
+			copyURLsCh <- URLs{Error: errInvalidTarget(o.targetURL).Trace(o.targetURL)}
+			return
+		}
+		for _, sourceURL := range httpSourceURLs {
+			copyURLsCh <- prepareHTTPCopyURLs(ctx, sourceURL, o.targetURL, isTargetDir)
+		}
+		if len(o.sourceURLs) > 0 {
+			for cpURLs := range prepareCopyURLs(ctx, o) {
+				copyURLsCh <- cpURLs
+			}
+		}
+	}()
+	return copyURLsCh
+}
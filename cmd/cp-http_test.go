@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseSourceDigest(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	testCases := []struct {
+		digest  string
+		success bool
+	}{
+		{"sha256:" + hex.EncodeToString(sum[:]), true},
+		{"SHA256:" + hex.EncodeToString(sum[:]), true},
+		{"md5:5d41402abc4b2a76b9719d911017c592", true},
+		{"sha256:5d41402abc4b2a76b9719d911017c592", false},
+		{"crc32:5d41402a", false},
+		{hex.EncodeToString(sum[:]), false},
+		{"sha1:not-hex", false},
+	}
+	for i, testCase := range testCases {
+		_, err := parseSourceDigest(testCase.digest)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestParseContentRangeSize(t *testing.T) {
+	testCases := []struct {
+		contentRange string
+		size         int64
+	}{
+		{"bytes 0-0/1234", 1234},
+		{"bytes 0-0/*", -1},
+		{"", -1},
+	}
+	for i, testCase := range testCases {
+		if size := parseContentRangeSize(testCase.contentRange); size != testCase.size {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.size, size)
+		}
+	}
+}
+
+func TestHTTPSourceName(t *testing.T) {
+	testCases := []struct {
+		url  string
+		name string
+	}{
+		{"https://example.com/releases/file.iso", "file.iso"},
+		{"https://example.com/releases/my%20file.iso?X-Amz-Signature=abc", "my file.iso"},
+		{"https://example.com/", "index.html"},
+		{"https://example.com", "index.html"},
+	}
+	for i, testCase := range testCases {
+		if name := httpSourceName(testCase.url); name != testCase.name {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.name, name)
+		}
+	}
+}
+
+// newFlakyHTTPSource serves data, breaking the connection in the middle of
+// the first download. HEAD requests are rejected as for presigned URLs.
+func newFlakyHTTPSource(data []byte) (*httptest.Server, *int32) {
+	var requests int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if atomic.AddInt32(&requests, 1) == 2 {
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusOK)
+			w.Write(data[:len(data)/2])
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	})), &requests
+}
+
+func TestHTTPSourceStream(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10000)
+	sum := sha256.Sum256(data)
+
+	server, requests := newFlakyHTTPSource(data)
+	defer server.Close()
+
+	content, err := statHTTPSource(context.Background(), server.URL+"/dir/data.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Size != int64(len(data)) || content.ETag != "v1" {
+		t.Fatalf("unexpected stat size %d, etag %q", content.Size, content.ETag)
+	}
+
+	digest, err := parseSourceDigest("sha256:" + hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, _, err := getHTTPSourceStream(context.Background(), server.URL+"/dir/data.bin", digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, e := io.ReadAll(reader)
+	reader.Close()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("expected %d bytes, got %d", len(data), len(got))
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Fatalf("expected the download to be resumed once, got %d requests", n)
+	}
+
+	// A wrong digest fails the download.
+	digest.sum[0] ^= 0xff
+	reader, _, err = getHTTPSourceStream(context.Background(), server.URL+"/dir/data.bin", digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, e = io.ReadAll(reader); e == nil {
+		t.Fatal("expected a digest mismatch error")
+	}
+	reader.Close()
+}
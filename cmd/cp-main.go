@@ -24,6 +24,7 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -93,11 +94,29 @@ var (
 			Name:  lhFlag,
 			Usage: "apply legal hold to the copied object (on, off)",
 		},
+		cli.BoolFlag{
+			Name:  "restore",
+			Usage: "restore archived source object(s) for a day with the standard tier and wait before copying them",
+		},
+		cli.BoolFlag{
+			Name:  "preserve-lock",
+			Usage: "apply the retention and legal hold of the source object(s) to the copied object(s)",
+		},
 		cli.BoolFlag{
 			Name:  "zip",
 			Usage: "Extract from remote zip file (MinIO server source only)",
 		},
+		cli.BoolFlag{
+			Name:  "resume",
+			Usage: "resume partial downloads to a local filesystem left by a previous run",
+		},
+		cli.StringFlag{
+			Name:  "source-digest",
+			Usage: "verify a single HTTP(S) source against a digest in ALGORITHM:HEX format (md5, sha1, sha256, sha512)",
+		},
 		checksumFlag,
+		smallFileThresholdFlag,
+		verboseObjectsFlag,
 	}
 )
 
@@ -117,7 +136,7 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, encFlags...), globalFlags...),
+	Flags:        append(append(append(cpFlags, cseKeyFlag, filterFromFlag), encFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -190,6 +209,35 @@ EXAMPLES:
   19. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod&type=backup" ./data/ play/another-bucket/
 
+  20. Copy objects between locked buckets keeping their retention and legal hold.
+      {{.Prompt}} {{.HelpName}} -r --preserve-lock play/locked-bucket/ s3/locked-bucket/
+
+  21. Copy objects archived in AWS S3 Glacier, restoring them first when needed.
+      {{.Prompt}} {{.HelpName}} -r --restore s3/archive-bucket/2019/ ./2019/
+
+  22. Download a remote file over HTTPS directly into a bucket, verifying its SHA256 digest.
+      {{.Prompt}} {{.HelpName}} --source-digest sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 \
+          https://example.com/releases/file.iso play/mybucket/
+
+  23. Encrypt files on the client side before uploading them, the key is read from MC_CSE_KEY.
+      {{.Prompt}} export MC_CSE_KEY=MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA
+      {{.Prompt}} {{.HelpName}} -r ./private/ play/mybucket/private/
+
+  24. Download and decrypt client-side encrypted objects.
+      {{.Prompt}} {{.HelpName}} -r --cse-key MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA play/mybucket/private/ ./private/
+
+  25. Download large objects, resuming the partial downloads of an interrupted previous run.
+      {{.Prompt}} {{.HelpName}} -r --resume play/mybucket/isos/ ./isos/
+
+  26. Copy a local folder recursively, selecting files with the ordered include/exclude rules of an rsync/rclone filter file.
+      {{.Prompt}} {{.HelpName}} -r --filter-from ~/backup-filters.txt ~/documents/ play/mybucket/documents/
+
+  27. Copy a folder of millions of small files, uploading the files up to 256KiB through the small file fast path.
+      {{.Prompt}} {{.HelpName}} -r --small-file-threshold 256KiB ~/thumbnails/ play/mybucket/thumbnails/
+
+  28. Copy a bucket in JSON mode printing a line for every object, instead of one per second past the first 1000 objects.
+      {{.Prompt}} {{.HelpName}} -r --json --verbose-objects play/mybucket/ s3/mybucket/
+
 `,
 }
 
@@ -201,10 +249,14 @@ type copyMessage struct {
 	Size       int64  `json:"size"`
 	TotalCount int64  `json:"totalCount"`
 	TotalSize  int64  `json:"totalSize"`
+	DryRun     bool   `json:"dryRun,omitempty"`
 }
 
 // String colorized copy message
 func (c copyMessage) String() string {
+	if c.DryRun {
+		return console.Colorize("Copy", fmt.Sprintf("DRYRUN: `%s` -> `%s`", c.Source, c.Target))
+	}
 	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target))
 }
 
@@ -244,10 +296,14 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 	targetURL := copyOpts.cpURLs.TargetContent.URL
 	length := copyOpts.cpURLs.SourceContent.Size
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
+	if sourceAlias == "" && sourceURL.Type == objectStorage {
+		// Plain HTTP(S) source.
+		sourcePath = sourceURL.String()
+	}
 
-	if progressReader, ok := copyOpts.pg.(*progressBar); ok {
+	if progressReader, ok := copyOpts.pg.(*progressBar); ok && !globalDryRun {
 		progressReader.SetCaption(copyOpts.cpURLs.SourceContent.URL.String() + ":")
-	} else {
+	} else if globalDryRun || copyOpts.objectLog.shouldLog(time.Now()) {
 		targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
 		printMsg(copyMessage{
 			Source:     sourcePath,
@@ -255,10 +311,16 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 			Size:       length,
 			TotalCount: copyOpts.cpURLs.TotalCount,
 			TotalSize:  copyOpts.cpURLs.TotalSize,
+			DryRun:     globalDryRun,
 		})
 	}
 
-	urls := uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{
+	// Only report what would be copied.
+	if globalDryRun {
+		return doCopyFake(copyOpts.cpURLs, copyOpts.pg)
+	}
+
+	uploadOpts := uploadSourceToTargetURLOpts{
 		urls:                copyOpts.cpURLs,
 		progress:            copyOpts.pg,
 		encKeyDB:            copyOpts.encryptionKeys,
@@ -266,9 +328,24 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 		isZip:               copyOpts.isZip,
 		multipartSize:       copyOpts.multipartSize,
 		multipartThreads:    copyOpts.multipartThreads,
+		smallFileThreshold:  copyOpts.smallFileThreshold,
 		updateProgressTotal: copyOpts.updateProgressTotal,
 		ifNotExists:         copyOpts.ifNotExists,
-	})
+		preserveLock:        copyOpts.preserveLock,
+		sourceDigest:        copyOpts.sourceDigest,
+		cseKey:              copyOpts.cseKey,
+		resume:              copyOpts.resume,
+	}
+	urls := uploadSourceToTargetURL(ctx, uploadOpts)
+	if copyOpts.restore && urls.Error != nil && isObjectArchived(urls.Error) {
+		// Restore the archived source object and copy it again.
+		err := restoreAndWaitObject(ctx, sourceAlias, sourceURL.String(), copyOpts.cpURLs.SourceContent.VersionID, 1, minio.TierStandard, copyOpts.encryptionKeys)
+		if err != nil {
+			urls.Error = err.Trace(sourceURL.String())
+		} else {
+			urls = uploadSourceToTargetURL(ctx, uploadOpts)
+		}
+	}
 	if copyOpts.isMvCmd && urls.Error == nil {
 		rmManager.add(ctx, sourceAlias, sourceURL.String())
 	}
@@ -313,7 +390,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	var pg ProgressReader
 
 	// Enable progress bar reader only during default mode.
-	if !globalQuiet && !globalJSON { // set up progress bar
+	if !globalQuiet && !globalJSON && !globalDryRun { // set up progress bar
 		pg = newProgressBar(totalBytes)
 	} else {
 		pg = newAccounter(totalBytes)
@@ -321,18 +398,54 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	sourceURLs := cli.Args()[:len(cli.Args())-1]
 	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
 
+	// Plain HTTP(S) sources are fetched directly, separately from the
+	// alias and filesystem ones.
+	var httpSourceURLs, otherSourceURLs []string
+	for _, sourceURL := range sourceURLs {
+		if !isHTTPSourceURL(sourceURL) {
+			otherSourceURLs = append(otherSourceURLs, sourceURL)
+			continue
+		}
+		if isMvCmd {
+			fatalIf(errInvalidArgument().Trace(sourceURL), "Unable to move from an HTTP(S) source.")
+		}
+		httpSourceURLs = append(httpSourceURLs, sourceURL)
+	}
+	sourceURLs = otherSourceURLs
+	var srcDigest *sourceDigest
+	if digest := cli.String("source-digest"); digest != "" {
+		var err *probe.Error
+		srcDigest, err = parseSourceDigest(digest)
+		fatalIf(err, "Unable to parse --source-digest.")
+	}
+	smallFileThreshold, err := parseSmallFileThreshold(cli.String("small-file-threshold"))
+	fatalIf(err.Trace(cli.String("small-file-threshold")), "Unable to parse --small-file-threshold, it takes a size up to 16MiB.")
+	objectLog := newObjectLogger(cli.Bool("verbose-objects"))
+	var cseKey []byte
+	if key := cli.String("cse-key"); key != "" {
+		var err *probe.Error
+		cseKey, err = parseCSEKey(key)
+		fatalIf(err, "Unable to parse --cse-key.")
+	}
+
 	// Check if the target path has object locking enabled
 	withLock, _ := isBucketLockEnabled(ctx, targetURL)
+	preserveLock := cli.Bool("preserve-lock")
+	if preserveLock && !withLock {
+		fatalIf(errInvalidArgument().Trace(targetURL), "--preserve-lock requires object locking enabled on the target bucket.")
+	}
 
 	isRecursive := cli.Bool("recursive")
 	olderThan := cli.String("older-than")
 	newerThan := cli.String("newer-than")
 	rewind := cli.String("rewind")
 	versionID := cli.String("version-id")
+	filterRules, pErr := loadFilterRules(cli.String("filter-from"))
+	fatalIf(pErr.Trace(cli.String("filter-from")), "Unable to load filter rules.")
 	md5, checksum := parseChecksum(cli)
-	if withLock {
-		// The Content-MD5 header is required for any request to upload an object with a retention period configured using Amazon S3 Object Lock.
-		md5, checksum = true, minio.ChecksumNone
+	if withLock && !checksum.IsSet() {
+		// The Content-MD5 header, or a checksum, is required for any request to upload an object with a retention period configured using Amazon S3 Object Lock.
+		md5 = true
 	}
 
 	go func() {
@@ -344,19 +457,28 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 			encKeyDB:    encryptionKeys,
 			olderThan:   olderThan,
 			newerThan:   newerThan,
+			filterRules: filterRules,
 			timeRef:     parseRewindFlag(rewind),
 			versionID:   versionID,
 			isZip:       cli.Bool("zip"),
 		}
 
-		for cpURLs := range prepareCopyURLs(ctx, opts) {
+		var urlsCh <-chan URLs
+		if len(httpSourceURLs) > 0 {
+			urlsCh = prepareCopyHTTPSources(ctx, httpSourceURLs, opts)
+		} else {
+			urlsCh = prepareCopyURLs(ctx, opts)
+		}
+		for cpURLs := range urlsCh {
 			if cpURLs.Error != nil {
 				errSeen = true
 				printCopyURLsError(&cpURLs)
 				break
 			}
 
-			totalBytes += cpURLs.SourceContent.Size
+			if cpURLs.SourceContent.Size > 0 {
+				totalBytes += cpURLs.SourceContent.Size
+			}
 			pg.SetTotal(totalBytes)
 			totalObjects++
 			cpURLsCh <- cpURLs
@@ -366,7 +488,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 
 	quitCh := make(chan struct{})
 	statusCh := make(chan URLs)
-	parallel := newParallelManager(statusCh)
+	parallel := newParallelManager(statusCh, smallFileWorkers(smallFileThreshold))
 
 	go func() {
 		gracefulStop := func() {
@@ -440,14 +562,21 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 					// Print the copy resume summary once in start
 					parallel.queueTask(func() URLs {
 						return doCopy(ctx, doCopyOpts{
-							cpURLs:         cpURLs,
-							pg:             pg,
-							encryptionKeys: encryptionKeys,
-							isMvCmd:        isMvCmd,
-							preserve:       preserve,
-							isZip:          isZip,
+							cpURLs:             cpURLs,
+							pg:                 pg,
+							encryptionKeys:     encryptionKeys,
+							isMvCmd:            isMvCmd,
+							preserve:           preserve,
+							isZip:              isZip,
+							preserveLock:       preserveLock,
+							restore:            cli.Bool("restore"),
+							sourceDigest:       srcDigest,
+							cseKey:             cseKey,
+							resume:             cli.Bool("resume"),
+							smallFileThreshold: smallFileThreshold,
+							objectLog:          objectLog,
 						})
-					}, cpURLs.SourceContent.Size)
+					}, max(cpURLs.SourceContent.Size, 0))
 				}
 			}
 		}
@@ -525,6 +654,7 @@ loop:
 					console.Eraseline()
 				}
 			} else {
+				objectLog.printSkipped()
 				printMsg(accntReader.Stat())
 			}
 		}
@@ -566,5 +696,12 @@ type doCopyOpts struct {
 	updateProgressTotal      bool
 	multipartSize            string
 	multipartThreads         string
+	smallFileThreshold       int64
 	ifNotExists              bool
+	preserveLock             bool
+	restore                  bool
+	sourceDigest             *sourceDigest
+	cseKey                   []byte
+	resume                   bool
+	objectLog                *objectLogger
 }
@@ -49,6 +49,28 @@ func checkCopySyntax(cliCtx *cli.Context) {
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "--zip and --rewind cannot be used together")
 	}
 
+	var httpSources int
+	for _, srcURL := range srcURLs {
+		if isHTTPSourceURL(srcURL) {
+			httpSources++
+		}
+	}
+	if httpSources > 0 {
+		if cliCtx.Bool("recursive") || cliCtx.String("rewind") != "" || versionID != "" || isZip ||
+			cliCtx.String("older-than") != "" || cliCtx.String("newer-than") != "" ||
+			cliCtx.Bool("preserve-lock") || cliCtx.Bool("restore") || cliCtx.String("filter-from") != "" {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...),
+				"--recursive, --rewind, --version-id, --zip, --older-than, --newer-than, --preserve-lock, --restore and --filter-from cannot be used with HTTP(S) sources.")
+		}
+	}
+	if cliCtx.String("source-digest") != "" {
+		if httpSources != 1 || len(srcURLs) != 1 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--source-digest requires a single HTTP(S) source.")
+		}
+		_, err := parseSourceDigest(cliCtx.String("source-digest"))
+		fatalIf(err, "Unable to parse --source-digest.")
+	}
+
 	// Check if bucket name is passed for URL type arguments.
 	url := newClientURL(tgtURL)
 	if url.Host != "" {
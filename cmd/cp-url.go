@@ -312,6 +312,7 @@ type prepareCopyURLsOpts struct {
 	isRecursive             bool
 	encKeyDB                map[string][]prefixSSEPair
 	olderThan, newerThan    string
+	filterRules             filterRules
 	timeRef                 time.Time
 	versionID               string
 	isZip                   bool
@@ -358,6 +359,12 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 		}
 	}(o)
 
+	var targetPath string
+	if len(o.filterRules) > 0 {
+		_, targetURL, _ := mustExpandAlias(o.targetURL)
+		targetPath = newClientURL(targetURL).Path
+	}
+
 	finalCopyURLsCh := make(chan URLs)
 	go func() {
 		defer close(finalCopyURLsCh)
@@ -376,9 +383,26 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 				continue
 			}
 
+			// Skip objects excluded by --filter-from rules, matched with their
+			// path relative to the target folder.
+			if len(o.filterRules) > 0 && !o.filterRules.isIncluded(copyFilterPath(cpURLs, targetPath)) {
+				continue
+			}
+
 			finalCopyURLsCh <- cpURLs
 		}
 	}()
 
 	return finalCopyURLsCh
 }
+
+// copyFilterPath returns the path of a copied object relative to the target
+// folder, or the source object name when copying to a single target object.
+func copyFilterPath(cpURLs URLs, targetPath string) string {
+	rel := strings.TrimPrefix(cpURLs.TargetContent.URL.Path, targetPath)
+	rel = strings.TrimLeft(rel, string(cpURLs.TargetContent.URL.Separator))
+	if rel == "" {
+		return filepath.Base(cpURLs.SourceContent.URL.Path)
+	}
+	return rel
+}
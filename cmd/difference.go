@@ -161,12 +161,17 @@ func metadataEqual(m1, m2 map[string]string) bool {
 	return true
 }
 
-func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool) (diffCh chan diffMessage) {
+// objectDifference compares the listings of source and target, the source
+// objects are enumerated from the inventory report manifest if set.
+func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool, listParallel int, inventory string) (diffCh chan diffMessage) {
 	sourceURL := sourceClnt.GetURL().String()
-	sourceCh := sourceClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: isMetadata, ShowDir: DirNone})
+	sourceCh := sourceClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: isMetadata, ShowDir: DirNone, Parallel: listParallel, Inventory: inventory})
+	if inventory != "" {
+		sourceCh = sortedListing(ctx, sourceCh)
+	}
 
 	targetURL := targetClnt.GetURL().String()
-	targetCh := targetClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: isMetadata, ShowDir: DirNone})
+	targetCh := targetClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: isMetadata, ShowDir: DirNone, Parallel: listParallel})
 
 	return difference(sourceURL, sourceCh, targetURL, targetCh, isMetadata, false)
 }
@@ -385,7 +390,7 @@ func difference(sourceURL string, sourceCh <-chan *ClientContent, targetURL stri
 		if err != nil {
 			// handle this specifically for filesystem related errors.
 			switch v := err.ToGoError().(type) {
-			case PathNotFound, PathInsufficientPermission, PathNotADirectory:
+			case PathNotFound, PathInsufficientPermission, PathNotADirectory, ListingNotSorted:
 				diffCh <- diffMessage{
 					Error: err,
 				}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+// dryRunCommands lists the mutating commands able to print their
+// intended actions without executing them when --dry-run is set.
+var dryRunCommands = map[string]bool{
+	"cp":                    true,
+	"mv":                    true,
+	"rm":                    true,
+	"rb":                    true,
+	"mirror":                true,
+	"undo":                  true,
+	"anonymous":             true,
+	"tag set":               true,
+	"tag remove":            true,
+	"retention set":         true,
+	"retention clear":       true,
+	"legalhold set":         true,
+	"legalhold clear":       true,
+	"ilm rule add":          true,
+	"ilm rule edit":         true,
+	"ilm rule remove":       true,
+	"ilm rule enable":       true,
+	"ilm rule disable":      true,
+	"ilm rule import":       true,
+	"replicate add":         true,
+	"replicate update":      true,
+	"replicate remove":      true,
+	"replicate import":      true,
+	"admin policy create":   true,
+	"admin policy remove":   true,
+	"admin policy attach":   true,
+	"admin policy detach":   true,
+	"admin group add":       true,
+	"admin group remove":    true,
+	"admin config import":   true,
+	"admin config restore":  true,
+	"admin heal":            true,
+	"admin service restart": true,
+	"admin update":          true,
+	"update":                true,
+}
+
+// isDryRunSupported returns false for the mutating commands which would
+// ignore --dry-run and apply their changes.
+func isDryRunSupported(cmdPath string) bool {
+	return !isMutatingCommand(cmdPath) || dryRunCommands[cmdPath]
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+func TestIsDryRunSupported(t *testing.T) {
+	testCases := []struct {
+		cmdPath   string
+		supported bool
+	}{
+		{"ls", true},
+		{"admin info", true},
+		{"rm", true},
+		{"ilm rule add", true},
+		{"admin policy attach", true},
+		{"mb", false},
+		{"admin user add", false},
+		{"alias set", false},
+	}
+	for _, tc := range testCases {
+		if got := isDryRunSupported(tc.cmdPath); got != tc.supported {
+			t.Errorf("%q: expected %v, got %v", tc.cmdPath, tc.supported, got)
+		}
+	}
+}
+
+// leafCommandPaths returns the full paths of all the leaf commands.
+func leafCommandPaths() map[string]bool {
+	leaves := make(map[string]bool)
+	var walk func(cmds []cli.Command, parent string)
+	walk = func(cmds []cli.Command, parent string) {
+		for _, cmd := range cmds {
+			cmdPath := strings.TrimSpace(parent + " " + cmd.Name)
+			if len(cmd.Subcommands) > 0 {
+				walk(cmd.Subcommands, cmdPath)
+				continue
+			}
+			leaves[cmdPath] = true
+		}
+	}
+	walk(appCmds, "")
+	return leaves
+}
+
+func TestDryRunCommandsExist(t *testing.T) {
+	leaves := leafCommandPaths()
+	for cmdPath := range dryRunCommands {
+		if !leaves[cmdPath] {
+			t.Errorf("dry-run command `%s` not found", cmdPath)
+		}
+	}
+}
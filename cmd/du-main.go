@@ -52,6 +52,9 @@ var (
 			Name:  "versions",
 			Usage: "include all object versions",
 		},
+		filterFromFlag,
+		listParallelFlag,
+		inventoryFlag,
 	}
 )
 
@@ -85,6 +88,15 @@ EXAMPLES:
 
   4. Summarize disk usage of 'jazz-songs' bucket with all objects versions
      {{.Prompt}} {{.HelpName}} --versions s3/jazz-songs/
+
+  5. Summarize disk usage of 'jazz-songs' bucket counting only objects selected by an rsync/rclone filter file
+     {{.Prompt}} {{.HelpName}} --filter-from backup-filters.txt s3/jazz-songs/
+
+  6. Summarize disk usage of a huge bucket, listing 16 of its top-level prefixes at a time
+     {{.Prompt}} {{.HelpName}} --list-parallel 16 s3/jazz-songs/
+
+  7. Summarize disk usage of 'jazz-songs' bucket from an S3 Inventory report instead of listing the bucket
+     {{.Prompt}} {{.HelpName}} --inventory s3/inventory/jazz-songs/daily/2022-01-01T00-00Z/manifest.json s3/jazz-songs/
 `,
 }
 
@@ -119,7 +131,11 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int) (sz, objs int64, err error) {
+// du summarizes the disk usage under urlStr, objects are matched against
+// the filter rules with their path relative to rootPath, which defaults to
+// urlStr itself. S3 listings run listParallel top-level prefixes at a time,
+// or enumerate the objects from the inventory report manifest if set.
+func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int, rules filterRules, listParallel int, inventory, rootPath string) (sz, objs int64, err error) {
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 
 	if !strings.HasSuffix(targetURL, "/") {
@@ -137,12 +153,17 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	recursive := depth == 1
 
 	targetAbsolutePath := path.Clean(clnt.GetURL().String())
+	if rootPath == "" {
+		rootPath = clnt.GetURL().Path
+	}
 
 	contentCh := clnt.List(ctx, ListOptions{
 		TimeRef:           timeRef,
 		WithOlderVersions: withVersions,
 		Recursive:         recursive,
 		ShowDir:           DirFirst,
+		Parallel:          listParallel,
+		Inventory:         inventory,
 	})
 	size := int64(0)
 	objects := int64(0)
@@ -174,14 +195,15 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, n, err := du(ctx, subDirAlias, timeRef, withVersions, depth)
+			used, n, err := du(ctx, subDirAlias, timeRef, withVersions, depth, rules, listParallel, inventory, rootPath)
 			if err != nil {
 				return 0, 0, err
 			}
 			size += used
 			objects += n
 		} else {
-			if !content.IsDeleteMarker && !content.Type.IsDir() {
+			if !content.IsDeleteMarker && !content.Type.IsDir() &&
+				rules.isIncluded(strings.TrimPrefix(content.URL.Path, rootPath)) {
 				size += content.Size
 				objects++
 			}
@@ -236,6 +258,16 @@ func mainDu(cliCtx *cli.Context) error {
 	withVersions := cliCtx.Bool("versions")
 	timeRef := parseRewindFlag(cliCtx.String("rewind"))
 
+	// An inventory report lists all objects at once, only the
+	// total can be summarized from it.
+	inventory := cliCtx.String("inventory")
+	if inventory != "" && (depth != 1 || withVersions || !timeRef.IsZero()) {
+		fatalIf(errDummy().Trace(), "You cannot specify --inventory with any of --depth, --recursive, --versions and --rewind flags.")
+	}
+
+	rules, pErr := loadFilterRules(cliCtx.String("filter-from"))
+	fatalIf(pErr.Trace(cliCtx.String("filter-from")), "Unable to load filter rules.")
+
 	var duErr error
 	var isDir bool
 	for _, urlStr := range cliCtx.Args() {
@@ -244,7 +276,7 @@ func mainDu(cliCtx *cli.Context) error {
 			fatalIf(errInvalidArgument().Trace(urlStr), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by 'du' command.", urlStr))
 		}
 
-		if _, _, err := du(ctx, urlStr, timeRef, withVersions, depth); duErr == nil {
+		if _, _, err := du(ctx, urlStr, timeRef, withVersions, depth, rules, cliCtx.Int("list-parallel"), inventory, ""); duErr == nil {
 			duErr = err
 		}
 	}
@@ -51,6 +51,9 @@ EXAMPLES:
 
   2. Enable SSE-KMS auto encryption with KMS key on bucket "mybucket" for alias "s3".
      {{.Prompt}} {{.HelpName}} sse-kms arn:aws:kms:us-east-1:xxx:key/xxx s3/mybucket  
+
+  3. Enable SSE-S3 auto encryption on bucket "mybucket" for alias "myminio".
+     {{.Prompt}} {{.HelpName}} sse-s3 myminio/mybucket
 `,
 }
 
@@ -107,6 +110,9 @@ func mainEncryptSet(cliCtx *cli.Context) error {
 	if algorithm != "sse-s3" && algorithm != "sse-kms" {
 		fatalIf(probe.NewError(fmt.Errorf("Unknown argument `%s` passed", algorithm)), "Invalid encryption algorithm")
 	}
+	if algorithm == "sse-s3" && keyID != "" {
+		fatalIf(errInvalidArgument().Trace(keyID), "A KMS key can only be specified with sse-kms")
+	}
 	fatalIf(client.SetEncryption(ctx, algorithm, keyID), "Unable to enable auto encryption")
 	msg := encryptSetMessage{
 		Op:     cliCtx.Command.Name,
@@ -114,6 +120,7 @@ func mainEncryptSet(cliCtx *cli.Context) error {
 		URL:    aliasedURL,
 	}
 	msg.Encryption.Algorithm = algorithm
+	msg.Encryption.KeyID = keyID
 	printMsg(msg)
 	return nil
 }
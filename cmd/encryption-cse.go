@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// # This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/secure-io/sio-go"
+)
+
+// Client-side encryption (--cse-key) envelope, stored as user metadata of
+// the encrypted object. The object data is encrypted with a random data
+// key in the DARE format, the data key itself is sealed with the user key.
+const (
+	cseAlgorithmHeader = "X-Amz-Meta-Mc-Cse-Algorithm"
+	cseSealedKeyHeader = "X-Amz-Meta-Mc-Cse-Sealed-Key"
+	cseNonceHeader     = "X-Amz-Meta-Mc-Cse-Nonce"
+	cseSizeHeader      = "X-Amz-Meta-Mc-Cse-Size"
+
+	cseAlgorithm = "DARE-" + string(sio.AES_256_GCM)
+)
+
+// parseCSEKey decodes a 32 bytes client-side encryption key, in the same
+// formats as --enc-c keys.
+func parseCSEKey(encodedKey string) ([]byte, *probe.Error) {
+	var key []byte
+	var e error
+	if len(encodedKey) == 64 {
+		key, e = hex.DecodeString(encodedKey)
+	} else {
+		key, e = base64.RawStdEncoding.DecodeString(encodedKey)
+	}
+	if e != nil {
+		return nil, errSSEClientKeyFormat("Key was neither base64 raw encoded nor hex encoded.").Trace()
+	}
+	if len(key) != 32 {
+		return nil, errSSEClientKeyFormat("Key is " + strconv.Itoa(len(key)) + " bytes, but should be 32 bytes.").Trace()
+	}
+	return key, nil
+}
+
+// isCSEEncrypted returns true if the object metadata carries a client-side
+// encryption envelope.
+func isCSEEncrypted(metadata map[string]string) bool {
+	_, ok := metadata[cseAlgorithmHeader]
+	return ok
+}
+
+// removeCSEMetadata removes the client-side encryption envelope from metadata.
+func removeCSEMetadata(metadata map[string]string) {
+	for k := range metadata {
+		switch http.CanonicalHeaderKey(k) {
+		case cseAlgorithmHeader, cseSealedKeyHeader, cseNonceHeader, cseSizeHeader:
+			delete(metadata, k)
+		}
+	}
+}
+
+// cseEncrypt encrypts reader, of size bytes or -1 if unknown, with a new
+// data key. It returns the encrypted stream, its size and the envelope
+// metadata to store with the object.
+func cseEncrypt(key []byte, reader io.Reader, size int64) (io.Reader, int64, map[string]string, *probe.Error) {
+	dataKey := make([]byte, 32)
+	if _, e := io.ReadFull(rand.Reader, dataKey); e != nil {
+		return nil, 0, nil, probe.NewError(e)
+	}
+	stream, e := sio.AES_256_GCM.Stream(dataKey)
+	if e != nil {
+		return nil, 0, nil, probe.NewError(e)
+	}
+	nonce := make([]byte, stream.NonceSize())
+	if _, e = io.ReadFull(rand.Reader, nonce); e != nil {
+		return nil, 0, nil, probe.NewError(e)
+	}
+
+	aead, err := newCSEKeyCipher(key)
+	if err != nil {
+		return nil, 0, nil, err.Trace()
+	}
+	sealNonce := make([]byte, aead.NonceSize())
+	if _, e = io.ReadFull(rand.Reader, sealNonce); e != nil {
+		return nil, 0, nil, probe.NewError(e)
+	}
+	sealedKey := aead.Seal(sealNonce, sealNonce, dataKey, []byte(cseAlgorithm))
+
+	encSize := int64(-1)
+	if size >= 0 {
+		encSize = size + stream.Overhead(size)
+	}
+	metadata := map[string]string{
+		cseAlgorithmHeader: cseAlgorithm,
+		cseSealedKeyHeader: base64.StdEncoding.EncodeToString(sealedKey),
+		cseNonceHeader:     base64.StdEncoding.EncodeToString(nonce),
+	}
+	if size >= 0 {
+		metadata[cseSizeHeader] = strconv.FormatInt(size, 10)
+	}
+	return stream.EncryptReader(reader, nonce, nil), encSize, metadata, nil
+}
+
+// cseDecrypt decrypts reader using the envelope found in metadata. It
+// returns the plain text stream and its size, -1 if unknown.
+func cseDecrypt(key []byte, reader io.Reader, metadata map[string]string) (io.Reader, int64, *probe.Error) {
+	if algorithm := metadata[cseAlgorithmHeader]; algorithm != cseAlgorithm {
+		return nil, 0, probe.NewError(errors.New("unsupported client-side encryption algorithm " + algorithm))
+	}
+	sealedKey, e := base64.StdEncoding.DecodeString(metadata[cseSealedKeyHeader])
+	if e != nil {
+		return nil, 0, probe.NewError(e)
+	}
+	nonce, e := base64.StdEncoding.DecodeString(metadata[cseNonceHeader])
+	if e != nil {
+		return nil, 0, probe.NewError(e)
+	}
+
+	aead, err := newCSEKeyCipher(key)
+	if err != nil {
+		return nil, 0, err.Trace()
+	}
+	if len(sealedKey) < aead.NonceSize() {
+		return nil, 0, probe.NewError(errors.New("invalid client-side encryption sealed key"))
+	}
+	dataKey, e := aead.Open(nil, sealedKey[:aead.NonceSize()], sealedKey[aead.NonceSize():], []byte(cseAlgorithm))
+	if e != nil {
+		return nil, 0, probe.NewError(errors.New("unable to unseal the client-side encryption key, wrong --cse-key?"))
+	}
+	stream, e := sio.AES_256_GCM.Stream(dataKey)
+	if e != nil {
+		return nil, 0, probe.NewError(e)
+	}
+	if len(nonce) != stream.NonceSize() {
+		return nil, 0, probe.NewError(errors.New("invalid client-side encryption nonce"))
+	}
+
+	size := int64(-1)
+	if sizeStr, ok := metadata[cseSizeHeader]; ok {
+		if size, e = strconv.ParseInt(sizeStr, 10, 64); e != nil {
+			return nil, 0, probe.NewError(e)
+		}
+	}
+	return stream.DecryptReader(reader, nonce, nil), size, nil
+}
+
+func newCSEKeyCipher(key []byte) (cipher.AEAD, *probe.Error) {
+	block, e := aes.NewCipher(key)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	aead, e := cipher.NewGCM(block)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return aead, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// # This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestParseCSEKey(t *testing.T) {
+	testCases := []struct {
+		key     string
+		success bool
+	}{
+		{"MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA", true},
+		{"3031323334353637383930313233343536373839303132333435363738393030", true},
+		{"MDEyMzQ1Njc4OTAxMjM0NTY3ODkw", false},
+		{"not a key", false},
+	}
+	for i, testCase := range testCases {
+		key, err := parseCSEKey(testCase.key)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if testCase.success && len(key) != 32 {
+			t.Fatalf("Test %d: expected a 32 bytes key, got %d bytes", i+1, len(key))
+		}
+	}
+}
+
+func TestClientSideEncryption(t *testing.T) {
+	key, err := parseCSEKey("MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{0, 1, 16 * 1024, 100000} {
+		data := bytes.Repeat([]byte{'a'}, size)
+		encReader, encSize, metadata, err := cseEncrypt(key, bytes.NewReader(data), int64(size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encrypted, e := io.ReadAll(encReader)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if int64(len(encrypted)) != encSize {
+			t.Fatalf("size %d: expected %d encrypted bytes, got %d", size, encSize, len(encrypted))
+		}
+		if !isCSEEncrypted(metadata) {
+			t.Fatalf("size %d: expected an encryption envelope, got %v", size, metadata)
+		}
+
+		decReader, plainSize, err := cseDecrypt(key, bytes.NewReader(encrypted), metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, e := io.ReadAll(decReader)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if plainSize != int64(size) || !bytes.Equal(decrypted, data) {
+			t.Fatalf("size %d: decrypted data does not match, got %d bytes", size, len(decrypted))
+		}
+
+		// Another key cannot unseal the data key.
+		otherKey := bytes.Repeat([]byte{'k'}, 32)
+		if _, _, err = cseDecrypt(otherKey, bytes.NewReader(encrypted), metadata); err == nil {
+			t.Fatalf("size %d: expected an error with a wrong key", size)
+		}
+
+		metadata["Content-Type"] = "text/plain"
+		removeCSEMetadata(metadata)
+		if isCSEEncrypted(metadata) || len(metadata) != 1 {
+			t.Fatalf("size %d: expected only Content-Type to remain, got %v", size, metadata)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// Stable error codes, reported in the "code" field of the JSON error
+// messages, so that scripts do not need to parse error messages.
+const (
+	errCodeUnknown            = "Unknown"
+	errCodeInvalidArgument    = "InvalidArgument"
+	errCodeAccessDenied       = "AccessDenied"
+	errCodeInvalidCredentials = "InvalidCredentials"
+	errCodeNoSuchBucket       = "NoSuchBucket"
+	errCodeNoSuchKey          = "NoSuchKey"
+	errCodeAlreadyExists      = "AlreadyExists"
+	errCodeQuotaExceeded      = "QuotaExceeded"
+	errCodeConnectionTimeout  = "ConnectionTimeout"
+	errCodeConnectionFailed   = "ConnectionFailed"
+	errCodeTLSError           = "TLSError"
+	errCodeServiceUnavailable = "ServiceUnavailable"
+	errCodeNotImplemented     = "NotImplemented"
+	errCodeCanceled           = "Canceled"
+)
+
+// errorCodeExitStatus maps every error code to the exit status of mc.
+var errorCodeExitStatus = map[string]int{
+	errCodeUnknown:            globalErrorExitStatus,
+	errCodeInvalidArgument:    2,
+	errCodeAccessDenied:       3,
+	errCodeInvalidCredentials: 4,
+	errCodeNoSuchBucket:       5,
+	errCodeNoSuchKey:          6,
+	errCodeAlreadyExists:      7,
+	errCodeQuotaExceeded:      8,
+	errCodeConnectionTimeout:  9,
+	errCodeConnectionFailed:   10,
+	errCodeTLSError:           11,
+	errCodeServiceUnavailable: 12,
+	errCodeNotImplemented:     13,
+	errCodeCanceled:           globalCancelExitStatus,
+}
+
+// s3ErrorCodes maps S3 and MinIO API error codes to mc error codes.
+var s3ErrorCodes = map[string]string{
+	"AccessDenied":      errCodeAccessDenied,
+	"AllAccessDisabled": errCodeAccessDenied,
+	"AccountProblem":    errCodeAccessDenied,
+
+	"InvalidAccessKeyId":          errCodeInvalidCredentials,
+	"SignatureDoesNotMatch":       errCodeInvalidCredentials,
+	"ExpiredToken":                errCodeInvalidCredentials,
+	"InvalidToken":                errCodeInvalidCredentials,
+	"XMinioAdminInvalidAccessKey": errCodeInvalidCredentials,
+
+	"NoSuchBucket":  errCodeNoSuchBucket,
+	"NoSuchKey":     errCodeNoSuchKey,
+	"NoSuchVersion": errCodeNoSuchKey,
+	"NoSuchUpload":  errCodeNoSuchKey,
+
+	"BucketAlreadyExists":     errCodeAlreadyExists,
+	"BucketAlreadyOwnedByYou": errCodeAlreadyExists,
+
+	"XMinioAdminBucketQuotaExceeded": errCodeQuotaExceeded,
+	"QuotaExceeded":                  errCodeQuotaExceeded,
+
+	"SlowDown":                   errCodeServiceUnavailable,
+	"SlowDownRead":               errCodeServiceUnavailable,
+	"SlowDownWrite":              errCodeServiceUnavailable,
+	"ServiceUnavailable":         errCodeServiceUnavailable,
+	"XMinioServerNotInitialized": errCodeServiceUnavailable,
+	"RequestTimeout":             errCodeConnectionTimeout,
+
+	"NotImplemented":       errCodeNotImplemented,
+	"XMinioNotImplemented": errCodeNotImplemented,
+
+	"InvalidArgument":   errCodeInvalidArgument,
+	"InvalidBucketName": errCodeInvalidArgument,
+	"InvalidRequest":    errCodeInvalidArgument,
+}
+
+// s3StatusErrorCode returns the error code of an API error with an
+// unknown code from its HTTP status.
+func s3StatusErrorCode(code string, statusCode int) string {
+	if c, ok := s3ErrorCodes[code]; ok {
+		return c
+	}
+	switch statusCode {
+	case http.StatusForbidden:
+		return errCodeAccessDenied
+	case http.StatusNotFound:
+		return errCodeNoSuchKey
+	case http.StatusServiceUnavailable, http.StatusTooManyRequests:
+		return errCodeServiceUnavailable
+	case http.StatusNotImplemented:
+		return errCodeNotImplemented
+	}
+	return errCodeUnknown
+}
+
+// errorCode classifies an error into one of the stable error codes.
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, context.Canceled) {
+		return errCodeCanceled
+	}
+
+	var s3Err minio.ErrorResponse
+	if errors.As(err, &s3Err) {
+		return s3StatusErrorCode(s3Err.Code, s3Err.StatusCode)
+	}
+	var adminErr madmin.ErrorResponse
+	if errors.As(err, &adminErr) {
+		return s3StatusErrorCode(adminErr.Code, 0)
+	}
+
+	switch err.(type) {
+	case BucketDoesNotExist:
+		return errCodeNoSuchBucket
+	case ObjectMissing, PathNotFound, ObjectIsDeleteMarker:
+		return errCodeNoSuchKey
+	case PathInsufficientPermission:
+		return errCodeAccessDenied
+	case BucketExists, ObjectAlreadyExists, ObjectAlreadyExistsAsDirectory:
+		return errCodeAlreadyExists
+	case InvalidArgument, BucketInvalid, BucketNameEmpty, ObjectNameEmpty, EmptyPath:
+		return errCodeInvalidArgument
+	case APINotImplemented:
+		return errCodeNotImplemented
+	}
+	if err.Error() == errInvalidArgument().ToGoError().Error() {
+		return errCodeInvalidArgument
+	}
+
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostnameErr      x509.HostnameError
+		certInvalid      x509.CertificateInvalidError
+		verifyErr        *tls.CertificateVerificationError
+		recordHeaderErr  tls.RecordHeaderError
+	)
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostnameErr) || errors.As(err, &certInvalid) ||
+		errors.As(err, &verifyErr) || errors.As(err, &recordHeaderErr) {
+		return errCodeTLSError
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return errCodeConnectionTimeout
+	}
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	if errors.As(err, &dnsErr) || errors.As(err, &opErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return errCodeConnectionFailed
+	}
+	return errCodeUnknown
+}
+
+// errorCodeOf returns the error code of a probe error.
+func errorCodeOf(err *probe.Error) string {
+	if err == nil {
+		return ""
+	}
+	return errorCode(err.ToGoError())
+}
+
+// exitStatusOf returns the exit status matching an error code.
+func exitStatusOf(code string) int {
+	if status, ok := errorCodeExitStatus[code]; ok {
+		return status
+	}
+	return globalErrorExitStatus
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorCode(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code string
+	}{
+		{nil, ""},
+		{errors.New("something"), errCodeUnknown},
+		{minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}, errCodeAccessDenied},
+		{minio.ErrorResponse{Code: "SignatureDoesNotMatch", StatusCode: 403}, errCodeInvalidCredentials},
+		{minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: 404}, errCodeNoSuchBucket},
+		{minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}, errCodeNoSuchKey},
+		{minio.ErrorResponse{StatusCode: 404}, errCodeNoSuchKey},
+		{minio.ErrorResponse{Code: "SlowDown", StatusCode: 503}, errCodeServiceUnavailable},
+		{minio.ErrorResponse{Code: "Weird", StatusCode: 500}, errCodeUnknown},
+		{fmt.Errorf("wrapped: %w", minio.ErrorResponse{Code: "BucketAlreadyOwnedByYou"}), errCodeAlreadyExists},
+		{madmin.ErrorResponse{Code: "XMinioAdminBucketQuotaExceeded"}, errCodeQuotaExceeded},
+		{BucketDoesNotExist{Bucket: "b"}, errCodeNoSuchBucket},
+		{PathNotFound{Path: "/tmp/x"}, errCodeNoSuchKey},
+		{PathInsufficientPermission{Path: "/root"}, errCodeAccessDenied},
+		{BucketExists{Bucket: "b"}, errCodeAlreadyExists},
+		{APINotImplemented{API: "x"}, errCodeNotImplemented},
+		{errInvalidArgument().ToGoError(), errCodeInvalidArgument},
+		{context.Canceled, errCodeCanceled},
+		{context.DeadlineExceeded, errCodeConnectionTimeout},
+		{&url.Error{Op: "Get", URL: "http://x", Err: timeoutError{}}, errCodeConnectionTimeout},
+		{&url.Error{Op: "Get", URL: "http://x", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}, errCodeConnectionFailed},
+		{&net.DNSError{Err: "no such host", Name: "x"}, errCodeConnectionFailed},
+		{&url.Error{Op: "Get", URL: "https://x", Err: x509.UnknownAuthorityError{}}, errCodeTLSError},
+	}
+	for i, testCase := range testCases {
+		if got := errorCode(testCase.err); got != testCase.code {
+			t.Errorf("Test %d: %v expected %q, got %q", i+1, testCase.err, testCase.code, got)
+		}
+	}
+	if got := errorCodeOf(probe.NewError(minio.ErrorResponse{Code: "NoSuchBucket"}).Trace("x")); got != errCodeNoSuchBucket {
+		t.Errorf("expected %q from a probe error, got %q", errCodeNoSuchBucket, got)
+	}
+}
+
+func TestExitStatusErrorCode(t *testing.T) {
+	defer lastErrorCode.Store("")
+	defer exitErrorCode.Store("")
+	seen := make(map[int]string)
+	for code, status := range errorCodeExitStatus {
+		if other, ok := seen[status]; ok {
+			t.Fatalf("%s and %s share the exit status %d", code, other, status)
+		}
+		seen[status] = code
+	}
+	if status := exitStatusOf("NotACode"); status != globalErrorExitStatus {
+		t.Fatalf("expected %d for an unknown code, got %d", globalErrorExitStatus, status)
+	}
+
+	lastErrorCode.Store(errCodeNoSuchBucket)
+	if e := exitStatus(globalErrorExitStatus); e.(interface{ ExitCode() int }).ExitCode() != 5 {
+		t.Fatalf("expected exit status 5, got %d", e.(interface{ ExitCode() int }).ExitCode())
+	}
+	if e := exitStatus(globalErrorExitStatus); e.(interface{ ExitCode() int }).ExitCode() != globalErrorExitStatus {
+		t.Fatal("expected the error code to be used for a single failure")
+	}
+	lastErrorCode.Store(errCodeNoSuchBucket)
+	if e := exitStatus(globalCancelExitStatus); e.(interface{ ExitCode() int }).ExitCode() != globalCancelExitStatus {
+		t.Fatal("expected an explicit exit status to be kept")
+	}
+	if e := exitStatus(globalErrorExitStatus); e.(interface{ ExitCode() int }).ExitCode() != globalErrorExitStatus {
+		t.Fatal("expected the error code to be consumed by an explicit exit status")
+	}
+}
+
+func TestErrorJSON(t *testing.T) {
+	defer func(jsonLine bool) { globalJSONLine = jsonLine }(globalJSONLine)
+
+	errorMsg := errorMessage{
+		Message: "Unable to stat `play/bucket/object`.",
+		Type:    "error",
+		Code:    errCodeNoSuchKey,
+		Cause:   causeMessage{Message: "Object does not exist."},
+	}
+	for _, jsonLine := range []bool{false, true} {
+		globalJSONLine = jsonLine
+		record := errorJSON(errorMsg)
+		if jsonLine && strings.Contains(record, "\n") {
+			t.Errorf("expected a single line record with --json-lines, got %q", record)
+		}
+		var parsed struct {
+			Status string       `json:"status"`
+			Error  errorMessage `json:"error"`
+		}
+		if e := json.Unmarshal([]byte(record), &parsed); e != nil {
+			t.Fatalf("--json-lines %v: %v", jsonLine, e)
+		}
+		if parsed.Status != "error" || parsed.Error.Code != errCodeNoSuchKey || parsed.Error.Message != errorMsg.Message {
+			t.Errorf("--json-lines %v: unexpected record %+v", jsonLine, parsed)
+		}
+	}
+}
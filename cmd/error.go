@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync/atomic"
 	"unicode"
 
 	"github.com/minio/cli"
@@ -41,6 +43,7 @@ type errorMessage struct {
 	Message   string             `json:"message"`
 	Cause     causeMessage       `json:"cause"`
 	Type      string             `json:"type"`
+	Code      string             `json:"code"`
 	CallTrace []probe.TracePoint `json:"trace,omitempty"`
 	SysInfo   map[string]string  `json:"sysinfo,omitempty"`
 }
@@ -53,11 +56,53 @@ func fatalIf(err *probe.Error, msg string, data ...interface{}) {
 	fatal(err, msg, data...)
 }
 
+// lastErrorCode is the code of the last error reported by errorIf and not
+// yet turned into an exit status by exitStatus.
+var lastErrorCode atomic.Value
+
+// exitErrorCode is the code of the error which set the exit status.
+var exitErrorCode atomic.Value
+
+// getErrorCode returns the code of an error, canceled when mc is being killed.
+func getErrorCode(err *probe.Error) string {
+	if errors.Is(globalContext.Err(), context.Canceled) {
+		return errCodeCanceled
+	}
+	return errorCodeOf(err)
+}
+
+// errorJSON returns the JSON record of an error, on a single line
+// with --json-lines.
+func errorJSON(errorMsg errorMessage) string {
+	record := struct {
+		Status string       `json:"status"`
+		Error  errorMessage `json:"error"`
+	}{
+		Status: "error",
+		Error:  errorMsg,
+	}
+	var buf []byte
+	var e error
+	if globalJSONLine {
+		buf, e = json.Marshal(record)
+	} else {
+		buf, e = json.MarshalIndent(record, "", " ")
+	}
+	if e != nil {
+		console.Fatalln(probe.NewError(e))
+	}
+	return string(buf)
+}
+
 func fatal(err *probe.Error, msg string, data ...interface{}) {
+	code := getErrorCode(err)
+	status := exitStatusOf(code)
+	finishAuditLog(status, code)
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: msg,
 			Type:    "fatal",
+			Code:    code,
 			Cause: causeMessage{
 				Message: err.ToGoError().Error(),
 				Error:   err.ToGoError(),
@@ -67,18 +112,8 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 			errorMsg.CallTrace = err.CallTrace
 			errorMsg.SysInfo = err.SysInfo
 		}
-		json, e := json.MarshalIndent(struct {
-			Status string       `json:"status"`
-			Error  errorMessage `json:"error"`
-		}{
-			Status: "error",
-			Error:  errorMsg,
-		}, "", " ")
-		if e != nil {
-			console.Fatalln(probe.NewError(e))
-		}
-		console.Println(string(json))
-		console.Fatalln()
+		console.Println(errorJSON(errorMsg))
+		os.Exit(status)
 	}
 
 	msg = fmt.Sprintf(msg, data...)
@@ -115,15 +150,27 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 		}
 	}
 
-	console.Fatalln(fmt.Sprintf("%s %s", msg, errmsg))
+	if status == globalErrorExitStatus {
+		console.Fatalln(fmt.Sprintf("%s %s", msg, errmsg))
+	}
+	console.Errorln(fmt.Sprintf("%s %s", msg, errmsg))
+	os.Exit(status)
 }
 
 // Exit coder wraps cli new exit error with a
 // custom exitStatus number. cli package requires
 // an error with `cli.ExitCoder` compatibility
 // after an action. Which woud allow cli package to
-// exit with the specified `exitStatus`.
+// exit with the specified `exitStatus`. A generic
+// error status is refined with the code of the error
+// reported for this failure, the code is consumed so
+// it does not carry over to a later failure.
 func exitStatus(status int) error {
+	code, _ := lastErrorCode.Swap("").(string)
+	if status == globalErrorExitStatus && code != "" {
+		status = exitStatusOf(code)
+		exitErrorCode.Store(code)
+	}
 	return cli.NewExitError("", status)
 }
 
@@ -132,10 +179,13 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 	if err == nil {
 		return
 	}
+	code := getErrorCode(err)
+	lastErrorCode.Store(code)
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: fmt.Sprintf(msg, data...),
 			Type:    "error",
+			Code:    code,
 			Cause: causeMessage{
 				Message: err.ToGoError().Error(),
 				Error:   err.ToGoError(),
@@ -145,17 +195,7 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 			errorMsg.CallTrace = err.CallTrace
 			errorMsg.SysInfo = err.SysInfo
 		}
-		json, e := json.MarshalIndent(struct {
-			Status string       `json:"status"`
-			Error  errorMessage `json:"error"`
-		}{
-			Status: "error",
-			Error:  errorMsg,
-		}, "", " ")
-		if e != nil {
-			console.Fatalln(probe.NewError(e))
-		}
-		console.Println(string(json))
+		console.Println(errorJSON(errorMsg))
 		return
 	}
 	msg = fmt.Sprintf(msg, data...)
@@ -34,6 +34,10 @@ var eventAddFlags = []cli.Flag{
 		Value: "put,delete,get",
 		Usage: "filter specific type of event. Defaults to all event",
 	},
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "set a unique identifier for the notification rule",
+	},
 	cli.StringFlag{
 		Name:  "prefix",
 		Usage: "filter event associated to the specified prefix",
@@ -64,6 +68,18 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+EVENTS:
+  put          object creation events (s3:ObjectCreated:*)
+  delete       object removal events (s3:ObjectRemoved:*)
+  get          object access events (s3:ObjectAccessed:*)
+  replica      replication events (s3:Replication:*)
+  ilm          object restore and transition events
+  restore      object restore events (s3:ObjectRestore:*)
+  transition   ILM transition events (s3:ObjectTransition:*)
+  expiry       ILM delete marker expiration events (s3:LifecycleDelMarkerExpiration:Delete)
+  scanner      scanner events (s3:Scanner:ManyVersions, s3:Scanner:BigPrefix)
+  Any other event type supported by the server can be passed with its full name, e.g. s3:ObjectRemoved:DeleteMarkerCreated
+
 EXAMPLES:
   1. Enable bucket notification with a specific ARN
     {{.Prompt}} {{.HelpName}} myminio/mybucket arn:aws:sqs:us-west-2:444455556666:your-queue
@@ -76,6 +92,9 @@ EXAMPLES:
 
   4. Enable bucket notification for Replication and ILM transition events to a specific ARN
     {{.Prompt}} {{.HelpName}} myminio/mysourcebucket arn:aws:sqs:us-west-2:444455556666:your-queue --event replica,ilm
+
+  5. Enable bucket notification for ILM transition and restore events with a rule ID
+    {{.Prompt}} {{.HelpName}} myminio/mybucket arn:minio:sqs::primary:webhook --event transition,restore --id archive-events
 `,
 }
 
@@ -89,6 +108,7 @@ func checkEventAddSyntax(ctx *cli.Context) {
 // eventAddMessage container
 type eventAddMessage struct {
 	ARN    string   `json:"arn"`
+	ID     string   `json:"id,omitempty"`
 	Event  []string `json:"event"`
 	Prefix string   `json:"prefix"`
 	Suffix string   `json:"suffix"`
@@ -121,6 +141,7 @@ func mainEventAdd(cliCtx *cli.Context) error {
 	arn := args[1]
 	ignoreExisting := cliCtx.Bool("p")
 
+	id := cliCtx.String("id")
 	event := strings.Split(cliCtx.String("event"), ",")
 	prefix := cliCtx.String("prefix")
 	suffix := cliCtx.String("suffix")
@@ -135,10 +156,11 @@ func mainEventAdd(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
 	}
 
-	err = s3Client.AddNotificationConfig(ctx, arn, event, prefix, suffix, ignoreExisting)
+	err = s3Client.AddNotificationConfig(ctx, arn, id, event, prefix, suffix, ignoreExisting)
 	fatalIf(err, "Unable to enable notification on the specified bucket.")
 	printMsg(eventAddMessage{
 		ARN:    arn,
+		ID:     id,
 		Event:  event,
 		Prefix: prefix,
 		Suffix: suffix,
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var eventEditFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "identifier of the notification rule to edit, required if the ARN has several rules",
+	},
+	cli.StringFlag{
+		Name:  "event",
+		Usage: "replace the events of the notification rule",
+	},
+	cli.StringFlag{
+		Name:  "prefix",
+		Usage: "replace the prefix filter of the notification rule, an empty value removes it",
+	},
+	cli.StringFlag{
+		Name:  "suffix",
+		Usage: "replace the suffix filter of the notification rule, an empty value removes it",
+	},
+}
+
+var eventEditCmd = cli.Command{
+	Name:         "edit",
+	Usage:        "edit an existing bucket notification in place",
+	Action:       mainEventEdit,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(eventEditFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET ARN [FLAGS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Change the events of the only notification rule of a specific ARN
+    {{.Prompt}} {{.HelpName}} myminio/mybucket arn:minio:sqs::primary:webhook --event put,delete
+
+  2. Change the prefix filter of a notification rule selected by its id
+    {{.Prompt}} {{.HelpName}} myminio/mybucket arn:minio:sqs::primary:webhook --id archive-events --prefix photos/
+
+  3. Remove the suffix filter of a notification rule
+    {{.Prompt}} {{.HelpName}} myminio/mybucket arn:minio:sqs::primary:webhook --suffix ""
+`,
+}
+
+// checkEventEditSyntax - validate all the passed arguments
+func checkEventEditSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if !ctx.IsSet("event") && !ctx.IsSet("prefix") && !ctx.IsSet("suffix") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "At least one of --event, --prefix or --suffix must be specified.")
+	}
+}
+
+// eventEditMessage container
+type eventEditMessage struct {
+	ARN    string `json:"arn"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+}
+
+// JSON jsonified edit message.
+func (u eventEditMessage) JSON() string {
+	u.Status = "success"
+	eventEditMessageJSONBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(eventEditMessageJSONBytes)
+}
+
+func (u eventEditMessage) String() string {
+	return console.Colorize("Event", "Successfully updated "+u.ARN)
+}
+
+func mainEventEdit(cliCtx *cli.Context) error {
+	ctx, cancelEventEdit := context.WithCancel(globalContext)
+	defer cancelEventEdit()
+
+	console.SetColor("Event", color.New(color.FgGreen, color.Bold))
+
+	checkEventEditSyntax(cliCtx)
+
+	args := cliCtx.Args()
+	path := args[0]
+	arn := args[1]
+	id := cliCtx.String("id")
+
+	var opts EditNotificationConfigOpts
+	if cliCtx.IsSet("event") {
+		opts.Events = strings.Split(cliCtx.String("event"), ",")
+	}
+	if cliCtx.IsSet("prefix") {
+		prefix := cliCtx.String("prefix")
+		opts.Prefix = &prefix
+	}
+	if cliCtx.IsSet("suffix") {
+		suffix := cliCtx.String("suffix")
+		opts.Suffix = &suffix
+	}
+
+	client, err := newClient(path)
+	if err != nil {
+		fatalIf(err.Trace(), "Unable to parse the provided url.")
+	}
+
+	s3Client, ok := client.(*S3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	err = s3Client.EditNotificationConfig(ctx, arn, id, opts)
+	fatalIf(err, "Unable to edit notification on the specified bucket.")
+	printMsg(eventEditMessage{
+		ARN: arn,
+		ID:  id,
+	})
+
+	return nil
+}
@@ -95,6 +95,9 @@ func (u eventListMessage) String() string {
 	if u.Suffix != "" {
 		msg += console.Colorize("Filter", fmt.Sprintf("suffix=\"%s\"", u.Suffix))
 	}
+	if u.ID != "" {
+		msg += console.Colorize("Filter", fmt.Sprintf("   ID: %s", u.ID))
+	}
 	return msg
 }
 
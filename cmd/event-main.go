@@ -23,6 +23,7 @@ var eventFlags = []cli.Flag{}
 
 var eventSubcommands = []cli.Command{
 	eventAddCmd,
+	eventEditCmd,
 	eventRemoveCmd,
 	eventListCmd,
 }
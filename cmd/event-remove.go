@@ -46,6 +46,10 @@ var eventRemoveFlags = []cli.Flag{
 		Name:  "suffix",
 		Usage: "filter event associated to the specified suffix",
 	},
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "remove only the notification rule with the specified id, and the specified arn if any",
+	},
 }
 
 var eventRemoveCmd = cli.Command{
@@ -71,6 +75,9 @@ EXAMPLES:
 
   2. Remove all bucket notifications. --force flag is mandatory here
     {{.Prompt}} {{.HelpName}} myminio/mybucket --force
+
+  3. Remove a single bucket notification rule by its id
+    {{.Prompt}} {{.HelpName}} myminio/mybucket --id archive-events
 `,
 }
 
@@ -79,7 +86,7 @@ func checkEventRemoveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
-	if len(ctx.Args()) == 1 && !ctx.Bool("force") {
+	if len(ctx.Args()) == 1 && !ctx.Bool("force") && ctx.String("id") == "" {
 		fatalIf(probe.NewError(errors.New("")), "--force flag needs to be passed to remove all bucket notifications.")
 	}
 }
@@ -87,6 +94,7 @@ func checkEventRemoveSyntax(ctx *cli.Context) {
 // eventRemoveMessage container
 type eventRemoveMessage struct {
 	ARN    string `json:"arn"`
+	ID     string `json:"id,omitempty"`
 	Status string `json:"status"`
 }
 
@@ -99,6 +107,9 @@ func (u eventRemoveMessage) JSON() string {
 }
 
 func (u eventRemoveMessage) String() string {
+	if u.ID != "" {
+		return console.Colorize("Event", "Successfully removed notification rule "+u.ID)
+	}
 	msg := console.Colorize("Event", "Successfully removed "+u.ARN)
 	return msg
 }
@@ -129,6 +140,13 @@ func mainEventRemove(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
 	}
 
+	if id := cliCtx.String("id"); id != "" {
+		err = s3Client.RemoveNotificationConfigByID(ctx, arn, id)
+		fatalIf(err, "Unable to remove notification rule on the specified bucket.")
+		printMsg(eventRemoveMessage{ARN: arn, ID: id})
+		return nil
+	}
+
 	// flags for the attributes of the even
 	event := cliCtx.String("event")
 	prefix := cliCtx.String("prefix")
@@ -0,0 +1,248 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// filterRule is a single include or exclude line of a filter file.
+type filterRule struct {
+	include bool
+	// dirOnly rules end with "/" and only match directories.
+	dirOnly bool
+	// crossDir rules contain "**" and are matched against full object
+	// paths only, not against their parent directories.
+	crossDir bool
+	pattern  string
+	re       *regexp.Regexp
+}
+
+// filterRules holds an ordered list of rules, the first matching rule
+// decides whether a path is included. Paths matching no rule are included.
+type filterRules []filterRule
+
+// loadFilterRules reads filter rules from a file, "-" reads from stdin.
+func loadFilterRules(filename string) (filterRules, *probe.Error) {
+	if filename == "" {
+		return nil, nil
+	}
+	if filename == "-" {
+		return parseFilterRules(os.Stdin)
+	}
+	f, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+	rules, err := parseFilterRules(f)
+	if err != nil {
+		return nil, err.Trace(filename)
+	}
+	return rules, nil
+}
+
+// parseFilterRules parses rsync/rclone style filter rules. Each line is
+// one of "+ PATTERN", "- PATTERN", "include PATTERN", "exclude PATTERN"
+// or "!" which clears all previous rules. Empty lines and lines starting
+// with '#' or ';' are ignored.
+func parseFilterRules(r io.Reader) (filterRules, *probe.Error) {
+	var rules filterRules
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.TrimSpace(line) == "!" {
+			rules = nil
+			continue
+		}
+		var include bool
+		var pattern string
+		switch {
+		case strings.HasPrefix(line, "+ "):
+			include, pattern = true, line[2:]
+		case strings.HasPrefix(line, "- "):
+			pattern = line[2:]
+		case strings.HasPrefix(line, "include "):
+			include, pattern = true, line[len("include "):]
+		case strings.HasPrefix(line, "exclude "):
+			pattern = line[len("exclude "):]
+		default:
+			return nil, errInvalidArgument().Trace("line", strconv.Itoa(lineNo), line)
+		}
+		rule, err := newFilterRule(include, pattern)
+		if err != nil {
+			return nil, err.Trace("line", strconv.Itoa(lineNo), line)
+		}
+		rules = append(rules, rule)
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return rules, nil
+}
+
+// newFilterRule compiles a single pattern. A leading "/" anchors the
+// pattern to the root of the transfer, otherwise it may match any trailing
+// part of a path starting at a path separator.
+func newFilterRule(include bool, pattern string) (filterRule, *probe.Error) {
+	rule := filterRule{include: include, pattern: pattern}
+	if pattern == "" || pattern == "/" {
+		return rule, errInvalidArgument().Trace(pattern)
+	}
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	rule.crossDir = strings.Contains(pattern, "**")
+	anchored := strings.HasPrefix(pattern, "/")
+	if anchored {
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	expr, err := globToRegexp(pattern)
+	if err != nil {
+		return rule, err.Trace(pattern)
+	}
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "(^|/)" + expr + "$"
+	}
+	re, e := regexp.Compile(expr)
+	if e != nil {
+		return rule, probe.NewError(e)
+	}
+	rule.re = re
+	return rule, nil
+}
+
+// globToRegexp translates the filter glob syntax to a regular expression.
+// '*' matches within a path segment, '**' matches across segments, '?'
+// matches a single character, '[...]' is a character class, '{a,b}' is
+// an alternation and '\' escapes the next character.
+func globToRegexp(glob string) (string, *probe.Error) {
+	var b strings.Builder
+	braces := 0
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return "", errInvalidArgument().Trace(glob)
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '{':
+			braces++
+			b.WriteString("(?:")
+		case '}':
+			if braces == 0 {
+				return "", errInvalidArgument().Trace(glob)
+			}
+			braces--
+			b.WriteString(")")
+		case ',':
+			if braces > 0 {
+				b.WriteString("|")
+			} else {
+				b.WriteString(",")
+			}
+		case '\\':
+			if i+1 == len(glob) {
+				return "", errInvalidArgument().Trace(glob)
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if braces != 0 {
+		return "", errInvalidArgument().Trace(glob)
+	}
+	return b.String(), nil
+}
+
+// isIncluded reports whether the object at relPath, relative to the root
+// of the transfer, passes the filter rules. The parent directories of the
+// object are checked first, from the top: as in rsync, an excluded
+// directory excludes everything under it, e.g. "- node_modules" excludes
+// "node_modules/a.js". The object itself is then checked against the
+// rules which are not directory rules.
+func (rules filterRules) isIncluded(relPath string) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	relPath = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(relPath)), "/")
+	if relPath == "" {
+		return true
+	}
+	for i := 0; i < len(relPath); i++ {
+		if relPath[i] != '/' {
+			continue
+		}
+		if included, ok := rules.match(relPath[:i], true); ok && !included {
+			return false
+		}
+	}
+	included, ok := rules.match(relPath, false)
+	return !ok || included
+}
+
+// match returns the decision of the first rule matching name. Directory
+// rules only apply to directories, rules with "**" only to objects and
+// other rules to both.
+func (rules filterRules) match(name string, isDir bool) (included, ok bool) {
+	for _, rule := range rules {
+		applies := !rule.dirOnly
+		if isDir {
+			applies = rule.dirOnly || !rule.crossDir
+		}
+		if applies && rule.re.MatchString(name) {
+			return rule.include, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFilterRules(t *testing.T) {
+	testCases := []struct {
+		input     string
+		wantRules int
+		wantErr   bool
+	}{
+		{"", 0, false},
+		{"# comment\n; comment\n\n+ *.jpg\n- *\n", 2, false},
+		{"include *.jpg\r\nexclude *\r\n", 2, false},
+		{"- *.tmp\n!\n- *.bak\n", 1, false},
+		{"- tmp/\n+ /docs/**\n", 2, false},
+		{"- {a,b\n", 0, true},
+		{"- [abc\n", 0, true},
+		{"* *.jpg\n", 0, true},
+		{"-*.jpg\n", 0, true},
+		{"- \n", 0, true},
+	}
+	for i, testCase := range testCases {
+		rules, err := parseFilterRules(strings.NewReader(testCase.input))
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if len(rules) != testCase.wantRules {
+			t.Fatalf("Test %d: expected %d rules, got %d", i+1, testCase.wantRules, len(rules))
+		}
+	}
+}
+
+func TestFilterRulesIsIncluded(t *testing.T) {
+	const filters = `# keep pictures, drop caches and the top level build folder
+- .cache/
+- /build/**
+- *.{tmp,bak}
++ *.jpg
++ /docs/**
+- photos/raw/*
+- /*.log
+`
+	rules, err := parseFilterRules(strings.NewReader(filters))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		path     string
+		included bool
+	}{
+		{"image.jpg", true},
+		{"a/b/image.jpg", true},
+		{"a/.cache/image.jpg", false},
+		{".cache/x", false},
+		{"build/out/app", false},
+		{"src/build/app", true},
+		{"file.tmp", false},
+		{"dir/file.bak", false},
+		{"docs/a/b.tmp", false},
+		{"docs/a/b.txt", true},
+		{"photos/raw/a.cr2", false},
+		{"photos/raw/sub/a.cr2", false},
+		{"photos/raw/a.jpg", true},
+		{"server.log", false},
+		{"logs/server.log", true},
+		{"/server.log", false},
+		{"", true},
+	}
+	for i, testCase := range testCases {
+		if got := rules.isIncluded(testCase.path); got != testCase.included {
+			t.Errorf("Test %d: %q expected included %v, got %v", i+1, testCase.path, testCase.included, got)
+		}
+	}
+
+	// rclone style: include some files and exclude everything else.
+	rules, err = parseFilterRules(strings.NewReader("+ *.jpg\n+ /keep/**\n- **\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, included := range map[string]bool{
+		"a.jpg":       true,
+		"x/y/a.jpg":   true,
+		"keep/a/b.go": true,
+		"a.png":       false,
+		"x/keep/a.go": false,
+	} {
+		if got := rules.isIncluded(path); got != included {
+			t.Errorf("%q expected included %v, got %v", path, included, got)
+		}
+	}
+
+	// Directory rules only apply to directories, an excluded directory
+	// excludes everything under it, whether the rule ends with "/" or not.
+	for _, testCase := range []struct {
+		filters string
+		paths   map[string]bool
+	}{
+		{"+ */\n+ *.tmp\n- *\n", map[string]bool{
+			"a.tmp":     true,
+			"dir/a.tmp": true,
+			"dir/b.doc": false,
+			"b.doc":     false,
+		}},
+		{"+ *.txt\n- /secret/\n", map[string]bool{
+			"a.txt":          true,
+			"secret/a.txt":   false,
+			"secret/x/a.txt": false,
+			"public/a.txt":   true,
+			"x/secret/a.txt": true,
+			"secret":         true,
+		}},
+		{"+ /secret/keep/\n- /secret/\n", map[string]bool{
+			"secret/keep/a":  false,
+			"secret/a":       false,
+			"other/secret/a": true,
+			"other/keep/a":   true,
+			"secret.txt":     true,
+		}},
+		{"- node_modules\n", map[string]bool{
+			"node_modules":                  false,
+			"node_modules/a.js":             false,
+			"node_modules/pkg/lib/index.js": false,
+			"app/node_modules/pkg/index.js": false,
+			"app/src/index.js":              true,
+			"node_modules.txt":              true,
+			"app/my_node_modules/pkg/a.js":  true,
+		}},
+		{"+ *.go\n- *\n", map[string]bool{
+			"main.go":     true,
+			"cmd/main.go": false,
+		}},
+	} {
+		rules, err := parseFilterRules(strings.NewReader(testCase.filters))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for path, included := range testCase.paths {
+			if got := rules.isIncluded(path); got != included {
+				t.Errorf("%q with %q expected included %v, got %v", path, testCase.filters, included, got)
+			}
+		}
+	}
+}
@@ -41,6 +41,8 @@ var (
 			Name:  "ignore",
 			Usage: "exclude objects matching the wildcard pattern",
 		},
+		filterFromFlag,
+		inventoryFlag,
 		cli.BoolFlag{
 			Name:  "versions",
 			Usage: "include all objects versions",
@@ -171,6 +173,12 @@ EXAMPLES:
 
   11. Copy all versions of all objects in bucket in the local machine
       {{.Prompt}} {{.HelpName}} s3/bucket --versions --exec "mc cp --version-id {version} {} /tmp/dir/{}.{version}"
+
+  12. Find objects under "s3/bucket" using the ordered include/exclude rules of an existing rsync/rclone filter file.
+      {{.Prompt}} {{.HelpName}} s3/bucket --filter-from backup-filters.txt
+
+  13. Find objects larger than 1GiB under "s3/bucket" from an S3 Inventory report instead of listing the bucket.
+      {{.Prompt}} {{.HelpName}} s3/bucket --larger 1GiB --inventory s3/inventory/bucket/daily/2022-01-01T00-00Z/manifest.json
 `,
 }
 
@@ -189,6 +197,11 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		}
 	}
 
+	// Inventory reports do not carry metadata or tags, nor object versions.
+	if cliCtx.IsSet("inventory") && (cliCtx.Bool("versions") || cliCtx.IsSet("metadata") || cliCtx.IsSet("tags")) {
+		fatalIf(errDummy().Trace(), "You cannot specify --inventory with any of --versions, --metadata and --tags flags.")
+	}
+
 	// Extract input URLs and validate.
 	for _, url := range args {
 		_, _, err := url2Stat(ctx, url2StatOptions{urlStr: url, versionID: "", fileAttr: false, encKeyDB: encKeyDB, timeRef: time.Time{}, isZip: false, ignoreBucketExistsCheck: false})
@@ -209,6 +222,7 @@ type findContext struct {
 	*cli.Context
 	execCmd       string
 	ignorePattern string
+	filterRules   filterRules
 	namePattern   string
 	pathPattern   string
 	regexPattern  *regexp.Regexp
@@ -222,6 +236,7 @@ type findContext struct {
 	withVersions  bool
 	matchMeta     map[string]*regexp.Regexp
 	matchTags     map[string]*regexp.Regexp
+	inventory     string
 
 	// Internal values
 	targetAlias   string
@@ -289,6 +304,9 @@ func mainFind(cliCtx *cli.Context) error {
 	if hostCfg != nil {
 		targetFullURL = hostCfg.URL
 	}
+	rules, err := loadFilterRules(cliCtx.String("filter-from"))
+	fatalIf(err.Trace(cliCtx.String("filter-from")), "Unable to load filter rules.")
+
 	var regMatch *regexp.Regexp
 	if cliCtx.String("regex") != "" {
 		regMatch = regexp.MustCompile(cliCtx.String("regex"))
@@ -303,6 +321,7 @@ func mainFind(cliCtx *cli.Context) error {
 		pathPattern:   cliCtx.String("path"),
 		regexPattern:  regMatch,
 		ignorePattern: cliCtx.String("ignore"),
+		filterRules:   rules,
 		withVersions:  withVersions,
 		olderThan:     olderThan,
 		newerThan:     newerThan,
@@ -315,5 +334,6 @@ func mainFind(cliCtx *cli.Context) error {
 		clnt:          clnt,
 		matchMeta:     getRegexMap(cliCtx, "metadata"),
 		matchTags:     getRegexMap(cliCtx, "tags"),
+		inventory:     cliCtx.String("inventory"),
 	})
 }
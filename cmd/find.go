@@ -278,6 +278,7 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 		Recursive:         true,
 		ShowDir:           DirFirst,
 		WithMetadata:      len(ctx.matchMeta) > 0 || len(ctx.matchTags) > 0,
+		Inventory:         ctx.inventory,
 	}
 
 	// iterate over all content which is within the given directory
@@ -406,6 +407,9 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	if match && ctx.ignorePattern != "" {
 		match = !pathMatch(ctx.ignorePattern, path)
 	}
+	if match && len(ctx.filterRules) > 0 {
+		match = ctx.filterRules.isIncluded(path)
+	}
 	if match && ctx.namePattern != "" {
 		match = nameMatch(ctx.namePattern, path)
 	}
@@ -59,6 +59,20 @@ var globalFlags = []cli.Flag{
 		Usage:  "enable JSON lines formatted output",
 		EnvVar: envPrefix + "JSON",
 	},
+	cli.BoolFlag{
+		Name:   "json-lines",
+		Usage:  "enable JSON output with one single line record per line (NDJSON), even on a terminal; implies --json",
+		EnvVar: envPrefix + "JSON_LINES",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show the changes of a mutating command without applying them",
+	},
+	cli.BoolFlag{
+		Name:   "quiet-update",
+		Usage:  "do not print a notice when a newer mc release is available",
+		EnvVar: envPrefix + "QUIET_UPDATE",
+	},
 	cli.BoolFlag{
 		Name:   "debug",
 		Usage:  "enable debug output",
@@ -84,6 +98,28 @@ var globalFlags = []cli.Flag{
 		Usage:  "limits downloads to a maximum rate in KiB/s, MiB/s, GiB/s. (default: unlimited)",
 		EnvVar: envPrefix + "LIMIT_DOWNLOAD",
 	},
+	cli.IntFlag{
+		Name:   "max-idle-conns-per-host",
+		Usage:  "maximum number of idle connections kept open to each host",
+		Value:  defaultMaxIdleConnsPerHost,
+		EnvVar: envPrefix + "MAX_IDLE_CONNS_PER_HOST",
+	},
+	cli.BoolFlag{
+		Name:   "http2",
+		Usage:  "negotiate HTTP/2 with TLS endpoints supporting it",
+		EnvVar: envPrefix + "HTTP2",
+	},
+	cli.DurationFlag{
+		Name:   "tcp-keepalive",
+		Usage:  "interval of TCP keep-alive probes, 0 disables them",
+		Value:  defaultTCPKeepAlive,
+		EnvVar: envPrefix + "TCP_KEEPALIVE",
+	},
+	cli.IntFlag{
+		Name:   "tls-session-cache",
+		Usage:  "number of TLS sessions cached to resume TLS connections without a full handshake, 0 disables resumption",
+		EnvVar: envPrefix + "TLS_SESSION_CACHE",
+	},
 	cli.DurationFlag{
 		Name:   "conn-read-deadline",
 		Usage:  "custom connection READ deadline",
@@ -122,8 +158,39 @@ var encS3Flag = cli.StringSliceFlag{
 	EnvVar: envPrefix + "ENC_S3",
 }
 
+var cseKeyFlag = cli.StringFlag{
+	Name:   "cse-key",
+	Usage:  "encrypt uploaded objects and decrypt downloaded objects on the client side with a 32 byte key. Formats: RawBase64 or Hex.",
+	EnvVar: envPrefix + "CSE_KEY",
+}
+
+var filterFromFlag = cli.StringFlag{
+	Name:  "filter-from",
+	Usage: "read ordered include/exclude rules from FILE, rsync/rclone filter syntax",
+}
+
+var listParallelFlag = cli.IntFlag{
+	Name:  "list-parallel",
+	Usage: "list up to N top-level prefixes of an S3 bucket concurrently, to speed up listing huge buckets",
+}
+
+var inventoryFlag = cli.StringFlag{
+	Name:  "inventory",
+	Usage: "enumerate objects from the S3 Inventory report described by this manifest.json (CSV format) instead of listing the bucket",
+}
+
+var verboseObjectsFlag = cli.BoolFlag{
+	Name:  "verbose-objects",
+	Usage: "print a line for every object, by default a line per second is printed past the first 1000 objects",
+}
+
+var smallFileThresholdFlag = cli.StringFlag{
+	Name:  "small-file-threshold",
+	Usage: "upload objects up to this size (e.g. 256KiB, at most 16MiB) in a single request from a pooled buffer",
+}
+
 var checksumFlag = cli.StringFlag{
-	Name:  "checksum",
+	Name:  "checksum, checksum-algorithm",
 	Usage: "Add checksum to uploaded object. Values: MD5, CRC32, CRC32C, SHA1 or SHA256. Requires server trailing headers (AWS, MinIO)",
 	Value: "",
 }
@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net"
@@ -41,6 +42,10 @@ import (
 const (
 	globalMCConfigVersion = "10"
 
+	// Defaults of the connection tuning flags.
+	defaultMaxIdleConnsPerHost = 1024
+	defaultTCPKeepAlive        = 15 * time.Second
+
 	globalMCConfigFile = "config.json"
 	globalMCCertsDir   = "certs"
 	globalMCCAsDir     = "CAs"
@@ -75,6 +80,7 @@ var (
 	globalInsecure     = false               // Insecure flag set via command line
 	globalResolvers    map[string]netip.Addr // Custom mappings from HOST[:PORT] to IP
 	globalAirgapped    = false               // Airgapped flag set via command line
+	globalDryRun       = false               // Dry run flag set via command line
 	globalSubnetConfig []madmin.SubsysConfig // Subnet config
 
 	// GlobalDevMode is set to true if the program is running in development mode
@@ -89,6 +95,11 @@ var (
 	globalLimitUpload   uint64
 	globalLimitDownload uint64
 
+	globalMaxIdleConnsPerHost = defaultMaxIdleConnsPerHost // Idle connections kept per host
+	globalHTTP2               = false                      // Negotiate HTTP/2 with TLS endpoints
+	globalTCPKeepAlive        = defaultTCPKeepAlive        // TCP keep-alive interval, negative disables
+	globalTLSSessionCache     tls.ClientSessionCache       // TLS session resumption cache, nil disables
+
 	globalContext, globalCancel = context.WithCancel(context.Background())
 )
 
@@ -119,20 +130,25 @@ func parsePagerDisableFlag(args []string) {
 func setGlobalsFromContext(ctx *cli.Context) error {
 	quiet := ctx.Bool("quiet") || ctx.GlobalBool("quiet")
 	debug := ctx.Bool("debug") || ctx.GlobalBool("debug")
-	json := ctx.Bool("json") || ctx.GlobalBool("json")
-	noColor := ctx.Bool("no-color") || ctx.GlobalBool("no-color")
+	jsonLines := ctx.Bool("json-lines") || ctx.GlobalBool("json-lines")
+	json := ctx.Bool("json") || ctx.GlobalBool("json") || jsonLines
+	// Also honour the NO_COLOR convention, see https://no-color.org
+	noColor := ctx.Bool("no-color") || ctx.GlobalBool("no-color") || os.Getenv("NO_COLOR") != ""
 	insecure := ctx.Bool("insecure") || ctx.GlobalBool("insecure")
 	devMode := ctx.Bool("dev") || ctx.GlobalBool("dev")
 	airgapped := ctx.Bool("airgap") || ctx.GlobalBool("airgap")
+	dryRun := ctx.Bool("dry-run") || ctx.GlobalBool("dry-run")
 
 	globalQuiet = globalQuiet || quiet
 	globalDebug = globalDebug || debug
-	globalJSONLine = !isTerminal() && json
+	// Indented JSON is only printed on a terminal, unless --json-lines is set.
+	globalJSONLine = globalJSONLine || jsonLines || (!isTerminal() && json)
 	globalJSON = globalJSON || json
 	globalNoColor = globalNoColor || noColor || globalJSONLine
 	globalInsecure = globalInsecure || insecure
 	GlobalDevMode = GlobalDevMode || devMode
 	globalAirgapped = globalAirgapped || airgapped
+	globalDryRun = globalDryRun || dryRun
 
 	// Disable colorified messages if requested.
 	if globalNoColor || globalQuiet {
@@ -175,6 +191,34 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 		}
 	}
 
+	switch {
+	case ctx.IsSet("max-idle-conns-per-host"):
+		globalMaxIdleConnsPerHost = ctx.Int("max-idle-conns-per-host")
+	case ctx.GlobalIsSet("max-idle-conns-per-host"):
+		globalMaxIdleConnsPerHost = ctx.GlobalInt("max-idle-conns-per-host")
+	}
+
+	globalHTTP2 = globalHTTP2 || ctx.Bool("http2") || ctx.GlobalBool("http2")
+
+	switch {
+	case ctx.IsSet("tcp-keepalive"):
+		globalTCPKeepAlive = ctx.Duration("tcp-keepalive")
+	case ctx.GlobalIsSet("tcp-keepalive"):
+		globalTCPKeepAlive = ctx.GlobalDuration("tcp-keepalive")
+	}
+	if globalTCPKeepAlive <= 0 {
+		// A negative interval disables the keep-alive probes of net.Dialer.
+		globalTCPKeepAlive = -1
+	}
+
+	tlsSessionCache := ctx.Int("tls-session-cache")
+	if tlsSessionCache <= 0 {
+		tlsSessionCache = ctx.GlobalInt("tls-session-cache")
+	}
+	if tlsSessionCache > 0 && globalTLSSessionCache == nil {
+		globalTLSSessionCache = tls.NewLRUClientSessionCache(tlsSessionCache)
+	}
+
 	dnsEntries := ctx.StringSlice("resolve")
 	if len(dnsEntries) > 0 {
 		globalResolvers = make(map[string]netip.Addr, len(dnsEntries))
@@ -200,5 +244,17 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 			globalResolvers[host] = addr
 		}
 	}
+
+	// Refuse --dry-run for the mutating commands which would ignore it.
+	if globalDryRun && ctx.Command.Name != "" && len(ctx.Command.Subcommands) == 0 {
+		if cmdPath := commandPath(ctx); !isDryRunSupported(cmdPath) {
+			fatalIf(errInvalidArgument().Trace(cmdPath), "--dry-run is not supported by `mc %s`.", cmdPath)
+		}
+	}
+
+	// Record mutating commands in the audit log, if enabled.
+	startAuditLog(ctx)
+	// Tell about a newer release, if the last update check found one.
+	notifyUpdate(ctx)
 	return nil
 }
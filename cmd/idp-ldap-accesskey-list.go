@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"errors"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/madmin-go/v3"
@@ -46,6 +47,26 @@ var idpLdapAccesskeyListFlags = []cli.Flag{
 		Name:  "all",
 		Usage: "list all access keys for all LDAP users",
 	},
+	cli.BoolFlag{
+		Name:  "table",
+		Usage: "print one access key per row",
+	},
+}
+
+// accesskeyExpiryFlags select access keys by their expiration.
+var accesskeyExpiryFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "expired",
+		Usage: "only list expired access keys",
+	},
+	cli.StringFlag{
+		Name:  "expiring-within",
+		Usage: "only list access keys expiring within a duration, e.g. 7d",
+	},
+	cli.BoolFlag{
+		Name:  "never-expires",
+		Usage: "only list access keys which never expire",
+	},
 }
 
 var idpLdapAccesskeyListCmd = cli.Command{
@@ -54,7 +75,7 @@ var idpLdapAccesskeyListCmd = cli.Command{
 	Usage:        "list access key pairs for LDAP",
 	Action:       mainIDPLdapAccesskeyList,
 	Before:       setGlobalsFromContext,
-	Flags:        append(idpLdapAccesskeyListFlags, globalFlags...),
+	Flags:        append(append(idpLdapAccesskeyListFlags, accesskeyExpiryFlags...), globalFlags...),
 	OnUsageError: onUsageError,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -86,11 +107,18 @@ EXAMPLES:
 
   7. Get authenticated user and associated access keys in local server (if not admin)
 	 {{.Prompt}} {{.HelpName}} local/
+
+  8. Get list of access keys of all LDAP users which expired or expire within the next week
+	 {{.Prompt}} {{.HelpName}} local/ --expired --expiring-within 7d
+
+  9. Get a table of the access keys of all LDAP users which never expire
+	 {{.Prompt}} {{.HelpName}} local/ --never-expires --table
 `,
 }
 
 func mainIDPLdapAccesskeyList(ctx *cli.Context) error {
 	aliasedURL, tentativeAll, users, opts := commonAccesskeyList(ctx)
+	expiry := parseAccesskeyExpiryFilter(ctx, opts)
 
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
@@ -106,19 +134,71 @@ func mainIDPLdapAccesskeyList(ctx *cli.Context) error {
 		fatalIf(probe.NewError(e), "Unable to list access keys.")
 	}
 
+	lists := make([]userAccesskeyList, 0, len(accessKeysMap))
 	for dn, accessKeys := range accessKeysMap {
-		m := userAccesskeyList{
+		lists = append(lists, userAccesskeyList{
 			Status:          "success",
 			User:            dn,
 			ServiceAccounts: accessKeys.ServiceAccounts,
 			STSKeys:         accessKeys.STSKeys,
 			LDAP:            true,
-		}
-		printMsg(m)
+		})
 	}
+	printAccesskeyLists(lists, expiry, ctx.Bool("table"))
 	return nil
 }
 
+// accesskeyExpiryFilter selects access keys by their expiration, keys
+// matching any of the set conditions are selected.
+type accesskeyExpiryFilter struct {
+	expired bool
+	within  time.Duration
+	never   bool
+}
+
+// parseAccesskeyExpiryFilter parses the accesskeyExpiryFlags.
+func parseAccesskeyExpiryFilter(ctx *cli.Context, opts madmin.ListAccessKeysOpts) (f accesskeyExpiryFilter) {
+	f.expired = ctx.Bool("expired")
+	f.never = ctx.Bool("never-expires")
+	if v := ctx.String("expiring-within"); v != "" {
+		d, e := ParseDuration(v)
+		if e != nil || d <= 0 {
+			fatalIf(errInvalidArgument().Trace(v), "Unable to parse --expiring-within=`"+v+"`.")
+		}
+		f.within = time.Duration(d)
+	}
+	if f.isSet() && opts.ListType == madmin.AccessKeyListUsersOnly {
+		fatalIf(errInvalidArgument().Trace(), "--expired, --expiring-within and --never-expires cannot be used with --users-only")
+	}
+	return f
+}
+
+func (f accesskeyExpiryFilter) isSet() bool {
+	return f.expired || f.within > 0 || f.never
+}
+
+// match returns true if the access key is selected at time now.
+func (f accesskeyExpiryFilter) match(info madmin.ServiceAccountInfo, now time.Time) bool {
+	expiration := nilExpiry(info.Expiration)
+	if expiration == nil || expiration.IsZero() {
+		return f.never
+	}
+	if !expiration.After(now) {
+		return f.expired
+	}
+	return f.within > 0 && !expiration.After(now.Add(f.within))
+}
+
+func (f accesskeyExpiryFilter) filter(keys []madmin.ServiceAccountInfo, now time.Time) []madmin.ServiceAccountInfo {
+	var selected []madmin.ServiceAccountInfo
+	for _, k := range keys {
+		if f.match(k, now) {
+			selected = append(selected, k)
+		}
+	}
+	return selected
+}
+
 func commonAccesskeyList(ctx *cli.Context) (aliasedURL string, tentativeAll bool, users []string, opts madmin.ListAccessKeysOpts) {
 	if len(ctx.Args()) == 0 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
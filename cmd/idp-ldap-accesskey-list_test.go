@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestAccesskeyExpiryFilter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	expiry := func(t time.Time) madmin.ServiceAccountInfo {
+		return madmin.ServiceAccountInfo{Expiration: &t}
+	}
+	expired := expiry(now.Add(-time.Hour))
+	expiringSoon := expiry(now.Add(12 * time.Hour))
+	expiringLater := expiry(now.Add(30 * 24 * time.Hour))
+	noExpiration := madmin.ServiceAccountInfo{}
+	sentinel := expiry(timeSentinel)
+
+	testCases := []struct {
+		filter   accesskeyExpiryFilter
+		info     madmin.ServiceAccountInfo
+		expected bool
+	}{
+		{filter: accesskeyExpiryFilter{expired: true}, info: expired, expected: true},
+		{filter: accesskeyExpiryFilter{expired: true}, info: expiringSoon, expected: false},
+		{filter: accesskeyExpiryFilter{expired: true}, info: noExpiration, expected: false},
+		{filter: accesskeyExpiryFilter{within: 24 * time.Hour}, info: expiringSoon, expected: true},
+		{filter: accesskeyExpiryFilter{within: 24 * time.Hour}, info: expiringLater, expected: false},
+		{filter: accesskeyExpiryFilter{within: 24 * time.Hour}, info: expired, expected: false},
+		{filter: accesskeyExpiryFilter{within: 24 * time.Hour}, info: sentinel, expected: false},
+		{filter: accesskeyExpiryFilter{never: true}, info: noExpiration, expected: true},
+		{filter: accesskeyExpiryFilter{never: true}, info: sentinel, expected: true},
+		{filter: accesskeyExpiryFilter{never: true}, info: expiringLater, expected: false},
+		{filter: accesskeyExpiryFilter{expired: true, never: true}, info: expired, expected: true},
+		{filter: accesskeyExpiryFilter{expired: true, never: true}, info: expiringSoon, expected: false},
+	}
+
+	for i, testCase := range testCases {
+		if got := testCase.filter.match(testCase.info, now); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	keys := []madmin.ServiceAccountInfo{expired, expiringSoon, expiringLater, noExpiration, sentinel}
+	if got := (accesskeyExpiryFilter{expired: true, within: 24 * time.Hour}).filter(keys, now); len(got) != 2 {
+		t.Errorf("expected the expired and expiring keys, got %d keys", len(got))
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/v3/wildcard"
 )
 
 var idpLdapPolicyAttachFlags = []cli.Flag{
@@ -123,6 +124,7 @@ type policyAssociationMessage struct {
 	PoliciesDetached []string `json:"policiesDetached,omitempty"`
 	User             string   `json:"user,omitempty"`
 	Group            string   `json:"group,omitempty"`
+	DryRun           bool     `json:"dryRun,omitempty"`
 }
 
 func (m policyAssociationMessage) String() string {
@@ -147,6 +149,12 @@ func (m policyAssociationMessage) String() string {
 		entityS = style.Render("From Group:")
 		entity = m.Group
 	}
+	if m.DryRun {
+		policiesS = style.Render("Would attach policies:")
+		if !m.attach {
+			policiesS = style.Render("Would detach policies:")
+		}
+	}
 	return fmt.Sprintf("%s %v\n%s %s\n", policiesS, policies, entityS, entity)
 }
 
@@ -250,15 +258,15 @@ func mainIDPLdapPolicyDetach(ctx *cli.Context) error {
 var idpLdapPolicyEntitiesFlags = []cli.Flag{
 	cli.StringSliceFlag{
 		Name:  "user, u",
-		Usage: "list policies associated with user(s)",
+		Usage: "list policies associated with user(s), wildcards allowed",
 	},
 	cli.StringSliceFlag{
 		Name:  "group, g",
-		Usage: "list policies associated with group(s)",
+		Usage: "list policies associated with group(s), wildcards allowed",
 	},
 	cli.StringSliceFlag{
 		Name:  "policy, p",
-		Usage: "list users or groups associated with policy",
+		Usage: "list users or groups associated with policy, wildcards allowed",
 	},
 }
 
@@ -296,6 +304,13 @@ EXAMPLES:
               --policy finteam-policy
               --user 'uid=bobfisher,ou=people,ou=hwengg,dc=min,dc=io' \
               --group 'cn=projectb,ou=groups,ou=swengg,dc=min,dc=io'
+  6. List all policies associated with the users of the 'swengg' organizational unit
+     {{.Prompt}} {{.HelpName}} play/ --user '*,ou=people,ou=swengg,dc=min,dc=io'
+  7. List all LDAP entities associated with policies starting with 'finteam-'
+     {{.Prompt}} {{.HelpName}} play/ --policy 'finteam-*'
+
+NOTE:
+  Wildcard patterns for users and groups only match DNs with a policy directly attached to them.
 `,
 }
 
@@ -304,9 +319,10 @@ func mainIDPLdapPolicyEntities(ctx *cli.Context) error {
 		showCommandHelpAndExit(ctx, 1)
 	}
 
-	usersToQuery := ctx.StringSlice("user")
-	groupsToQuery := ctx.StringSlice("group")
-	policiesToQuery := ctx.StringSlice("policy")
+	var query, patterns madmin.PolicyEntitiesQuery
+	query.Users, patterns.Users = splitWildcards(ctx.StringSlice("user"))
+	query.Groups, patterns.Groups = splitWildcards(ctx.StringSlice("group"))
+	query.Policy, patterns.Policy = splitWildcards(ctx.StringSlice("policy"))
 
 	args := ctx.Args()
 
@@ -316,18 +332,77 @@ func mainIDPLdapPolicyEntities(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	res, e := client.GetLDAPPolicyEntities(globalContext,
-		madmin.PolicyEntitiesQuery{
-			Users:  usersToQuery,
-			Groups: groupsToQuery,
-			Policy: policiesToQuery,
-		})
+	if len(patterns.Users) > 0 || len(patterns.Groups) > 0 || len(patterns.Policy) > 0 {
+		// Resolve the patterns against all the policy mappings.
+		all, e := client.GetLDAPPolicyEntities(globalContext, madmin.PolicyEntitiesQuery{})
+		fatalIf(probe.NewError(e), "Unable to fetch LDAP policy entities")
+
+		query = expandPolicyEntitiesQuery(query, patterns, all)
+		if len(query.Users) == 0 && len(query.Groups) == 0 && len(query.Policy) == 0 {
+			// Nothing matched, an empty query would return everything.
+			printMsg(policyEntitiesFrom(madmin.PolicyEntitiesResult{Timestamp: all.Timestamp}))
+			return nil
+		}
+	}
+
+	res, e := client.GetLDAPPolicyEntities(globalContext, query)
 	fatalIf(probe.NewError(e), "Unable to fetch LDAP policy entities")
 
 	printMsg(policyEntitiesFrom(res))
 	return nil
 }
 
+// splitWildcards separates wildcard patterns from exact values.
+func splitWildcards(values []string) (exact, patterns []string) {
+	for _, v := range values {
+		if strings.ContainsAny(v, "*?") {
+			patterns = append(patterns, v)
+		} else {
+			exact = append(exact, v)
+		}
+	}
+	return exact, patterns
+}
+
+// matchAnyWildcard returns true if s matches one of the patterns, DNs
+// and policy names are compared case insensitively.
+func matchAnyWildcard(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if wildcard.Match(strings.ToLower(pattern), strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPolicyEntitiesQuery adds the policies, users and groups of the
+// policy mappings in all matching the patterns to the query.
+func expandPolicyEntitiesQuery(query, patterns madmin.PolicyEntitiesQuery, all madmin.PolicyEntitiesResult) madmin.PolicyEntitiesQuery {
+	users := set.CreateStringSet(query.Users...)
+	groups := set.CreateStringSet(query.Groups...)
+	policies := set.CreateStringSet(query.Policy...)
+	for _, m := range all.PolicyMappings {
+		if matchAnyWildcard(patterns.Policy, m.Policy) {
+			policies.Add(m.Policy)
+		}
+		for _, user := range m.Users {
+			if matchAnyWildcard(patterns.Users, user) {
+				users.Add(user)
+			}
+		}
+		for _, group := range m.Groups {
+			if matchAnyWildcard(patterns.Groups, group) {
+				groups.Add(group)
+			}
+		}
+	}
+	return madmin.PolicyEntitiesQuery{
+		Users:  users.ToSlice(),
+		Groups: groups.ToSlice(),
+		Policy: policies.ToSlice(),
+	}
+}
+
 type policyEntities struct {
 	Status string                      `json:"status"`
 	Result madmin.PolicyEntitiesResult `json:"result"`
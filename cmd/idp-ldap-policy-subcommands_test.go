@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestMatchAnyWildcard(t *testing.T) {
+	testCases := []struct {
+		patterns []string
+		s        string
+		expected bool
+	}{
+		{patterns: []string{"uid=*,ou=people,dc=min,dc=io"}, s: "uid=bobfisher,ou=people,dc=min,dc=io", expected: true},
+		{patterns: []string{"UID=*,OU=People,DC=min,DC=io"}, s: "uid=bobfisher,ou=people,dc=min,dc=io", expected: true},
+		{patterns: []string{"uid=*,ou=people,dc=min,dc=io"}, s: "UID=BobFisher,OU=People,DC=min,DC=io", expected: true},
+		{patterns: []string{"cn=*,ou=groups,dc=min,dc=io"}, s: "uid=bobfisher,ou=people,dc=min,dc=io", expected: false},
+		{patterns: []string{"read*", "consoleAdmin"}, s: "ConsoleAdmin", expected: true},
+		{patterns: []string{"read*"}, s: "writeonly", expected: false},
+		{patterns: nil, s: "readwrite", expected: false},
+	}
+
+	for i, testCase := range testCases {
+		if got := matchAnyWildcard(testCase.patterns, testCase.s); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestExpandPolicyEntitiesQuery(t *testing.T) {
+	all := madmin.PolicyEntitiesResult{
+		PolicyMappings: []madmin.PolicyEntities{
+			{
+				Policy: "readwrite",
+				Users:  []string{"uid=bobfisher,ou=people,dc=min,dc=io"},
+				Groups: []string{"cn=projectb,ou=groups,dc=min,dc=io"},
+			},
+			{
+				Policy: "readonly",
+				Users:  []string{"uid=dillon,ou=people,dc=min,dc=io"},
+			},
+			{
+				Policy: "consoleAdmin",
+				Groups: []string{"cn=admins,ou=groups,dc=min,dc=io"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		query    madmin.PolicyEntitiesQuery
+		patterns madmin.PolicyEntitiesQuery
+		expected madmin.PolicyEntitiesQuery
+	}{
+		// Case insensitive DN patterns.
+		{
+			patterns: madmin.PolicyEntitiesQuery{
+				Users:  []string{"UID=*,OU=People,DC=min,DC=io"},
+				Groups: []string{"CN=Project*,ou=groups,dc=min,dc=io"},
+			},
+			expected: madmin.PolicyEntitiesQuery{
+				Users:  []string{"uid=bobfisher,ou=people,dc=min,dc=io", "uid=dillon,ou=people,dc=min,dc=io"},
+				Groups: []string{"cn=projectb,ou=groups,dc=min,dc=io"},
+				Policy: []string{},
+			},
+		},
+		// Policy patterns, added to the policies already queried.
+		{
+			query:    madmin.PolicyEntitiesQuery{Policy: []string{"diagnostics"}},
+			patterns: madmin.PolicyEntitiesQuery{Policy: []string{"read*"}},
+			expected: madmin.PolicyEntitiesQuery{
+				Users:  []string{},
+				Groups: []string{},
+				Policy: []string{"diagnostics", "readonly", "readwrite"},
+			},
+		},
+		// No match leaves the query unchanged.
+		{
+			query: madmin.PolicyEntitiesQuery{Users: []string{"uid=dillon,ou=people,dc=min,dc=io"}},
+			patterns: madmin.PolicyEntitiesQuery{
+				Users:  []string{"uid=*,ou=robots,dc=min,dc=io"},
+				Groups: []string{"cn=nobody*"},
+				Policy: []string{"write*"},
+			},
+			expected: madmin.PolicyEntitiesQuery{
+				Users:  []string{"uid=dillon,ou=people,dc=min,dc=io"},
+				Groups: []string{},
+				Policy: []string{},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		got := expandPolicyEntitiesQuery(testCase.query, testCase.patterns, all)
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, got)
+		}
+	}
+}
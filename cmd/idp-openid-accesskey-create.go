@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var idpOpenidAccesskeyCreateCmd = cli.Command{
+	Name:         "create",
+	Usage:        "create access key pairs for OpenID users",
+	Action:       mainIDPOpenidAccesskeyCreate,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminAccesskeyCreateFlags, globalFlags...),
+	OnUsageError: onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [USER]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Create a new access key pair with the same policy as the authenticated OpenID user
+     {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Create a new access key pair with custom access key and secret key
+     {{.Prompt}} {{.HelpName}} myminio/ --access-key myaccesskey --secret-key mysecretkey
+
+  3. Create a new access key pair that expires in 1 day
+     {{.Prompt}} {{.HelpName}} myminio/ --expiry-duration 24h
+
+  4. Create a new access key pair with a name and description
+     {{.Prompt}} {{.HelpName}} myminio/ --name "ci-runner" --description "used by the CI pipeline"
+`,
+}
+
+func mainIDPOpenidAccesskeyCreate(ctx *cli.Context) error {
+	return commonAccesskeyCreate(ctx, false)
+}
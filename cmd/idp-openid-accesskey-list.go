@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Number of users whose access keys are listed concurrently.
+const openidAccesskeyListConcurrency = 8
+
+var idpOpenidAccesskeyListCmd = cli.Command{
+	Name:         "list",
+	ShortName:    "ls",
+	Usage:        "list access key pairs for OpenID",
+	Action:       mainIDPOpenidAccesskeyList,
+	Before:       setGlobalsFromContext,
+	Flags:        append(accesskeyExpiryFlags, globalFlags...),
+	OnUsageError: onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [USER...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Get list of access keys of the authenticated OpenID user
+	 {{.Prompt}} {{.HelpName}} local/
+
+  2. Get list of access keys associated with OpenID users 'alice' and 'bob' (if admin)
+	 {{.Prompt}} {{.HelpName}} local/ alice bob
+
+  3. Get list of access keys of the authenticated OpenID user which expire within the next week
+	 {{.Prompt}} {{.HelpName}} local/ --expiring-within 7d
+`,
+}
+
+// openidAccesskeys is the result of listing the access keys of a user.
+type openidAccesskeys struct {
+	resp madmin.ListServiceAccountsResp
+	err  error
+}
+
+// listOpenidAccesskeys lists the access keys of users concurrently, an
+// empty user lists the access keys of the authenticated user.
+func listOpenidAccesskeys(ctx context.Context, client *madmin.AdminClient, users []string) []openidAccesskeys {
+	results := make([]openidAccesskeys, len(users))
+	sem := make(chan struct{}, openidAccesskeyListConcurrency)
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, user string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].resp, results[i].err = client.ListServiceAccounts(ctx, user)
+		}(i, user)
+	}
+	wg.Wait()
+	return results
+}
+
+func mainIDPOpenidAccesskeyList(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	users := args.Tail()
+	if len(users) == 0 {
+		users = []string{""}
+	}
+	expiry := parseAccesskeyExpiryFilter(ctx, madmin.ListAccessKeysOpts{})
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	now := time.Now()
+	for i, result := range listOpenidAccesskeys(globalContext, client, users) {
+		user := users[i]
+		fatalIf(probe.NewError(result.err), "Unable to list access keys of `"+user+"`.")
+
+		accounts := result.resp.Accounts
+		if user == "" && len(accounts) > 0 {
+			user = accounts[0].ParentUser
+		}
+		if expiry.isSet() {
+			if accounts = expiry.filter(accounts, now); len(accounts) == 0 {
+				continue
+			}
+		}
+		printMsg(userAccesskeyList{
+			Status:          "success",
+			User:            user,
+			ServiceAccounts: accounts,
+		})
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ var (
 		idpOpenidInfoCmd,
 		idpOpenidEnableCmd,
 		idpOpenidDisableCmd,
+		idpOpenidAccesskeyCmd,
 		// TODO: idpOpenidPolicyCmd,
 	}
 	idpOpenidCmd = cli.Command{
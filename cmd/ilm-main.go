@@ -27,6 +27,7 @@ var ilmSubcommands = []cli.Command{
 	ilmRuleCmd,
 	ilmTierCmd,
 	ilmRestoreCmd,
+	ilmPreviewCmd,
 }
 
 var ilmCmd = cli.Command{
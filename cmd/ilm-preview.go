@@ -0,0 +1,373 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/olekukonko/tablewriter"
+)
+
+var ilmPreviewFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "versions",
+		Usage: "also evaluate the noncurrent versions rules",
+	},
+	cli.IntFlag{
+		Name:  "days",
+		Usage: "evaluate the rules as if N days have passed",
+	},
+}
+
+var ilmPreviewCmd = cli.Command{
+	Name:         "preview",
+	Usage:        "preview the objects affected by the lifecycle rules",
+	Action:       mainILMPreview,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(ilmPreviewFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+DESCRIPTION:
+  Evaluate the lifecycle rules of a bucket against its current objects and report,
+  for each rule, the number of objects and bytes that would expire or transition.
+  Nothing is modified. Rules filtering on tags require a server returning object
+  tags in listings, such as MinIO.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Preview the objects that are due for expiration or transition in the bucket "mybucket".
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  2. Preview the objects affected by the lifecycle rules in 30 days, including noncurrent versions.
+     {{.Prompt}} {{.HelpName}} --versions --days 30 myminio/mybucket
+
+  3. Preview the objects affected by the lifecycle rules under the prefix "logs/".
+     {{.Prompt}} {{.HelpName}} myminio/mybucket/logs/
+`,
+}
+
+// ilmPreviewStats counts objects and their size.
+type ilmPreviewStats struct {
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+}
+
+func (s *ilmPreviewStats) add(size int64) {
+	s.Objects++
+	s.Bytes += size
+}
+
+func (s ilmPreviewStats) String() string {
+	if s.Objects == 0 {
+		return "-"
+	}
+	return humanize.Comma(s.Objects) + " (" + humanize.IBytes(uint64(s.Bytes)) + ")"
+}
+
+// ilmPreviewRule holds the objects affected by a lifecycle rule.
+type ilmPreviewRule struct {
+	ID                   string          `json:"id"`
+	Expire               ilmPreviewStats `json:"expire"`
+	Transition           ilmPreviewStats `json:"transition"`
+	NoncurrentExpire     ilmPreviewStats `json:"noncurrentExpire"`
+	NoncurrentTransition ilmPreviewStats `json:"noncurrentTransition"`
+}
+
+// ilmPreviewMessage container for ilm preview messages
+type ilmPreviewMessage struct {
+	Status   string           `json:"status"`
+	Target   string           `json:"target"`
+	At       time.Time        `json:"at"`
+	Versions bool             `json:"-"`
+	Scanned  ilmPreviewStats  `json:"scanned"`
+	Rules    []ilmPreviewRule `json:"rules"`
+}
+
+// JSON jsonified ilm preview message
+func (m ilmPreviewMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// String colorized ilm preview message
+func (m ilmPreviewMessage) String() string {
+	var s strings.Builder
+
+	table := tablewriter.NewWriter(&s)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	header := []string{"RULE", "EXPIRE", "TRANSITION"}
+	if m.Versions {
+		header = append(header, "NONCURRENT EXPIRE", "NONCURRENT TRANSITION")
+	}
+	table.SetHeader(header)
+	for _, rule := range m.Rules {
+		row := []string{rule.ID, rule.Expire.String(), rule.Transition.String()}
+		if m.Versions {
+			row = append(row, rule.NoncurrentExpire.String(), rule.NoncurrentTransition.String())
+		}
+		table.Append(row)
+	}
+	table.Render()
+
+	s.WriteString("\nScanned " + m.Scanned.String() + " objects as of " + m.At.Format(printDate))
+	return s.String()
+}
+
+// ilmExpectedTime returns the time at which an action applies on an
+// object, actions are due at the midnight UTC following modTime + days.
+func ilmExpectedTime(modTime time.Time, days int) time.Time {
+	if days == 0 {
+		return modTime
+	}
+	return modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour).Truncate(24 * time.Hour)
+}
+
+// ilmRuleMatches returns true if the rule is enabled and its filter matches the object.
+func ilmRuleMatches(rule lifecycle.Rule, key string, size int64, tags map[string]string) bool {
+	if rule.Status != "Enabled" {
+		return false
+	}
+
+	f := rule.RuleFilter
+	prefix := rule.Prefix
+	if f.Prefix != "" {
+		prefix = f.Prefix
+	} else if f.And.Prefix != "" {
+		prefix = f.And.Prefix
+	}
+	if !strings.HasPrefix(key, prefix) {
+		return false
+	}
+
+	ruleTags := f.And.Tags
+	if !f.Tag.IsEmpty() {
+		ruleTags = []lifecycle.Tag{f.Tag}
+	}
+	for _, tag := range ruleTags {
+		if v, ok := tags[tag.Key]; !ok || v != tag.Value {
+			return false
+		}
+	}
+
+	szLt, szGt := f.ObjectSizeLessThan, f.ObjectSizeGreaterThan
+	if szLt == 0 {
+		szLt = f.And.ObjectSizeLessThan
+	}
+	if szGt == 0 {
+		szGt = f.And.ObjectSizeGreaterThan
+	}
+	if szLt > 0 && size >= szLt {
+		return false
+	}
+	if szGt > 0 && size <= szGt {
+		return false
+	}
+	return true
+}
+
+// ilmRuleHasTags returns true if one of the rules filters on object tags.
+func ilmRuleHasTags(rules []lifecycle.Rule) bool {
+	for _, rule := range rules {
+		if !rule.RuleFilter.Tag.IsEmpty() || len(rule.RuleFilter.And.Tags) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ilmPreview evaluates lifecycle rules against a listing of objects, the
+// versions of a key are expected to be listed from the latest to the oldest.
+type ilmPreview struct {
+	rules   []lifecycle.Rule
+	now     time.Time
+	scanned ilmPreviewStats
+	results []ilmPreviewRule
+
+	key            string
+	successorTime  time.Time
+	noncurrentSeen int
+}
+
+func newILMPreview(rules []lifecycle.Rule, now time.Time) *ilmPreview {
+	p := &ilmPreview{rules: rules, now: now}
+	for _, rule := range rules {
+		p.results = append(p.results, ilmPreviewRule{ID: rule.ID})
+	}
+	return p
+}
+
+func (p *ilmPreview) due(modTime time.Time, days lifecycle.ExpirationDays, date lifecycle.ExpirationDate) bool {
+	if !date.IsZero() {
+		return !date.After(p.now)
+	}
+	return !ilmExpectedTime(modTime, int(days)).After(p.now)
+}
+
+// add evaluates the rules against an object version.
+func (p *ilmPreview) add(key string, obj *ClientContent) {
+	latest := key != p.key
+	if latest {
+		p.key = key
+		p.noncurrentSeen = 0
+	}
+	defer func() { p.successorTime = obj.Time }()
+
+	if obj.IsDeleteMarker {
+		return
+	}
+	p.scanned.add(obj.Size)
+
+	if latest {
+		p.addCurrent(key, obj)
+		return
+	}
+	p.addNoncurrent(key, obj)
+	p.noncurrentSeen++
+}
+
+func (p *ilmPreview) addCurrent(key string, obj *ClientContent) {
+	transition := -1
+	for i, rule := range p.rules {
+		if !ilmRuleMatches(rule, key, obj.Size, obj.Tags) {
+			continue
+		}
+		exp := rule.Expiration
+		if exp.Days > 0 || !exp.Date.IsZero() {
+			if p.due(obj.Time, exp.Days, exp.Date) {
+				// Expiration takes precedence over transition.
+				p.results[i].Expire.add(obj.Size)
+				return
+			}
+		}
+		tr := rule.Transition
+		if transition < 0 && tr.StorageClass != "" && tr.StorageClass != obj.StorageClass && p.due(obj.Time, tr.Days, tr.Date) {
+			transition = i
+		}
+	}
+	if transition >= 0 {
+		p.results[transition].Transition.add(obj.Size)
+	}
+}
+
+func (p *ilmPreview) addNoncurrent(key string, obj *ClientContent) {
+	transition := -1
+	for i, rule := range p.rules {
+		if !ilmRuleMatches(rule, key, obj.Size, obj.Tags) {
+			continue
+		}
+		exp := rule.NoncurrentVersionExpiration
+		if exp.NoncurrentDays > 0 && p.noncurrentSeen >= exp.NewerNoncurrentVersions &&
+			p.due(p.successorTime, exp.NoncurrentDays, lifecycle.ExpirationDate{}) {
+			p.results[i].NoncurrentExpire.add(obj.Size)
+			return
+		}
+		tr := rule.NoncurrentVersionTransition
+		if transition < 0 && tr.StorageClass != "" && tr.StorageClass != obj.StorageClass &&
+			p.noncurrentSeen >= tr.NewerNoncurrentVersions &&
+			p.due(p.successorTime, tr.NoncurrentDays, lifecycle.ExpirationDate{}) {
+			transition = i
+		}
+	}
+	if transition >= 0 {
+		p.results[transition].NoncurrentTransition.add(obj.Size)
+	}
+}
+
+// checkILMPreviewSyntax - validate arguments passed by user
+func checkILMPreviewSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, globalErrorExitStatus)
+	}
+	if ctx.Int("days") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "--days cannot be negative.")
+	}
+}
+
+func mainILMPreview(cliCtx *cli.Context) error {
+	ctx, cancelILMPreview := context.WithCancel(globalContext)
+	defer cancelILMPreview()
+
+	checkILMPreviewSyntax(cliCtx)
+
+	urlStr := cliCtx.Args().Get(0)
+	versions := cliCtx.Bool("versions")
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr)
+
+	ilmCfg, _, err := client.GetLifecycle(ctx)
+	fatalIf(err.Trace(urlStr), "Unable to get lifecycle")
+	if len(ilmCfg.Rules) == 0 {
+		fatalIf(probe.NewError(errors.New("lifecycle configuration not set")).Trace(urlStr),
+			"Unable to preview lifecycle configuration")
+	}
+
+	now := UTCNow().AddDate(0, 0, cliCtx.Int("days"))
+	preview := newILMPreview(ilmCfg.Rules, now)
+
+	for content := range client.List(ctx, ListOptions{
+		Recursive:         true,
+		WithMetadata:      ilmRuleHasTags(ilmCfg.Rules),
+		WithOlderVersions: versions,
+		WithDeleteMarkers: versions,
+		ShowDir:           DirNone,
+	}) {
+		if content.Err != nil {
+			fatalIf(content.Err.Trace(urlStr), "Unable to list objects")
+		}
+		_, key := url2BucketAndObject(&content.URL)
+		preview.add(key, content)
+	}
+
+	printMsg(ilmPreviewMessage{
+		Target:   urlStr,
+		At:       now,
+		Versions: versions,
+		Scanned:  preview.scanned,
+		Rules:    preview.results,
+	})
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestILMExpectedTime(t *testing.T) {
+	modTime := time.Date(2024, 3, 10, 15, 4, 5, 0, time.UTC)
+	if got := ilmExpectedTime(modTime, 0); !got.Equal(modTime) {
+		t.Errorf("expected %v, got %v", modTime, got)
+	}
+	if got, expected := ilmExpectedTime(modTime, 1), time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestILMRuleMatches(t *testing.T) {
+	testCases := []struct {
+		rule     lifecycle.Rule
+		key      string
+		size     int64
+		tags     map[string]string
+		expected bool
+	}{
+		{lifecycle.Rule{Status: "Enabled"}, "a/b", 10, nil, true},
+		{lifecycle.Rule{Status: "Disabled"}, "a/b", 10, nil, false},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{Prefix: "a/"}}, "a/b", 10, nil, true},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{Prefix: "c/"}}, "a/b", 10, nil, false},
+		{lifecycle.Rule{Status: "Enabled", Prefix: "c/"}, "a/b", 10, nil, false},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{Tag: lifecycle.Tag{Key: "k", Value: "v"}}}, "a/b", 10, map[string]string{"k": "v"}, true},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{Tag: lifecycle.Tag{Key: "k", Value: "v"}}}, "a/b", 10, nil, false},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{And: lifecycle.And{
+			Prefix: "a/", Tags: []lifecycle.Tag{{Key: "k", Value: "v"}}, ObjectSizeGreaterThan: 5,
+		}}}, "a/b", 10, map[string]string{"k": "v"}, true},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{ObjectSizeLessThan: 10}}, "a/b", 10, nil, false},
+		{lifecycle.Rule{Status: "Enabled", RuleFilter: lifecycle.Filter{ObjectSizeGreaterThan: 10}}, "a/b", 10, nil, false},
+	}
+
+	for i, tc := range testCases {
+		if got := ilmRuleMatches(tc.rule, tc.key, tc.size, tc.tags); got != tc.expected {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+}
+
+func TestILMPreview(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rules := []lifecycle.Rule{
+		{
+			ID:         "expire-logs",
+			Status:     "Enabled",
+			RuleFilter: lifecycle.Filter{Prefix: "logs/"},
+			Expiration: lifecycle.Expiration{Days: 30},
+		},
+		{
+			ID:         "archive",
+			Status:     "Enabled",
+			Transition: lifecycle.Transition{Days: 10, StorageClass: "WARM"},
+			NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{
+				NoncurrentDays:          5,
+				NewerNoncurrentVersions: 1,
+			},
+		},
+	}
+
+	p := newILMPreview(rules, now)
+	versions := []struct {
+		key     string
+		age     time.Duration
+		size    int64
+		class   string
+		deleted bool
+	}{
+		{"logs/old", 60 * 24 * time.Hour, 100, "", false},      // expired by expire-logs
+		{"logs/new", 20 * 24 * time.Hour, 200, "", false},      // transitioned by archive
+		{"data/obj", 2 * 24 * time.Hour, 300, "", false},       // too recent
+		{"data/obj", 20 * 24 * time.Hour, 400, "", false},      // kept as newer noncurrent version
+		{"data/obj", 30 * 24 * time.Hour, 500, "", false},      // noncurrent expired by archive
+		{"data/tier", 20 * 24 * time.Hour, 600, "WARM", false}, // already transitioned
+		{"data/del", 1 * 24 * time.Hour, 0, "", true},          // delete marker
+	}
+	for _, v := range versions {
+		p.add(v.key, &ClientContent{
+			Time:           now.Add(-v.age),
+			Size:           v.size,
+			StorageClass:   v.class,
+			IsDeleteMarker: v.deleted,
+		})
+	}
+
+	if p.scanned.Objects != 6 || p.scanned.Bytes != 2100 {
+		t.Errorf("unexpected scanned stats %+v", p.scanned)
+	}
+	expected := []ilmPreviewRule{
+		{ID: "expire-logs", Expire: ilmPreviewStats{Objects: 1, Bytes: 100}},
+		{ID: "archive", Transition: ilmPreviewStats{Objects: 1, Bytes: 200}, NoncurrentExpire: ilmPreviewStats{Objects: 1, Bytes: 500}},
+	}
+	for i := range expected {
+		if p.results[i] != expected[i] {
+			t.Errorf("rule %s: expected %+v, got %+v", expected[i].ID, expected[i], p.results[i])
+		}
+	}
+}
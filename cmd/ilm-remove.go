@@ -78,6 +78,7 @@ type ilmRmMessage struct {
 	ID     string `json:"id"`
 	Target string `json:"target"`
 	All    bool   `json:"all"`
+	DryRun bool   `json:"dryRun,omitempty"`
 }
 
 func (i ilmRmMessage) String() string {
@@ -85,6 +86,12 @@ func (i ilmRmMessage) String() string {
 	if i.All {
 		msg = "Rules for `" + i.Target + "` removed."
 	}
+	if i.DryRun {
+		msg = "Would remove rule ID `" + i.ID + "` from target " + i.Target + "."
+		if i.All {
+			msg = "Would remove all rules for `" + i.Target + "`."
+		}
+	}
 	return console.Colorize(ilmThemeResultSuccess, msg)
 }
 
@@ -141,13 +148,16 @@ func mainILMRemove(cliCtx *cli.Context) error {
 		fatalIf(err.Trace(urlStr, cliCtx.String("id")), "Unable to remove rule by id")
 	}
 
-	fatalIf(client.SetLifecycle(ctx, ilmCfg).Trace(urlStr), "Unable to set lifecycle rules")
+	if !globalDryRun {
+		fatalIf(client.SetLifecycle(ctx, ilmCfg).Trace(urlStr), "Unable to set lifecycle rules")
+	}
 
 	printMsg(ilmRmMessage{
 		Status: "success",
 		ID:     cliCtx.String("id"),
 		All:    ilmAll,
 		Target: urlStr,
+		DryRun: globalDryRun,
 	})
 
 	return nil
@@ -21,11 +21,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 )
 
 // ilm restore specific flags.
@@ -48,6 +50,15 @@ var (
 			Name:  "version-id, vid",
 			Usage: "select a specific version id",
 		},
+		cli.StringFlag{
+			Name:  "tier",
+			Value: string(minio.TierExpedited),
+			Usage: "retrieval tier of objects archived in AWS S3 Glacier, valid values are [Standard, Bulk, Expedited]",
+		},
+		cli.BoolFlag{
+			Name:  "no-wait",
+			Usage: "only send the restore requests without waiting for the objects to be restored",
+		},
 	}
 )
 
@@ -87,6 +98,9 @@ EXAMPLES:
 
   5. Restore an SSE-C encrypted object.
      {{.Prompt}} {{.HelpName}} --enc-c "myminio/mybucket/=MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA" myminio/mybucket/myobject.txt
+
+  6. Restore all objects archived in AWS S3 Glacier Deep Archive under a prefix for 7 days with the bulk tier, without waiting.
+     {{.Prompt}} {{.HelpName}} --recursive --days 7 --tier bulk --no-wait s3/mybucket/dir/
 `,
 }
 
@@ -103,20 +117,50 @@ func checkILMRestoreSyntax(ctx *cli.Context) {
 	if ctx.Bool("version-id") && (ctx.Bool("recursive") || ctx.Bool("versions")) {
 		fatalIf(errDummy().Trace(), "You cannot combine --version-id with --recursive or --versions flags.")
 	}
+
+	if _, err := parseRestoreTier(ctx.String("tier")); err != nil {
+		fatalIf(err.Trace(ctx.String("tier")), "--tier should be one of Standard, Bulk or Expedited")
+	}
+}
+
+// parseRestoreTier - returns the retrieval tier, case-insensitively.
+func parseRestoreTier(tier string) (minio.TierType, *probe.Error) {
+	for _, t := range []minio.TierType{minio.TierStandard, minio.TierBulk, minio.TierExpedited} {
+		if strings.EqualFold(tier, string(t)) {
+			return t, nil
+		}
+	}
+	return "", errInvalidArgument().Trace(tier)
+}
+
+// isObjectArchived - returns true if the error means the object is archived
+// and must be restored before it can be read.
+func isObjectArchived(err *probe.Error) bool {
+	return minio.ToErrorResponse(err.ToGoError()).Code == "InvalidObjectState"
+}
+
+// restoreAndWaitObject - sends a restore request for an archived object and
+// waits until its restored copy can be read.
+func restoreAndWaitObject(ctx context.Context, targetAlias, targetURL, versionID string, days int, tier minio.TierType, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	err := restoreObject(ctx, targetAlias, targetURL, versionID, days, tier)
+	if err != nil && minio.ToErrorResponse(err.ToGoError()).Code != "RestoreAlreadyInProgress" {
+		return err
+	}
+	return waitRestoreObject(ctx, targetAlias, targetURL, versionID, encKeyDB)
 }
 
 // Send Restore S3 API
-func restoreObject(ctx context.Context, targetAlias, targetURL, versionID string, days int) *probe.Error {
+func restoreObject(ctx context.Context, targetAlias, targetURL, versionID string, days int, tier minio.TierType) *probe.Error {
 	clnt, err := newClientFromAlias(targetAlias, targetURL)
 	if err != nil {
 		return err
 	}
 
-	return clnt.Restore(ctx, versionID, days)
+	return clnt.Restore(ctx, versionID, days, tier)
 }
 
 // Send restore S3 API request to one or more objects depending on the arguments
-func sendRestoreRequests(ctx context.Context, targetAlias, targetURL, targetVersionID string, recursive, applyOnVersions bool, days int, restoreSentReq chan *probe.Error) {
+func sendRestoreRequests(ctx context.Context, targetAlias, targetURL, targetVersionID string, recursive, applyOnVersions bool, days int, tier minio.TierType, restoreSentReq chan *probe.Error) {
 	defer close(restoreSentReq)
 
 	client, err := newClientFromAlias(targetAlias, targetURL)
@@ -126,7 +170,7 @@ func sendRestoreRequests(ctx context.Context, targetAlias, targetURL, targetVers
 	}
 
 	if !recursive {
-		err := restoreObject(ctx, targetAlias, targetURL, targetVersionID, days)
+		err := restoreObject(ctx, targetAlias, targetURL, targetVersionID, days, tier)
 		restoreSentReq <- err
 		return
 	}
@@ -141,7 +185,7 @@ func sendRestoreRequests(ctx context.Context, targetAlias, targetURL, targetVers
 			errorIf(content.Err.Trace(client.GetURL().String()), "Unable to list folder.")
 			continue
 		}
-		err := restoreObject(ctx, targetAlias, content.URL.String(), content.VersionID, days)
+		err := restoreObject(ctx, targetAlias, content.URL.String(), content.VersionID, days, tier)
 		if err != nil {
 			restoreSentReq <- err
 			continue
@@ -178,7 +222,11 @@ func waitRestoreObject(ctx context.Context, targetAlias, targetURL, versionID st
 			return nil
 		}
 		// Restore still going on, wait for 5 seconds before checking again
-		time.Sleep(5 * time.Second)
+		select {
+		case <-ctx.Done():
+			return probe.NewError(ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
 	}
 }
 
@@ -264,7 +312,8 @@ func showRestoreStatus(restoreReqStatus, restoreFinishedStatus chan *probe.Error
 		fmt.Println("")
 	}
 
-	done = false
+	// Not waiting for the objects to be restored.
+	done = restoreFinishedStatus == nil
 
 	for !done {
 		select {
@@ -283,7 +332,9 @@ func showRestoreStatus(restoreReqStatus, restoreFinishedStatus chan *probe.Error
 	}
 
 	if !globalJSON {
-		fmt.Println("")
+		if restoreFinishedStatus != nil {
+			fmt.Println("")
+		}
 	} else {
 		type ilmRestore struct {
 			Status   string `json:"status"`
@@ -310,6 +361,8 @@ func mainILMRestore(cliCtx *cli.Context) (cErr error) {
 	recursive := cliCtx.Bool("recursive")
 	includeVersions := cliCtx.Bool("versions")
 	days := cliCtx.Int("days")
+	tier, _ := parseRestoreTier(cliCtx.String("tier"))
+	noWait := cliCtx.Bool("no-wait")
 
 	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
@@ -320,7 +373,10 @@ func mainILMRestore(cliCtx *cli.Context) (cErr error) {
 	}
 
 	restoreReqStatus := make(chan *probe.Error)
-	restoreStatus := make(chan *probe.Error)
+	var restoreStatus chan *probe.Error
+	if !noWait {
+		restoreStatus = make(chan *probe.Error)
+	}
 
 	done := make(chan struct{})
 
@@ -328,8 +384,10 @@ func mainILMRestore(cliCtx *cli.Context) (cErr error) {
 		showRestoreStatus(restoreReqStatus, restoreStatus, done)
 	}()
 
-	sendRestoreRequests(ctx, targetAlias, targetURL, versionID, recursive, includeVersions, days, restoreReqStatus)
-	checkRestoreStatus(ctx, targetAlias, targetURL, versionID, recursive, includeVersions, encKeyDB, restoreStatus)
+	sendRestoreRequests(ctx, targetAlias, targetURL, versionID, recursive, includeVersions, days, tier, restoreReqStatus)
+	if !noWait {
+		checkRestoreStatus(ctx, targetAlias, targetURL, versionID, recursive, includeVersions, encKeyDB, restoreStatus)
+	}
 
 	// Wait until the UI printed all the status
 	<-done
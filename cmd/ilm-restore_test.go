@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestParseRestoreTier(t *testing.T) {
+	testCases := []struct {
+		tier      string
+		expected  minio.TierType
+		expectErr bool
+	}{
+		{tier: "Expedited", expected: minio.TierExpedited},
+		{tier: "standard", expected: minio.TierStandard},
+		{tier: "BULK", expected: minio.TierBulk},
+		{tier: "", expectErr: true},
+		{tier: "fast", expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		tier, err := parseRestoreTier(testCase.tier)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if tier != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, tier)
+		}
+	}
+}
+
+func TestIsObjectArchived(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: minio.ErrorResponse{Code: "InvalidObjectState"}, expected: true},
+		{err: minio.ErrorResponse{Code: "NoSuchKey"}, expected: false},
+		{err: errors.New("connection reset"), expected: false},
+	}
+
+	for i, testCase := range testCases {
+		if got := isObjectArchived(probe.NewError(testCase.err).Trace("s3/bucket/object")); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -65,6 +65,15 @@ EXAMPLES:
   3. Add a lifecycle rule with an expiration and a noncurrent version expiration action for all objects with prefix doc/ in mybucket.
      {{.Prompt}} {{.HelpName}} --prefix "doc/" --expire-days "300" --noncurrent-expire-days "100" \
           myminio/mybucket/
+
+  4. Add a lifecycle rule removing the noncurrent versions older than 30 days while keeping the 5 newest ones in mybucket.
+     {{.Prompt}} {{.HelpName}} --noncurrent-expire-days "30" --noncurrent-expire-newer "5" myminio/mybucket
+
+  5. Add a lifecycle rule removing the delete markers without any noncurrent version left in mybucket.
+     {{.Prompt}} {{.HelpName}} --expire-delete-marker myminio/mybucket
+
+  6. Add a lifecycle rule aborting the multipart uploads still incomplete after 7 days in mybucket.
+     {{.Prompt}} {{.HelpName}} --abort-incomplete-upload-days "7" myminio/mybucket
 `,
 }
 
@@ -186,15 +195,23 @@ var ilmAddFlags = []cli.Flag{
 		Name:  "expire-all-object-versions",
 		Usage: "expire all object versions",
 	},
+	cli.IntFlag{
+		Name:  "abort-incomplete-upload-days",
+		Usage: "number of days after which incomplete multipart uploads are aborted",
+	},
 }
 
 type ilmAddMessage struct {
 	Status string `json:"status"`
 	Target string `json:"target"`
 	ID     string `json:"id"`
+	DryRun bool   `json:"dryRun,omitempty"`
 }
 
 func (i ilmAddMessage) String() string {
+	if i.DryRun {
+		return console.Colorize(ilmThemeResultSuccess, "Would add lifecycle configuration rule with ID `"+i.ID+"` to "+i.Target+".")
+	}
 	return console.Colorize(ilmThemeResultSuccess, "Lifecycle configuration rule added with ID `"+i.ID+"` to "+i.Target+".")
 }
 
@@ -242,12 +259,15 @@ func mainILMAdd(cliCtx *cli.Context) error {
 
 	lfcCfg.Rules = append(lfcCfg.Rules, newRule)
 
-	fatalIf(client.SetLifecycle(ctx, lfcCfg).Trace(urlStr), "Unable to add this lifecycle rule")
+	if !globalDryRun {
+		fatalIf(client.SetLifecycle(ctx, lfcCfg).Trace(urlStr), "Unable to add this lifecycle rule")
+	}
 
 	printMsg(ilmAddMessage{
 		Status: "success",
 		Target: urlStr,
 		ID:     opts.ID,
+		DryRun: globalDryRun,
 	})
 
 	return nil
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "github.com/minio/cli"
+
+var ilmDisableCmd = cli.Command{
+	Name:         "disable",
+	Usage:        "disable lifecycle configuration rules with given ids",
+	Action:       mainILMDisable,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(ilmRuleStatusFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --id RULEID [--id RULEID...] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Disable lifecycle configuration rules, the other rules and fields of the
+  lifecycle configuration are left untouched. A disabled rule can be enabled
+  again with 'mc ilm rule enable'.
+
+EXAMPLES:
+  1. Pause the expiration rule with id "rHTY.a123" of mybucket on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" myminio/mybucket
+
+  2. Disable the rules with ids "rHTY.a123" and "hGHKijqpo123" of mybucket on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --id "hGHKijqpo123" myminio/mybucket
+`,
+}
+
+func mainILMDisable(cliCtx *cli.Context) error {
+	return setILMRulesStatus(cliCtx, "Disabled")
+}
@@ -59,6 +59,12 @@ EXAMPLES:
   3. Disable the rule with id "rHTY.a123".
      {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --disable s3/mybucket
 
+  4. Move the objects of the rule with id "rHTY.a123" to the tier "COLDTIER", keeping its transition days.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --transition-tier "COLDTIER" s3/mybucket
+
+  5. Change the prefix of the rule with id "rHTY.a123" and remove its tags.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --prefix "logs/" --tags "" s3/mybucket
+
 `,
 }
 
@@ -85,9 +91,13 @@ type ilmEditMessage struct {
 	Status string `json:"status"`
 	Target string `json:"target"`
 	ID     string `json:"id"`
+	DryRun bool   `json:"dryRun,omitempty"`
 }
 
 func (i ilmEditMessage) String() string {
+	if i.DryRun {
+		return console.Colorize(ilmThemeResultSuccess, "Would modify lifecycle configuration rule with ID `"+i.ID+"` of "+i.Target+".")
+	}
 	return console.Colorize(ilmThemeResultSuccess, "Lifecycle configuration rule with ID `"+i.ID+"` modified  to "+i.Target+".")
 }
 
@@ -152,12 +162,15 @@ func mainILMEdit(cliCtx *cli.Context) error {
 	err = ilm.ApplyRuleFields(rule, opts)
 	fatalIf(err.Trace(args...), "Unable to generate new lifecycle rules for the input")
 
-	fatalIf(client.SetLifecycle(ctx, lfcCfg).Trace(urlStr), "Unable to set new lifecycle rules")
+	if !globalDryRun {
+		fatalIf(client.SetLifecycle(ctx, lfcCfg).Trace(urlStr), "Unable to set new lifecycle rules")
+	}
 
 	printMsg(ilmEditMessage{
 		Status: "success",
 		Target: urlStr,
 		ID:     opts.ID,
+		DryRun: globalDryRun,
 	})
 
 	return nil
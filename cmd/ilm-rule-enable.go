@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var ilmRuleStatusFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "id",
+		Usage: "id of the lifecycle rule, can be repeated",
+	},
+}
+
+var ilmEnableCmd = cli.Command{
+	Name:         "enable",
+	Usage:        "enable lifecycle configuration rules with given ids",
+	Action:       mainILMEnable,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(ilmRuleStatusFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --id RULEID [--id RULEID...] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Enable lifecycle configuration rules, the other rules and fields of the
+  lifecycle configuration are left untouched.
+
+EXAMPLES:
+  1. Enable the rule with id "rHTY.a123" of mybucket on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" myminio/mybucket
+
+  2. Enable the rules with ids "rHTY.a123" and "hGHKijqpo123" of mybucket on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --id "hGHKijqpo123" myminio/mybucket
+`,
+}
+
+type ilmRuleStatusMessage struct {
+	Status     string   `json:"status"`
+	Target     string   `json:"target"`
+	IDs        []string `json:"ids"`
+	RuleStatus string   `json:"ruleStatus"`
+	Changed    bool     `json:"changed"`
+	DryRun     bool     `json:"dryRun,omitempty"`
+}
+
+func (i ilmRuleStatusMessage) String() string {
+	rules := "Rule ID `" + i.IDs[0] + "`"
+	if len(i.IDs) > 1 {
+		rules = "Rule IDs `" + strings.Join(i.IDs, "`, `") + "`"
+	}
+	state := strings.ToLower(i.RuleStatus)
+	if !i.Changed {
+		return console.Colorize(ilmThemeResultSuccess, rules+" of target "+i.Target+" already "+state+".")
+	}
+	if i.DryRun {
+		return console.Colorize(ilmThemeResultSuccess, rules+" of target "+i.Target+" would be "+state+".")
+	}
+	return console.Colorize(ilmThemeResultSuccess, rules+" of target "+i.Target+" "+state+".")
+}
+
+func (i ilmRuleStatusMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func checkILMRuleStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, globalErrorExitStatus)
+	}
+	if len(ctx.StringSlice("id")) == 0 {
+		fatalIf(errInvalidArgument(), "ID for lifecycle rule cannot be empty, please refer mc "+ctx.Command.FullName()+" --help for more details")
+	}
+}
+
+// setILMRulesStatus sets the status of the rules with the given ids, the
+// lifecycle configuration is only updated if a rule status changes.
+func setILMRulesStatus(cliCtx *cli.Context, status string) error {
+	ctx, cancelILMRuleStatus := context.WithCancel(globalContext)
+	defer cancelILMRuleStatus()
+
+	checkILMRuleStatusSyntax(cliCtx)
+	setILMDisplayColorScheme()
+
+	args := cliCtx.Args()
+	urlStr := args.Get(0)
+	ids := cliCtx.StringSlice("id")
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr)
+
+	lfcCfg, _, err := client.GetLifecycle(ctx)
+	fatalIf(err.Trace(args...), "Unable to fetch lifecycle rules for "+urlStr)
+
+	var changed bool
+	for _, id := range ids {
+		var found bool
+		for i := range lfcCfg.Rules {
+			if lfcCfg.Rules[i].ID != id {
+				continue
+			}
+			found = true
+			if lfcCfg.Rules[i].Status != status {
+				lfcCfg.Rules[i].Status = status
+				changed = true
+			}
+			break
+		}
+		if !found {
+			fatalIf(errInvalidArgument().Trace(id), "Unable to find rule id `"+id+"`")
+		}
+	}
+
+	if changed && !globalDryRun {
+		fatalIf(client.SetLifecycle(ctx, lfcCfg).Trace(urlStr), "Unable to set new lifecycle rules")
+	}
+
+	printMsg(ilmRuleStatusMessage{
+		Status:     "success",
+		Target:     urlStr,
+		IDs:        ids,
+		RuleStatus: status,
+		Changed:    changed,
+		DryRun:     globalDryRun,
+	})
+	return nil
+}
+
+func mainILMEnable(cliCtx *cli.Context) error {
+	return setILMRulesStatus(cliCtx, "Enabled")
+}
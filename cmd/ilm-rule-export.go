@@ -24,17 +24,26 @@ import (
 
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/mc/cmd/ilm"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
+var ilmExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "format",
+		Value: "json",
+		Usage: "format of the exported configuration, one of json or yaml",
+	},
+}
+
 var ilmExportCmd = cli.Command{
 	Name:         "export",
-	Usage:        "export lifecycle configuration in JSON format",
+	Usage:        "export lifecycle configuration in JSON or YAML format",
 	Action:       mainILMExport,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(ilmExportFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -42,14 +51,21 @@ USAGE:
   {{.HelpName}} TARGET
 
 DESCRIPTION:
-  Exports lifecycle configuration in JSON format to STDOUT.
+  Exports lifecycle configuration in JSON format to STDOUT. The YAML format uses
+  stable field names and documents them in a header comment.
 
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
 EXAMPLES:
   1. Export lifecycle configuration for 'mybucket' to 'lifecycle.json' file.
      {{.Prompt}} {{.HelpName}} myminio/mybucket > lifecycle.json
 
   2. Print lifecycle configuration for 'mybucket' to STDOUT.
      {{.Prompt}} {{.HelpName}} play/mybucket
+
+  3. Export lifecycle configuration for 'mybucket' to 'lifecycle.yaml' file in YAML format.
+     {{.Prompt}} {{.HelpName}} --format yaml myminio/mybucket > lifecycle.yaml
 `,
 }
 
@@ -58,9 +74,17 @@ type ilmExportMessage struct {
 	Target    string                   `json:"target"`
 	Config    *lifecycle.Configuration `json:"config"`
 	UpdatedAt time.Time                `json:"updatedAt,omitempty"`
+	Format    string                   `json:"-"`
 }
 
 func (i ilmExportMessage) String() string {
+	if i.Format == "yaml" {
+		msgBytes, e := ilm.ToYAML(i.Config)
+		fatalIf(probe.NewError(e), "Unable to export ILM configuration")
+
+		return string(msgBytes)
+	}
+
 	msgBytes, e := json.MarshalIndent(i.Config, "", " ")
 	fatalIf(probe.NewError(e), "Unable to export ILM configuration")
 
@@ -79,6 +103,9 @@ func checkILMExportSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, globalErrorExitStatus)
 	}
+	if format := ctx.String("format"); format != "json" && format != "yaml" {
+		fatalIf(errInvalidArgument().Trace(format), "--format must be one of json or yaml.")
+	}
 }
 
 func mainILMExport(cliCtx *cli.Context) error {
@@ -106,6 +133,7 @@ func mainILMExport(cliCtx *cli.Context) error {
 		Target:    urlStr,
 		Config:    ilmCfg,
 		UpdatedAt: updatedAt,
+		Format:    cliCtx.String("format"),
 	})
 
 	return nil
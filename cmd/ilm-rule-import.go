@@ -18,23 +18,36 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/mc/cmd/ilm"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 	"github.com/minio/pkg/v3/console"
 )
 
+var ilmImportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "diff",
+		Usage: "show the rule changes compared to the current configuration before applying them",
+	},
+}
+
 var ilmImportCmd = cli.Command{
 	Name:         "import",
-	Usage:        "import lifecycle configuration in JSON format",
+	Usage:        "import lifecycle configuration in JSON or YAML format",
 	Action:       mainILMImport,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(ilmImportFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -42,14 +55,24 @@ USAGE:
   {{.HelpName}} TARGET
 
 DESCRIPTION:
-  Import entire lifecycle configuration from STDIN, input file is expected to be in JSON format.
+  Import entire lifecycle configuration from STDIN, input file is expected to be in JSON format
+  or in the YAML format of 'mc ilm rule export --format yaml'.
 
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
 EXAMPLES:
   1. Set lifecycle configuration for the mybucket on alias 'myminio' to the rules imported from lifecycle.json
      {{.Prompt}} {{.HelpName}} myminio/mybucket < lifecycle.json
 
   2. Set lifecycle configuration for the mybucket on alias 'myminio'. User is expected to enter the JSON contents on STDIN
      {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  3. Show the rule changes of lifecycle.yaml compared to the lifecycle configuration of mybucket, then apply them
+     {{.Prompt}} {{.HelpName}} --diff myminio/mybucket < lifecycle.yaml
+
+  4. Show the rule changes of lifecycle.yaml compared to the lifecycle configuration of mybucket without applying them
+     {{.Prompt}} {{.HelpName}} --dry-run myminio/mybucket < lifecycle.yaml
 `,
 }
 
@@ -68,20 +91,67 @@ func (i ilmImportMessage) JSON() string {
 	return string(msgBytes)
 }
 
-// readILMConfig read from stdin, returns XML.
-func readILMConfig() (*lifecycle.Configuration, *probe.Error) {
-	// User is expected to enter the lifecycleConfiguration instance contents in JSON format
-	cfg := lifecycle.NewConfiguration()
+// ilmImportDiffMessage container for the rule changes of an imported configuration.
+type ilmImportDiffMessage struct {
+	Status  string         `json:"status"`
+	Target  string         `json:"target"`
+	Changes []ilm.RuleDiff `json:"changes"`
+	DryRun  bool           `json:"dryRun,omitempty"`
+}
+
+func (i ilmImportDiffMessage) String() string {
+	if len(i.Changes) == 0 {
+		return console.Colorize("ILMDiffNone", "No lifecycle rule changes.")
+	}
+	lines := make([]string, 0, len(i.Changes))
+	for _, d := range i.Changes {
+		switch d.Action {
+		case ilm.RuleAdded:
+			lines = append(lines, console.Colorize("ILMDiffAdded", d.String()))
+		case ilm.RuleRemoved:
+			lines = append(lines, console.Colorize("ILMDiffRemoved", d.String()))
+		default:
+			lines = append(lines, console.Colorize("ILMDiffChanged", d.String()))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (i ilmImportDiffMessage) JSON() string {
+	i.Status = "success"
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
 
-	// Consume json from STDIN
-	dec := json.NewDecoder(os.Stdin)
-	if e := dec.Decode(cfg); e != nil {
-		return cfg, probe.NewError(e)
+// parseILMConfig parses a lifecycle configuration in JSON format, or in
+// YAML format when the input is not a JSON document.
+func parseILMConfig(data []byte) (*lifecycle.Configuration, *probe.Error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		cfg := lifecycle.NewConfiguration()
+		if e := json.Unmarshal(data, cfg); e != nil {
+			return cfg, probe.NewError(e)
+		}
+		return cfg, nil
 	}
 
+	cfg, e := ilm.FromYAML(data)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
 	return cfg, nil
 }
 
+// readILMConfig read from stdin, returns XML.
+func readILMConfig() (*lifecycle.Configuration, *probe.Error) {
+	// User is expected to enter the lifecycleConfiguration instance contents in JSON or YAML format
+	data, e := io.ReadAll(os.Stdin)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return parseILMConfig(data)
+}
+
 // checkILMImportSyntax - validate arguments passed by user
 func checkILMImportSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
@@ -111,6 +181,31 @@ func mainILMImport(cliCtx *cli.Context) error {
 		fatalIf(errDummy(), "The provided ILM configuration does not contain any rule, aborting.")
 	}
 
+	if cliCtx.Bool("diff") || globalDryRun {
+		console.SetColor("ILMDiffNone", color.New(color.FgGreen))
+		console.SetColor("ILMDiffAdded", color.New(color.FgGreen))
+		console.SetColor("ILMDiffRemoved", color.New(color.FgRed))
+		console.SetColor("ILMDiffChanged", color.New(color.FgYellow))
+
+		current, _, err := client.GetLifecycle(ctx)
+		if err != nil {
+			if e := err.ToGoError(); minio.ToErrorResponse(e).Code == "NoSuchLifecycleConfiguration" {
+				current = lifecycle.NewConfiguration()
+			} else {
+				fatalIf(err.Trace(urlStr), "Unable to get lifecycle configuration")
+			}
+		}
+
+		printMsg(ilmImportDiffMessage{
+			Target:  urlStr,
+			Changes: ilm.Diff(current, ilmCfg),
+			DryRun:  globalDryRun,
+		})
+		if globalDryRun {
+			return nil
+		}
+	}
+
 	fatalIf(client.SetLifecycle(ctx, ilmCfg).Trace(urlStr), "Unable to set new lifecycle rules")
 
 	printMsg(ilmImportMessage{
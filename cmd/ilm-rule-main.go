@@ -22,6 +22,8 @@ import "github.com/minio/cli"
 var ilmRuleSubcommands = []cli.Command{
 	ilmAddCmd,
 	ilmEditCmd,
+	ilmEnableCmd,
+	ilmDisableCmd,
 	ilmLsCmd,
 	ilmRmCmd,
 	ilmExportCmd,
@@ -80,6 +80,7 @@ type LifecycleOptions struct {
 	NewerNoncurrentTransitionVersions       *int
 	NoncurrentVersionTransitionStorageClass *string
 	ExpiredObjectAllversions                *bool
+	AbortIncompleteMultipartUploadDays      *int
 }
 
 // Filter returns lifecycle.Filter appropriate for opts
@@ -139,8 +140,18 @@ func (opts LifecycleOptions) ToILMRule() (lifecycle.Rule, *probe.Error) {
 		nonCurrentVersionTransitionDays         lifecycle.ExpirationDays
 		newerNonCurrentTransitionVersions       int
 		nonCurrentVersionTransitionStorageClass string
+		abortIncompleteUploadDays               lifecycle.ExpirationDays
 	)
 
+	// A tier can be changed alone on an existing rule, but a new
+	// rule needs to know when to transition.
+	if opts.StorageClass != nil && opts.TransitionDays == nil && opts.TransitionDate == nil {
+		return lifecycle.Rule{}, probe.NewError(errors.New("transition-date or transition-days must be set"))
+	}
+	if opts.NoncurrentVersionTransitionStorageClass != nil && opts.NoncurrentVersionTransitionDays == nil {
+		return lifecycle.Rule{}, probe.NewError(errors.New("noncurrentversion-transition-days must be set"))
+	}
+
 	id = opts.ID
 	status = func() string {
 		if opts.Status != nil && !*opts.Status {
@@ -175,6 +186,9 @@ func (opts LifecycleOptions) ToILMRule() (lifecycle.Rule, *probe.Error) {
 	if opts.NoncurrentVersionTransitionStorageClass != nil {
 		nonCurrentVersionTransitionStorageClass = *opts.NoncurrentVersionTransitionStorageClass
 	}
+	if opts.AbortIncompleteMultipartUploadDays != nil {
+		abortIncompleteUploadDays = lifecycle.ExpirationDays(*opts.AbortIncompleteMultipartUploadDays)
+	}
 
 	newRule := lifecycle.Rule{
 		ID:         id,
@@ -191,6 +205,9 @@ func (opts LifecycleOptions) ToILMRule() (lifecycle.Rule, *probe.Error) {
 			NewerNoncurrentVersions: newerNonCurrentTransitionVersions,
 			StorageClass:            nonCurrentVersionTransitionStorageClass,
 		},
+		AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{
+			DaysAfterInitiation: abortIncompleteUploadDays,
+		},
 	}
 
 	if err := validateILMRule(newRule); err != nil {
@@ -243,6 +260,7 @@ func GetLifecycleOptions(ctx *cli.Context) (LifecycleOptions, *probe.Error) {
 		newerNoncurrentTransitionVersions *int
 		noncurrentTier                    *string
 		expiredObjectAllversions          *bool
+		abortIncompleteUploadDays         *int
 	)
 
 	id = ctx.String("id")
@@ -309,12 +327,6 @@ func GetLifecycleOptions(ctx *cli.Context) (LifecycleOptions, *probe.Error) {
 	if f := "noncurrent-transition-tier"; ctx.IsSet(f) {
 		noncurrentTier = strPtr(strings.ToUpper(ctx.String(f)))
 	}
-	if tier != nil && !ctx.IsSet("transition-days") && !ctx.IsSet("transition-date") {
-		return LifecycleOptions{}, probe.NewError(errors.New("transition-date or transition-days must be set"))
-	}
-	if noncurrentTier != nil && !ctx.IsSet("noncurrentversion-transition-days") && !ctx.IsSet("noncurrent-transition-days") {
-		return LifecycleOptions{}, probe.NewError(errors.New("noncurrentversion-transition-days must be set"))
-	}
 	// for MinIO transition storage-class is same as label defined on
 	// `mc admin bucket remote add --service ilm --label` command
 	if ctx.IsSet("tags") {
@@ -373,6 +385,9 @@ func GetLifecycleOptions(ctx *cli.Context) (LifecycleOptions, *probe.Error) {
 	if ctx.IsSet("expire-all-object-versions") {
 		expiredObjectAllversions = boolPtr(ctx.Bool("expire-all-object-versions"))
 	}
+	if f := "abort-incomplete-upload-days"; ctx.IsSet(f) {
+		abortIncompleteUploadDays = intPtr(ctx.Int(f))
+	}
 
 	return LifecycleOptions{
 		ID:                                      id,
@@ -393,26 +408,80 @@ func GetLifecycleOptions(ctx *cli.Context) (LifecycleOptions, *probe.Error) {
 		NewerNoncurrentTransitionVersions:       newerNoncurrentTransitionVersions,
 		NoncurrentVersionTransitionStorageClass: noncurrentTier,
 		ExpiredObjectAllversions:                expiredObjectAllversions,
+		AbortIncompleteMultipartUploadDays:      abortIncompleteUploadDays,
 	}, nil
 }
 
-// ApplyRuleFields applies non nil fields of LifcycleOptions to the existing lifecycle rule
-func ApplyRuleFields(dest *lifecycle.Rule, opts LifecycleOptions) *probe.Error {
-	// If src has tags, it should override the destination
-	if opts.Tags != nil {
-		dest.RuleFilter.And.Tags = extractILMTags(*opts.Tags)
+// applyFilterFields merges the filter fields set in opts with the ones of
+// an existing rule filter. An empty tags value removes all the tags.
+func applyFilterFields(f lifecycle.Filter, opts LifecycleOptions) lifecycle.Filter {
+	prefix := f.Prefix
+	if prefix == "" {
+		prefix = f.And.Prefix
+	}
+	tags := f.And.Tags
+	if !f.Tag.IsEmpty() {
+		tags = []lifecycle.Tag{f.Tag}
+	}
+	szLt, szGt := f.ObjectSizeLessThan, f.ObjectSizeGreaterThan
+	if szLt == 0 {
+		szLt = f.And.ObjectSizeLessThan
+	}
+	if szGt == 0 {
+		szGt = f.And.ObjectSizeGreaterThan
 	}
 
-	// since prefix is a part of command args, it is always present in the src rule and
-	// it should be always set to the destination.
 	if opts.Prefix != nil {
-		if dest.RuleFilter.And.Tags != nil {
-			dest.RuleFilter.And.Prefix = *opts.Prefix
-		} else {
-			dest.RuleFilter.Prefix = *opts.Prefix
+		prefix = *opts.Prefix
+	}
+	if opts.Tags != nil {
+		tags = extractILMTags(*opts.Tags)
+	}
+	if opts.ObjectSizeLessThan != nil {
+		szLt = *opts.ObjectSizeLessThan
+	}
+	if opts.ObjectSizeGreaterThan != nil {
+		szGt = *opts.ObjectSizeGreaterThan
+	}
+
+	return newRuleFilter(prefix, tags, szLt, szGt)
+}
+
+// newRuleFilter returns the filter matching all the given predicates, they
+// are grouped in an And element when there is more than one.
+func newRuleFilter(prefix string, tags []lifecycle.Tag, szLt, szGt int64) lifecycle.Filter {
+	predCount := len(tags)
+	for _, set := range []bool{prefix != "", szLt != 0, szGt != 0} {
+		if set {
+			predCount++
 		}
 	}
 
+	var f lifecycle.Filter
+	if predCount >= 2 {
+		f.And = lifecycle.And{
+			Tags:                  tags,
+			Prefix:                prefix,
+			ObjectSizeLessThan:    szLt,
+			ObjectSizeGreaterThan: szGt,
+		}
+	} else {
+		f.Prefix = prefix
+		f.ObjectSizeLessThan = szLt
+		f.ObjectSizeGreaterThan = szGt
+		if len(tags) == 1 {
+			f.Tag = tags[0]
+		}
+	}
+	return f
+}
+
+// ApplyRuleFields applies non nil fields of LifcycleOptions to the existing lifecycle rule
+func ApplyRuleFields(dest *lifecycle.Rule, opts LifecycleOptions) *probe.Error {
+	if opts.Prefix != nil || opts.Tags != nil || opts.ObjectSizeLessThan != nil || opts.ObjectSizeGreaterThan != nil {
+		dest.RuleFilter = applyFilterFields(dest.RuleFilter, opts)
+	}
+
 	// only one of expiration day, date or transition day, date is expected
 	if opts.ExpiryDate != nil {
 		date, err := parseExpiryDate(*opts.ExpiryDate)
@@ -482,6 +551,10 @@ func ApplyRuleFields(dest *lifecycle.Rule, opts LifecycleOptions) *probe.Error {
 		dest.Transition.StorageClass = *opts.StorageClass
 	}
 
+	if opts.AbortIncompleteMultipartUploadDays != nil {
+		dest.AbortIncompleteMultipartUpload.DaysAfterInitiation = lifecycle.ExpirationDays(*opts.AbortIncompleteMultipartUploadDays)
+	}
+
 	// Updated the status
 	if opts.Status != nil {
 		dest.Status = func() string {
@@ -492,5 +565,12 @@ func ApplyRuleFields(dest *lifecycle.Rule, opts LifecycleOptions) *probe.Error {
 		}()
 	}
 
+	if dest.Transition.StorageClass != "" && dest.Transition.IsDaysNull() && dest.Transition.IsDateNull() {
+		return probe.NewError(errors.New("transition-date or transition-days must be set"))
+	}
+	if e := validateNoncurrentTransition(*dest); e != nil {
+		return probe.NewError(e)
+	}
+
 	return nil
 }
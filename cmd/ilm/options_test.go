@@ -153,3 +153,66 @@ func TestOptionFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyRuleFields(t *testing.T) {
+	rule := lifecycle.Rule{
+		ID:     "rule1",
+		Status: "Enabled",
+		RuleFilter: lifecycle.Filter{
+			And: lifecycle.And{
+				Prefix: "doc/",
+				Tags:   []lifecycle.Tag{{Key: "key1", Value: "val1"}},
+			},
+		},
+		Transition: lifecycle.Transition{Days: 30, StorageClass: "WARM"},
+	}
+
+	// Changing only the tier keeps the transition days.
+	if err := ApplyRuleFields(&rule, LifecycleOptions{StorageClass: strPtr("COLD")}); err != nil {
+		t.Fatal(err)
+	}
+	if rule.Transition.StorageClass != "COLD" || rule.Transition.Days != 30 {
+		t.Fatalf("unexpected transition %+v", rule.Transition)
+	}
+
+	// Removing the tags leaves a prefix only filter.
+	if err := ApplyRuleFields(&rule, LifecycleOptions{Tags: strPtr("")}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (lifecycle.Filter{Prefix: "doc/"}); fmt.Sprint(rule.RuleFilter) != fmt.Sprint(expected) {
+		t.Fatalf("expected filter %v, got %v", expected, rule.RuleFilter)
+	}
+
+	// Adding a size predicate keeps the existing prefix.
+	if err := ApplyRuleFields(&rule, LifecycleOptions{ObjectSizeLessThan: int64Ptr(1024)}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (lifecycle.Filter{And: lifecycle.And{Prefix: "doc/", ObjectSizeLessThan: 1024}}); fmt.Sprint(rule.RuleFilter) != fmt.Sprint(expected) {
+		t.Fatalf("expected filter %v, got %v", expected, rule.RuleFilter)
+	}
+
+	// A tier without any transition days or date is rejected.
+	rule = lifecycle.Rule{ID: "rule2", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 10}}
+	if err := ApplyRuleFields(&rule, LifecycleOptions{StorageClass: strPtr("WARM")}); err == nil {
+		t.Fatal("expected an error when a tier is set without transition days or date")
+	}
+}
+
+func TestToILMRuleAbortIncompleteUpload(t *testing.T) {
+	days := 7
+	rule, err := LifecycleOptions{ID: "abort", AbortIncompleteMultipartUploadDays: &days}.ToILMRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.AbortIncompleteMultipartUpload.DaysAfterInitiation != 7 {
+		t.Fatalf("unexpected abort incomplete multipart upload %+v", rule.AbortIncompleteMultipartUpload)
+	}
+	if tables := ToTables(&lifecycle.Configuration{Rules: []lifecycle.Rule{rule}}); len(tables) != 1 {
+		t.Fatalf("expected one table, got %d", len(tables))
+	}
+
+	days = -1
+	if _, err = (LifecycleOptions{ID: "abort", AbortIncompleteMultipartUploadDays: &days}).ToILMRule(); err == nil {
+		t.Fatal("expected an error for negative days")
+	}
+}
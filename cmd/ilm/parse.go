@@ -90,8 +90,12 @@ func validateRuleAction(rule lifecycle.Rule) error {
 	newerNoncurrentVersionsExpiry := rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0
 	noncurrentTransitionSet := rule.NoncurrentVersionTransition.StorageClass != ""
 	newerNoncurrentVersionsTransition := rule.NoncurrentVersionTransition.NewerNoncurrentVersions > 0
-	if !expirySet && !transitionSet && !noncurrentExpirySet && !noncurrentTransitionSet && !newerNoncurrentVersionsExpiry && !newerNoncurrentVersionsTransition {
-		return errors.New("at least one of Expiry, Transition, NoncurrentExpiry, NoncurrentVersionTransition actions should be specified in a rule")
+	abortIncompleteUploadSet := !rule.AbortIncompleteMultipartUpload.IsDaysNull()
+	if !expirySet && !transitionSet && !noncurrentExpirySet && !noncurrentTransitionSet && !newerNoncurrentVersionsExpiry && !newerNoncurrentVersionsTransition && !abortIncompleteUploadSet {
+		return errors.New("at least one of Expiry, Transition, NoncurrentExpiry, NoncurrentVersionTransition, AbortIncompleteMultipartUpload actions should be specified in a rule")
+	}
+	if rule.AbortIncompleteMultipartUpload.DaysAfterInitiation < 0 {
+		return errors.New("number of days to abort incomplete multipart uploads can't be negative")
 	}
 	return nil
 }
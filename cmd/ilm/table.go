@@ -49,7 +49,8 @@ func (f LsFilter) Apply(rules []lifecycle.Rule) []lifecycle.Rule {
 		switch f {
 		case ExpiryOnly:
 			return !rule.Expiration.IsNull() || !rule.NoncurrentVersionExpiration.IsDaysNull() ||
-				rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0
+				rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 ||
+				!rule.AbortIncompleteMultipartUpload.IsDaysNull()
 		case TransitionOnly:
 			return !rule.Transition.IsNull() || !rule.NoncurrentVersionTransition.IsStorageClassEmpty()
 		}
@@ -139,6 +140,41 @@ func (e expirationNoncurrentTable) ColumnHeaders() (headers table.Row) {
 	return table.Row{"ID", "Status", "Prefix", "Tags", "Days to Expire", "Keep Versions"}
 }
 
+type abortIncompleteTable []abortIncompleteRow
+
+type abortIncompleteRow struct {
+	ID     string
+	Status string
+	Prefix string
+	Tags   string
+	Days   int
+}
+
+func (a abortIncompleteTable) Len() int {
+	return len(a)
+}
+
+func (a abortIncompleteTable) Title() string {
+	return "Incomplete multipart uploads cleanup (AbortIncompleteMultipartUpload)"
+}
+
+func (a abortIncompleteTable) Rows() (rows []table.Row) {
+	for _, row := range a {
+		if row.Prefix == "" {
+			row.Prefix = "-"
+		}
+		if row.Tags == "" {
+			row.Tags = "-"
+		}
+		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Days})
+	}
+	return rows
+}
+
+func (a abortIncompleteTable) ColumnHeaders() (headers table.Row) {
+	return table.Row{"ID", "Status", "Prefix", "Tags", "Days to Abort"}
+}
+
 type tierCurrentTable []tierCurrentRow
 
 type tierCurrentRow struct {
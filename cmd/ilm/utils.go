@@ -87,6 +87,7 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 	var tierNoncur tierNoncurrentTable
 	var expCur expirationCurrentTable
 	var expNoncur expirationNoncurrentTable
+	var abortIncomplete abortIncompleteTable
 	for _, rule := range cfg.Rules {
 		if !rule.Expiration.IsNull() {
 			expCur = append(expCur, expirationCurrentRow{
@@ -108,6 +109,15 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 				KeepVersions: rule.NoncurrentVersionExpiration.NewerNoncurrentVersions,
 			})
 		}
+		if !rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+			abortIncomplete = append(abortIncomplete, abortIncompleteRow{
+				ID:     rule.ID,
+				Status: rule.Status,
+				Prefix: getPrefix(rule),
+				Tags:   getTags(rule),
+				Days:   int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation),
+			})
+		}
 		if !rule.Transition.IsNull() {
 			tierCur = append(tierCur, tierCurrentRow{
 				ID:     rule.ID,
@@ -138,6 +148,7 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 	}
 	inclTbl(expCur)
 	inclTbl(expNoncur)
+	inclTbl(abortIncomplete)
 	inclTbl(tierCur)
 	inclTbl(tierNoncur)
 	return table
@@ -0,0 +1,438 @@
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"gopkg.in/yaml.v2"
+)
+
+// yamlHeader documents the fields of the YAML representation.
+const yamlHeader = `# Bucket lifecycle configuration.
+#
+# rules:
+#   - id: unique identifier of the rule
+#     status: Enabled or Disabled
+#     filter: objects the rule applies to, all objects if omitted
+#       prefix: object name prefix
+#       tags: object tags that must all match, as key: value
+#       sizeLessThan: objects smaller than this size in bytes
+#       sizeGreaterThan: objects larger than this size in bytes
+#     expiration: current version expiration
+#       days: days after creation
+#       date: date as YYYY-MM-DD
+#       deleteMarker: remove delete markers without noncurrent versions
+#       allVersions: remove all the versions of the object
+#     transition: current version transition
+#       days: days after creation
+#       date: date as YYYY-MM-DD
+#       tier: name of the remote tier
+#     noncurrentExpiration: noncurrent versions expiration
+#       days: days after becoming noncurrent
+#       newerVersions: number of newer noncurrent versions to keep
+#     noncurrentTransition: noncurrent versions transition
+#       days: days after becoming noncurrent
+#       newerVersions: number of newer noncurrent versions to keep
+#       tier: name of the remote tier
+#     deleteMarkerExpiration: delete markers expiration
+#       days: days after creation
+#     allVersionsExpiration: expiration of all the versions of an object
+#       days: days after the latest version creation
+#       deleteMarker: also apply when the latest version is a delete marker
+#     abortIncompleteUpload: incomplete multipart uploads cleanup
+#       days: days after initiation
+`
+
+type yamlConfig struct {
+	Rules []yamlRule `yaml:"rules"`
+}
+
+type yamlRule struct {
+	ID                     string                     `yaml:"id,omitempty"`
+	Status                 string                     `yaml:"status"`
+	Filter                 *yamlFilter                `yaml:"filter,omitempty"`
+	Expiration             *yamlExpiration            `yaml:"expiration,omitempty"`
+	Transition             *yamlTransition            `yaml:"transition,omitempty"`
+	NoncurrentExpiration   *yamlNoncurrentExpiration  `yaml:"noncurrentExpiration,omitempty"`
+	NoncurrentTransition   *yamlNoncurrentTransition  `yaml:"noncurrentTransition,omitempty"`
+	DeleteMarkerExpiration *yamlDays                  `yaml:"deleteMarkerExpiration,omitempty"`
+	AllVersionsExpiration  *yamlAllVersionsExpiration `yaml:"allVersionsExpiration,omitempty"`
+	AbortIncompleteUpload  *yamlDays                  `yaml:"abortIncompleteUpload,omitempty"`
+}
+
+type yamlFilter struct {
+	Prefix          string            `yaml:"prefix,omitempty"`
+	Tags            map[string]string `yaml:"tags,omitempty"`
+	SizeLessThan    int64             `yaml:"sizeLessThan,omitempty"`
+	SizeGreaterThan int64             `yaml:"sizeGreaterThan,omitempty"`
+}
+
+type yamlExpiration struct {
+	Days         int    `yaml:"days,omitempty"`
+	Date         string `yaml:"date,omitempty"`
+	DeleteMarker bool   `yaml:"deleteMarker,omitempty"`
+	AllVersions  bool   `yaml:"allVersions,omitempty"`
+}
+
+type yamlTransition struct {
+	Days int    `yaml:"days,omitempty"`
+	Date string `yaml:"date,omitempty"`
+	Tier string `yaml:"tier"`
+}
+
+type yamlNoncurrentExpiration struct {
+	Days          int `yaml:"days,omitempty"`
+	NewerVersions int `yaml:"newerVersions,omitempty"`
+}
+
+type yamlNoncurrentTransition struct {
+	Days          int    `yaml:"days,omitempty"`
+	NewerVersions int    `yaml:"newerVersions,omitempty"`
+	Tier          string `yaml:"tier"`
+}
+
+type yamlDays struct {
+	Days int `yaml:"days"`
+}
+
+type yamlAllVersionsExpiration struct {
+	Days         int  `yaml:"days"`
+	DeleteMarker bool `yaml:"deleteMarker,omitempty"`
+}
+
+func formatYAMLDate(date lifecycle.ExpirationDate) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(defaultILMDateFormat)
+}
+
+func parseYAMLDate(id, s string) (lifecycle.ExpirationDate, error) {
+	if s == "" {
+		return lifecycle.ExpirationDate{}, nil
+	}
+	date, e := time.Parse(defaultILMDateFormat, s)
+	if e != nil {
+		return lifecycle.ExpirationDate{}, fmt.Errorf("rule %q: invalid date %q, expected YYYY-MM-DD", id, s)
+	}
+	return lifecycle.ExpirationDate{Time: date}, nil
+}
+
+func toYAMLRule(rule lifecycle.Rule) yamlRule {
+	r := yamlRule{
+		ID:     rule.ID,
+		Status: rule.Status,
+	}
+
+	f := yamlFilter{
+		Prefix:          getPrefix(rule),
+		SizeLessThan:    rule.RuleFilter.ObjectSizeLessThan,
+		SizeGreaterThan: rule.RuleFilter.ObjectSizeGreaterThan,
+	}
+	if f.SizeLessThan == 0 {
+		f.SizeLessThan = rule.RuleFilter.And.ObjectSizeLessThan
+	}
+	if f.SizeGreaterThan == 0 {
+		f.SizeGreaterThan = rule.RuleFilter.And.ObjectSizeGreaterThan
+	}
+	tags := rule.RuleFilter.And.Tags
+	if !rule.RuleFilter.Tag.IsEmpty() {
+		tags = append(tags, rule.RuleFilter.Tag)
+	}
+	if len(tags) > 0 {
+		f.Tags = make(map[string]string, len(tags))
+		for _, tag := range tags {
+			f.Tags[tag.Key] = tag.Value
+		}
+	}
+	if f.Prefix != "" || len(f.Tags) > 0 || f.SizeLessThan > 0 || f.SizeGreaterThan > 0 {
+		r.Filter = &f
+	}
+
+	if exp := rule.Expiration; !exp.IsNull() {
+		r.Expiration = &yamlExpiration{
+			Days:         int(exp.Days),
+			Date:         formatYAMLDate(exp.Date),
+			DeleteMarker: exp.DeleteMarker.IsEnabled(),
+			AllVersions:  bool(exp.DeleteAll),
+		}
+	}
+	if tr := rule.Transition; tr.StorageClass != "" {
+		r.Transition = &yamlTransition{
+			Days: int(tr.Days),
+			Date: formatYAMLDate(tr.Date),
+			Tier: tr.StorageClass,
+		}
+	}
+	if exp := rule.NoncurrentVersionExpiration; exp.NoncurrentDays > 0 || exp.NewerNoncurrentVersions > 0 {
+		r.NoncurrentExpiration = &yamlNoncurrentExpiration{
+			Days:          int(exp.NoncurrentDays),
+			NewerVersions: exp.NewerNoncurrentVersions,
+		}
+	}
+	if tr := rule.NoncurrentVersionTransition; tr.StorageClass != "" {
+		r.NoncurrentTransition = &yamlNoncurrentTransition{
+			Days:          int(tr.NoncurrentDays),
+			NewerVersions: tr.NewerNoncurrentVersions,
+			Tier:          tr.StorageClass,
+		}
+	}
+	if !rule.DelMarkerExpiration.IsNull() {
+		r.DeleteMarkerExpiration = &yamlDays{Days: rule.DelMarkerExpiration.Days}
+	}
+	if !rule.AllVersionsExpiration.IsNull() {
+		r.AllVersionsExpiration = &yamlAllVersionsExpiration{
+			Days:         rule.AllVersionsExpiration.Days,
+			DeleteMarker: rule.AllVersionsExpiration.DeleteMarker.IsEnabled(),
+		}
+	}
+	if !rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+		r.AbortIncompleteUpload = &yamlDays{Days: int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)}
+	}
+	return r
+}
+
+func (r yamlRule) toRule() (lifecycle.Rule, error) {
+	rule := lifecycle.Rule{
+		ID:     r.ID,
+		Status: r.Status,
+	}
+	if rule.Status == "" {
+		rule.Status = "Enabled"
+	}
+	if rule.Status != "Enabled" && rule.Status != "Disabled" {
+		return rule, fmt.Errorf("rule %q: status must be Enabled or Disabled", r.ID)
+	}
+
+	if f := r.Filter; f != nil {
+		keys := make([]string, 0, len(f.Tags))
+		for k := range f.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var tags []lifecycle.Tag
+		for _, k := range keys {
+			tags = append(tags, lifecycle.Tag{Key: k, Value: f.Tags[k]})
+		}
+		rule.RuleFilter = newRuleFilter(f.Prefix, tags, f.SizeLessThan, f.SizeGreaterThan)
+	}
+
+	var e error
+	if exp := r.Expiration; exp != nil {
+		rule.Expiration.Days = lifecycle.ExpirationDays(exp.Days)
+		rule.Expiration.DeleteMarker = lifecycle.ExpireDeleteMarker(exp.DeleteMarker)
+		rule.Expiration.DeleteAll = lifecycle.ExpirationBoolean(exp.AllVersions)
+		if rule.Expiration.Date, e = parseYAMLDate(r.ID, exp.Date); e != nil {
+			return rule, e
+		}
+	}
+	if tr := r.Transition; tr != nil {
+		rule.Transition.Days = lifecycle.ExpirationDays(tr.Days)
+		rule.Transition.StorageClass = tr.Tier
+		if rule.Transition.Date, e = parseYAMLDate(r.ID, tr.Date); e != nil {
+			return rule, e
+		}
+	}
+	if exp := r.NoncurrentExpiration; exp != nil {
+		rule.NoncurrentVersionExpiration.NoncurrentDays = lifecycle.ExpirationDays(exp.Days)
+		rule.NoncurrentVersionExpiration.NewerNoncurrentVersions = exp.NewerVersions
+	}
+	if tr := r.NoncurrentTransition; tr != nil {
+		rule.NoncurrentVersionTransition.NoncurrentDays = lifecycle.ExpirationDays(tr.Days)
+		rule.NoncurrentVersionTransition.NewerNoncurrentVersions = tr.NewerVersions
+		rule.NoncurrentVersionTransition.StorageClass = tr.Tier
+	}
+	if d := r.DeleteMarkerExpiration; d != nil {
+		rule.DelMarkerExpiration.Days = d.Days
+	}
+	if exp := r.AllVersionsExpiration; exp != nil {
+		rule.AllVersionsExpiration.Days = exp.Days
+		rule.AllVersionsExpiration.DeleteMarker = lifecycle.ExpireDeleteMarker(exp.DeleteMarker)
+	}
+	if d := r.AbortIncompleteUpload; d != nil {
+		rule.AbortIncompleteMultipartUpload.DaysAfterInitiation = lifecycle.ExpirationDays(d.Days)
+	}
+	return rule, nil
+}
+
+// ToYAML returns the documented YAML representation of a lifecycle configuration.
+func ToYAML(cfg *lifecycle.Configuration) ([]byte, error) {
+	var c yamlConfig
+	for _, rule := range cfg.Rules {
+		c.Rules = append(c.Rules, toYAMLRule(rule))
+	}
+	data, e := yaml.Marshal(c)
+	if e != nil {
+		return nil, e
+	}
+	return append([]byte(yamlHeader), data...), nil
+}
+
+// FromYAML parses the YAML representation of a lifecycle configuration.
+func FromYAML(data []byte) (*lifecycle.Configuration, error) {
+	var c yamlConfig
+	if e := yaml.UnmarshalStrict(data, &c); e != nil {
+		return nil, e
+	}
+	cfg := lifecycle.NewConfiguration()
+	for _, r := range c.Rules {
+		rule, e := r.toRule()
+		if e != nil {
+			return nil, e
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+	return cfg, nil
+}
+
+// RuleChange is the change of a single field of a rule.
+type RuleChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// RuleDiff is the difference of a rule between two lifecycle configurations.
+type RuleDiff struct {
+	ID      string       `json:"id"`
+	Action  string       `json:"action"`
+	Changes []RuleChange `json:"changes,omitempty"`
+}
+
+// Rule diff actions
+const (
+	RuleAdded    = "add"
+	RuleRemoved  = "remove"
+	RuleModified = "modify"
+)
+
+// ruleFields flattens a rule in its YAML representation, keyed by field path.
+func ruleFields(rule lifecycle.Rule) map[string]string {
+	data, e := yaml.Marshal(toYAMLRule(rule))
+	if e != nil {
+		return nil
+	}
+	var m yaml.MapSlice
+	if e = yaml.Unmarshal(data, &m); e != nil {
+		return nil
+	}
+	fields := make(map[string]string)
+	var flatten func(prefix string, m yaml.MapSlice)
+	flatten = func(prefix string, m yaml.MapSlice) {
+		for _, item := range m {
+			key := prefix + fmt.Sprint(item.Key)
+			if v, ok := item.Value.(yaml.MapSlice); ok {
+				flatten(key+".", v)
+				continue
+			}
+			fields[key] = fmt.Sprint(item.Value)
+		}
+	}
+	flatten("", m)
+	delete(fields, "id")
+	return fields
+}
+
+// Diff returns the rule level changes to go from current to updated,
+// rules are matched by their ID.
+func Diff(current, updated *lifecycle.Configuration) []RuleDiff {
+	currentRules := make(map[string]lifecycle.Rule)
+	for _, rule := range current.Rules {
+		currentRules[rule.ID] = rule
+	}
+
+	var diffs []RuleDiff
+	seen := make(map[string]bool)
+	for _, rule := range updated.Rules {
+		fields := ruleFields(rule)
+		old, ok := currentRules[rule.ID]
+		if !ok || rule.ID == "" {
+			d := RuleDiff{ID: rule.ID, Action: RuleAdded}
+			for _, k := range sortedKeys(fields) {
+				d.Changes = append(d.Changes, RuleChange{Field: k, New: fields[k]})
+			}
+			diffs = append(diffs, d)
+			continue
+		}
+		seen[rule.ID] = true
+
+		oldFields := ruleFields(old)
+		keys := sortedKeys(fields)
+		for k := range oldFields {
+			if _, ok := fields[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		d := RuleDiff{ID: rule.ID, Action: RuleModified}
+		for _, k := range keys {
+			if fields[k] != oldFields[k] {
+				d.Changes = append(d.Changes, RuleChange{Field: k, Old: oldFields[k], New: fields[k]})
+			}
+		}
+		if len(d.Changes) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+
+	for _, rule := range current.Rules {
+		if !seen[rule.ID] {
+			diffs = append(diffs, RuleDiff{ID: rule.ID, Action: RuleRemoved})
+		}
+	}
+	return diffs
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns a human readable representation of the rule diff.
+func (d RuleDiff) String() string {
+	var sb strings.Builder
+	switch d.Action {
+	case RuleAdded:
+		sb.WriteString("+ " + d.ID)
+	case RuleRemoved:
+		sb.WriteString("- " + d.ID)
+	default:
+		sb.WriteString("~ " + d.ID)
+	}
+	for _, c := range d.Changes {
+		sb.WriteString("\n    " + c.Field + ": ")
+		switch {
+		case c.Old == "":
+			sb.WriteString(c.New)
+		case c.New == "":
+			sb.WriteString(c.Old + " -> (removed)")
+		default:
+			sb.WriteString(c.Old + " -> " + c.New)
+		}
+	}
+	return sb.String()
+}
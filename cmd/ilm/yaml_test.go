@@ -0,0 +1,100 @@
+// Copyright (c) 2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	cfg := lifecycle.NewConfiguration()
+	cfg.Rules = []lifecycle.Rule{
+		{
+			ID:     "logs",
+			Status: "Enabled",
+			RuleFilter: lifecycle.Filter{And: lifecycle.And{
+				Prefix: "logs/",
+				Tags:   []lifecycle.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+			}},
+			Expiration: lifecycle.Expiration{Days: 90},
+			Transition: lifecycle.Transition{Days: 30, StorageClass: "WARM"},
+			NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{
+				NoncurrentDays:          7,
+				NewerNoncurrentVersions: 2,
+			},
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{DaysAfterInitiation: 3},
+		},
+		{
+			ID:         "archive",
+			Status:     "Disabled",
+			RuleFilter: lifecycle.Filter{ObjectSizeGreaterThan: 1024},
+			Expiration: lifecycle.Expiration{Date: lifecycle.ExpirationDate{Time: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		},
+	}
+
+	data, e := ToYAML(cfg)
+	if e != nil {
+		t.Fatal(e)
+	}
+	got, e := FromYAML(data)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !reflect.DeepEqual(got.Rules, cfg.Rules) {
+		t.Fatalf("expected %+v, got %+v", cfg.Rules, got.Rules)
+	}
+
+	if _, e = FromYAML([]byte("rules:\n  - id: x\n    expire: {days: 1}\n")); e == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	if _, e = FromYAML([]byte("rules:\n  - id: x\n    expiration: {date: 2030/01/01}\n")); e == nil {
+		t.Fatal("expected an error for an invalid date")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	current := &lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{ID: "keep", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 10}},
+		{ID: "change", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 10}},
+		{ID: "drop", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 10}},
+	}}
+	updated := &lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{ID: "keep", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 10}},
+		{ID: "change", Status: "Disabled", RuleFilter: lifecycle.Filter{Prefix: "tmp/"}, Expiration: lifecycle.Expiration{Days: 10}},
+		{ID: "new", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 1}},
+	}}
+
+	expected := []RuleDiff{
+		{ID: "change", Action: RuleModified, Changes: []RuleChange{
+			{Field: "filter.prefix", New: "tmp/"},
+			{Field: "status", Old: "Enabled", New: "Disabled"},
+		}},
+		{ID: "new", Action: RuleAdded, Changes: []RuleChange{
+			{Field: "expiration.days", New: "1"},
+			{Field: "status", New: "Enabled"},
+		}},
+		{ID: "drop", Action: RuleRemoved},
+	}
+	if got := Diff(current, updated); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// inventoryManifest is the manifest.json describing an S3 Inventory
+// report, only the fields needed to read the report are decoded.
+type inventoryManifest struct {
+	SourceBucket      string `json:"sourceBucket"`
+	DestinationBucket string `json:"destinationBucket"`
+	FileFormat        string `json:"fileFormat"`
+	FileSchema        string `json:"fileSchema"`
+	Files             []struct {
+		Key  string `json:"key"`
+		Size int64  `json:"size"`
+	} `json:"files"`
+}
+
+// parseInventoryManifest decodes and validates an inventory manifest.
+func parseInventoryManifest(r io.Reader) (*inventoryManifest, *probe.Error) {
+	m := &inventoryManifest{}
+	if e := json.NewDecoder(r).Decode(m); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if !strings.EqualFold(m.FileFormat, "CSV") {
+		return nil, probe.NewError(fmt.Errorf("unsupported inventory file format `%s`, only CSV is supported", m.FileFormat))
+	}
+	if m.SourceBucket == "" || m.DestinationBucket == "" {
+		return nil, probe.NewError(errors.New("inventory manifest is missing the source or destination bucket"))
+	}
+	// The destination bucket is recorded as an ARN.
+	m.DestinationBucket = strings.TrimPrefix(m.DestinationBucket, "arn:aws:s3:::")
+	return m, nil
+}
+
+// inventorySchema maps the fields of a CSV inventory to their column.
+type inventorySchema map[string]int
+
+func parseInventorySchema(fileSchema string) (inventorySchema, *probe.Error) {
+	schema := inventorySchema{}
+	for i, field := range strings.Split(fileSchema, ",") {
+		schema[strings.TrimSpace(field)] = i
+	}
+	if _, ok := schema["Key"]; !ok {
+		return nil, probe.NewError(fmt.Errorf("inventory schema `%s` has no Key field", fileSchema))
+	}
+	return schema, nil
+}
+
+// field returns the value of the named field in a CSV record.
+func (s inventorySchema) field(record []string, name string) string {
+	i, ok := s[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
+
+// parseInventoryRecord converts a CSV inventory record to an object,
+// ok is false for records that are not the latest version of an object.
+func parseInventoryRecord(schema inventorySchema, record []string) (object minio.ObjectInfo, ok bool, err *probe.Error) {
+	if schema.field(record, "IsLatest") == "false" || schema.field(record, "IsDeleteMarker") == "true" {
+		return object, false, nil
+	}
+
+	// Keys are URL encoded in inventory reports.
+	key, e := url.QueryUnescape(schema.field(record, "Key"))
+	if e != nil {
+		return object, false, probe.NewError(e)
+	}
+	object.Key = key
+
+	if v := schema.field(record, "Size"); v != "" {
+		if object.Size, e = strconv.ParseInt(v, 10, 64); e != nil {
+			return object, false, probe.NewError(e)
+		}
+	}
+	if v := schema.field(record, "LastModifiedDate"); v != "" {
+		if object.LastModified, e = time.Parse(time.RFC3339Nano, v); e != nil {
+			return object, false, probe.NewError(e)
+		}
+	}
+	object.ETag = schema.field(record, "ETag")
+	// Only the latest objects are listed, the version ID is not kept
+	// so that they are addressed like in a regular listing: removing
+	// one adds a delete marker instead of deleting the listed version.
+	object.StorageClass = schema.field(record, "StorageClass")
+	object.IsLatest = true
+	return object, true, nil
+}
+
+// readInventoryCSV sends the objects of a CSV inventory file found
+// under prefix, it returns false if ctx got canceled.
+func readInventoryCSV(ctx context.Context, r io.Reader, schema inventorySchema, prefix string, objectCh chan<- minio.ObjectInfo) (bool, *probe.Error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	csvReader.ReuseRecord = true
+	for {
+		record, e := csvReader.Read()
+		if e == io.EOF {
+			return true, nil
+		}
+		if e != nil {
+			return true, probe.NewError(e)
+		}
+
+		object, ok, err := parseInventoryRecord(schema, record)
+		if err != nil {
+			return true, err.Trace(record...)
+		}
+		if !ok || !strings.HasPrefix(object.Key, prefix) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case objectCh <- object:
+		}
+	}
+}
+
+// getInventoryObject opens an object of an inventory report.
+func getInventoryObject(ctx context.Context, urlStr string) (io.ReadCloser, *probe.Error) {
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	reader, _, err := clnt.Get(ctx, GetOptions{})
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	return reader, nil
+}
+
+// listInventory lists the objects under prefix from the S3 Inventory
+// report described by the manifest at opts.Inventory instead of
+// listing the bucket.
+func (c *S3Client) listInventory(ctx context.Context, bucket, prefix string, opts ListOptions) <-chan minio.ObjectInfo {
+	objectCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectCh)
+		if err := readInventory(ctx, bucket, prefix, opts.Inventory, objectCh); err != nil {
+			select {
+			case <-ctx.Done():
+			case objectCh <- minio.ObjectInfo{Err: err.ToGoError()}:
+			}
+		}
+	}()
+	return objectCh
+}
+
+// readInventory sends the objects of bucket under prefix found in
+// the inventory report described by the manifest at manifestURL.
+func readInventory(ctx context.Context, bucket, prefix, manifestURL string, objectCh chan<- minio.ObjectInfo) *probe.Error {
+	alias, _, _ := mustExpandAlias(manifestURL)
+	if alias == "" {
+		return probe.NewError(fmt.Errorf("inventory manifest `%s` must be on an alias", manifestURL))
+	}
+
+	reader, err := getInventoryObject(ctx, manifestURL)
+	if err != nil {
+		return err
+	}
+	manifest, err := parseInventoryManifest(reader)
+	reader.Close()
+	if err != nil {
+		return err.Trace(manifestURL)
+	}
+	if manifest.SourceBucket != bucket {
+		return probe.NewError(fmt.Errorf("inventory `%s` is a report of bucket `%s`, not `%s`", manifestURL, manifest.SourceBucket, bucket))
+	}
+	schema, err := parseInventorySchema(manifest.FileSchema)
+	if err != nil {
+		return err.Trace(manifestURL)
+	}
+
+	for _, file := range manifest.Files {
+		fileURL := alias + "/" + manifest.DestinationBucket + "/" + file.Key
+		reader, err := getInventoryObject(ctx, fileURL)
+		if err != nil {
+			return err
+		}
+
+		var r io.Reader = reader
+		if strings.HasSuffix(file.Key, ".gz") {
+			gzReader, e := gzip.NewReader(reader)
+			if e != nil {
+				reader.Close()
+				return probe.NewError(e).Trace(fileURL)
+			}
+			r = gzReader
+		}
+
+		more, err := readInventoryCSV(ctx, r, schema, prefix, objectCh)
+		reader.Close()
+		if err != nil {
+			return err.Trace(fileURL)
+		}
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+// sortedListing passes a listing through until the first entry out of
+// order, as comparing listings relies on them being sorted by key while
+// inventory reports are not necessarily.
+func sortedListing(ctx context.Context, contentCh <-chan *ClientContent) <-chan *ClientContent {
+	sortedCh := make(chan *ClientContent)
+	go func() {
+		defer close(sortedCh)
+		// Drain the listing if it gets cut short.
+		defer func() {
+			for range contentCh {
+			}
+		}()
+
+		var prev string
+		for content := range contentCh {
+			if content.Err == nil {
+				if content.URL.Path < prev {
+					content = &ClientContent{
+						Err: probe.NewError(ListingNotSorted{Key: content.URL.Path, PrevKey: prev}),
+					}
+				} else {
+					prev = content.URL.Path
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case sortedCh <- content:
+			}
+			if content.Err != nil {
+				return
+			}
+		}
+	}()
+	return sortedCh
+}
+
+// statInventoryObject returns the current state of an object listed from
+// an inventory report, which may be out of date. A nil content is
+// returned if the object does not exist anymore.
+func statInventoryObject(ctx context.Context, alias string, content *ClientContent) (*ClientContent, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return nil, err.Trace(content.URL.String())
+	}
+	current, err := clnt.Stat(ctx, StatOptions{headOnly: true})
+	if err != nil {
+		if errors.As(err.ToGoError(), &ObjectMissing{}) {
+			return nil, nil
+		}
+		return nil, err.Trace(content.URL.String())
+	}
+	return current, nil
+}
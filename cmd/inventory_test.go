@@ -0,0 +1,175 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestParseInventoryManifest(t *testing.T) {
+	testCases := []struct {
+		manifest    string
+		destination string
+		files       int
+		fail        bool
+	}{
+		{`{"sourceBucket":"src","destinationBucket":"arn:aws:s3:::inv","fileFormat":"CSV","fileSchema":"Bucket, Key","files":[{"key":"a.csv.gz"},{"key":"b.csv.gz"}]}`, "inv", 2, false},
+		{`{"sourceBucket":"src","destinationBucket":"inv","fileFormat":"csv","fileSchema":"Bucket, Key","files":[]}`, "inv", 0, false},
+		{`{"sourceBucket":"src","destinationBucket":"arn:aws:s3:::inv","fileFormat":"Parquet","fileSchema":"message s3.inventory {}"}`, "", 0, true},
+		{`{"destinationBucket":"arn:aws:s3:::inv","fileFormat":"CSV"}`, "", 0, true},
+		{`{"sourceBucket":`, "", 0, true},
+	}
+
+	for i, tc := range testCases {
+		m, err := parseInventoryManifest(strings.NewReader(tc.manifest))
+		if (err != nil) != tc.fail {
+			t.Fatalf("Test %d: expected failure %t, got %v", i+1, tc.fail, err)
+		}
+		if tc.fail {
+			continue
+		}
+		if m.DestinationBucket != tc.destination || len(m.Files) != tc.files {
+			t.Fatalf("Test %d: expected destination %s with %d files, got %s with %d files", i+1, tc.destination, tc.files, m.DestinationBucket, len(m.Files))
+		}
+	}
+}
+
+func TestParseInventoryRecord(t *testing.T) {
+	schema, err := parseInventorySchema("Bucket, Key, VersionId, IsLatest, IsDeleteMarker, Size, LastModifiedDate, ETag, StorageClass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		record []string
+		object minio.ObjectInfo
+		ok     bool
+		fail   bool
+	}{
+		{
+			[]string{"src", "photos/a.jpg", "", "", "", "10", "2022-01-02T03:04:05.000Z", "abc", "STANDARD"},
+			minio.ObjectInfo{Key: "photos/a.jpg", Size: 10, LastModified: modTime, ETag: "abc", StorageClass: "STANDARD", IsLatest: true},
+			true, false,
+		},
+		{
+			[]string{"src", "my+photos%2Fa%20b.jpg", "v1", "true", "false", "0", "2022-01-02T03:04:05Z", "", "GLACIER"},
+			minio.ObjectInfo{Key: "my photos/a b.jpg", LastModified: modTime, StorageClass: "GLACIER", IsLatest: true},
+			true, false,
+		},
+		{[]string{"src", "a", "v1", "false", "false", "1", "", "", ""}, minio.ObjectInfo{}, false, false},
+		{[]string{"src", "a", "v2", "true", "true", "", "", "", ""}, minio.ObjectInfo{}, false, false},
+		{[]string{"src", "a", "", "", "", "ten", "", "", ""}, minio.ObjectInfo{}, false, true},
+		{[]string{"src", "a", "", "", "", "1", "yesterday", "", ""}, minio.ObjectInfo{}, false, true},
+		{[]string{"src", "%zz", "", "", "", "1", "", "", ""}, minio.ObjectInfo{}, false, true},
+	}
+
+	for i, tc := range testCases {
+		object, ok, err := parseInventoryRecord(schema, tc.record)
+		if (err != nil) != tc.fail {
+			t.Fatalf("Test %d: expected failure %t, got %v", i+1, tc.fail, err)
+		}
+		if ok != tc.ok {
+			t.Fatalf("Test %d: expected ok %t, got %t", i+1, tc.ok, ok)
+		}
+		if ok && !reflect.DeepEqual(object, tc.object) {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, tc.object, object)
+		}
+	}
+
+	if _, err := parseInventorySchema("Bucket, Size"); err == nil {
+		t.Fatal("expected a schema without Key to fail")
+	}
+}
+
+func TestReadInventoryCSV(t *testing.T) {
+	schema, err := parseInventorySchema("Bucket, Key, Size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := "\"src\",\"docs%2Fa.txt\",\"1\"\n\"src\",\"photos%2Fb.jpg\",\"2\"\n\"src\",\"photos%2Fc.jpg\",\"3\"\n"
+
+	testCases := []struct {
+		prefix string
+		keys   []string
+	}{
+		{"", []string{"docs/a.txt", "photos/b.jpg", "photos/c.jpg"}},
+		{"photos/", []string{"photos/b.jpg", "photos/c.jpg"}},
+		{"videos/", nil},
+	}
+
+	for i, tc := range testCases {
+		objectCh := make(chan minio.ObjectInfo, 10)
+		more, err := readInventoryCSV(context.Background(), strings.NewReader(data), schema, tc.prefix, objectCh)
+		close(objectCh)
+		if err != nil || !more {
+			t.Fatalf("Test %d: unexpected result %t, %v", i+1, more, err)
+		}
+		var keys []string
+		for object := range objectCh {
+			keys = append(keys, object.Key)
+		}
+		if !reflect.DeepEqual(keys, tc.keys) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.keys, keys)
+		}
+	}
+}
+
+func TestSortedListing(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		received int
+		fail     bool
+	}{
+		{[]string{"/b/a", "/b/b", "/b/c"}, 3, false},
+		{[]string{"/b/a", "/b/c", "/b/b", "/b/d"}, 2, true},
+		{nil, 0, false},
+	}
+
+	for i, tc := range testCases {
+		contentCh := make(chan *ClientContent)
+		go func() {
+			defer close(contentCh)
+			for _, p := range tc.paths {
+				contentCh <- &ClientContent{URL: *newClientURL(p)}
+			}
+		}()
+
+		var received int
+		var failed bool
+		for content := range sortedListing(context.Background(), contentCh) {
+			if content.Err != nil {
+				if _, ok := content.Err.ToGoError().(ListingNotSorted); !ok {
+					t.Fatalf("Test %d: unexpected error %v", i+1, content.Err)
+				}
+				failed = true
+				continue
+			}
+			received++
+		}
+		if failed != tc.fail || received != tc.received {
+			t.Fatalf("Test %d: expected failure %t after %d entries, got %t after %d", i+1, tc.fail, tc.received, failed, received)
+		}
+	}
+}
@@ -52,7 +52,7 @@ var legalHoldClearCmd = cli.Command{
 	Action:       mainLegalHoldClear,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(lhClearFlags, globalFlags...),
+	Flags:        append(append(lhClearFlags, objectLockFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -75,6 +75,9 @@ EXAMPLES:
 
    4. Disable object legal hold recursively for all objects versions older than one year
       $ {{.HelpName}} myminio/mybucket/prefix --recursive --rewind 365d --versions
+
+   5. Disable object legal hold recursively for the objects at a prefix tagged with case=1234
+      $ {{.HelpName}} myminio/mybucket/prefix --recursive --tags "case=^1234$"
 `,
 }
 
@@ -100,5 +103,6 @@ func mainLegalHoldClear(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "Bucket locking needs to be enabled in order to use this feature.")
 	}
 
-	return setLegalHold(ctx, targetURL, versionID, timeRef, withVersions, recursive, minio.LegalHoldDisabled)
+	filter := parseObjectLockFilter(cliCtx, recursive || withVersions)
+	return setLegalHold(ctx, targetURL, versionID, timeRef, withVersions, recursive, minio.LegalHoldDisabled, filter)
 }
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
@@ -49,6 +50,14 @@ var lhInfoFlags = []cli.Flag{
 		Name:  "versions",
 		Usage: "show legal hold status of multiple versions of object(s)",
 	},
+	cli.BoolFlag{
+		Name:  "summary",
+		Usage: "only show the number of objects with and without legal hold",
+	},
+	cli.BoolFlag{
+		Name:  "held-only",
+		Usage: "only list the objects under legal hold",
+	},
 }
 
 var legalHoldInfoCmd = cli.Command{
@@ -80,6 +89,12 @@ EXAMPLES:
 
    4. Show object legal hold recursively for all objects versions older than one year
       $ {{.HelpName}} myminio/mybucket/prefix --recursive --rewind 365d --versions
+
+   5. Count the objects with and without legal hold at a prefix
+      $ {{.HelpName}} myminio/mybucket/prefix --recursive --summary
+
+   6. List the objects under legal hold at a prefix, one per line
+      $ {{.HelpName}} myminio/mybucket/prefix --recursive --held-only
 `,
 }
 
@@ -128,8 +143,71 @@ func (l legalHoldInfoMessage) JSON() string {
 	return string(msgBytes)
 }
 
+// legalHoldHeldMessage is an object under legal hold, printed as a bare URL
+// so that the list can be consumed by other commands.
+type legalHoldHeldMessage struct {
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	VersionID string `json:"versionID,omitempty"`
+}
+
+func (l legalHoldHeldMessage) String() string {
+	if l.VersionID != "" {
+		return l.URL + "\t" + l.VersionID
+	}
+	return l.URL
+}
+
+func (l legalHoldHeldMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// legalHoldSummaryMessage counts the objects by legal hold status.
+type legalHoldSummaryMessage struct {
+	Status   string `json:"status"`
+	URL      string `json:"url"`
+	Objects  int64  `json:"objects"`
+	Held     int64  `json:"held"`
+	Released int64  `json:"released"`
+	NotSet   int64  `json:"notSet"`
+	Errors   int64  `json:"errors,omitempty"`
+}
+
+func (l *legalHoldSummaryMessage) add(lhold minio.LegalHoldStatus) {
+	l.Objects++
+	switch lhold {
+	case minio.LegalHoldEnabled:
+		l.Held++
+	case minio.LegalHoldDisabled:
+		l.Released++
+	default:
+		l.NotSet++
+	}
+}
+
+func (l legalHoldSummaryMessage) String() string {
+	msg := fmt.Sprintf("%s held, %s released, %s not set",
+		console.Colorize("LegalHoldOn", humanize.Comma(l.Held)),
+		console.Colorize("LegalHoldOff", humanize.Comma(l.Released)),
+		console.Colorize("LegalHoldNotSet", humanize.Comma(l.NotSet)))
+	msg = fmt.Sprintf("Total: %s objects (%s) under `%s`", humanize.Comma(l.Objects), msg, l.URL)
+	if l.Errors > 0 {
+		msg += ", " + console.Colorize("LegalHoldPartialFailure", humanize.Comma(l.Errors)+" errors")
+	}
+	return msg
+}
+
+func (l legalHoldSummaryMessage) JSON() string {
+	l.Status = "success"
+	msgBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
 // showLegalHoldInfo - show legalhold for one or many objects within a given prefix, with or without versioning
-func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef time.Time, withVersions, recursive bool) error {
+func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef time.Time, withVersions, recursive, summary, heldOnly bool) error {
 	clnt, err := newClient(urlStr)
 	if err != nil {
 		fatalIf(err.Trace(), "Unable to parse the provided url.")
@@ -162,6 +240,7 @@ func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef ti
 	}
 
 	alias, _, _ := mustExpandAlias(urlStr)
+	counts := legalHoldSummaryMessage{URL: urlStr}
 	var cErr error
 	errorsFound := false
 	objectsFound := false
@@ -190,24 +269,41 @@ func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef ti
 		lhold, probeErr := newClnt.GetObjectLegalHold(ctx, content.VersionID)
 		if probeErr != nil {
 			errorsFound = true
+			counts.Errors++
 			errorIf(probeErr.Trace(content.URL.Path), "Failed to get legal hold information on `%s`", content.URL.Path)
-		} else {
-			if !globalJSON {
-
-				contentURL := filepath.ToSlash(content.URL.Path)
-				key := strings.TrimPrefix(contentURL, prefixPath)
+			continue
+		}
+		counts.add(lhold)
 
-				printMsg(legalHoldInfoMessage{
-					LegalHold: lhold,
+		switch {
+		case summary:
+		case heldOnly:
+			if lhold == minio.LegalHoldEnabled {
+				printMsg(legalHoldHeldMessage{
 					Status:    "success",
-					URLPath:   content.URL.String(),
-					Key:       key,
+					URL:       alias + getKey(content),
 					VersionID: content.VersionID,
 				})
 			}
+		default:
+			contentURL := filepath.ToSlash(content.URL.Path)
+			key := strings.TrimPrefix(contentURL, prefixPath)
+
+			printMsg(legalHoldInfoMessage{
+				LegalHold: lhold,
+				Status:    "success",
+				URLPath:   content.URL.String(),
+				Key:       key,
+				VersionID: content.VersionID,
+			})
 		}
 	}
 
+	// The list of held objects is kept free of totals to be machine readable.
+	if objectsFound && !heldOnly {
+		printMsg(counts)
+	}
+
 	if cErr == nil && !globalJSON {
 		switch {
 		case errorsFound:
@@ -230,6 +326,13 @@ func mainLegalHoldInfo(cliCtx *cli.Context) error {
 	console.SetColor("LegalHoldMessageFailure", color.New(color.FgYellow))
 
 	targetURL, versionID, timeRef, recursive, withVersions := parseLegalHoldArgs(cliCtx)
+	summary, heldOnly := cliCtx.Bool("summary"), cliCtx.Bool("held-only")
+	if summary && heldOnly {
+		fatalIf(errInvalidArgument(), "You cannot pass --summary with --held-only.")
+	}
+	if (summary || heldOnly) && !recursive && !withVersions {
+		fatalIf(errInvalidArgument(), "--summary and --held-only require --recursive or --versions.")
+	}
 	if timeRef.IsZero() && withVersions {
 		timeRef = time.Now().UTC()
 	}
@@ -245,5 +348,5 @@ func mainLegalHoldInfo(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "Bucket lock needs to be enabled in order to use this feature.")
 	}
 
-	return showLegalHoldInfo(ctx, targetURL, versionID, timeRef, withVersions, recursive)
+	return showLegalHoldInfo(ctx, targetURL, versionID, timeRef, withVersions, recursive, summary, heldOnly)
 }
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestLegalHoldSummary(t *testing.T) {
+	var summary legalHoldSummaryMessage
+	for _, lhold := range []minio.LegalHoldStatus{minio.LegalHoldEnabled, minio.LegalHoldEnabled, minio.LegalHoldDisabled, ""} {
+		summary.add(lhold)
+	}
+	if summary.Objects != 4 || summary.Held != 2 || summary.Released != 1 || summary.NotSet != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	held := legalHoldHeldMessage{URL: "myminio/mybucket/obj"}
+	if held.String() != "myminio/mybucket/obj" {
+		t.Fatalf("unexpected held message %q", held.String())
+	}
+	held.VersionID = "v1"
+	if held.String() != "myminio/mybucket/obj\tv1" {
+		t.Fatalf("unexpected held message %q", held.String())
+	}
+}
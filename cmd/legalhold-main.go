@@ -54,6 +54,7 @@ type legalHoldCmdMessage struct {
 	VersionID string                `json:"versionID"`
 	Status    string                `json:"status"`
 	Err       error                 `json:"error,omitempty"`
+	DryRun    bool                  `json:"dryRun,omitempty"`
 }
 
 // Colorized message for console printing.
@@ -67,6 +68,12 @@ func (l legalHoldCmdMessage) String() string {
 	}
 
 	msg := fmt.Sprintf("Object legal hold successfully %s for `%s`", op, l.Key)
+	if l.DryRun {
+		if l.LegalHold == minio.LegalHoldDisabled {
+			op = "clear"
+		}
+		msg = fmt.Sprintf("Would %s object legal hold for `%s`", op, l.Key)
+	}
 	if l.VersionID != "" {
 		msg += fmt.Sprintf(" (version-id=%s)", l.VersionID)
 	}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/v3/console"
 )
@@ -55,7 +56,7 @@ var legalHoldSetCmd = cli.Command{
 	Action:       mainLegalHoldSet,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(lhSetFlags, globalFlags...),
+	Flags:        append(append(lhSetFlags, objectLockFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -78,11 +79,14 @@ EXAMPLES:
 
    4. Enable object legal hold recursively for all objects versions older than one year
       $ {{.HelpName}} myminio/mybucket/prefix --recursive --rewind 365d --versions
+
+   5. Enable object legal hold recursively for the objects at a prefix tagged with case=1234, except the logs
+      $ {{.HelpName}} myminio/mybucket/prefix --recursive --tags "case=^1234$" --exclude "logs/*"
 `,
 }
 
 // setLegalHold - Set legalhold for all objects within a given prefix.
-func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Time, withVersions, recursive bool, lhold minio.LegalHoldStatus) error {
+func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Time, withVersions, recursive bool, lhold minio.LegalHoldStatus, filter objectLockFilter) error {
 	clnt, err := newClient(urlStr)
 	if err != nil {
 		fatalIf(err.Trace(), "Unable to parse the provided url.")
@@ -96,7 +100,9 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 	prefixPath = strings.TrimPrefix(prefixPath, "./")
 
 	if !recursive && !withVersions {
-		err = clnt.PutObjectLegalHold(ctx, versionID, lhold)
+		if !globalDryRun {
+			err = clnt.PutObjectLegalHold(ctx, versionID, lhold)
+		}
 		if err != nil {
 			errorIf(err.Trace(urlStr), "Failed to set legal hold on `%s` successfully", urlStr)
 		} else {
@@ -109,6 +115,7 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 				URLPath:   clnt.GetURL().String(),
 				Key:       key,
 				VersionID: versionID,
+				DryRun:    globalDryRun,
 			})
 		}
 		return nil
@@ -117,7 +124,7 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 	alias, _, _ := mustExpandAlias(urlStr)
 	var cErr error
 	objectsFound := false
-	lstOptions := ListOptions{Recursive: recursive, ShowDir: DirNone}
+	lstOptions := ListOptions{Recursive: recursive, ShowDir: DirNone, WithMetadata: len(filter.tags) > 0}
 	if !timeRef.IsZero() {
 		lstOptions.WithOlderVersions = withVersions
 		lstOptions.TimeRef = timeRef
@@ -133,6 +140,10 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 			break
 		}
 
+		if !filter.match(strings.TrimPrefix(alias+getKey(content), urlStr), content) {
+			continue
+		}
+
 		objectsFound = true
 
 		newClnt, perr := newClientFromAlias(alias, content.URL.String())
@@ -141,7 +152,10 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 			continue
 		}
 
-		probeErr := newClnt.PutObjectLegalHold(ctx, content.VersionID, lhold)
+		var probeErr *probe.Error
+		if !globalDryRun {
+			probeErr = newClnt.PutObjectLegalHold(ctx, content.VersionID, lhold)
+		}
 		if probeErr != nil {
 			errorIf(probeErr.Trace(content.URL.Path), "Failed to set legal hold on `%s` successfully", content.URL.Path)
 		} else {
@@ -155,6 +169,7 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 					URLPath:   content.URL.String(),
 					Key:       key,
 					VersionID: content.VersionID,
+					DryRun:    globalDryRun,
 				})
 			}
 		}
@@ -217,5 +232,6 @@ func mainLegalHoldSet(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "Bucket lock needs to be enabled in order to use this feature.")
 	}
 
-	return setLegalHold(ctx, targetURL, versionID, timeRef, withVersions, recursive, minio.LegalHoldEnabled)
+	filter := parseObjectLockFilter(cliCtx, recursive || withVersions)
+	return setLegalHold(ctx, targetURL, versionID, timeRef, withVersions, recursive, minio.LegalHoldEnabled, filter)
 }
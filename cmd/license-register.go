@@ -101,6 +101,8 @@ func (li licRegisterMessage) String() string {
 	case "offline":
 		msg = fmt.Sprintln("Open the following URL in the browser to register", li.Alias, "on SUBNET:")
 		msg = console.Colorize(licRegisterMsgTag, msg) + console.Colorize(licRegisterLinkTag, li.URL)
+		msg += console.Colorize(licRegisterMsgTag,
+			fmt.Sprintf("\nThen paste the license shown on SUBNET with 'mc license update %s -'.", li.Alias))
 	}
 	return msg
 }
@@ -18,8 +18,11 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -39,7 +42,10 @@ var licenseUpdateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} ALIAS LICENSE-FILE-PATH
+  {{.HelpName}} ALIAS [LICENSE-FILE-PATH]
+
+LICENSE-FILE-PATH:
+  Path of the license file, use '-' to paste the license or read it from STDIN.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -50,6 +56,8 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} play license.key
   2. Update (renew) license for already registered cluster with alias 'play'
      {{.Prompt}} {{.HelpName}} play
+  3. Update license for cluster with alias 'play' in an airgapped environment, by pasting the license from SUBNET
+     {{.Prompt}} {{.HelpName}} play -
 `,
 }
 
@@ -125,11 +133,41 @@ func performLicenseUpdate(licFile, alias string) licUpdateMessage {
 		Status: "success",
 	}
 
-	licBytes, e := os.ReadFile(licFile)
-	fatalIf(probe.NewError(e), fmt.Sprintf("Unable to read license file %s", licFile))
-
-	lic := string(licBytes)
+	var lic string
+	if licFile == "-" {
+		lic = readPastedLicense(os.Stdin)
+	} else {
+		licBytes, e := os.ReadFile(licFile)
+		fatalIf(probe.NewError(e), fmt.Sprintf("Unable to read license file %s", licFile))
+		lic = string(licBytes)
+	}
 	validateAndSaveLic(lic, alias, true)
 
 	return lum
 }
+
+// readPastedLicense reads a license pasted in the terminal, up to the
+// first empty line, or the whole input when it is not a terminal.
+func readPastedLicense(r io.Reader) string {
+	if !isTerminal() {
+		licBytes, e := io.ReadAll(r)
+		fatalIf(probe.NewError(e), "Unable to read the license from STDIN")
+		return strings.TrimSpace(string(licBytes))
+	}
+
+	fmt.Println("Paste the license, followed by an empty line:")
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	fatalIf(probe.NewError(scanner.Err()), "Unable to read the pasted license")
+	return strings.Join(lines, "")
+}
@@ -60,6 +60,7 @@ var (
 			Name:  "zip",
 			Usage: "list files inside zip archive (MinIO servers only)",
 		},
+		listParallelFlag,
 	}
 )
 
@@ -112,6 +113,12 @@ EXAMPLES:
   
   10. List all objects on mybucket, for the GLACIER storage class
      {{.Prompt}} {{.HelpName}} --storage-class 'GLACIER' s3/mybucket 
+
+  11. List all objects on mybucket recursively as one JSON record per line, e.g. to be consumed by jq -c.
+     {{.Prompt}} {{.HelpName}} --json-lines -r s3/mybucket | jq -c 'select(.size > 1048576)'
+
+  12. List all objects of a huge bucket recursively, listing 16 of its top-level prefixes at a time.
+     {{.Prompt}} {{.HelpName}} -r --list-parallel 16 s3/mybucket
 `,
 }
 
@@ -188,6 +195,7 @@ func checkListSyntax(cliCtx *cli.Context) ([]string, doListOptions) {
 		withVersions: withVersions,
 		listZip:      listZip,
 		filter:       storageClasss,
+		listParallel: cliCtx.Int("list-parallel"),
 	}
 	return args, opts
 }
@@ -94,6 +94,54 @@ func (c contentMessage) JSON() string {
 	return string(jsonMessageBytes)
 }
 
+// AppendJSON appends the single line JSON form of the message.
+func (c contentMessage) AppendJSON(dst []byte) []byte {
+	dst = append(dst, `{"status":"success"`...)
+	dst = appendJSONKey(dst, "type")
+	dst = appendJSONString(dst, c.Filetype)
+	dst = appendJSONKey(dst, "lastModified")
+	dst = appendJSONTime(dst, c.Time)
+	dst = appendJSONKey(dst, "size")
+	dst = appendJSONInt(dst, c.Size)
+	dst = appendJSONKey(dst, "key")
+	dst = appendJSONString(dst, c.Key)
+	dst = appendJSONKey(dst, "etag")
+	dst = appendJSONString(dst, c.ETag)
+	if c.URL != "" {
+		dst = appendJSONKey(dst, "url")
+		dst = appendJSONString(dst, c.URL)
+	}
+	if c.VersionID != "" {
+		dst = appendJSONKey(dst, "versionId")
+		dst = appendJSONString(dst, c.VersionID)
+	}
+	if c.VersionOrd != 0 {
+		dst = appendJSONKey(dst, "versionOrdinal")
+		dst = appendJSONInt(dst, int64(c.VersionOrd))
+	}
+	if c.VersionIndex != 0 {
+		dst = appendJSONKey(dst, "versionIndex")
+		dst = appendJSONInt(dst, int64(c.VersionIndex))
+	}
+	if c.IsDeleteMarker {
+		dst = appendJSONKey(dst, "isDeleteMarker")
+		dst = append(dst, "true"...)
+	}
+	if c.StorageClass != "" {
+		dst = appendJSONKey(dst, "storageClass")
+		dst = appendJSONString(dst, c.StorageClass)
+	}
+	if len(c.Metadata) > 0 {
+		dst = appendJSONKey(dst, "metadata")
+		dst = appendJSONStringMap(dst, c.Metadata)
+	}
+	if len(c.Tags) > 0 {
+		dst = appendJSONKey(dst, "tags")
+		dst = appendJSONStringMap(dst, c.Tags)
+	}
+	return append(dst, '}')
+}
+
 // Use OS separator and adds a trailing separator if it is a dir
 func getOSDependantKey(path string, isDir bool) string {
 	sep := "/"
@@ -214,6 +262,7 @@ type doListOptions struct {
 	withVersions bool
 	listZip      bool
 	filter       string
+	listParallel int
 }
 
 // doList - list all entities inside a folder.
@@ -234,6 +283,7 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 		WithDeleteMarkers: true,
 		ShowDir:           DirNone,
 		ListZip:           o.listZip,
+		Parallel:          o.listParallel,
 	}) {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
@@ -16,3 +16,85 @@
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package cmd
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	json "github.com/minio/colorjson"
+)
+
+var testContentMessages = []contentMessage{
+	{},
+	{
+		Filetype: "file",
+		Time:     time.Date(2024, 2, 29, 13, 4, 5, 0, time.UTC),
+		Size:     1 << 40,
+		Key:      "dir/object.txt",
+		ETag:     "d41d8cd98f00b204e9800998ecf8427e",
+		URL:      "play/bucket/dir/object.txt",
+	},
+	{
+		Filetype:       "file",
+		Time:           time.Date(2024, 2, 29, 13, 4, 5, 123456789, time.FixedZone("", -7*3600)),
+		Key:            "<a&b>\"quoted\"\\\n\r\t\x00\x1f\x7f",
+		VersionID:      "3ddac055-89a7-40fa-8cd3-530a5581b6b8",
+		VersionOrd:     3,
+		VersionIndex:   -1,
+		IsDeleteMarker: true,
+		StorageClass:   "STANDARD",
+	},
+	{
+		Filetype: "folder",
+		Key:      "日本語/  /\xff\xfe/😀/",
+		Metadata: map[string]string{"X-Amz-Meta-B": "2", "Content-Type": "text/plain", "X-Amz-Meta-<": "&"},
+		Tags:     map[string]string{"z": "", "a": " "},
+	},
+	{
+		Metadata: map[string]string{},
+		Tags:     map[string]string{},
+	},
+}
+
+func TestContentMessageAppendJSON(t *testing.T) {
+	for i, msg := range testContentMessages {
+		msg.Status = "success"
+		expected, e := json.Marshal(msg)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if got := msg.AppendJSON(nil); string(got) != string(expected) {
+			t.Errorf("Test %d: expected %s, got %s", i+1, expected, got)
+		}
+	}
+}
+
+// reflectMessage hides the AppendJSON method of a message.
+type reflectMessage struct {
+	message
+}
+
+func benchmarkPrintContentMessage(b *testing.B, wrap func(contentMessage) message) {
+	oldOutput, oldJSON, oldJSONLine := color.Output, globalJSON, globalJSONLine
+	defer func() {
+		color.Output, globalJSON, globalJSONLine = oldOutput, oldJSON, oldJSONLine
+	}()
+	color.Output, globalJSON, globalJSONLine = io.Discard, true, true
+
+	msg := wrap(testContentMessages[1])
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		printMsg(msg)
+	}
+}
+
+func BenchmarkPrintContentMessageJSON(b *testing.B) {
+	benchmarkPrintContentMessage(b, func(c contentMessage) message { return reflectMessage{c} })
+}
+
+func BenchmarkPrintContentMessageAppendJSON(b *testing.B) {
+	benchmarkPrintContentMessage(b, func(c contentMessage) message { return c })
+}
@@ -35,7 +35,6 @@ import (
 
 	"github.com/inconshreveable/mousetrap"
 	"github.com/minio/cli"
-	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/pkg/v3/console"
@@ -70,6 +69,14 @@ GLOBAL FLAGS:
   {{end}}{{end}}
 TIP:
   Use '{{.Name}} --autocompletion' to enable shell autocompletion
+  Override colors in theme.json of the config folder, e.g. {"version": "1", "colors": {"Copy": "blue,bold"}}
+  Set MC_AUDIT_LOG to 'on', 'syslog' or a file path to keep an audit log of mutating commands
+
+EXIT STATUS:
+  0 success, 1 unknown error, 2 invalid argument, 3 access denied, 4 invalid credentials,
+  5 no such bucket, 6 no such object, 7 already exists, 8 quota exceeded, 9 connection timeout,
+  10 connection failed, 11 TLS error, 12 service unavailable, 13 not implemented, 130 canceled.
+  With --json, error messages carry the matching "code" e.g. "NoSuchBucket".
 
 COPYRIGHT:
   Copyright (c) 2015-` + CopyrightYear + ` MinIO, Inc.
@@ -140,7 +147,14 @@ func Main(args []string) error {
 
 	parsePagerDisableFlag(args)
 	// Run the app
-	return registerApp(appName).Run(args)
+	e := registerApp(appName).Run(args)
+	waitUpdateCheck()
+	if e != nil {
+		finishAuditLog(globalErrorExitStatus, errorCode(e))
+		return e
+	}
+	finishAuditLog(0, "")
+	return nil
 }
 
 func flagValue(f cli.Flag) reflect.Value {
@@ -375,6 +389,13 @@ func registerBefore(ctx *cli.Context) error {
 	// Check if config can be read.
 	checkConfig()
 
+	// Apply the user color theme, if any.
+	if !globalNoColor {
+		colors, err := loadTheme(getThemeFile())
+		errorIf(err.Trace(getThemeFile()), "Unable to load the color theme, using the default colors.")
+		applyTheme(colors)
+	}
+
 	return nil
 }
 
@@ -400,7 +421,8 @@ func checkUpdate(ctx *cli.Context) {
 	// Do not print update messages, if quiet flag is set.
 	if !ctx.Bool("quiet") && !ctx.GlobalBool("quiet") {
 		// Its OK to ignore any errors during doUpdate() here.
-		if updateMsg, _, currentReleaseTime, latestReleaseTime, _, err := getUpdateInfo("", 2*time.Second); err == nil {
+		channelURL, _ := getChannelURL(mcChannelStable)
+		if updateMsg, _, currentReleaseTime, latestReleaseTime, _, err := getUpdateInfo(releaseInfoURL(channelURL), 2*time.Second); err == nil {
 			printMsg(updateMessage{
 				Status:  "success",
 				Message: updateMsg,
@@ -419,6 +441,7 @@ var appCmds = []cli.Command{
 	adminCmd,
 	anonymousCmd,
 	batchCmd,
+	browseCmd,
 	cpCmd,
 	catCmd,
 	configCmd,
@@ -480,10 +503,8 @@ func registerApp(name string) *cli.App {
 	app := cli.NewApp()
 	app.Name = name
 	app.Action = func(ctx *cli.Context) error {
-		mcEnable := env.Get("MC_UPDATE", madmin.EnableOn)
-		minioEnable := env.Get("MINIO_UPDATE", madmin.EnableOn)
-
-		if strings.HasPrefix(ReleaseTag, "RELEASE.") && (mcEnable == madmin.EnableOn || minioEnable == madmin.EnableOn) {
+		cfg, _ := loadUpdateCheckConfig(getUpdateCheckConfigFile())
+		if strings.HasPrefix(ReleaseTag, "RELEASE.") && isUpdateCheckEnabled(cfg) && !ctx.GlobalBool("quiet-update") {
 			// Check for new updates from dl.min.io.
 			checkUpdate(ctx)
 		}
@@ -513,6 +534,9 @@ func registerApp(name string) *cli.App {
 	app.EnableBashCompletion = true
 	app.OnUsageError = onUsageError
 	app.After = func(*cli.Context) error {
+		if globalDebug && globalConnStats.requests.Load() > 0 {
+			console.Debugln(globalConnStats.String())
+		}
 		globalExpiringCerts.Range(func(k, v interface{}) bool {
 			host := k.(string)
 			expires := v.(time.Time)
@@ -19,11 +19,13 @@ package cmd
 
 import (
 	"context"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/v3/console"
 )
 
@@ -45,6 +47,14 @@ var mbFlags = []cli.Flag{
 		Name:  "with-versioning",
 		Usage: "enable versioned bucket",
 	},
+	cli.StringFlag{
+		Name:  "default-retention-mode",
+		Usage: "set default retention mode of a new bucket, valid values are [GOVERNANCE, COMPLIANCE]; implies --with-lock, existing buckets are left unchanged",
+	},
+	cli.StringFlag{
+		Name:  "default-retention-validity",
+		Usage: "set default retention validity of the bucket in days or years (e.g. 30d, 1y)",
+	},
 }
 
 // make a bucket.
@@ -88,6 +98,9 @@ EXAMPLES:
 
   8. Create a new bucket on MinIO with versioning enabled.
      {{.Prompt}} {{.HelpName}} --with-versioning myminio/myversionedbucket
+
+  9. Create a new bucket on MinIO with object lock enabled and a default retention of one year in compliance mode.
+     {{.Prompt}} {{.HelpName}} --with-lock --default-retention-mode compliance --default-retention-validity 1y myminio/mycompliancebucket
 `,
 }
 
@@ -111,11 +124,41 @@ func (s makeBucketMessage) JSON() string {
 	return string(makeBucketJSONBytes)
 }
 
+// parseDefaultRetention parses the default retention mode and validity of
+// a new bucket, both are empty when no default retention is requested.
+func parseDefaultRetention(modeStr, validityStr string) (mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, err *probe.Error) {
+	if modeStr == "" && validityStr == "" {
+		return "", 0, "", nil
+	}
+	if modeStr == "" || validityStr == "" {
+		return "", 0, "", errInvalidArgument().Trace(modeStr, validityStr)
+	}
+
+	mode = minio.RetentionMode(strings.ToUpper(modeStr))
+	if !mode.IsValid() {
+		return "", 0, "", errInvalidArgument().Trace(modeStr)
+	}
+	validity, unit, err = parseRetentionValidity(validityStr)
+	if err != nil {
+		return "", 0, "", err.Trace(validityStr)
+	}
+	if validity == 0 {
+		return "", 0, "", errInvalidArgument().Trace(validityStr)
+	}
+	return mode, validity, unit, nil
+}
+
 // Validate command line arguments.
 func checkMakeBucketSyntax(cliCtx *cli.Context) {
 	if !cliCtx.Args().Present() {
 		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
 	}
+	modeStr, validityStr := cliCtx.String("default-retention-mode"), cliCtx.String("default-retention-validity")
+	if (modeStr == "") != (validityStr == "") {
+		fatalIf(errInvalidArgument().Trace(modeStr, validityStr), "--default-retention-mode and --default-retention-validity must be specified together.")
+	}
+	_, _, _, err := parseDefaultRetention(modeStr, validityStr)
+	fatalIf(err, "Invalid default retention, mode must be GOVERNANCE or COMPLIANCE and validity a positive number of days or years (e.g. 30d, 1y).")
 }
 
 // mainMakeBucket is entry point for mb command.
@@ -130,6 +173,10 @@ func mainMakeBucket(cliCtx *cli.Context) error {
 	region := cliCtx.String("region")
 	ignoreExisting := cliCtx.Bool("p")
 	withLock := cliCtx.Bool("l")
+	mode, validity, unit, _ := parseDefaultRetention(cliCtx.String("default-retention-mode"), cliCtx.String("default-retention-validity"))
+	if mode != "" {
+		withLock = true
+	}
 
 	var cErr error
 	for _, targetURL := range cliCtx.Args() {
@@ -144,6 +191,13 @@ func mainMakeBucket(cliCtx *cli.Context) error {
 		ctx, cancelMakeBucket := context.WithCancel(globalContext)
 		defer cancelMakeBucket()
 
+		// The default retention of an existing bucket is never overwritten.
+		var existed bool
+		if ignoreExisting && mode != "" {
+			_, err = clnt.Stat(ctx, StatOptions{})
+			existed = err == nil
+		}
+
 		// Make bucket.
 		if err = clnt.MakeBucket(ctx, region, ignoreExisting, withLock); err != nil {
 			switch err.ToGoError().(type) {
@@ -160,6 +214,19 @@ func mainMakeBucket(cliCtx *cli.Context) error {
 			fatalIf(clnt.SetVersion(ctx, "enable", []string{}, false), "Unable to enable versioning")
 		}
 
+		switch {
+		case mode != "" && existed:
+			if !globalJSON {
+				console.Infof("Bucket `%s` already exists, its default retention is left unchanged.\n", targetURL)
+			}
+		case mode != "":
+			if err = clnt.SetObjectLockConfig(ctx, mode, validity, unit); err != nil {
+				errorIf(err.Trace(targetURL), "Bucket `%s` created but unable to set its default retention.", targetURL)
+				cErr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+		}
+
 		// Successfully created a bucket.
 		printMsg(makeBucketMessage{Status: "success", Bucket: targetURL})
 	}
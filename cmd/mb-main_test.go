@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestParseDefaultRetention(t *testing.T) {
+	testCases := []struct {
+		mode, validity string
+		expectedMode   minio.RetentionMode
+		expectedValid  uint64
+		expectedUnit   minio.ValidityUnit
+		expectErr      bool
+	}{
+		{mode: "", validity: ""},
+		{mode: "compliance", validity: "1y", expectedMode: minio.Compliance, expectedValid: 1, expectedUnit: minio.Years},
+		{mode: "GOVERNANCE", validity: "30d", expectedMode: minio.Governance, expectedValid: 30, expectedUnit: minio.Days},
+		{mode: "governance", validity: "", expectErr: true},
+		{mode: "", validity: "30d", expectErr: true},
+		{mode: "legal", validity: "30d", expectErr: true},
+		{mode: "compliance", validity: "30m", expectErr: true},
+		{mode: "compliance", validity: "0d", expectErr: true},
+		{mode: "compliance", validity: "d", expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		mode, validity, unit, err := parseDefaultRetention(testCase.mode, testCase.validity)
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error for %q %q", i+1, testCase.mode, testCase.validity)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if mode != testCase.expectedMode || validity != testCase.expectedValid || unit != testCase.expectedUnit {
+			t.Errorf("Test %d: expected %s %d%s, got %s %d%s", i+1, testCase.expectedMode, testCase.expectedValid, testCase.expectedUnit, mode, validity, unit)
+		}
+	}
+}
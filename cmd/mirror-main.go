@@ -61,10 +61,6 @@ var (
 			Usage:  "perform a fake mirror operation",
 			Hidden: true, // deprecated 2022
 		},
-		cli.BoolFlag{
-			Name:  "dry-run",
-			Usage: "perform a fake mirror operation",
-		},
 		cli.BoolFlag{
 			Name:  "watch, w",
 			Usage: "watch and synchronize changes",
@@ -82,6 +78,10 @@ var (
 			Name:  "preserve, a",
 			Usage: "preserve file(s)/object(s) attributes and bucket(s) policy/locking configuration(s) on target bucket(s)",
 		},
+		cli.BoolFlag{
+			Name:  "preserve-lock",
+			Usage: "apply the retention and legal hold of the source object(s) to the mirrored object(s)",
+		},
 		cli.BoolFlag{
 			Name:   "md5",
 			Usage:  "force all upload(s) to calculate md5sum checksum",
@@ -112,6 +112,11 @@ var (
 			Name:  "exclude-storageclass",
 			Usage: "exclude object(s) that match the specified storage class",
 		},
+		filterFromFlag,
+		listParallelFlag,
+		smallFileThresholdFlag,
+		inventoryFlag,
+		verboseObjectsFlag,
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "filter object(s) older than value in duration string (e.g. 7d10h31s)",
@@ -223,6 +228,24 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Mirror a locked bucket to a new bucket keeping its locking configuration and the retention and legal hold of every object.
+      {{.Prompt}} {{.HelpName}} -a --preserve-lock play/locked-bucket s3/locked-bucket
+
+  18. Mirror a local folder to Amazon S3 cloud storage reusing the ordered include/exclude rules of an rsync/rclone filter file.
+      {{.Prompt}} {{.HelpName}} --filter-from ~/backup-filters.txt ~/photos s3/photos
+
+  19. Mirror a huge bucket, listing 16 of the top-level prefixes of the source and the target at a time.
+      {{.Prompt}} {{.HelpName}} --list-parallel 16 s3/huge-bucket backup/huge-bucket
+
+  20. Mirror a folder of millions of small files, uploading the files up to 256KiB through the small file fast path.
+      {{.Prompt}} {{.HelpName}} --small-file-threshold 256KiB ~/thumbnails s3/thumbnails
+
+  21. Mirror a huge bucket enumerating the source objects from an S3 Inventory report instead of listing the source bucket.
+      {{.Prompt}} {{.HelpName}} --inventory s3/inventory/huge-bucket/daily/2022-01-01T00-00Z/manifest.json s3/huge-bucket backup/huge-bucket
+
+  22. Mirror a bucket in JSON mode printing a line for every object, instead of one per second past the first 1000 objects.
+      {{.Prompt}} {{.HelpName}} --json --verbose-objects s3/huge-bucket backup/huge-bucket
 `,
 }
 
@@ -267,6 +290,9 @@ type mirrorJob struct {
 
 	parallel *ParallelManager
 
+	// samples the per-object messages printed in quiet and json modes
+	objectLog *objectLogger
+
 	// channel for status messages
 	statusCh chan URLs
 
@@ -500,7 +526,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
-	if !mj.opts.isSummary {
+	if !mj.opts.isSummary && mj.objectLog.shouldLog(time.Now()) {
 		mj.status.PrintMsg(mirrorMessage{
 			Source:     sourcePath,
 			Target:     targetPath,
@@ -519,7 +545,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 
 	if !mj.opts.isRetriable {
 		now := time.Now()
-		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false})
+		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false, preserveLock: mj.opts.isPreserveLock, smallFileThreshold: mj.opts.smallFileThreshold})
 		if ret.Error == nil {
 			durationMs := time.Since(now).Milliseconds()
 			mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
@@ -538,7 +564,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 		}
 
 		now := time.Now()
-		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false})
+		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false, preserveLock: mj.opts.isPreserveLock, smallFileThreshold: mj.opts.smallFileThreshold})
 		if ret.Error == nil {
 			durationMs := time.Since(now).Milliseconds()
 			mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
@@ -555,6 +581,7 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 	// now we want to start the progress bar
 	mj.status.Start()
 	defer mj.status.Finish()
+	defer mj.objectLog.printSkipped()
 
 	var cancelInProgress bool
 
@@ -657,6 +684,10 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 		if matchExcludeOptions(mj.opts.excludeOptions, sourceSuffix, sourceURL.Type) {
 			continue
 		}
+		// Skip the object, if it is excluded by the filter rules
+		if !mj.opts.filterRules.isIncluded(sourceSuffix) {
+			continue
+		}
 		// Skip the bucket, if it matches the Exclude options provided
 		if matchExcludeBucketOptions(mj.opts.excludeBuckets, sourceSuffix) {
 			continue
@@ -892,7 +923,7 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 		watcher:   NewWatcher(UTCNow()),
 	}
 
-	mj.parallel = newParallelManager(mj.statusCh)
+	mj.parallel = newParallelManager(mj.statusCh, smallFileWorkers(opts.smallFileThreshold))
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
@@ -903,6 +934,9 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 	} else {
 		mj.status = NewProgressStatus(mj.parallel)
 	}
+	if globalQuiet || globalJSON {
+		mj.objectLog = newObjectLogger(opts.verboseObjects)
+	}
 
 	return &mj
 }
@@ -983,10 +1017,21 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 	isWatch := cli.Bool("watch") || cli.Bool("multi-master") || cli.Bool("active-active")
 	isRemove := cli.Bool("remove")
 	md5, checksum := parseChecksum(cli)
+	isPreserveLock := cli.Bool("preserve-lock")
+	if isPreserveLock && !checksum.IsSet() {
+		// The Content-MD5 header, or a checksum, is required for any request to upload an object with a retention period configured using Amazon S3 Object Lock.
+		md5 = true
+	}
 
 	// preserve is also expected to be overwritten if necessary
 	isMetadata := cli.Bool("a") || isWatch || len(userMetadata) > 0
-	isFake := cli.Bool("fake") || cli.Bool("dry-run")
+	isFake := cli.Bool("fake") || globalDryRun
+
+	rules, pErr := loadFilterRules(cli.String("filter-from"))
+	fatalIf(pErr.Trace(cli.String("filter-from")), "Unable to load filter rules.")
+
+	smallFileThreshold, pErr := parseSmallFileThreshold(cli.String("small-file-threshold"))
+	fatalIf(pErr.Trace(cli.String("small-file-threshold")), "Unable to parse --small-file-threshold, it takes a size up to 16MiB.")
 
 	mopts := mirrorOptions{
 		isFake:                isFake,
@@ -994,6 +1039,7 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 		isOverwrite:           isOverwrite,
 		isWatch:               isWatch,
 		isMetadata:            isMetadata,
+		isPreserveLock:        isPreserveLock,
 		isSummary:             cli.Bool("summary"),
 		isRetriable:           cli.Bool("retry"),
 		md5:                   md5,
@@ -1003,6 +1049,11 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 		excludeOptions:        cli.StringSlice("exclude"),
 		excludeBuckets:        cli.StringSlice("exclude-bucket"),
 		excludeStorageClasses: cli.StringSlice("exclude-storageclass"),
+		filterRules:           rules,
+		listParallel:          cli.Int("list-parallel"),
+		inventory:             cli.String("inventory"),
+		verboseObjects:        cli.Bool("verbose-objects"),
+		smallFileThreshold:    smallFileThreshold,
 		olderThan:             cli.String("older-than"),
 		newerThan:             cli.String("newer-than"),
 		storageClass:          cli.String("storage-class"),
@@ -66,6 +66,13 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 		}
 	}
 
+	// Inventory reports carry no metadata to compare and cannot be watched,
+	// nor are they reliable enough to remove target objects missing from them.
+	if cliCtx.IsSet("inventory") && (cliCtx.Bool("a") || cliCtx.IsSet("attr") || cliCtx.Bool("remove") ||
+		cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "You cannot specify --inventory with any of -a, --attr, --remove, --watch and --active-active flags.")
+	}
+
 	/****** Generic rules *******/
 	if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
 		_, srcContent, err := url2Stat(ctx, url2StatOptions{urlStr: srcURL, versionID: "", fileAttr: false, encKeyDB: encKeyDB, timeRef: time.Time{}, isZip: false, ignoreBucketExistsCheck: false})
@@ -167,7 +174,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 	}
 
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts.isMetadata) {
+	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts.isMetadata, opts.listParallel, opts.inventory) {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error, ErrorCond: differInUnknown}
@@ -180,6 +187,11 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			continue
 		}
 
+		// Skip the source object if it is excluded by the filter rules
+		if !opts.filterRules.isIncluded(srcSuffix) {
+			continue
+		}
+
 		// Skip the source bucket if it matches the Exclude options provided
 		if matchExcludeBucketOptions(opts.excludeBuckets, srcSuffix) {
 			continue
@@ -191,6 +203,12 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			continue
 		}
 
+		// Skip the target object if it is excluded by the filter rules,
+		// excluded objects are never removed from the target either.
+		if !opts.filterRules.isIncluded(tgtSuffix) {
+			continue
+		}
+
 		// Skip the target bucket if it matches the Exclude options provided
 		if matchExcludeBucketOptions(opts.excludeBuckets, tgtSuffix) {
 			continue
@@ -267,10 +285,16 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 type mirrorOptions struct {
 	isFake, isOverwrite, activeActive                     bool
 	isWatch, isRemove, isMetadata                         bool
+	isPreserveLock                                        bool
 	isRetriable                                           bool
 	isSummary                                             bool
 	skipErrors                                            bool
 	excludeOptions, excludeStorageClasses, excludeBuckets []string
+	filterRules                                           filterRules
+	listParallel                                          int
+	inventory                                             string
+	verboseObjects                                        bool
+	smallFileThreshold                                    int64
 	encKeyDB                                              map[string][]prefixSSEPair
 	md5, disableMultipart                                 bool
 	olderThan, newerThan                                  string
@@ -59,6 +59,7 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		verboseObjectsFlag,
 	}
 )
 
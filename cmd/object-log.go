@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// objectLogHead is the number of objects of a transfer always logged.
+	objectLogHead = 1000
+	// objectLogInterval is the interval objects are logged at past objectLogHead.
+	objectLogInterval = time.Second
+)
+
+// objectLogger samples the per-object messages of massive transfers: the
+// first objectLogHead objects are logged, then one every objectLogInterval,
+// the messages carry the running totals. Every object is logged if verbose.
+type objectLogger struct {
+	seen, skipped int64
+	lastLogged    int64
+	verbose       bool
+}
+
+func newObjectLogger(verbose bool) *objectLogger {
+	return &objectLogger{verbose: verbose}
+}
+
+// shouldLog reports whether the message of the next object is logged.
+func (l *objectLogger) shouldLog(now time.Time) bool {
+	if l == nil || l.verbose {
+		return true
+	}
+	if atomic.AddInt64(&l.seen, 1) <= objectLogHead {
+		atomic.StoreInt64(&l.lastLogged, now.UnixNano())
+		return true
+	}
+	last := atomic.LoadInt64(&l.lastLogged)
+	if now.UnixNano()-last >= int64(objectLogInterval) && atomic.CompareAndSwapInt64(&l.lastLogged, last, now.UnixNano()) {
+		return true
+	}
+	atomic.AddInt64(&l.skipped, 1)
+	return false
+}
+
+// printSkipped prints the number of objects not logged, if any.
+func (l *objectLogger) printSkipped() {
+	if l == nil {
+		return
+	}
+	if skipped := atomic.LoadInt64(&l.skipped); skipped > 0 {
+		printMsg(objectLogMessage{Status: "success", Logged: atomic.LoadInt64(&l.seen) - skipped, Skipped: skipped})
+	}
+}
+
+// objectLogMessage container for the objects not logged by a transfer.
+type objectLogMessage struct {
+	Status  string `json:"status"`
+	Logged  int64  `json:"logged"`
+	Skipped int64  `json:"skipped"`
+}
+
+func (m objectLogMessage) String() string {
+	return fmt.Sprintf("Logged %d objects and skipped %d, use --verbose-objects to log every object.", m.Logged, m.Skipped)
+}
+
+func (m objectLogMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
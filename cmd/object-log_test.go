@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestObjectLogger(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		logger  *objectLogger
+		objects int
+		// objects are seen every step
+		step    time.Duration
+		logged  int
+		skipped int64
+	}{
+		{"nil", nil, 3000, time.Millisecond, 3000, 0},
+		{"verbose", newObjectLogger(true), 3000, time.Millisecond, 3000, 0},
+		{"head", newObjectLogger(false), objectLogHead, time.Millisecond, objectLogHead, 0},
+		// 2000 objects past the head over 2s, one logged per second.
+		{"sampled", newObjectLogger(false), objectLogHead + 2000, time.Millisecond, objectLogHead + 2, 1998},
+		{"slow", newObjectLogger(false), objectLogHead + 10, 2 * time.Second, objectLogHead + 10, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logged int
+			now := start
+			for i := 0; i < tc.objects; i++ {
+				now = now.Add(tc.step)
+				if tc.logger.shouldLog(now) {
+					logged++
+				}
+			}
+			if logged != tc.logged {
+				t.Fatalf("expected %d objects logged, got %d", tc.logged, logged)
+			}
+			if tc.logger != nil && tc.logger.skipped != tc.skipped {
+				t.Fatalf("expected %d objects skipped, got %d", tc.skipped, tc.logger.skipped)
+			}
+		})
+	}
+}
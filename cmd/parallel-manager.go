@@ -59,6 +59,10 @@ type ParallelManager struct {
 	// aligned at 64bit. See https://github.com/golang/go/issues/599
 	sentBytes int64
 
+	// Count of finished tasks, the transfer speed of small objects
+	// is limited by the number of requests rather than bytes.
+	doneTasks int64
+
 	// Synchronize workers
 	wg          *sync.WaitGroup
 	barrierSync sync.RWMutex
@@ -103,6 +107,7 @@ func (p *ParallelManager) addWorker() {
 
 			// Execute the task and send the result to channel.
 			p.resultCh <- t.fn()
+			atomic.AddInt64(&p.doneTasks, 1)
 
 			if t.barrier {
 				p.barrierSync.Unlock()
@@ -119,15 +124,16 @@ func (p *ParallelManager) Read(b []byte) (n int, err error) {
 }
 
 // monitorProgress monitors realtime transfer speed of data
-// and increases threads until it reaches a maximum number of
-// threads or notice there is no apparent enhancement of
-// transfer speed.
+// and of tasks and increases threads until it reaches a maximum
+// number of threads or notice there is no apparent enhancement
+// of transfer speed.
 func (p *ParallelManager) monitorProgress() {
 	go func() {
 		ticker := time.NewTicker(monitorPeriod)
 		defer ticker.Stop()
 
 		var prevSentBytes, maxBandwidth int64
+		var prevDoneTasks, maxTaskRate int64
 		var retry int
 
 		for {
@@ -141,7 +147,11 @@ func (p *ParallelManager) monitorProgress() {
 				bandwidth := sentBytes - prevSentBytes
 				prevSentBytes = sentBytes
 
-				if bandwidth <= maxBandwidth {
+				doneTasks := atomic.LoadInt64(&p.doneTasks)
+				taskRate := doneTasks - prevDoneTasks
+				prevDoneTasks = doneTasks
+
+				if bandwidth <= maxBandwidth && taskRate <= maxTaskRate {
 					retry++
 					// We still want to add more workers
 					// until we are sure that it is not
@@ -151,7 +161,8 @@ func (p *ParallelManager) monitorProgress() {
 					}
 				} else {
 					retry = 0
-					maxBandwidth = bandwidth
+					maxBandwidth = max(maxBandwidth, bandwidth)
+					maxTaskRate = max(maxTaskRate, taskRate)
 				}
 
 				for i := 0; i < defaultWorkerFactor; i++ {
@@ -266,7 +277,7 @@ func availableMemory() (available uint64) {
 }
 
 // newParallelManager starts new workers waiting for executing tasks
-func newParallelManager(resultCh chan URLs) *ParallelManager {
+func newParallelManager(resultCh chan URLs, workers int) *ParallelManager {
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
@@ -276,8 +287,12 @@ func newParallelManager(resultCh chan URLs) *ParallelManager {
 		maxMem:        availableMemory(),
 	}
 
-	// Start with runtime.NumCPU().
-	for i := 0; i < runtime.NumCPU(); i++ {
+	// Start with the requested number of workers, runtime.NumCPU()
+	// by default.
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	for i := 0; i < workers; i++ {
 		p.addWorker()
 	}
 
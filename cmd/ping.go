@@ -21,7 +21,9 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/rand"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -90,6 +92,12 @@ EXAMPLES:
 
   4. Stop pinging when error count > 20.
      {{.Prompt}} {{.HelpName}} --error-count 20 myminio
+
+  5. Send 100 requests every second and print a summary with the min/avg/max/p99 latency and the error rate.
+     {{.Prompt}} {{.HelpName}} --count 100 myminio
+
+NOTE:
+  A latency and error summary of every server is printed when the run ends because of --count, --exit or --error-count.
 `,
 }
 
@@ -171,6 +179,116 @@ type serverStats struct {
 	errorCount int    // used to keep a track of consecutive errors
 	err        string
 	counter    int // used to find the average, acts as denominator
+	sent       int // total number of requests
+	failed     int // total number of failed requests
+	// uniform sample of the response times
+	latencies []time.Duration
+}
+
+// pingMaxLatencySamples is the maximum number of response times kept
+// per server to estimate the latency percentiles with bounded memory.
+const pingMaxLatencySamples = 1000
+
+// sampleLatency adds a response time to a uniform sample of the count
+// response times seen so far, count includes the new one.
+func sampleLatency(latencies []time.Duration, d time.Duration, count int) []time.Duration {
+	if len(latencies) < pingMaxLatencySamples {
+		return append(latencies, d)
+	}
+	// Reservoir sampling, keep each response time with equal probability.
+	if i := rand.Intn(count); i < pingMaxLatencySamples {
+		latencies[i] = d
+	}
+	return latencies
+}
+
+// pingSummary holds the statistics of all the requests sent to a server.
+type pingSummary struct {
+	Endpoint string  `json:"endpoint"`
+	Sent     int     `json:"sent"`
+	Errors   int     `json:"errors"`
+	Loss     float64 `json:"loss"`
+	Min      string  `json:"min,omitempty"`
+	Average  string  `json:"average,omitempty"`
+	Max      string  `json:"max,omitempty"`
+	P99      string  `json:"p99,omitempty"`
+}
+
+// pingSummaryMessage is printed once ping is done.
+type pingSummaryMessage struct {
+	Status  string        `json:"status"`
+	Servers []pingSummary `json:"servers"`
+}
+
+// String colorized ping summary message.
+func (m pingSummaryMessage) String() string {
+	var s strings.Builder
+	w := tabwriter.NewWriter(&s, 1, 8, 3, ' ', 0)
+	fmt.Fprintln(w, console.Colorize("Info", "ENDPOINT\tSENT\tERRORS\tLOSS\tMIN\tAVG\tMAX\tP99"))
+	for _, srv := range m.Servers {
+		line := fmt.Sprintf("%s\t%d\t%d\t%.1f%%\t%s\t%s\t%s\t%s", srv.Endpoint, srv.Sent, srv.Errors, srv.Loss,
+			orDash(srv.Min), orDash(srv.Average), orDash(srv.Max), orDash(srv.P99))
+		if srv.Errors > 0 {
+			line = console.Colorize("InfoFail", line)
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+	return strings.TrimSuffix(s.String(), "\n")
+}
+
+// JSON jsonified ping summary message.
+func (m pingSummaryMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// latencyPercentile returns the nearest-rank percentile p (0-100] of the
+// latencies.
+func latencyPercentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// newPingSummary builds the summary of all the servers, sorted by endpoint.
+func newPingSummary(serverMap map[string]serverStats) pingSummaryMessage {
+	msg := pingSummaryMessage{Status: "success"}
+	for host, stat := range serverMap {
+		srv := pingSummary{
+			Endpoint: host,
+			Sent:     stat.sent,
+			Errors:   stat.failed,
+		}
+		if stat.sent > 0 {
+			srv.Loss = float64(stat.failed) * 100 / float64(stat.sent)
+		}
+		if len(stat.latencies) > 0 {
+			srv.Min = time.Duration(stat.min).Round(time.Microsecond).String()
+			srv.Average = time.Duration(stat.avg).Round(time.Microsecond).String()
+			srv.Max = time.Duration(stat.max).Round(time.Microsecond).String()
+			srv.P99 = latencyPercentile(stat.latencies, 99).Round(time.Microsecond).String()
+		}
+		msg.Servers = append(msg.Servers, srv)
+	}
+	sort.Slice(msg.Servers, func(i, j int) bool { return msg.Servers[i].Endpoint < msg.Servers[j].Endpoint })
+	return msg
 }
 
 func fetchAdminInfo(admClnt *madmin.AdminClient) (madmin.InfoMessage, error) {
@@ -286,8 +404,11 @@ func pingStats(cliCtx *cli.Context, result madmin.AliveResult, serverMap map[str
 	minPing := uint64(math.MaxUint64)
 	var maxPing uint64
 	var counter, errorCount int
+	prev := serverMap[result.Endpoint.Host]
+	sent, failed, latencies := prev.sent+1, prev.failed, prev.latencies
 
 	if result.Error != nil {
+		failed++
 		errorString = result.Error.Error()
 		if stat, ok := serverMap[result.Endpoint.Host]; ok {
 			minPing = stat.min
@@ -328,8 +449,21 @@ func pingStats(cliCtx *cli.Context, result madmin.AliveResult, serverMap map[str
 		}
 		avg = sum / uint64(counter)
 		dns = uint64(result.DNSResolveTime.Nanoseconds())
+		latencies = sampleLatency(latencies, result.ResponseTime, counter)
+	}
+	return serverStats{
+		min:        minPing,
+		max:        maxPing,
+		sum:        sum,
+		avg:        avg,
+		dns:        dns,
+		errorCount: errorCount,
+		err:        errorString,
+		counter:    counter,
+		sent:       sent,
+		failed:     failed,
+		latencies:  latencies,
 	}
-	return serverStats{minPing, maxPing, sum, avg, dns, errorCount, errorString, counter}
 }
 
 // mainPing is entry point for ping command.
@@ -360,28 +494,30 @@ func mainPing(cliCtx *cli.Context) error {
 	// map to contain server stats for all the servers
 	serverMap := make(map[string]serverStats)
 
+	// Print the summary when interrupted as well.
+	defer holdSignalExit()()
+
 	index := 1
 	if cliCtx.IsSet("count") {
 		count := cliCtx.Int("count")
 		if count < 1 {
 			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "ping count cannot be less than 1")
 		}
-		for index <= count {
-			// return if consecutive error count more then specified value
-			if stop {
-				return nil
-			}
+		for index <= count && !stop && globalContext.Err() == nil {
 			ping(ctx, cliCtx, anonClient, admInfo, serverMap, index)
 			index++
 		}
+		printMsg(newPingSummary(serverMap))
 	} else {
 		for {
 			select {
 			case <-globalContext.Done():
-				return globalContext.Err()
+				printMsg(newPingSummary(serverMap))
+				return nil
 			default:
 				// return if consecutive error count more then specified value
 				if stop {
+					printMsg(newPingSummary(serverMap))
 					return nil
 				}
 				ping(ctx, cliCtx, anonClient, admInfo, serverMap, index)
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	testCases := []struct {
+		latencies []time.Duration
+		p         float64
+		want      time.Duration
+	}{
+		{nil, 99, 0},
+		{[]time.Duration{5 * time.Millisecond}, 99, 5 * time.Millisecond},
+		{latencies, 99, 99 * time.Millisecond},
+		{latencies, 50, 50 * time.Millisecond},
+		{latencies, 100, 100 * time.Millisecond},
+		{latencies[:10], 99, 100 * time.Millisecond},
+	}
+	for i, testCase := range testCases {
+		if got := latencyPercentile(testCase.latencies, testCase.p); got != testCase.want {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.want, got)
+		}
+	}
+	if latencies[0] != 100*time.Millisecond {
+		t.Error("latencyPercentile must not reorder its input")
+	}
+}
+
+func TestNewPingSummary(t *testing.T) {
+	msg := newPingSummary(map[string]serverStats{
+		"node2:9000": {sent: 4, failed: 4},
+		"node1:9000": {
+			min: uint64(time.Millisecond), max: uint64(3 * time.Millisecond), avg: uint64(2 * time.Millisecond),
+			sent: 4, failed: 1,
+			latencies: []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond},
+		},
+	})
+	if len(msg.Servers) != 2 || msg.Servers[0].Endpoint != "node1:9000" {
+		t.Fatalf("unexpected servers %+v", msg.Servers)
+	}
+	got := msg.Servers[0]
+	if got.Sent != 4 || got.Errors != 1 || got.Loss != 25 || got.Min != "1ms" || got.Average != "2ms" || got.Max != "3ms" || got.P99 != "3ms" {
+		t.Errorf("unexpected summary %+v", got)
+	}
+	got = msg.Servers[1]
+	if got.Loss != 100 || got.Min != "" || got.P99 != "" {
+		t.Errorf("unexpected summary %+v", got)
+	}
+}
+
+func TestSampleLatency(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 10*pingMaxLatencySamples; i++ {
+		latencies = sampleLatency(latencies, time.Duration(i)*time.Millisecond, i)
+	}
+	if len(latencies) != pingMaxLatencySamples {
+		t.Fatalf("expected %d samples, got %d", pingMaxLatencySamples, len(latencies))
+	}
+	// The sample is uniform, its median is close to the one of all the response times.
+	if p50 := latencyPercentile(latencies, 50); p50 < 4*time.Second || p50 > 6*time.Second {
+		t.Fatalf("unexpected sampled median %s", p50)
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"strings"
+	"sync"
 
+	"github.com/fatih/color"
 	"github.com/minio/pkg/v3/console"
 )
 
@@ -33,6 +35,12 @@ type message interface {
 
 // printMsg prints message string or JSON structure depending on the type of output console.
 func printMsg(msg message) {
+	if globalJSON && globalJSONLine {
+		if m, ok := msg.(jsonAppender); ok {
+			printJSONLine(m)
+			return
+		}
+	}
 	var msgStr string
 	if !globalJSON {
 		msgStr = msg.String()
@@ -49,3 +57,21 @@ func printMsg(msg message) {
 	msgStr = strings.TrimSuffix(msgStr, "\n")
 	console.Println(msgStr)
 }
+
+// jsonLineBuf is reused to encode messages printed by printJSONLine.
+var jsonLineBuf struct {
+	sync.Mutex
+	buf []byte
+}
+
+// printJSONLine prints a message as a single line of JSON, bypassing
+// reflection and the console printer for large listings.
+func printJSONLine(msg jsonAppender) {
+	jsonLineBuf.Lock()
+	defer jsonLineBuf.Unlock()
+
+	buf := msg.AppendJSON(jsonLineBuf.buf[:0])
+	buf = append(buf, '\n')
+	color.Output.Write(buf)
+	jsonLineBuf.buf = buf
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+)
+
+// indentedTestMessage always prints indented JSON.
+type indentedTestMessage struct{}
+
+func (indentedTestMessage) String() string { return "message" }
+func (indentedTestMessage) JSON() string {
+	return "{\n \"status\": \"success\",\n \"key\": \"a\"\n}"
+}
+
+func TestPrintMsgJSONLines(t *testing.T) {
+	oldOutput, oldJSON, oldJSONLine := color.Output, globalJSON, globalJSONLine
+	defer func() {
+		color.Output, globalJSON, globalJSONLine = oldOutput, oldJSON, oldJSONLine
+	}()
+
+	var buf bytes.Buffer
+	color.Output = &buf
+	msg := indentedTestMessage{}
+
+	globalJSON, globalJSONLine = true, false
+	printMsg(msg)
+	if lines := strings.Count(buf.String(), "\n"); lines < 2 {
+		t.Fatalf("expected indented JSON, got %q", buf.String())
+	}
+
+	buf.Reset()
+	globalJSONLine = true
+	printMsg(msg)
+	printMsg(msg)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 || lines[0] != `{"status":"success","key":"a"}` {
+		t.Fatalf("expected one JSON record per line, got %q", buf.String())
+	}
+}
+
+func TestJSONLinesFlag(t *testing.T) {
+	oldJSON, oldJSONLine, oldNoColor := globalJSON, globalJSONLine, globalNoColor
+	defer func() {
+		globalJSON, globalJSONLine, globalNoColor = oldJSON, oldJSONLine, oldNoColor
+	}()
+	globalJSON, globalJSONLine = false, false
+
+	app := cli.NewApp()
+	app.Writer = os.Stderr
+	app.Flags = globalFlags
+	app.Before = setGlobalsFromContext
+	app.Action = func(*cli.Context) error { return nil }
+	if e := app.Run([]string{"mc", "--json-lines"}); e != nil {
+		t.Fatal(e)
+	}
+	if !globalJSON || !globalJSONLine {
+		t.Fatalf("expected --json-lines to enable single line JSON, got json=%v lines=%v", globalJSON, globalJSONLine)
+	}
+}
@@ -18,19 +18,37 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
 
+var quotaInfoFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "usage",
+		Usage: "show the current usage of the bucket compared to its quota",
+	},
+	cli.BoolFlag{
+		Name:  "watch, w",
+		Usage: "periodically show the current usage of the bucket compared to its quota",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between usage updates with --watch",
+		Value: 10 * time.Second,
+	},
+}
+
 var quotaInfoCmd = cli.Command{
 	Name:         "info",
 	Usage:        "show bucket quota",
 	Action:       mainQuotaInfo,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(quotaInfoFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -43,6 +61,14 @@ FLAGS:
 EXAMPLES:
   1. Display bucket quota configured for "mybucket" on MinIO.
      {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  2. Display the current usage of "mybucket" compared to its quota.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --usage
+
+  3. Display the usage of "mybucket" compared to its quota every minute.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --watch --interval 1m
+
+  The usage is computed by the MinIO object scanner, it may lag behind recent writes.
 `,
 }
 
@@ -51,6 +77,9 @@ func checkQuotaInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Bool("watch") && ctx.Duration("interval") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("interval")), "--interval must be positive.")
+	}
 }
 
 // mainQuotaInfo is the handler for "mc quota info" command.
@@ -59,6 +88,7 @@ func mainQuotaInfo(ctx *cli.Context) error {
 
 	console.SetColor("QuotaMessage", color.New(color.FgGreen))
 	console.SetColor("QuotaInfo", color.New(color.FgCyan))
+	console.SetColor("QuotaExceeded", color.New(color.FgRed, color.Bold))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -75,13 +105,32 @@ func mainQuotaInfo(ctx *cli.Context) error {
 	if qCfg.Size > 0 {
 		sz = qCfg.Size
 	}
-	printMsg(quotaMessage{
+	msg := quotaMessage{
 		op:        ctx.Command.Name,
 		Bucket:    targetURL,
 		Quota:     sz,
 		QuotaType: string(qCfg.Type),
 		Status:    "success",
-	})
+		showUsage: ctx.Bool("usage") || ctx.Bool("watch"),
+	}
+	if !msg.showUsage {
+		printMsg(msg)
+		return nil
+	}
 
-	return nil
+	bucket := splitStr(targetURL, "/", 1)[0]
+	for {
+		duInfo, e := client.DataUsageInfo(globalContext)
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to get bucket usage")
+		msg.Usage = duInfo.BucketsUsage[bucket].Size
+		printMsg(msg)
+		if !ctx.Bool("watch") {
+			return nil
+		}
+		select {
+		case <-globalContext.Done():
+			return nil
+		case <-time.After(ctx.Duration("interval")):
+		}
+	}
 }
@@ -77,6 +77,16 @@ type quotaMessage struct {
 	Bucket    string `json:"bucket"`
 	Quota     uint64 `json:"quota,omitempty"`
 	QuotaType string `json:"type,omitempty"`
+	Usage     uint64 `json:"usage"`
+	showUsage bool
+}
+
+// quotaUsagePercent returns the percentage of the quota used.
+func quotaUsagePercent(usage, quota uint64) float64 {
+	if quota == 0 {
+		return 0
+	}
+	return float64(usage) * 100 / float64(quota)
 }
 
 func (q quotaMessage) String() string {
@@ -88,13 +98,34 @@ func (q quotaMessage) String() string {
 		return console.Colorize("QuotaMessage",
 			fmt.Sprintf("Successfully cleared bucket quota configured on `%s`", q.Bucket))
 	default:
-		return console.Colorize("QuotaInfo",
-			fmt.Sprintf("Bucket `%s` has %s quota of %s", q.Bucket, q.QuotaType, humanize.IBytes(q.Quota)))
+		if q.showUsage && q.Quota == 0 {
+			return console.Colorize("QuotaInfo",
+				fmt.Sprintf("Bucket `%s` has no quota, %s used", q.Bucket, humanize.IBytes(q.Usage)))
+		}
+		msg := fmt.Sprintf("Bucket `%s` has %s quota of %s", q.Bucket, q.QuotaType, humanize.IBytes(q.Quota))
+		if q.showUsage {
+			theme := "QuotaInfo"
+			if q.Usage >= q.Quota {
+				theme = "QuotaExceeded"
+			}
+			return console.Colorize(theme, fmt.Sprintf("%s, %s used (%.2f%%)",
+				msg, humanize.IBytes(q.Usage), quotaUsagePercent(q.Usage, q.Quota)))
+		}
+		return console.Colorize("QuotaInfo", msg)
 	}
 }
 
 func (q quotaMessage) JSON() string {
-	jsonMessageBytes, e := json.MarshalIndent(q, "", " ")
+	type quotaJSON quotaMessage
+	var v interface{} = quotaJSON(q)
+	if !q.showUsage {
+		// The usage was not fetched, hide it rather than reporting zero.
+		v = struct {
+			quotaJSON
+			Usage *uint64 `json:"usage,omitempty"`
+		}{quotaJSON: quotaJSON(q)}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(jsonMessageBytes)
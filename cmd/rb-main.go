@@ -72,6 +72,9 @@ EXAMPLES:
 
   4. Remove all buckets and objects recursively from S3 host
      {{.Prompt}} {{.HelpName}} --force --dangerous s3
+
+  5. Show the buckets which would be removed from S3 host, without removing them
+     {{.Prompt}} {{.HelpName}} --force --dangerous --dry-run s3
 `,
 }
 
@@ -79,10 +82,14 @@ EXAMPLES:
 type removeBucketMessage struct {
 	Status string `json:"status"`
 	Bucket string `json:"bucket"`
+	DryRun bool   `json:"dryRun,omitempty"`
 }
 
 // String colorized delete bucket message.
 func (s removeBucketMessage) String() string {
+	if s.DryRun {
+		return console.Colorize("RemoveBucket", fmt.Sprintf("Would remove `%s`.", s.Bucket))
+	}
 	return console.Colorize("RemoveBucket", fmt.Sprintf("Removed `%s` successfully.", s.Bucket))
 }
 
@@ -305,11 +312,13 @@ func mainRemoveBucket(cliCtx *cli.Context) error {
 		}
 
 		for _, bucketURL := range bucketsURL {
-			e := deleteBucket(ctx, bucketURL, isForce)
-			fatalIf(e.Trace(bucketURL), "Failed to remove `"+bucketURL+"`.")
+			if !globalDryRun {
+				e := deleteBucket(ctx, bucketURL, isForce)
+				fatalIf(e.Trace(bucketURL), "Failed to remove `"+bucketURL+"`.")
+			}
 
 			printMsg(removeBucketMessage{
-				Bucket: bucketURL, Status: "success",
+				Bucket: bucketURL, Status: "success", DryRun: globalDryRun,
 			})
 		}
 	}
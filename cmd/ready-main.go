@@ -42,6 +42,15 @@ var readyFlags = []cli.Flag{
 		Name:  "maintenance",
 		Usage: "check if the cluster is taken down for maintenance",
 	},
+	cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "give up and exit with an error if the cluster is not ready within the duration, 0 waits forever",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between two health checks",
+		Value: healthCheckInterval,
+	},
 }
 
 // Checks if the cluster is ready or not
@@ -70,6 +79,9 @@ EXAMPLES:
 
   3. Check if the cluster is taken down for maintenance
      {{.Prompt}} {{.HelpName}} myminio --maintenance
+
+  4. Wait up to 2 minutes for the cluster to be ready, checking every second, e.g. in an init container
+     {{.Prompt}} {{.HelpName}} myminio --timeout 2m --interval 1s
 `,
 }
 
@@ -112,6 +124,14 @@ func mainReady(cliCtx *cli.Context) error {
 	// Set command flags from context.
 	clusterRead := cliCtx.Bool("cluster-read")
 	maintenance := cliCtx.Bool("maintenance")
+	timeout := cliCtx.Duration("timeout")
+	interval := cliCtx.Duration("interval")
+	if timeout < 0 {
+		fatalIf(errInvalidArgument().Trace(), "--timeout cannot be negative.")
+	}
+	if interval <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--interval must be greater than zero.")
+	}
 
 	ctx, cancelClusterReady := context.WithCancel(globalContext)
 	defer cancelClusterReady()
@@ -125,28 +145,56 @@ func mainReady(cliCtx *cli.Context) error {
 		Maintenance: maintenance,
 	}
 
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ready := waitForReady(ctx, interval, func(ctx context.Context) (madmin.HealthResult, error) {
+		return anonClient.Healthy(ctx, healthOpts)
+	}, func(healthResult madmin.HealthResult, hErr error) {
+		printMsg(readyMessage{
+			Alias:           aliasedURL,
+			Status:          "success",
+			Healthy:         healthResult.Healthy,
+			MaintenanceMode: healthResult.MaintenanceMode,
+			WriteQuorum:     healthResult.WriteQuorum,
+			HealingDrives:   healthResult.HealingDrives,
+			Err:             hErr,
+		})
+	})
+	if !ready {
+		if ctx.Err() == context.DeadlineExceeded {
+			errorIf(errDummy().Trace(aliasedURL), "The cluster `%s` was not ready within %s.", aliasedURL, timeout)
+		}
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
+// waitForReady polls the health check every interval until it reports a
+// healthy cluster or ctx is done, every result is passed to report.
+// It returns whether the cluster became ready.
+func waitForReady(ctx context.Context, interval time.Duration, check func(context.Context) (madmin.HealthResult, error), report func(madmin.HealthResult, error)) bool {
 	timer := time.NewTimer(0)
 	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			return false
 		case <-timer.C:
-			healthResult, hErr := anonClient.Healthy(ctx, healthOpts)
-			printMsg(readyMessage{
-				Alias:           aliasedURL,
-				Status:          "success",
-				Healthy:         healthResult.Healthy,
-				MaintenanceMode: healthResult.MaintenanceMode,
-				WriteQuorum:     healthResult.WriteQuorum,
-				HealingDrives:   healthResult.HealingDrives,
-				Err:             hErr,
-			})
+			healthResult, hErr := check(ctx)
+			if ctx.Err() != nil {
+				// Do not report the failure of a check interrupted by ctx.
+				return false
+			}
+			report(healthResult, hErr)
 			if healthResult.Healthy {
-				return nil
+				return true
 			}
-			timer.Reset(healthCheckInterval)
+			timer.Reset(interval)
 		}
 	}
 }
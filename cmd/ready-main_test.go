@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestWaitForReady(t *testing.T) {
+	testCases := []struct {
+		readyAfter  int // number of checks before the cluster is healthy, -1 never
+		timeout     time.Duration
+		wantReady   bool
+		wantReports int
+	}{
+		{0, time.Second, true, 1},
+		{3, time.Second, true, 4},
+		{-1, 50 * time.Millisecond, false, -1},
+	}
+	for i, testCase := range testCases {
+		ctx, cancel := context.WithTimeout(context.Background(), testCase.timeout)
+		checks, reports := 0, 0
+		ready := waitForReady(ctx, time.Millisecond, func(context.Context) (madmin.HealthResult, error) {
+			checks++
+			if testCase.readyAfter < 0 || checks <= testCase.readyAfter {
+				return madmin.HealthResult{}, errors.New("not ready")
+			}
+			return madmin.HealthResult{Healthy: true}, nil
+		}, func(madmin.HealthResult, error) {
+			reports++
+		})
+		cancel()
+		if ready != testCase.wantReady {
+			t.Fatalf("Test %d: expected ready %v, got %v", i+1, testCase.wantReady, ready)
+		}
+		if testCase.wantReports >= 0 && reports != testCase.wantReports {
+			t.Fatalf("Test %d: expected %d reports, got %d", i+1, testCase.wantReports, reports)
+		}
+	}
+}
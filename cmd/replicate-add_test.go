@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestFindReplicationTarget(t *testing.T) {
+	targets := []madmin.BucketTarget{
+		{Arn: "arn:minio:replication::id1:bucket1", TargetBucket: "bucket1"},
+		{Arn: "arn:minio:replication::id2:bucket2", TargetBucket: "bucket2"},
+	}
+
+	testCases := []struct {
+		arn          string
+		targetBucket string
+		expectErr    bool
+	}{
+		{arn: "arn:minio:replication::id2:bucket2", targetBucket: "bucket2"},
+		{arn: "arn:minio:replication::id3:bucket3", expectErr: true},
+		{arn: "arn:minio:ilm::id1:bucket1", expectErr: true},
+		{arn: "arn:aws:s3:::bucket1", expectErr: true},
+		{arn: "bucket1", expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		target, err := findReplicationTarget(targets, testCase.arn)
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error for %s", i+1, testCase.arn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if target.TargetBucket != testCase.targetBucket {
+			t.Errorf("Test %d: expected target bucket %s, got %s", i+1, testCase.targetBucket, target.TargetBucket)
+		}
+	}
+}
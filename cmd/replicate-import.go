@@ -66,6 +66,7 @@ type replicateImportMessage struct {
 	Status            string             `json:"status"`
 	URL               string             `json:"url"`
 	ReplicationConfig replication.Config `json:"config"`
+	DryRun            bool               `json:"dryRun,omitempty"`
 }
 
 func (r replicateImportMessage) JSON() string {
@@ -76,6 +77,9 @@ func (r replicateImportMessage) JSON() string {
 }
 
 func (r replicateImportMessage) String() string {
+	if r.DryRun {
+		return console.Colorize("replicateImportMessage", "Would set replication configuration on `"+r.URL+"`.")
+	}
 	return console.Colorize("replicateImportMessage", "Replication configuration successfully set on `"+r.URL+"`.")
 }
 
@@ -109,11 +113,14 @@ func mainReplicateImport(cliCtx *cli.Context) error {
 	rCfg, err := readReplicationConfig()
 	fatalIf(err.Trace(args...), "Unable to read replication configuration")
 
-	fatalIf(client.SetReplication(ctx, rCfg, replication.Options{Op: replication.ImportOption}).Trace(aliasedURL), "Unable to set replication configuration")
+	if !globalDryRun {
+		fatalIf(client.SetReplication(ctx, rCfg, replication.Options{Op: replication.ImportOption}).Trace(aliasedURL), "Unable to set replication configuration")
+	}
 	printMsg(replicateImportMessage{
 		Op:     cliCtx.Command.Name,
 		Status: "success",
 		URL:    aliasedURL,
+		DryRun: globalDryRun,
 	})
 	return nil
 }
@@ -97,6 +97,7 @@ type replicateRemoveMessage struct {
 	Status string `json:"status"`
 	URL    string `json:"url"`
 	ID     string `json:"id"`
+	DryRun bool   `json:"dryRun,omitempty"`
 }
 
 func (l replicateRemoveMessage) JSON() string {
@@ -107,6 +108,12 @@ func (l replicateRemoveMessage) JSON() string {
 }
 
 func (l replicateRemoveMessage) String() string {
+	if l.DryRun {
+		if l.ID != "" {
+			return console.Colorize("replicateRemoveMessage", "Would remove replication configuration rule with ID `"+l.ID+"` from "+l.URL+".")
+		}
+		return console.Colorize("replicateRemoveMessage", "Would remove replication configuration from "+l.URL+".")
+	}
 	if l.ID != "" {
 		return console.Colorize("replicateRemoveMessage", "Replication configuration rule with ID `"+l.ID+"`removed from "+l.URL+".")
 	}
@@ -142,9 +149,11 @@ func mainReplicateRemove(cliCtx *cli.Context) error {
 		})
 		return nil
 	}
-	if rmAll && rmForce {
+	switch {
+	case globalDryRun:
+	case rmAll && rmForce:
 		fatalIf(client.RemoveReplication(ctx), "Unable to remove replication configuration")
-	} else {
+	default:
 		var removeArn string
 		for _, rule := range rcfg.Rules {
 			if rule.ID == ruleID {
@@ -167,6 +176,7 @@ func mainReplicateRemove(cliCtx *cli.Context) error {
 		Status: "success",
 		URL:    aliasedURL,
 		ID:     ruleID,
+		DryRun: globalDryRun,
 	})
 	return nil
 }
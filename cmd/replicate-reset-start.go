@@ -26,6 +26,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/pkg/v3/console"
@@ -129,6 +130,15 @@ func mainReplicateResyncStart(cliCtx *cli.Context) error {
 		}
 	}
 
+	// Validate the target ARN before starting the resync.
+	admClient, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+	_, sourceBucket := url2Alias(aliasedURL)
+	targets, e := admClient.ListRemoteTargets(ctx, sourceBucket, string(madmin.ReplicationService))
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to fetch remote targets")
+	_, err = findReplicationTarget(targets, cliCtx.String("remote-bucket"))
+	fatalIf(err.Trace(args...), "Invalid --remote-bucket")
+
 	rinfo, err := client.ResetReplication(ctx, olderThan, cliCtx.String("remote-bucket"))
 	fatalIf(err.Trace(args...), "Unable to reset replication")
 	printMsg(replicateResyncMessage{
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -84,6 +85,22 @@ func (r replicateResyncStatusMessage) JSON() string {
 	return string(jsonMessageBytes)
 }
 
+// resyncDuration - returns how long the resync of a target took, or has
+// been running for if it is not finished yet.
+func resyncDuration(st replication.ResyncTarget, now time.Time) time.Duration {
+	if st.StartTime.IsZero() {
+		return 0
+	}
+	end := st.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	if end.Before(st.StartTime) {
+		return 0
+	}
+	return end.Sub(st.StartTime)
+}
+
 func (r replicateResyncStatusMessage) String() string {
 	if len(r.ResyncTargetsInfo.Targets) == 0 {
 		return console.Colorize("replicateResyncStatusWarn", "No replication resync status available.")
@@ -101,6 +118,13 @@ func (r replicateResyncStatusMessage) String() string {
 		rows += console.Colorize("TDetail", "   Status: ")
 		rows += console.Colorize(st.ResyncStatus, st.ResyncStatus)
 		rows += "\n"
+		if !st.StartTime.IsZero() {
+			rows += console.Colorize("TDetail", "   Started: ")
+			rows += st.StartTime.Local().Format(printDate)
+			rows += console.Colorize("TDetail", " Duration: ")
+			rows += timeDurationToHumanizedDuration(resyncDuration(st, UTCNow())).String()
+			rows += "\n"
+		}
 
 		maxLen := 15
 		theme := []string{"Replicated", "Failed"}
@@ -116,7 +140,7 @@ func (r replicateResyncStatusMessage) String() string {
 			Field{"Count", maxLen},
 		).buildRow("   Replicated", humanize.IBytes(uint64(st.ReplicatedSize)), humanize.Comma(int64(st.ReplicatedCount))))
 		rows += "\n"
-		rows += console.Colorize(theme[0], newPrettyTable(" | ",
+		rows += console.Colorize(theme[1], newPrettyTable(" | ",
 			Field{"Status", 21},
 			Field{"Size", maxLen},
 			Field{"Count", maxLen},
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestResyncDuration(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		target   replication.ResyncTarget
+		expected time.Duration
+	}{
+		{
+			target:   replication.ResyncTarget{},
+			expected: 0,
+		},
+		{
+			target:   replication.ResyncTarget{StartTime: now.Add(-2 * time.Hour)},
+			expected: 2 * time.Hour,
+		},
+		{
+			target:   replication.ResyncTarget{StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-time.Hour)},
+			expected: 2 * time.Hour,
+		},
+		{
+			target:   replication.ResyncTarget{StartTime: now, EndTime: now.Add(-time.Hour)},
+			expected: 0,
+		},
+	}
+
+	for i, testCase := range testCases {
+		if got := resyncDuration(testCase.target, now); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -47,6 +47,20 @@ var replicateStatusFlags = []cli.Flag{
 		Name:  "nodes,n",
 		Usage: "show replication speed for all nodes",
 	},
+	cli.BoolFlag{
+		Name:  "watch, w",
+		Usage: "refresh the replication status until interrupted",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between status updates with --watch",
+		Value: 5 * time.Second,
+	},
+	cli.IntFlag{
+		Name:  "max-failed",
+		Usage: "exit with an error when the failed replications in the last hour across configured targets exceed this number",
+		Value: -1,
+	},
 }
 
 var replicateStatusCmd = cli.Command{
@@ -71,6 +85,12 @@ EXAMPLES:
 
   2. Get replication speed across nodes for bucket "mybucket" for alias "myminio".
      {{.Prompt}} {{.HelpName}} --nodes  myminio/mybucket
+
+  3. Refresh server side replication metrics for bucket "mybucket" for alias "myminio" every 10 seconds.
+     {{.Prompt}} {{.HelpName}} --watch --interval 10s myminio/mybucket
+
+  4. Exit with an error from a monitoring job when more than 100 replications failed in the last hour for bucket "mybucket".
+     {{.Prompt}} {{.HelpName}} --max-failed 100 myminio/mybucket
 `,
 }
 
@@ -79,6 +99,15 @@ func checkReplicateStatusSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Bool("watch") && ctx.Duration("interval") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("interval")), "--interval must be greater than zero.")
+	}
+	if ctx.IsSet("max-failed") && ctx.Int("max-failed") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("max-failed")), "--max-failed cannot be negative.")
+	}
+	if ctx.IsSet("nodes") && ctx.IsSet("max-failed") {
+		fatalIf(errInvalidArgument().Trace(), "--max-failed cannot be specified with --nodes.")
+	}
 }
 
 type replicateStatusMessage struct {
@@ -90,6 +119,21 @@ type replicateStatusMessage struct {
 	cfg     replication.Config    `json:"-"`
 }
 
+// failedLastHour - returns the number of replications failed in the last
+// hour for the targets in the replication config, ignoring removed targets.
+func (s replicateStatusMessage) failedLastHour() int64 {
+	var failed float64
+	for arn, st := range s.Metrics.CurrentStats.Stats {
+		for _, r := range s.cfg.Rules {
+			if r.Destination.Bucket == arn || s.cfg.Role == arn {
+				failed += st.Failed.LastHour.Count
+				break
+			}
+		}
+	}
+	return int64(failed)
+}
+
 func (s replicateStatusMessage) JSON() string {
 	s.Status = "success"
 	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
@@ -313,31 +357,58 @@ func mainReplicateStatus(cliCtx *cli.Context) error {
 	fatalIf(cerr, "Unable to initialize admin connection.")
 	_, sourceBucket := url2Alias(args[0])
 
-	replicateStatus, err := client.GetReplicationMetrics(ctx)
-	fatalIf(err.Trace(args...), "Unable to get replication status")
-	targets, e := admClient.ListRemoteTargets(globalContext, sourceBucket, "")
-	fatalIf(probe.NewError(e).Trace(args...), "Unable to fetch remote target.")
-	cfg, err := client.GetReplication(ctx)
-	fatalIf(err.Trace(args...), "Unable to fetch replication configuration.")
-
-	if cliCtx.IsSet("nodes") {
-		printMsg(replicateXferMessage{
-			Op:             cliCtx.Command.Name,
-			Status:         "success",
-			ReplQueueStats: replicateStatus.QueueStats,
-		})
-		return nil
-	}
+	watch := cliCtx.Bool("watch")
+	maxFailed := cliCtx.Int("max-failed")
+
+	var lines int
+	for {
+		replicateStatus, err := client.GetReplicationMetrics(ctx)
+		fatalIf(err.Trace(args...), "Unable to get replication status")
+		targets, e := admClient.ListRemoteTargets(globalContext, sourceBucket, "")
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to fetch remote target.")
+		cfg, err := client.GetReplication(ctx)
+		fatalIf(err.Trace(args...), "Unable to fetch replication configuration.")
+
+		var msg message
+		statusMsg := replicateStatusMessage{
+			Op:      cliCtx.Command.Name,
+			URL:     aliasedURL,
+			Metrics: replicateStatus,
+			Targets: targets,
+			cfg:     cfg,
+		}
+		msg = statusMsg
+		if cliCtx.IsSet("nodes") {
+			msg = replicateXferMessage{
+				Op:             cliCtx.Command.Name,
+				Status:         "success",
+				ReplQueueStats: replicateStatus.QueueStats,
+			}
+		}
 
-	printMsg(replicateStatusMessage{
-		Op:      cliCtx.Command.Name,
-		URL:     aliasedURL,
-		Metrics: replicateStatus,
-		Targets: targets,
-		cfg:     cfg,
-	})
+		// Redraw the previous status in place while watching.
+		if watch && !globalJSON && isTerminal() {
+			console.RewindLines(lines)
+			lines = strings.Count(strings.TrimSuffix(msg.String(), "\n"), "\n") + 1
+		}
+		printMsg(msg)
 
-	return nil
+		if maxFailed >= 0 {
+			if failed := statusMsg.failedLastHour(); failed > int64(maxFailed) {
+				errorIf(errDummy().Trace(args...), "%d replications failed in the last hour, more than --max-failed %d.", failed, maxFailed)
+				return exitStatus(globalErrorExitStatus)
+			}
+		}
+		if !watch {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cliCtx.Duration("interval")):
+		}
+	}
 }
 
 type replicateXferMessage struct {
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestReplicateStatusFailedLastHour(t *testing.T) {
+	failed := func(lastHour float64) replication.TargetMetrics {
+		return replication.TargetMetrics{Failed: replication.TimedErrStats{
+			LastMinute: replication.RStat{Count: 1},
+			LastHour:   replication.RStat{Count: lastHour},
+			Totals:     replication.RStat{Count: 1000},
+		}}
+	}
+	cfg := replication.Config{Rules: []replication.Rule{
+		{Destination: replication.Destination{Bucket: "arn:minio:replication::id1:bucket1"}},
+		{Destination: replication.Destination{Bucket: "arn:minio:replication::id2:bucket2"}},
+	}}
+
+	testCases := []struct {
+		stats    map[string]replication.TargetMetrics
+		cfg      replication.Config
+		expected int64
+	}{
+		{
+			stats:    nil,
+			cfg:      cfg,
+			expected: 0,
+		},
+		{
+			stats: map[string]replication.TargetMetrics{
+				"arn:minio:replication::id1:bucket1": failed(3),
+				"arn:minio:replication::id2:bucket2": failed(4),
+			},
+			cfg:      cfg,
+			expected: 7,
+		},
+		// Stale targets removed from the config are ignored.
+		{
+			stats: map[string]replication.TargetMetrics{
+				"arn:minio:replication::id1:bucket1": failed(3),
+				"arn:minio:replication::id0:bucket0": failed(50),
+			},
+			cfg:      cfg,
+			expected: 3,
+		},
+		// Legacy configs reference the target through the role.
+		{
+			stats: map[string]replication.TargetMetrics{
+				"arn:minio:replication::id0:bucket0": failed(5),
+			},
+			cfg: replication.Config{
+				Role:  "arn:minio:replication::id0:bucket0",
+				Rules: []replication.Rule{{}},
+			},
+			expected: 5,
+		},
+	}
+
+	for i, testCase := range testCases {
+		msg := replicateStatusMessage{
+			Metrics: replication.MetricsV2{CurrentStats: replication.Metrics{Stats: testCase.stats}},
+			cfg:     testCase.cfg,
+		}
+		if got := msg.failedLastHour(); got != testCase.expected {
+			t.Errorf("Test %d: expected %d failures, got %d", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -251,6 +251,7 @@ type replicateUpdateMessage struct {
 	Status string `json:"status"`
 	URL    string `json:"url"`
 	ID     string `json:"id"`
+	DryRun bool   `json:"dryRun,omitempty"`
 }
 
 func (l replicateUpdateMessage) JSON() string {
@@ -261,6 +262,9 @@ func (l replicateUpdateMessage) JSON() string {
 }
 
 func (l replicateUpdateMessage) String() string {
+	if l.DryRun {
+		return console.Colorize("replicateUpdateMessage", "Would modify replication configuration rule `"+l.ID+"` of "+l.URL+".")
+	}
 	if l.ID != "" {
 		return console.Colorize("replicateUpdateMessage", "Replication configuration rule with ID `"+l.ID+"` applied to "+l.URL+".")
 	}
@@ -320,7 +324,9 @@ func mainReplicateUpdate(cliCtx *cli.Context) error {
 	}
 	if cliCtx.IsSet("remote-bucket") {
 		bktTarget, ops := modifyRemoteTarget(cliCtx, targets, arn)
-		_, e = admClient.UpdateRemoteTarget(globalContext, bktTarget, ops...)
+		if !globalDryRun {
+			_, e = admClient.UpdateRemoteTarget(globalContext, bktTarget, ops...)
+		}
 		if e != nil {
 			fatalIf(probe.NewError(e).Trace(args...), "Unable to update remote target `"+bktTarget.Endpoint+"` from `"+bktTarget.SourceBucket+"` -> `"+bktTarget.TargetBucket+"`")
 		}
@@ -379,11 +385,14 @@ func mainReplicateUpdate(cliCtx *cli.Context) error {
 		opts.ExistingObjectReplicate = existingReplState
 	}
 
-	fatalIf(client.SetReplication(ctx, &rcfg, opts), "unable to modify replication rule")
+	if !globalDryRun {
+		fatalIf(client.SetReplication(ctx, &rcfg, opts), "unable to modify replication rule")
+	}
 	printMsg(replicateUpdateMessage{
-		Op:  cliCtx.Command.Name,
-		URL: aliasedURL,
-		ID:  opts.ID,
+		Op:     cliCtx.Command.Name,
+		URL:    aliasedURL,
+		ID:     opts.ID,
+		DryRun: globalDryRun,
 	})
 	return nil
 }
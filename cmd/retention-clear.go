@@ -46,7 +46,7 @@ var retentionClearFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:  "default",
-		Usage: "set default bucket locking",
+		Usage: "clear default bucket locking",
 	},
 }
 
@@ -56,7 +56,7 @@ var retentionClearCmd = cli.Command{
 	Action:       mainRetentionClear,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(retentionClearFlags, globalFlags...),
+	Flags:        append(append(retentionClearFlags, objectLockFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -85,6 +85,9 @@ EXAMPLES:
 
   6. Clear a bucket retention configuration
      $ {{.HelpName}} --default myminio/mybucket/
+
+  7. Clear object retention recursively for all objects at a given prefix, except the CSV files
+     $ {{.HelpName}} myminio/mybucket/prefix --recursive --exclude "*.csv"
 `,
 }
 
@@ -114,8 +117,8 @@ func parseClearRetentionArgs(cliCtx *cli.Context) (target, versionID string, tim
 }
 
 // Clear Retention for one object/version or many objects within a given prefix, bypass governance is always enabled
-func clearRetention(ctx context.Context, target, versionID string, timeRef time.Time, withVersions, isRecursive bool) error {
-	return applyRetention(ctx, lockOpClear, target, versionID, timeRef, withVersions, isRecursive, "", 0, minio.Days, true)
+func clearRetention(ctx context.Context, target, versionID string, timeRef time.Time, withVersions, isRecursive bool, filter objectLockFilter) error {
+	return applyRetention(ctx, lockOpClear, target, versionID, timeRef, withVersions, isRecursive, "", 0, minio.Days, true, filter)
 }
 
 func clearBucketLock(urlStr string) error {
@@ -138,9 +141,11 @@ func mainRetentionClear(cliCtx *cli.Context) error {
 		return clearBucketLock(target)
 	}
 
+	filter := parseObjectLockFilter(cliCtx, versionID == "" && (recursive || withVersions))
+
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
 	}
 
-	return clearRetention(ctx, target, versionID, rewind, withVersions, recursive)
+	return clearRetention(ctx, target, versionID, rewind, withVersions, recursive, filter)
 }
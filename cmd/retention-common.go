@@ -20,15 +20,90 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/wildcard"
 )
 
+// Flags selecting the objects of a recursive retention or legal hold operation.
+var objectLockFilterFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "exclude object(s) that match specified object name pattern",
+	},
+	cli.StringSliceFlag{
+		Name:  "tags",
+		Usage: "match tags with RE2 regex pattern. Specify each with key=regex. MinIO server only.",
+	},
+	cli.StringFlag{
+		Name:  "larger",
+		Usage: "match all objects larger than specified size in units (e.g. 10MiB)",
+	},
+	cli.StringFlag{
+		Name:  "smaller",
+		Usage: "match all objects smaller than specified size in units (e.g. 10MiB)",
+	},
+}
+
+// objectLockFilter selects the objects a recursive operation applies to.
+type objectLockFilter struct {
+	exclude []string
+	tags    map[string]*regexp.Regexp
+	larger  uint64
+	smaller uint64
+}
+
+// parseObjectLockFilter parses the filter flags, they are only
+// allowed when listing objects.
+func parseObjectLockFilter(cliCtx *cli.Context, listing bool) (f objectLockFilter) {
+	f.exclude = cliCtx.StringSlice("exclude")
+	f.tags = getRegexMap(cliCtx, "tags")
+	var e error
+	if s := cliCtx.String("larger"); s != "" {
+		f.larger, e = humanize.ParseBytes(s)
+		fatalIf(probe.NewError(e).Trace(s), "Unable to parse input bytes.")
+	}
+	if s := cliCtx.String("smaller"); s != "" {
+		f.smaller, e = humanize.ParseBytes(s)
+		fatalIf(probe.NewError(e).Trace(s), "Unable to parse input bytes.")
+	}
+	if !f.isEmpty() && !listing {
+		fatalIf(errInvalidArgument(), "--exclude, --tags, --larger and --smaller require --recursive or --versions.")
+	}
+	return f
+}
+
+func (f objectLockFilter) isEmpty() bool {
+	return len(f.exclude) == 0 && len(f.tags) == 0 && f.larger == 0 && f.smaller == 0
+}
+
+// match returns true if the object, named relative to the target
+// of the operation, is selected by the filter.
+func (f objectLockFilter) match(name string, content *ClientContent) bool {
+	name = strings.TrimPrefix(name, "/")
+	for _, pattern := range f.exclude {
+		if wildcard.Match(pattern, name) {
+			return false
+		}
+	}
+	if f.larger > 0 && content.Size <= int64(f.larger) {
+		return false
+	}
+	if f.smaller > 0 && content.Size >= int64(f.smaller) {
+		return false
+	}
+	return len(f.tags) == 0 || matchRegexMaps(f.tags, content.Tags)
+}
+
 // Structured message depending on the type of console.
 type retentionCmdMessage struct {
 	Op        lockOpType          `json:"op"`
@@ -38,6 +113,7 @@ type retentionCmdMessage struct {
 	VersionID string              `json:"versionID"`
 	Status    string              `json:"status"`
 	Err       error               `json:"error"`
+	DryRun    bool                `json:"dryRun,omitempty"`
 }
 
 // Colorized message for console printing.
@@ -51,6 +127,9 @@ func (m retentionCmdMessage) String() string {
 	if m.Err != nil {
 		color = "RetentionFailure"
 		msg = fmt.Sprintf("Unable to %s object retention on `%s`: %s", m.Op, m.URLPath, m.Err)
+	} else if m.DryRun {
+		color = "RetentionSuccess"
+		msg = fmt.Sprintf("Would %s object retention for `%s`", m.Op, m.URLPath)
 	} else {
 		color = "RetentionSuccess"
 		msg = fmt.Sprintf("Object retention successfully %s%s for `%s`", m.Op, ed, m.URLPath)
@@ -87,13 +166,21 @@ type retentionBucketMessage struct {
 	Mode     minio.RetentionMode `json:"mode"`
 	Validity string              `json:"validity"`
 	Status   string              `json:"status"`
+	DryRun   bool                `json:"dryRun,omitempty"`
 }
 
 // Colorized message for console printing.
 func (m retentionBucketMessage) String() string {
 	if m.Op == lockOpClear {
+		if m.DryRun {
+			return console.Colorize("RetentionSuccess", "Would clear the object lock configuration.")
+		}
 		return console.Colorize("RetentionSuccess", "Object lock configuration cleared successfully.")
 	}
+	if m.DryRun {
+		return console.Colorize("RetentionSuccess", fmt.Sprintf("Would configure object locking '%s' for %s.",
+			console.Colorize("Mode", m.Mode), console.Colorize("Validity", m.Validity)))
+	}
 	// info/set command
 	if !m.Mode.IsValid() {
 		return console.Colorize("RetentionNotFound", "Object locking is not enabled.")
@@ -135,9 +222,12 @@ func setRetentionSingle(ctx context.Context, op lockOpType, alias, url, versionI
 		Mode:      mode,
 		URLPath:   urlJoinPath(alias, url),
 		VersionID: versionID,
+		DryRun:    globalDryRun,
 	}
 
-	err = newClnt.PutObjectRetention(ctx, versionID, mode, retainUntil, bypassGovernance)
+	if !globalDryRun {
+		err = newClnt.PutObjectRetention(ctx, versionID, mode, retainUntil, bypassGovernance)
+	}
 	if err != nil {
 		msg.Err = err.ToGoError()
 		msg.Status = "failure"
@@ -179,7 +269,7 @@ func fatalIfBucketLockNotSupported(ctx context.Context, aliasedURL string) {
 
 // Apply Retention for one object/version or many objects within a given prefix.
 func applyRetention(ctx context.Context, op lockOpType, target, versionID string, timeRef time.Time, withVersions, isRecursive bool,
-	mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypassGovernance bool,
+	mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypassGovernance bool, filter objectLockFilter,
 ) error {
 	clnt, err := newClient(target)
 	if err != nil {
@@ -213,7 +303,7 @@ func applyRetention(ctx context.Context, op lockOpType, target, versionID string
 		return nil
 	}
 
-	lstOptions := ListOptions{Recursive: isRecursive, ShowDir: DirNone}
+	lstOptions := ListOptions{Recursive: isRecursive, ShowDir: DirNone, WithMetadata: len(filter.tags) > 0}
 	if !timeRef.IsZero() {
 		lstOptions.WithOlderVersions = withVersions
 		lstOptions.WithDeleteMarkers = true
@@ -239,6 +329,10 @@ func applyRetention(ctx context.Context, op lockOpType, target, versionID string
 			break
 		}
 
+		if !filter.match(strings.TrimPrefix(alias+getKey(content), target), content) {
+			continue
+		}
+
 		err := setRetentionSingle(ctx, op, alias, content.URL.String(), content.VersionID, mode, until, bypassGovernance)
 		if err != nil {
 			errorIf(err.Trace(clnt.GetURL().String()), "Invalid URL")
@@ -265,9 +359,13 @@ func applyBucketLock(op lockOpType, urlStr string, mode minio.RetentionMode, val
 
 	ctx, cancelLock := context.WithCancel(globalContext)
 	defer cancelLock()
+	dryRun := false
 	if op == lockOpClear || mode != "" {
-		err = client.SetObjectLockConfig(ctx, mode, validity, unit)
-		fatalIf(err, "Unable to apply bucket lock configuration.")
+		dryRun = globalDryRun
+		if !dryRun {
+			err = client.SetObjectLockConfig(ctx, mode, validity, unit)
+			fatalIf(err, "Unable to apply bucket lock configuration.")
+		}
 	} else {
 		_, mode, validity, unit, err = client.GetObjectLockConfig(ctx)
 		fatalIf(err, "Unable to apply bucket lock configuration.")
@@ -279,6 +377,7 @@ func applyBucketLock(op lockOpType, urlStr string, mode minio.RetentionMode, val
 		Mode:     mode,
 		Validity: fmt.Sprintf("%d%s", validity, unit),
 		Status:   "success",
+		DryRun:   dryRun,
 	})
 
 	return nil
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestObjectLockFilter(t *testing.T) {
+	filter := objectLockFilter{
+		exclude: []string{"logs/*"},
+		tags:    map[string]*regexp.Regexp{"case": regexp.MustCompile("^1234$")},
+		larger:  10,
+		smaller: 100,
+	}
+	tagged := map[string]string{"case": "1234"}
+
+	testCases := []struct {
+		name     string
+		size     int64
+		tags     map[string]string
+		expected bool
+	}{
+		{"/docs/a.pdf", 50, tagged, true},
+		{"docs/a.pdf", 50, tagged, true},
+		{"/logs/a.log", 50, tagged, false},
+		{"/docs/a.pdf", 50, map[string]string{"case": "12345"}, false},
+		{"/docs/a.pdf", 50, nil, false},
+		{"/docs/a.pdf", 10, tagged, false},
+		{"/docs/a.pdf", 100, tagged, false},
+	}
+	for i, tc := range testCases {
+		if got := filter.match(tc.name, &ClientContent{Size: tc.size, Tags: tc.tags}); got != tc.expected {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+
+	if !(objectLockFilter{}).isEmpty() || filter.isEmpty() {
+		t.Fatal("unexpected empty filter")
+	}
+	if !(objectLockFilter{}).match("/any", &ClientContent{}) {
+		t.Fatal("an empty filter must match all objects")
+	}
+}
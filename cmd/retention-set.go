@@ -62,7 +62,7 @@ var retentionSetCmd = cli.Command{
 	Action:       mainRetentionSet,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(retentionSetFlags, globalFlags...),
+	Flags:        append(append(retentionSetFlags, objectLockFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -90,6 +90,9 @@ EXAMPLES:
 
   5. Set default lock retention configuration for a bucket
      $ {{.HelpName}} --default governance 30d myminio/mybucket/
+
+  6. Set object retention recursively for the objects at a given prefix tagged with case=1234 and larger than 1MiB
+     $ {{.HelpName}} compliance 5y myminio/mybucket/prefix --recursive --tags "case=^1234$" --larger 1MiB
 `,
 }
 
@@ -129,9 +132,9 @@ func parseSetRetentionArgs(cliCtx *cli.Context) (target, versionID string, recur
 
 // Set Retention for one object/version or many objects within a given prefix.
 func setRetention(ctx context.Context, target, versionID string, timeRef time.Time, withVersions, isRecursive bool,
-	mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypassGovernance bool,
+	mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypassGovernance bool, filter objectLockFilter,
 ) error {
-	return applyRetention(ctx, lockOpSet, target, versionID, timeRef, withVersions, isRecursive, mode, validity, unit, bypassGovernance)
+	return applyRetention(ctx, lockOpSet, target, versionID, timeRef, withVersions, isRecursive, mode, validity, unit, bypassGovernance, filter)
 }
 
 func setBucketLock(urlStr string, mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit) error {
@@ -154,9 +157,11 @@ func mainRetentionSet(cliCtx *cli.Context) error {
 		return setBucketLock(target, mode, validity, unit)
 	}
 
+	filter := parseObjectLockFilter(cliCtx, versionID == "" && (recursive || withVersions))
+
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
 	}
 
-	return setRetention(ctx, target, versionID, rewind, withVersions, recursive, mode, validity, unit, bypass)
+	return setRetention(ctx, target, versionID, rewind, withVersions, recursive, mode, validity, unit, bypass, filter)
 }
@@ -67,10 +67,6 @@ var (
 			Name:  "incomplete, I",
 			Usage: "remove incomplete uploads",
 		},
-		cli.BoolFlag{
-			Name:  "dry-run",
-			Usage: "perform a fake remove operation",
-		},
 		cli.BoolFlag{
 			Name:   "fake",
 			Usage:  "perform a fake remove operation",
@@ -96,6 +92,12 @@ var (
 			Name:  "non-current",
 			Usage: "remove object(s) versions that are non-current",
 		},
+		cli.StringFlag{
+			Name:  "noncurrent-older-than",
+			Usage: "only remove the versions non-current for longer than value in duration string (e.g. 7d10h31s), latest versions are kept",
+		},
+		filterFromFlag,
+		inventoryFlag,
 		cli.BoolFlag{
 			Name:   "purge",
 			Usage:  "attempt a prefix purge, requires confirmation please use with caution - only works with '--force'",
@@ -122,6 +124,11 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 
+NOTE:
+  With --inventory, the objects listed in the report are removed without listing the bucket, the report
+  must be recent. Objects overwritten since the report was generated are removed as well. With --older-than
+  or --newer-than, the modification time of each listed object is fetched again before it is removed.
+
 EXAMPLES:
   01. Remove a file.
       {{.Prompt}} {{.HelpName}} 1999/old-backup.tgz
@@ -162,6 +169,15 @@ EXAMPLES:
   14. Perform a fake removal of object(s) versions that are non-current and older than 10 days. If top-level version is a delete 
   marker, this will also be deleted when --non-current flag is specified.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --versions --non-current --older-than 10d --dry-run
+
+  15. Remove the object(s) versions which became non-current more than 30 days ago, latest versions and delete markers are kept.
+      {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --noncurrent-older-than 30d --dry-run
+
+  16. Perform a fake removal of the objects selected by the ordered include/exclude rules of an rsync/rclone filter file.
+      {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --filter-from cleanup-filters.txt --dry-run
+
+  17. Perform a fake removal of the objects older than 90 days enumerated from an S3 Inventory report instead of listing the bucket.
+      {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --older-than 90d --inventory s3/inventory/docs/daily/2022-01-01T00-00Z/manifest.json --dry-run
 `,
 }
 
@@ -219,11 +235,35 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 	rewind := cliCtx.String("rewind")
 	isNamespaceRemoval := false
 
+	if cliCtx.IsSet("filter-from") && !isRecursive {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --filter-from without --recursive.")
+	}
+
+	if cliCtx.IsSet("inventory") && (!isRecursive || isVersions || rewind != "" || cliCtx.Bool("incomplete") || cliCtx.IsSet("noncurrent-older-than")) {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --inventory without --recursive, or with any of --versions, --rewind, --incomplete and --noncurrent-older-than flags.")
+	}
+
 	if versionID != "" && (isRecursive || isVersions || rewind != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --version-id with any of --versions, --rewind and --recursive flags.")
 	}
 
+	if noncurrentOlderThan := cliCtx.String("noncurrent-older-than"); noncurrentOlderThan != "" {
+		if _, e := ParseDuration(noncurrentOlderThan); e != nil {
+			fatalIf(probe.NewError(e).Trace(noncurrentOlderThan), "Unable to parse --noncurrent-older-than.")
+		}
+		if !isRecursive {
+			fatalIf(errDummy().Trace(),
+				"You cannot specify --noncurrent-older-than without --recursive.")
+		}
+		if isNoncurrentVersion || rewind != "" || versionID != "" || cliCtx.IsSet("older-than") || cliCtx.IsSet("newer-than") {
+			fatalIf(errDummy().Trace(),
+				"You cannot specify --noncurrent-older-than with any of --non-current, --rewind, --version-id, --older-than and --newer-than flags.")
+		}
+	}
+
 	if isNoncurrentVersion && !(isVersions && isRecursive) {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --non-current without --versions --recursive, please use --non-current --versions --recursive.")
@@ -239,7 +279,7 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 			"You cannot specify --purge with --recursive.")
 	}
 
-	if isForceDel && (isNoncurrentVersion || isVersions || cliCtx.IsSet("older-than") || cliCtx.IsSet("newer-than") || versionID != "") {
+	if isForceDel && (isNoncurrentVersion || isVersions || cliCtx.IsSet("noncurrent-older-than") || cliCtx.IsSet("older-than") || cliCtx.IsSet("newer-than") || versionID != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --purge flag with any flag(s) other than --force.")
 	}
@@ -390,17 +430,57 @@ func removeSingle(url, versionID string, opts removeOpts) error {
 }
 
 type removeOpts struct {
-	timeRef           time.Time
-	withVersions      bool
-	nonCurrentVersion bool
-	isForce           bool
-	isRecursive       bool
-	isIncomplete      bool
-	isFake            bool
-	isBypass          bool
-	isForceDel        bool
-	olderThan         string
-	newerThan         string
+	timeRef             time.Time
+	withVersions        bool
+	nonCurrentVersion   bool
+	isForce             bool
+	isRecursive         bool
+	isIncomplete        bool
+	isFake              bool
+	isBypass            bool
+	isForceDel          bool
+	olderThan           string
+	newerThan           string
+	noncurrentOlderThan time.Duration
+	filterRules         filterRules
+	inventory           string
+}
+
+// selectNoncurrentVersions returns the versions of an object, listed from
+// the latest to the oldest, to remove with --non-current. With
+// --noncurrent-older-than the age of a version is the time since it became
+// non-current, that is the modification time of the next newer version.
+func selectNoncurrentVersions(versions []*ClientContent, opts removeOpts, now time.Time) (selected []*ClientContent) {
+	for i, content := range versions {
+		if content.Time.IsZero() {
+			// Skip prefix levels.
+			continue
+		}
+		if opts.noncurrentOlderThan > 0 {
+			if i == 0 || content.IsLatest {
+				continue
+			}
+			if now.Sub(versions[i-1].Time) < opts.noncurrentOlderThan {
+				continue
+			}
+			selected = append(selected, content)
+			continue
+		}
+
+		if content.IsLatest && !content.IsDeleteMarker {
+			continue
+		}
+		// Skip objects older than --older-than parameter, if specified
+		if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
+			continue
+		}
+		// Skip objects newer than --newer-than parameter if specified
+		if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
+			continue
+		}
+		selected = append(selected, content)
+	}
+	return selected
 }
 
 func printDryRunMsg(targetAlias string, content *ClientContent, printModTime bool) {
@@ -437,7 +517,7 @@ func listAndRemove(url string, opts removeOpts) error {
 	contentCh := make(chan *ClientContent)
 	isRemoveBucket := false
 
-	listOpts := ListOptions{Recursive: opts.isRecursive, Incomplete: opts.isIncomplete, ShowDir: DirLast}
+	listOpts := ListOptions{Recursive: opts.isRecursive, Incomplete: opts.isIncomplete, ShowDir: DirLast, Inventory: opts.inventory}
 	if !opts.timeRef.IsZero() {
 		listOpts.WithOlderVersions = opts.withVersions
 		listOpts.WithDeleteMarkers = true
@@ -468,6 +548,14 @@ func listAndRemove(url string, opts removeOpts) error {
 			continue
 		}
 
+		// With filter rules only matching objects are removed, folders
+		// are kept as they may still hold excluded objects.
+		if len(opts.filterRules) > 0 {
+			if content.Type.IsDir() || !opts.filterRules.isIncluded(strings.TrimPrefix(urlString, clnt.GetURL().Path)) {
+				continue
+			}
+		}
+
 		if !opts.isRecursive {
 			currentObjectURL := getStandardizedURL(targetAlias + getKey(content))
 			standardizedURL := getStandardizedURL(currentObjectURL)
@@ -479,24 +567,7 @@ func listAndRemove(url string, opts removeOpts) error {
 		if opts.nonCurrentVersion && opts.isRecursive && opts.withVersions {
 			if lastPath != content.URL.Path {
 				lastPath = content.URL.Path
-				for _, content := range perObjectVersions {
-					if content.IsLatest && !content.IsDeleteMarker {
-						continue
-					}
-					if !content.Time.IsZero() {
-						// Skip objects older than --older-than parameter, if specified
-						if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
-							continue
-						}
-
-						// Skip objects newer than --newer-than parameter if specified
-						if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
-							continue
-						}
-					} else {
-						// Skip prefix levels.
-						continue
-					}
+				for _, content := range selectNoncurrentVersions(perObjectVersions, opts, time.Now()) {
 
 					if opts.isFake {
 						printDryRunMsg(targetAlias, content, true)
@@ -545,6 +616,21 @@ func listAndRemove(url string, opts removeOpts) error {
 		// inform the user that he was searching in an empty area
 		atLeastOneObjectFound = true
 
+		if opts.inventory != "" && !content.Time.IsZero() && (opts.olderThan != "" || opts.newerThan != "") {
+			// The inventory report may be out of date, filter objects
+			// by their current modification time. This costs a call per
+			// object, so it is only done when filtering by time.
+			current, err := statInventoryObject(ctx, targetAlias, content)
+			if err != nil {
+				errorIf(err.Trace(url), "Failed to remove `%s`.", content.URL.String())
+				continue
+			}
+			if current == nil {
+				continue
+			}
+			content.Time = current.Time
+		}
+
 		if !content.Time.IsZero() {
 			// Skip objects older than --older-than parameter, if specified
 			if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
@@ -600,24 +686,7 @@ func listAndRemove(url string, opts removeOpts) error {
 	}
 
 	if opts.nonCurrentVersion && opts.isRecursive && opts.withVersions {
-		for _, content := range perObjectVersions {
-			if content.IsLatest && !content.IsDeleteMarker {
-				continue
-			}
-			if !content.Time.IsZero() {
-				// Skip objects older than --older-than parameter, if specified
-				if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
-					continue
-				}
-
-				// Skip objects newer than --newer-than parameter if specified
-				if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
-					continue
-				}
-			} else {
-				// Skip prefix levels.
-				continue
-			}
+		for _, content := range selectNoncurrentVersions(perObjectVersions, opts, time.Now()) {
 
 			if opts.isFake {
 				printDryRunMsg(targetAlias, content, true)
@@ -704,7 +773,7 @@ func mainRm(cliCtx *cli.Context) error {
 
 	isIncomplete := cliCtx.Bool("incomplete")
 	isRecursive := cliCtx.Bool("recursive")
-	isFake := cliCtx.Bool("dry-run") || cliCtx.Bool("fake")
+	isFake := globalDryRun || cliCtx.Bool("fake")
 	isStdin := cliCtx.Bool("stdin")
 	isBypass := cliCtx.Bool("bypass")
 	olderThan := cliCtx.String("older-than")
@@ -715,6 +784,17 @@ func mainRm(cliCtx *cli.Context) error {
 	withVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := parseRewindFlag(cliCtx.String("rewind"))
+	inventory := cliCtx.String("inventory")
+
+	rules, pErr := loadFilterRules(cliCtx.String("filter-from"))
+	fatalIf(pErr.Trace(cliCtx.String("filter-from")), "Unable to load filter rules.")
+
+	var noncurrentOlderThan time.Duration
+	if s := cliCtx.String("noncurrent-older-than"); s != "" {
+		d, _ := ParseDuration(s)
+		noncurrentOlderThan = time.Duration(d)
+		withVersions, withNoncurrentVersion = true, true
+	}
 
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
@@ -729,16 +809,19 @@ func mainRm(cliCtx *cli.Context) error {
 	for _, url := range cliCtx.Args() {
 		if isRecursive || withVersions {
 			e = listAndRemove(url, removeOpts{
-				timeRef:           rewind,
-				withVersions:      withVersions,
-				nonCurrentVersion: withNoncurrentVersion,
-				isForce:           isForce,
-				isRecursive:       isRecursive,
-				isIncomplete:      isIncomplete,
-				isFake:            isFake,
-				isBypass:          isBypass,
-				olderThan:         olderThan,
-				newerThan:         newerThan,
+				timeRef:             rewind,
+				withVersions:        withVersions,
+				nonCurrentVersion:   withNoncurrentVersion,
+				isForce:             isForce,
+				isRecursive:         isRecursive,
+				isIncomplete:        isIncomplete,
+				isFake:              isFake,
+				isBypass:            isBypass,
+				olderThan:           olderThan,
+				newerThan:           newerThan,
+				noncurrentOlderThan: noncurrentOlderThan,
+				filterRules:         rules,
+				inventory:           inventory,
 			})
 		} else {
 			e = removeSingle(url, versionID, removeOpts{
@@ -765,16 +848,19 @@ func mainRm(cliCtx *cli.Context) error {
 		url := scanner.Text()
 		if isRecursive || withVersions {
 			e = listAndRemove(url, removeOpts{
-				timeRef:           rewind,
-				withVersions:      withVersions,
-				nonCurrentVersion: withNoncurrentVersion,
-				isForce:           isForce,
-				isRecursive:       isRecursive,
-				isIncomplete:      isIncomplete,
-				isFake:            isFake,
-				isBypass:          isBypass,
-				olderThan:         olderThan,
-				newerThan:         newerThan,
+				timeRef:             rewind,
+				withVersions:        withVersions,
+				nonCurrentVersion:   withNoncurrentVersion,
+				isForce:             isForce,
+				isRecursive:         isRecursive,
+				isIncomplete:        isIncomplete,
+				isFake:              isFake,
+				isBypass:            isBypass,
+				olderThan:           olderThan,
+				newerThan:           newerThan,
+				noncurrentOlderThan: noncurrentOlderThan,
+				filterRules:         rules,
+				inventory:           inventory,
 			})
 		} else {
 			e = removeSingle(url, versionID, removeOpts{
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectNoncurrentVersions(t *testing.T) {
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	version := func(id string, age time.Duration, latest, deleteMarker bool) *ClientContent {
+		return &ClientContent{VersionID: id, Time: now.Add(-age), IsLatest: latest, IsDeleteMarker: deleteMarker}
+	}
+
+	testCases := []struct {
+		name     string
+		versions []*ClientContent
+		opts     removeOpts
+		expected []string
+	}{
+		{
+			name: "non-current keeps latest version",
+			versions: []*ClientContent{
+				version("v3", day, true, false),
+				version("v2", 10*day, false, false),
+				version("v1", 40*day, false, false),
+			},
+			opts:     removeOpts{},
+			expected: []string{"v2", "v1"},
+		},
+		{
+			name: "non-current removes latest delete marker",
+			versions: []*ClientContent{
+				version("dm", day, true, true),
+				version("v1", 40*day, false, false),
+			},
+			opts:     removeOpts{},
+			expected: []string{"dm", "v1"},
+		},
+		{
+			name: "non-current with older-than uses modification time",
+			versions: []*ClientContent{
+				version("v3", day, true, false),
+				version("v2", 10*day, false, false),
+				version("v1", 40*day, false, false),
+			},
+			opts:     removeOpts{olderThan: "30d"},
+			expected: []string{"v1"},
+		},
+		{
+			name: "noncurrent-older-than uses successor time",
+			versions: []*ClientContent{
+				version("v3", 20*day, true, false),
+				version("v2", 35*day, false, false),
+				version("v1", 100*day, false, false),
+			},
+			opts:     removeOpts{noncurrentOlderThan: 30 * day},
+			expected: []string{"v1"},
+		},
+		{
+			name: "noncurrent-older-than never removes latest delete marker",
+			versions: []*ClientContent{
+				version("dm", 60*day, true, true),
+				version("v1", 100*day, false, false),
+			},
+			opts:     removeOpts{noncurrentOlderThan: 30 * day},
+			expected: []string{"v1"},
+		},
+		{
+			name: "noncurrent-older-than keeps recently replaced versions",
+			versions: []*ClientContent{
+				version("v2", day, true, false),
+				version("v1", 100*day, false, false),
+			},
+			opts:     removeOpts{noncurrentOlderThan: 30 * day},
+			expected: nil,
+		},
+		{
+			name: "prefix levels are skipped",
+			versions: []*ClientContent{
+				{},
+			},
+			opts:     removeOpts{},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var got []string
+			for _, content := range selectNoncurrentVersions(testCase.versions, testCase.opts, now) {
+				got = append(got, content.VersionID)
+			}
+			if len(got) != len(testCase.expected) {
+				t.Fatalf("expected %v, got %v", testCase.expected, got)
+			}
+			for i := range got {
+				if got[i] != testCase.expected[i] {
+					t.Fatalf("expected %v, got %v", testCase.expected, got)
+				}
+			}
+		})
+	}
+}
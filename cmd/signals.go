@@ -20,8 +20,28 @@ package cmd
 import (
 	"os"
 	"os/signal"
+	"sync"
 )
 
+// signalHolds counts the commands delaying the exit on a signal.
+var signalHolds sync.WaitGroup
+
+// holdSignalExit delays the exit on a signal until the returned function
+// is called, letting a command finish its work and print a summary once
+// the global context is canceled. If a signal was received, the returned
+// function does not return and the process exits with the signal status.
+func holdSignalExit() (release func()) {
+	signalHolds.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(signalHolds.Done)
+		if globalContext.Err() != nil {
+			// trapSignals exits the process.
+			select {}
+		}
+	}
+}
+
 // trapSignals traps the registered signals and cancel the global context.
 func trapSignals(sig ...os.Signal) {
 	// channel to receive signals.
@@ -45,6 +65,9 @@ func trapSignals(sig ...os.Signal) {
 	// Cancel the global context
 	globalCancel()
 
+	// Wait for the commands reporting on cancellation.
+	signalHolds.Wait()
+
 	var exitCode int
 	switch s.String() {
 	case "interrupt":
@@ -56,5 +79,6 @@ func trapSignals(sig ...os.Signal) {
 	default:
 		exitCode = globalErrorExitStatus
 	}
+	finishAuditLog(exitCode, errCodeCanceled)
 	os.Exit(exitCode)
 }
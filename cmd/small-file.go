@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// maxSmallFileThreshold caps --small-file-threshold, every worker of a copy
+// keeps a buffer of this size.
+const maxSmallFileThreshold = 16 << 20
+
+// smallFileWorkers returns the number of workers a copy starts with, more
+// than the default when small files take the fast path since their uploads
+// wait on the network rather than on the CPU.
+func smallFileWorkers(smallFileThreshold int64) int {
+	if smallFileThreshold > 0 {
+		return 4 * runtime.NumCPU()
+	}
+	return 0
+}
+
+// smallFilePool holds the buffers small files are read in.
+var smallFilePool sync.Pool
+
+// parseSmallFileThreshold parses the --small-file-threshold value, zero
+// disables the small file fast path.
+func parseSmallFileThreshold(value string) (int64, *probe.Error) {
+	if value == "" {
+		return 0, nil
+	}
+	threshold, e := humanize.ParseBytes(value)
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	if threshold > maxSmallFileThreshold {
+		return 0, errInvalidArgument().Trace(value)
+	}
+	return int64(threshold), nil
+}
+
+// readSmallFile reads a source of a known size in a pooled buffer, the
+// returned function gives the buffer back to the pool.
+func readSmallFile(source io.Reader, size int64) ([]byte, func(), *probe.Error) {
+	bufp, _ := smallFilePool.Get().(*[]byte)
+	if bufp == nil || int64(cap(*bufp)) < size {
+		buf := make([]byte, size)
+		bufp = &buf
+	}
+	buf := (*bufp)[:size]
+	release := func() { smallFilePool.Put(bufp) }
+	if _, e := io.ReadFull(source, buf); e != nil {
+		release()
+		return nil, nil, probe.NewError(e)
+	}
+	return buf, release, nil
+}
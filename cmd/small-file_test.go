@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseSmallFileThreshold(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"256KiB", 256 << 10, false},
+		{"16MiB", 16 << 20, false},
+		{"17MiB", 0, true},
+		{"big", 0, true},
+	}
+	for i, testCase := range testCases {
+		got, err := parseSmallFileThreshold(testCase.value)
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if got != testCase.want {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestReadSmallFile(t *testing.T) {
+	for i, data := range []string{"hello world", "", "a bigger file than the pooled buffer"} {
+		buf, release, err := readSmallFile(strings.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !bytes.Equal(buf, []byte(data)) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, data, buf)
+		}
+		release()
+	}
+
+	// A source shorter than its size is an error.
+	if _, _, err := readSmallFile(strings.NewReader("short"), 10); err == nil {
+		t.Fatal("expected an error for a truncated source")
+	}
+}
@@ -55,6 +55,10 @@ var (
 			Name:  "no-list",
 			Usage: "disable all LIST operations for stat",
 		},
+		cli.BoolFlag{
+			Name:  "parts",
+			Usage: "show the parts and the checksums of object(s) using GetObjectAttributes, if supported by the server",
+		},
 	}
 )
 
@@ -76,6 +80,9 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 
+ENVIRONMENT VARIABLES:
+  MC_STAT_CONCURRENCY: number of objects to stat concurrently in recursive mode, defaults to 16.
+
 EXAMPLES:
   1. Stat all contents of mybucket on Amazon S3 cloud storage.
      {{.Prompt}} {{.HelpName}} s3/mybucket/
@@ -98,6 +105,9 @@ EXAMPLES:
 
   7. Stat all objects versions recursively created before 1st January 2020.
      {{.Prompt}} {{.HelpName}} --versions --rewind 2020.01.01T00:00 s3/personal-docs/
+
+  8. Stat an object with the size and the checksum of each of its parts.
+     {{.Prompt}} {{.HelpName}} --parts s3/personal-docs/2018-account_report.docx
 `,
 }
 
@@ -189,7 +199,7 @@ func mainStat(cliCtx *cli.Context) error {
 
 	headOnly := cliCtx.Bool("no-list")
 	for _, targetURL := range args {
-		fatalIf(statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, headOnly, encKeyDB), "Unable to stat `"+targetURL+"`.")
+		fatalIf(statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, headOnly, cliCtx.Bool("parts"), encKeyDB), "Unable to stat `"+targetURL+"`.")
 	}
 
 	return nil
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/env"
+)
+
+const (
+	// envStatConcurrency overrides the number of concurrent
+	// metadata (HEAD, GetTags) calls issued for a listing.
+	envStatConcurrency = "MC_STAT_CONCURRENCY"
+
+	defaultStatConcurrency = 16
+
+	// statCacheSize is the number of recent lookups memoized by a
+	// pipeline, enough for the entries of a listing page.
+	statCacheSize = 1000
+)
+
+// statJob is a listed entry along with the result of the
+// metadata lookup done for it.
+type statJob struct {
+	content *ClientContent
+	result  interface{}
+	err     *probe.Error
+	done    chan struct{}
+}
+
+// statLookupFunc fetches the metadata of a listed entry, a nil
+// result and error skips the entry.
+type statLookupFunc func(ctx context.Context, content *ClientContent) (interface{}, *probe.Error)
+
+// statPipeline issues metadata calls for listed entries concurrently
+// while handing back the results in the order of the listing. The most
+// recent lookups are memoized by URL and version, older ones are released
+// to keep the memory bounded on large listings.
+type statPipeline struct {
+	workers int
+
+	mu    sync.Mutex
+	cache map[string]*statJob
+	// keys of the cached jobs in insertion order, used as a ring.
+	keys []string
+	next int
+}
+
+// getStatConcurrency returns the number of concurrent metadata calls.
+func getStatConcurrency() (int, *probe.Error) {
+	v := env.Get(envStatConcurrency, "")
+	if v == "" {
+		return defaultStatConcurrency, nil
+	}
+	n, e := strconv.Atoi(v)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(envStatConcurrency, v)
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n, nil
+}
+
+func newStatPipeline(workers int) *statPipeline {
+	if workers < 1 {
+		workers = 1
+	}
+	return &statPipeline{
+		workers: workers,
+		cache:   make(map[string]*statJob, statCacheSize),
+		keys:    make([]string, 0, statCacheSize),
+	}
+}
+
+func statJobKey(content *ClientContent) string {
+	return content.URL.String() + "?versionId=" + content.VersionID
+}
+
+// cached returns a recent job scheduled for the same entry, otherwise
+// registers the new job in place of the oldest one.
+func (p *statPipeline) cached(job *statJob) *statJob {
+	key := statJobKey(job.content)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if prev, ok := p.cache[key]; ok {
+		return prev
+	}
+	if len(p.keys) < statCacheSize {
+		p.keys = append(p.keys, key)
+	} else {
+		delete(p.cache, p.keys[p.next])
+		p.keys[p.next] = key
+		p.next = (p.next + 1) % statCacheSize
+	}
+	p.cache[key] = job
+	return nil
+}
+
+// Run calls lookup for every entry sent on contentCh with at most
+// p.workers calls in flight. Entries carrying a listing error are
+// passed through without a lookup. The returned channel is closed
+// once contentCh is drained or ctx is canceled.
+func (p *statPipeline) Run(ctx context.Context, contentCh <-chan *ClientContent, lookup statLookupFunc) <-chan *statJob {
+	pendingCh := make(chan *statJob, p.workers)
+	sem := make(chan struct{}, p.workers)
+
+	go func() {
+		defer close(pendingCh)
+		for content := range contentCh {
+			job := &statJob{content: content, done: make(chan struct{})}
+			if content.Err != nil {
+				close(job.done)
+			} else if prev := p.cached(job); prev != nil {
+				go func() {
+					<-prev.done
+					job.result, job.err = prev.result, prev.err
+					close(job.done)
+				}()
+			} else {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				go func() {
+					defer close(job.done)
+					defer func() { <-sem }()
+					job.result, job.err = lookup(ctx, job.content)
+				}()
+			}
+			select {
+			case pendingCh <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	resultCh := make(chan *statJob)
+	go func() {
+		defer close(resultCh)
+		for job := range pendingCh {
+			select {
+			case <-job.done:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case resultCh <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return resultCh
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestStatPipeline(t *testing.T) {
+	testCases := []struct {
+		name    string
+		workers int
+		keys    []string
+		failAt  int
+		calls   int64
+	}{
+		{"single worker", 1, []string{"a", "b", "c", "d"}, -1, 4},
+		{"ordered", 4, []string{"a", "b", "c", "d", "e", "f", "g", "h"}, -1, 8},
+		{"memoized", 4, []string{"a", "b", "a", "c", "b", "a"}, -1, 3},
+		{"listing error", 2, []string{"a", "b", "c"}, 1, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentCh := make(chan *ClientContent)
+			go func() {
+				defer close(contentCh)
+				for i, key := range tc.keys {
+					content := &ClientContent{URL: *newClientURL("/bucket/" + key)}
+					if i == tc.failAt {
+						content.Err = probe.NewError(errors.New("listing failed"))
+					}
+					contentCh <- content
+				}
+			}()
+
+			var calls, inflight, maxInflight int64
+			lookup := func(_ context.Context, content *ClientContent) (interface{}, *probe.Error) {
+				atomic.AddInt64(&calls, 1)
+				n := atomic.AddInt64(&inflight, 1)
+				defer atomic.AddInt64(&inflight, -1)
+				for {
+					m := atomic.LoadInt64(&maxInflight)
+					if n <= m || atomic.CompareAndSwapInt64(&maxInflight, m, n) {
+						break
+					}
+				}
+				// Finish later entries first to check the ordering.
+				key := content.URL.Path[len("/bucket/"):]
+				time.Sleep(time.Duration('z'-key[0]) * time.Millisecond)
+				return key, nil
+			}
+
+			var i int
+			for job := range newStatPipeline(tc.workers).Run(context.Background(), contentCh, lookup) {
+				if i == tc.failAt {
+					if job.content.Err == nil || job.result != nil {
+						t.Fatalf("entry %d: expected listing error to be passed through", i)
+					}
+					i++
+					continue
+				}
+				if job.err != nil {
+					t.Fatalf("entry %d: unexpected error %v", i, job.err)
+				}
+				if job.result.(string) != tc.keys[i] {
+					t.Fatalf("entry %d: expected %s, got %v", i, tc.keys[i], job.result)
+				}
+				i++
+			}
+			if i != len(tc.keys) {
+				t.Fatalf("expected %d entries, got %d", len(tc.keys), i)
+			}
+			if calls != tc.calls {
+				t.Fatalf("expected %d lookups, got %d", tc.calls, calls)
+			}
+			if maxInflight > int64(tc.workers) {
+				t.Fatalf("expected at most %d concurrent lookups, got %d", tc.workers, maxInflight)
+			}
+		})
+	}
+}
+
+func TestStatPipelineCacheBounded(t *testing.T) {
+	p := newStatPipeline(1)
+	for i := 0; i < 3*statCacheSize; i++ {
+		job := &statJob{content: &ClientContent{URL: *newClientURL("/bucket/" + strconv.Itoa(i))}}
+		if p.cached(job) != nil {
+			t.Fatalf("entry %d: unexpected cached job", i)
+		}
+	}
+	if len(p.cache) != statCacheSize {
+		t.Fatalf("expected %d cached jobs, got %d", statCacheSize, len(p.cache))
+	}
+	recent := &statJob{content: &ClientContent{URL: *newClientURL("/bucket/" + strconv.Itoa(3*statCacheSize-1))}}
+	if p.cached(recent) == nil {
+		t.Fatal("expected the most recent job to be cached")
+	}
+	old := &statJob{content: &ClientContent{URL: *newClientURL("/bucket/0")}}
+	if p.cached(old) != nil {
+		t.Fatal("expected the oldest job to be released")
+	}
+}
+
+func TestGetStatConcurrency(t *testing.T) {
+	testCases := []struct {
+		value   string
+		workers int
+		fail    bool
+	}{
+		{"", defaultStatConcurrency, false},
+		{"32", 32, false},
+		{"0", 1, false},
+		{"many", 0, true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Setenv(envStatConcurrency, tc.value)
+			workers, err := getStatConcurrency()
+			if (err != nil) != tc.fail {
+				t.Fatalf("expected failure %t, got %v", tc.fail, err)
+			}
+			if workers != tc.workers {
+				t.Fatalf("expected %d workers, got %d", tc.workers, workers)
+			}
+		})
+	}
+}
@@ -55,6 +55,57 @@ type statMessage struct {
 	DeleteMarker      bool               `json:"deleteMarker,omitempty"`
 	Restore           *minio.RestoreInfo `json:"restore,omitempty"`
 	Checksum          map[string]string  `json:"checksum,omitempty"`
+	Parts             []statPart         `json:"parts,omitempty"`
+}
+
+// statPart is a part of a multipart object as returned by GetObjectAttributes.
+type statPart struct {
+	Number   int               `json:"number"`
+	Size     int64             `json:"size"`
+	Checksum map[string]string `json:"checksum,omitempty"`
+}
+
+// checksumMap returns the non-empty checksums keyed by algorithm.
+func checksumMap(crc32, crc32c, sha1, sha256 string) map[string]string {
+	checksums := make(map[string]string)
+	for algo, v := range map[string]string{"CRC32": crc32, "CRC32C": crc32c, "SHA1": sha1, "SHA256": sha256} {
+		if v != "" {
+			checksums[algo] = v
+		}
+	}
+	if len(checksums) == 0 {
+		return nil
+	}
+	return checksums
+}
+
+// formatChecksums formats checksums as sorted algo=value pairs.
+func formatChecksums(checksums map[string]string) string {
+	pairs := make([]string, 0, len(checksums))
+	for algo, v := range checksums {
+		pairs = append(pairs, algo+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// addObjectAttributes adds the checksums and the parts returned by
+// GetObjectAttributes to the stat message.
+func (stat *statMessage) addObjectAttributes(attrs *minio.ObjectAttributes) {
+	if len(stat.Checksum) == 0 {
+		cs := attrs.Checksum
+		stat.Checksum = checksumMap(cs.ChecksumCRC32, cs.ChecksumCRC32C, cs.ChecksumSHA1, cs.ChecksumSHA256)
+	}
+	for _, part := range attrs.ObjectParts.Parts {
+		if part == nil {
+			continue
+		}
+		stat.Parts = append(stat.Parts, statPart{
+			Number:   part.PartNumber,
+			Size:     int64(part.Size),
+			Checksum: checksumMap(part.ChecksumCRC32, part.ChecksumCRC32C, part.ChecksumSHA1, part.ChecksumSHA256),
+		})
+	}
 }
 
 func (stat statMessage) String() (msg string) {
@@ -91,6 +142,13 @@ func (stat statMessage) String() (msg string) {
 		cs := strings.TrimSuffix(strings.TrimPrefix(fmt.Sprintf("%v", stat.Checksum), "map["), "]")
 		msgBuilder.WriteString(fmt.Sprintf("%-10s: %v", "Checksum", cs) + "\n")
 	}
+	if len(stat.Parts) > 0 {
+		msgBuilder.WriteString(fmt.Sprintf("%-10s: %d", "Parts", len(stat.Parts)) + "\n")
+		for _, part := range stat.Parts {
+			msgBuilder.WriteString(fmt.Sprintf("  %-8s: %-10s %s", fmt.Sprintf("#%d", part.Number),
+				humanize.IBytes(uint64(part.Size)), formatChecksums(part.Checksum)) + "\n")
+		}
+	}
 	if stat.Restore != nil {
 		msgBuilder.WriteString(fmt.Sprintf("%-10s:", "Restore") + "\n")
 		if !stat.Restore.ExpiryTime.IsZero() && !stat.Restore.ExpiryTime.Equal(timeSentinel) {
@@ -201,6 +259,29 @@ func parseStat(c *ClientContent) statMessage {
 	return content
 }
 
+// statObjectAttributes fetches the checksums and the parts of an object with
+// GetObjectAttributes, nothing is added if the server does not support it.
+func statObjectAttributes(ctx context.Context, urlStr, versionID string, encKeyDB map[string][]prefixSSEPair, msg *statMessage) {
+	if msg.Type == "folder" {
+		return
+	}
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return
+	}
+	alias, _, _ := mustExpandAlias(urlStr)
+	sse := getSSE(alias+clnt.GetURL().Path, encKeyDB[alias])
+	attrs, err := s3Clnt.GetObjectAttributes(ctx, versionID, sse)
+	if err != nil {
+		return
+	}
+	msg.addObjectAttributes(attrs)
+}
+
 // Return standardized URL to be used to compare later.
 func getStandardizedURL(targetURL string) string {
 	return filepath.FromSlash(targetURL)
@@ -209,7 +290,7 @@ func getStandardizedURL(targetURL string) string {
 // statURL - uses combination of GET listing and HEAD to fetch information of one or more objects
 // HEAD can fail with 400 with an SSE-C encrypted object but we still return information gathered
 // from GET listing.
-func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive, headOnly bool, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive, headOnly, withParts bool, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	clnt, err := newClient(targetURL)
 	if err != nil {
 		return err
@@ -244,7 +325,11 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 		// Trim prefix path from the content path.
 		stat.URL.Path = strings.TrimPrefix(contentURL, filepath.ToSlash(prefixPath))
 
-		printMsg(parseStat(stat))
+		msg := parseStat(stat)
+		if withParts {
+			statObjectAttributes(ctx, url, versionID, encKeyDB, &msg)
+		}
+		printMsg(msg)
 		return nil
 	}
 
@@ -299,9 +384,66 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 		lstOptions.TimeRef = timeRef
 	}
 
+	workers, err := getStatConcurrency()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	standardizedURL := getStandardizedURL(targetURL)
+	prefixPath = filepath.ToSlash(prefixPath)
+
+	// outOfPrefix reports entries listed outside of the
+	// requested prefix in non-recursive mode.
+	outOfPrefix := func(content *ClientContent) (string, bool) {
+		url := getStandardizedURL(targetAlias + getKey(content))
+		return url, !isRecursive && !strings.HasPrefix(filepath.FromSlash(url), standardizedURL) && !filepath.IsAbs(url)
+	}
+
+	lookup := func(ctx context.Context, content *ClientContent) (interface{}, *probe.Error) {
+		if content.StorageClass == s3StorageClassGlacier {
+			return nil, nil
+		}
+
+		url, out := outOfPrefix(content)
+		if out {
+			return nil, nil
+		}
+
+		if versionID != "" {
+			if versionID != content.VersionID {
+				return nil, nil
+			}
+		}
+		_, stat, err := url2Stat(ctx, url2StatOptions{
+			urlStr: url, versionID: content.VersionID,
+			fileAttr: true, encKeyDB: encKeyDB,
+			timeRef: timeRef, isZip: false,
+			ignoreBucketExistsCheck: false,
+		})
+		if err != nil {
+			return nil, err.Trace(url)
+		}
+
+		// Convert any os specific delimiters to "/".
+		contentURL := filepath.ToSlash(stat.URL.Path)
+		// Trim prefix path from the content path.
+		contentURL = strings.TrimPrefix(contentURL, prefixPath)
+		stat.URL.Path = contentURL
+
+		msg := parseStat(stat)
+		if withParts && !stat.IsDeleteMarker {
+			statObjectAttributes(ctx, url, content.VersionID, encKeyDB, &msg)
+		}
+		return msg, nil
+	}
+
 	var e error
 	var found int
-	for content := range clnt.List(ctx, lstOptions) {
+	for job := range newStatPipeline(workers).Run(ctx, clnt.List(ctx, lstOptions), lookup) {
+		content := job.content
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -324,40 +466,16 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 		}
 		found++
 
-		if content.StorageClass == s3StorageClassGlacier {
-			continue
+		if job.err != nil {
+			return job.err
 		}
-
-		url := getStandardizedURL(targetAlias + getKey(content))
-		standardizedURL := getStandardizedURL(targetURL)
-
-		if !isRecursive && !strings.HasPrefix(filepath.FromSlash(url), standardizedURL) && !filepath.IsAbs(url) {
-			return errTargetNotFound(targetURL).Trace(url, standardizedURL)
-		}
-
-		if versionID != "" {
-			if versionID != content.VersionID {
-				continue
+		if job.result == nil {
+			if url, out := outOfPrefix(content); out && content.StorageClass != s3StorageClassGlacier {
+				return errTargetNotFound(targetURL).Trace(url, standardizedURL)
 			}
+			continue
 		}
-		_, stat, err := url2Stat(ctx, url2StatOptions{
-			urlStr: url, versionID: content.VersionID,
-			fileAttr: true, encKeyDB: encKeyDB,
-			timeRef: timeRef, isZip: false,
-			ignoreBucketExistsCheck: false,
-		})
-		if err != nil {
-			return err.Trace(url)
-		}
-
-		// Convert any os specific delimiters to "/".
-		contentURL := filepath.ToSlash(stat.URL.Path)
-		prefixPath = filepath.ToSlash(prefixPath)
-		// Trim prefix path from the content path.
-		contentURL = strings.TrimPrefix(contentURL, prefixPath)
-		stat.URL.Path = contentURL
-
-		printMsg(parseStat(stat))
+		printMsg(job.result.(statMessage))
 	}
 
 	if found <= 0 {
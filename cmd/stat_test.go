@@ -23,6 +23,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/minio/minio-go/v7"
 )
 
 func TestParseStat(t *testing.T) {
@@ -68,3 +70,38 @@ func TestParseStat(t *testing.T) {
 		})
 	}
 }
+
+func TestStatAddObjectAttributes(t *testing.T) {
+	attrs := &minio.ObjectAttributes{}
+	attrs.Checksum.ChecksumCRC32C = "obj-crc32c=="
+	attrs.ObjectParts.Parts = []*minio.ObjectAttributePart{
+		{PartNumber: 1, Size: 5 << 20, ChecksumCRC32C: "part1=="},
+		nil,
+		{PartNumber: 2, Size: 1024, ChecksumCRC32C: "part2==", ChecksumSHA256: "sha=="},
+	}
+
+	// Checksums from HEAD are kept.
+	statMsg := statMessage{Type: "file", Checksum: map[string]string{"CRC32C": "head=="}}
+	statMsg.addObjectAttributes(attrs)
+	if statMsg.Checksum["CRC32C"] != "head==" {
+		t.Errorf("Expecting checksum from HEAD, got %v", statMsg.Checksum)
+	}
+
+	statMsg = statMessage{Type: "file"}
+	statMsg.addObjectAttributes(attrs)
+	if !reflect.DeepEqual(statMsg.Checksum, map[string]string{"CRC32C": "obj-crc32c=="}) {
+		t.Errorf("Unexpected object checksum %v", statMsg.Checksum)
+	}
+	expectedParts := []statPart{
+		{Number: 1, Size: 5 << 20, Checksum: map[string]string{"CRC32C": "part1=="}},
+		{Number: 2, Size: 1024, Checksum: map[string]string{"CRC32C": "part2==", "SHA256": "sha=="}},
+	}
+	if !reflect.DeepEqual(statMsg.Parts, expectedParts) {
+		t.Errorf("Expecting parts %v, got %v", expectedParts, statMsg.Parts)
+	}
+
+	out := statMsg.String()
+	if !strings.Contains(out, "Parts     : 2") || !strings.Contains(out, "CRC32C=part2== SHA256=sha==") {
+		t.Errorf("Unexpected stat output %q", out)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -64,8 +65,91 @@ var supportDiagFlags = append([]cli.Flag{
 		Usage: "Data anonymization mode (standard|strict)",
 		Value: anonymizeStandard,
 	},
+	cli.StringSliceFlag{
+		Name:  "include",
+		Usage: "collect only the given diagnostics [" + strings.Join(diagCollectorNames(), ",") + "]",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "skip the given diagnostics [" + strings.Join(diagCollectorNames(), ",") + "]",
+	},
+	cli.StringFlag{
+		Name:  "upload",
+		Usage: "upload a previously generated diagnostics report instead of collecting a new one",
+	},
 }, subnetCommonFlags...)
 
+// diagCollectors groups the health data types collected by the diagnostics.
+var diagCollectors = map[string][]madmin.HealthDataType{
+	"info":   {madmin.HealthDataTypeMinioInfo},
+	"config": {madmin.HealthDataTypeMinioConfig},
+	"sys": {
+		madmin.HealthDataTypeSysCPU, madmin.HealthDataTypeSysDriveHw, madmin.HealthDataTypeSysDocker,
+		madmin.HealthDataTypeSysOsInfo, madmin.HealthDataTypeSysLoad, madmin.HealthDataTypeSysMem,
+		madmin.HealthDataTypeSysNet, madmin.HealthDataTypeSysProcess, madmin.HealthDataTypeSysErrors,
+		madmin.HealthDataTypeSysServices, madmin.HealthDataTypeSysConfig,
+	},
+}
+
+// diagCollectorNames returns the sorted names of the diagnostics collectors.
+func diagCollectorNames() []string {
+	names := make([]string, 0, len(diagCollectors))
+	for name := range diagCollectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diagDataTypes returns the health data types to collect given the
+// collectors or the individual health data types to include and exclude.
+func diagDataTypes(include, exclude []string) ([]madmin.HealthDataType, error) {
+	resolve := func(names []string) (map[madmin.HealthDataType]bool, error) {
+		types := make(map[madmin.HealthDataType]bool)
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if collector, ok := diagCollectors[name]; ok {
+				for _, t := range collector {
+					types[t] = true
+				}
+				continue
+			}
+			if t, ok := madmin.HealthDataTypesMap[name]; ok {
+				types[t] = true
+				continue
+			}
+			switch name {
+			case "perf":
+				return nil, errors.New("performance tests are not part of the diagnostics, use 'mc support perf'")
+			case "logs":
+				return nil, errors.New("logs are not part of the diagnostics, use 'mc support logs'")
+			}
+			return nil, fmt.Errorf("unknown diagnostics '%s', valid values are %s or a health data type", name, strings.Join(diagCollectorNames(), ", "))
+		}
+		return types, nil
+	}
+
+	included, e := resolve(include)
+	if e != nil {
+		return nil, e
+	}
+	excluded, e := resolve(exclude)
+	if e != nil {
+		return nil, e
+	}
+
+	var types []madmin.HealthDataType
+	for _, t := range madmin.HealthDataTypesList {
+		if (len(included) == 0 || included[t]) && !excluded[t] {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return nil, errors.New("no diagnostics left to collect")
+	}
+	return types, nil
+}
+
 var supportDiagCmd = cli.Command{
 	Name:         "diag",
 	Aliases:      []string{"diagnostics"},
@@ -92,6 +176,15 @@ EXAMPLES:
 
   3. Upload MinIO diagnostics report for cluster with alias 'myminio' to SUBNET, with strict anonymization
      {{.Prompt}} {{.HelpName}} myminio --anonymize=strict
+
+  4. Upload MinIO diagnostics report for cluster with alias 'myminio' to SUBNET, without the server config
+     {{.Prompt}} {{.HelpName}} myminio --exclude config
+
+  5. Upload MinIO diagnostics report for cluster with alias 'myminio' to SUBNET, with the system information only
+     {{.Prompt}} {{.HelpName}} myminio --include sys
+
+  6. Upload a MinIO diagnostics report saved by a previous failed upload to SUBNET
+     {{.Prompt}} {{.HelpName}} myminio --upload myminio-health_20240101120000.json.gz
 `,
 }
 
@@ -120,6 +213,13 @@ func checkSupportDiagSyntax(ctx *cli.Context) {
 	if anon != anonymizeStandard && anon != anonymizeStrict {
 		fatal(errDummy().Trace(), "Invalid anonymization mode. Valid options are 'standard' or 'strict'.")
 	}
+
+	if _, e := diagDataTypes(ctx.StringSlice("include"), ctx.StringSlice("exclude")); e != nil {
+		fatalIf(errInvalidArgument().Trace(), e.Error())
+	}
+	if ctx.IsSet("upload") && globalAirgapped {
+		fatalIf(errInvalidArgument().Trace(), "--upload cannot be used in airgapped mode.")
+	}
 }
 
 // compress and tar MinIO diagnostics output
@@ -215,7 +315,10 @@ func execSupportDiag(ctx *cli.Context, client *madmin.AdminClient, alias, apiKey
 	var headers map[string]string
 	setSuccessMessageColor()
 
-	filename := fmt.Sprintf("%s-health_%s.json.gz", filepath.Clean(alias), UTCNow().Format("20060102150405"))
+	filename := ctx.String("upload")
+	if filename == "" {
+		filename = fmt.Sprintf("%s-health_%s.json.gz", filepath.Clean(alias), UTCNow().Format("20060102150405"))
+	}
 	if !globalAirgapped {
 		// Retrieve subnet credentials (login/license) beforehand as
 		// it can take a long time to fetch the health information
@@ -223,6 +326,11 @@ func execSupportDiag(ctx *cli.Context, client *madmin.AdminClient, alias, apiKey
 		reqURL, headers = prepareSubnetUploadURL(uploadURL, alias, apiKey)
 	}
 
+	if ctx.IsSet("upload") {
+		uploadSupportDiag(alias, filename, reqURL, headers, false)
+		return
+	}
+
 	healthInfo, version, e := fetchServerDiagInfo(ctx, client)
 	fatalIf(probe.NewError(e), "Unable to fetch health information.")
 
@@ -242,23 +350,42 @@ func execSupportDiag(ctx *cli.Context, client *madmin.AdminClient, alias, apiKey
 	fatalIf(probe.NewError(e), "Unable to save MinIO diagnostics report")
 
 	if !globalAirgapped {
-		_, e = (&SubnetFileUploader{
-			alias:             alias,
-			FilePath:          filename,
-			ReqURL:            reqURL,
-			Headers:           headers,
-			DeleteAfterUpload: true,
-		}).UploadFileToSubnet()
-		fatalIf(probe.NewError(e), "Unable to upload MinIO diagnostics report to SUBNET portal")
+		uploadSupportDiag(alias, filename, reqURL, headers, true)
+	}
+}
 
-		printMsg(supportDiagMessage{})
+// uploadSupportDiag uploads the diagnostics report to SUBNET. A report
+// generated by this run is deleted once uploaded and kept on failure so
+// that its upload can be retried with --upload, a report passed with
+// --upload is never deleted.
+func uploadSupportDiag(alias, filename, reqURL string, headers map[string]string, generated bool) {
+	_, e := (&SubnetFileUploader{
+		alias:             alias,
+		FilePath:          filename,
+		ReqURL:            reqURL,
+		Headers:           headers,
+		DeleteAfterUpload: generated,
+	}).UploadFileToSubnet()
+	if e != nil {
+		if _, se := os.Stat(filename); se == nil {
+			fatalIf(probe.NewError(e), fmt.Sprintf("Unable to upload MinIO diagnostics report to SUBNET portal, "+
+				"the report was saved as '%s', retry with 'mc support diag %s --upload %s'", filename, alias, filename))
+		}
+		fatalIf(probe.NewError(e), "Unable to upload MinIO diagnostics report to SUBNET portal")
 	}
+
+	printMsg(supportDiagMessage{})
 }
 
 func fetchServerDiagInfo(ctx *cli.Context, client *madmin.AdminClient) (interface{}, string, error) {
 	opts := GetHealthDataTypeSlice(ctx, "test")
 	if len(*opts) == 0 {
-		opts = &options
+		types, e := diagDataTypes(ctx.StringSlice("include"), ctx.StringSlice("exclude"))
+		if e != nil {
+			return nil, "", e
+		}
+		selected := HealthDataTypeSlice(types)
+		opts = &selected
 	}
 
 	optsMap := make(map[madmin.HealthDataType]struct{})
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestDiagDataTypes(t *testing.T) {
+	types, e := diagDataTypes(nil, nil)
+	if e != nil || len(types) != len(madmin.HealthDataTypesList) {
+		t.Fatalf("expected all health data types, got %v, %v", types, e)
+	}
+
+	types, e = diagDataTypes([]string{"info", "sysmem"}, nil)
+	if e != nil || len(types) != 2 || types[0] != madmin.HealthDataTypeMinioInfo || types[1] != madmin.HealthDataTypeSysMem {
+		t.Fatalf("unexpected included types %v, %v", types, e)
+	}
+
+	types, e = diagDataTypes(nil, []string{"config", "sys"})
+	if e != nil || len(types) != 1 || types[0] != madmin.HealthDataTypeMinioInfo {
+		t.Fatalf("unexpected types after exclusion %v, %v", types, e)
+	}
+
+	for _, include := range [][]string{{"perf"}, {"logs"}, {"unknown"}} {
+		if _, e = diagDataTypes(include, nil); e == nil {
+			t.Errorf("expected an error for %v", include)
+		}
+	}
+	if _, e = diagDataTypes([]string{"info"}, []string{"info"}); e == nil {
+		t.Error("expected an error when nothing is left to collect")
+	}
+}
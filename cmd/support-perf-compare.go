@@ -0,0 +1,270 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	gojson "encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// perfMetric is a performance figure extracted from the perf results,
+// higher values are always better.
+type perfMetric struct {
+	Name  string
+	Unit  string
+	Value float64
+}
+
+// perfMetrics returns the performance figures of the perf results.
+func perfMetrics(out PerfTestOutput) []perfMetric {
+	var metrics []perfMetric
+	add := func(name, unit string, value float64) {
+		if value > 0 {
+			metrics = append(metrics, perfMetric{Name: name, Unit: unit, Value: value})
+		}
+	}
+
+	if r := out.ObjectResults; r != nil {
+		add("object.put.throughput", "B/s", float64(r.PUTResults.Perf.Throughput))
+		add("object.put.objects", "objs/s", float64(r.PUTResults.Perf.ObjectsPerSec))
+		add("object.get.throughput", "B/s", float64(r.GETResults.Perf.Throughput))
+		add("object.get.objects", "objs/s", float64(r.GETResults.Perf.ObjectsPerSec))
+	}
+	if r := out.DriveResults; r != nil {
+		var read, write uint64
+		for _, srv := range r.Results {
+			for _, perf := range srv.Perf {
+				read += perf.ReadThroughput
+				write += perf.WriteThroughput
+			}
+		}
+		add("drive.write.throughput", "B/s", float64(write))
+		add("drive.read.throughput", "B/s", float64(read))
+	}
+	if r := out.NetResults; r != nil {
+		var tx, rx uint64
+		for _, srv := range r.Results {
+			tx += srv.Perf.TX
+			rx += srv.Perf.RX
+		}
+		add("net.tx.throughput", "B/s", float64(tx))
+		add("net.rx.throughput", "B/s", float64(rx))
+	}
+	if r := out.SiteReplicationResults; r != nil {
+		var tx, rx uint64
+		for _, srv := range r.Results {
+			tx += srv.Perf.TX
+			rx += srv.Perf.RX
+		}
+		add("site-replication.tx.throughput", "B/s", float64(tx))
+		add("site-replication.rx.throughput", "B/s", float64(rx))
+	}
+	if r := out.ClientResults; r != nil && r.TimeSpent > 0 {
+		add("client.throughput", "B/s", float64(r.BytesSent)/time.Duration(r.TimeSpent).Seconds())
+	}
+	return metrics
+}
+
+// perfComparison is the comparison of a performance figure with its baseline.
+type perfComparison struct {
+	Metric     string  `json:"metric"`
+	Unit       string  `json:"unit"`
+	Baseline   float64 `json:"baseline"`
+	Current    float64 `json:"current"`
+	Change     float64 `json:"change"`
+	Regression bool    `json:"regression"`
+}
+
+// comparePerf compares the perf results with the baseline, a figure
+// dropping more than threshold percent is reported as a regression.
+func comparePerf(baseline, current PerfTestOutput, threshold float64) (comparisons []perfComparison) {
+	base := make(map[string]float64)
+	for _, m := range perfMetrics(baseline) {
+		base[m.Name] = m.Value
+	}
+	for _, m := range perfMetrics(current) {
+		b, ok := base[m.Name]
+		if !ok {
+			continue
+		}
+		change := (m.Value - b) / b * 100
+		comparisons = append(comparisons, perfComparison{
+			Metric:     m.Name,
+			Unit:       m.Unit,
+			Baseline:   b,
+			Current:    m.Value,
+			Change:     math.Round(change*100) / 100,
+			Regression: change < -threshold,
+		})
+	}
+	return comparisons
+}
+
+// perfCompareMessage is container for the comparison of the perf results with a baseline.
+type perfCompareMessage struct {
+	Status        string           `json:"status"`
+	Time          time.Time        `json:"time"`
+	Baseline      string           `json:"baseline"`
+	BaselineSaved bool             `json:"baselineSaved,omitempty"`
+	Threshold     float64          `json:"threshold"`
+	Verdict       string           `json:"verdict"`
+	Metrics       []perfComparison `json:"metrics,omitempty"`
+}
+
+// regression returns true if any performance figure regressed.
+func (m perfCompareMessage) regression() bool {
+	for _, c := range m.Metrics {
+		if c.Regression {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON jsonified perf comparison message.
+func (m perfCompareMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// String colorized perf comparison message.
+func (m perfCompareMessage) String() string {
+	if m.BaselineSaved {
+		return console.Colorize("PerfPass", fmt.Sprintf("Baseline saved to %s", m.Baseline))
+	}
+
+	formatValue := func(unit string, value float64) string {
+		if unit == "B/s" {
+			return humanize.IBytes(uint64(value)) + "/s"
+		}
+		return humanize.Comma(int64(value)) + " " + unit
+	}
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "Comparison with baseline %s (threshold %s%%):\n", m.Baseline, humanize.Ftoa(m.Threshold))
+	for _, c := range m.Metrics {
+		clr := "PerfPass"
+		if c.Regression {
+			clr = "PerfFail"
+		}
+		fmt.Fprintf(&s, "   %-32s %14s -> %-14s %s\n", c.Metric,
+			formatValue(c.Unit, c.Baseline), formatValue(c.Unit, c.Current),
+			console.Colorize(clr, fmt.Sprintf("%+.2f%%", c.Change)))
+	}
+	clr := "PerfPass"
+	if m.Verdict != "pass" {
+		clr = "PerfFail"
+	}
+	s.WriteString("Verdict: " + console.Colorize(clr, strings.ToUpper(m.Verdict)))
+	return s.String()
+}
+
+// readPerfBaseline reads the perf results stored in the baseline file.
+func readPerfBaseline(filename string) (baseline PerfTestOutput, e error) {
+	data, e := os.ReadFile(filename)
+	if e != nil {
+		return baseline, e
+	}
+	if e = gojson.Unmarshal(data, &baseline); e != nil {
+		return baseline, fmt.Errorf("%s is not a valid perf baseline: %w", filename, e)
+	}
+	return baseline, nil
+}
+
+// writePerfBaseline stores the perf results in the baseline file.
+func writePerfBaseline(filename string, out PerfTestOutput) error {
+	data, e := gojson.MarshalIndent(out, "", "    ")
+	if e != nil {
+		return e
+	}
+	return os.WriteFile(filename, data, 0o600)
+}
+
+// comparePerfBaseline compares the perf results with the baseline file,
+// the results are saved as the baseline when the file does not exist.
+func comparePerfBaseline(filename string, out PerfTestOutput, threshold float64) (perfCompareMessage, error) {
+	msg := perfCompareMessage{
+		Status:    "success",
+		Time:      UTCNow(),
+		Baseline:  filename,
+		Threshold: threshold,
+		Verdict:   "pass",
+	}
+
+	baseline, e := readPerfBaseline(filename)
+	if os.IsNotExist(e) {
+		msg.BaselineSaved = true
+		return msg, writePerfBaseline(filename, out)
+	}
+	if e != nil {
+		return msg, e
+	}
+
+	msg.Metrics = comparePerf(baseline, out, threshold)
+	if len(msg.Metrics) == 0 {
+		return msg, fmt.Errorf("no performance results in common with the baseline %s", filename)
+	}
+	if msg.regression() {
+		msg.Verdict = "regression"
+	}
+	return msg, nil
+}
+
+// perfHistoryEntry is a perf run recorded in the local perf history.
+type perfHistoryEntry struct {
+	Time    time.Time      `json:"time"`
+	Alias   string         `json:"alias"`
+	Test    string         `json:"test,omitempty"`
+	Results PerfTestOutput `json:"results"`
+}
+
+// perfHistoryFile returns the file where the perf runs of the alias are recorded,
+// one JSON document per line.
+func perfHistoryFile(alias string) string {
+	return filepath.Join(mustGetMcConfigDir(), "perf-history", filepath.Clean(alias)+".json")
+}
+
+// recordPerfHistory appends the perf run to the local perf history.
+func recordPerfHistory(filename string, entry perfHistoryEntry) error {
+	if e := os.MkdirAll(filepath.Dir(filename), 0o700); e != nil {
+		return e
+	}
+	f, e := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if e != nil {
+		return e
+	}
+	defer f.Close()
+
+	data, e := gojson.Marshal(entry)
+	if e != nil {
+		return e
+	}
+	_, e = f.Write(append(data, '\n'))
+	return e
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestComparePerf(t *testing.T) {
+	objResults := func(put, get uint64) PerfTestOutput {
+		return PerfTestOutput{
+			ObjectResults: &ObjTestResults{
+				PUTResults: ObjPUTPerfResults{Perf: ObjPUTStats{Throughput: put, ObjectsPerSec: 100}},
+				GETResults: ObjGETPerfResults{Perf: ObjGETStats{ObjPUTStats: ObjPUTStats{Throughput: get, ObjectsPerSec: 100}}},
+			},
+		}
+	}
+	baseline := objResults(1000, 2000)
+	baseline.DriveResults = &DriveTestResults{Results: []DriveTestResult{
+		{Endpoint: "node1", Perf: []madmin.DrivePerf{{ReadThroughput: 10, WriteThroughput: 20}, {ReadThroughput: 10, WriteThroughput: 20}}},
+	}}
+
+	metrics := perfMetrics(baseline)
+	if len(metrics) != 6 {
+		t.Fatalf("expected 6 metrics, got %v", metrics)
+	}
+	if metrics[4].Name != "drive.write.throughput" || metrics[4].Value != 40 {
+		t.Fatalf("unexpected drive metric %+v", metrics[4])
+	}
+
+	comparisons := comparePerf(baseline, objResults(950, 1500), 10)
+	if len(comparisons) != 4 {
+		t.Fatalf("expected only the common metrics to be compared, got %v", comparisons)
+	}
+	for _, c := range comparisons {
+		switch c.Metric {
+		case "object.put.throughput":
+			if c.Regression || c.Change != -5 {
+				t.Errorf("unexpected PUT comparison %+v", c)
+			}
+		case "object.get.throughput":
+			if !c.Regression || c.Change != -25 {
+				t.Errorf("unexpected GET comparison %+v", c)
+			}
+		}
+	}
+
+	filename := filepath.Join(t.TempDir(), "baseline.json")
+	msg, e := comparePerfBaseline(filename, baseline, 10)
+	if e != nil || !msg.BaselineSaved {
+		t.Fatalf("expected the baseline to be saved, got %+v, %v", msg, e)
+	}
+	msg, e = comparePerfBaseline(filename, objResults(1200, 1900), 10)
+	if e != nil || msg.Verdict != "pass" || msg.regression() {
+		t.Fatalf("expected no regression, got %+v, %v", msg, e)
+	}
+	msg, e = comparePerfBaseline(filename, objResults(500, 1900), 10)
+	if e != nil || msg.Verdict != "regression" || !msg.regression() {
+		t.Fatalf("expected a regression, got %+v, %v", msg, e)
+	}
+}
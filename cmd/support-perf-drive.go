@@ -68,11 +68,15 @@ func mainAdminSpeedTestDrive(ctx *cli.Context, aliasedURL string, outCh chan<- P
 
 	if globalJSON {
 		if e != nil {
-			printMsg(convertPerfResult(PerfTestResult{
+			r := PerfTestResult{
 				Type:  DrivePerfTest,
 				Err:   e.Error(),
 				Final: true,
-			}))
+			}
+			printMsg(convertPerfResult(r))
+			if outCh != nil {
+				outCh <- r
+			}
 
 			return nil
 		}
@@ -83,11 +87,15 @@ func mainAdminSpeedTestDrive(ctx *cli.Context, aliasedURL string, outCh chan<- P
 				results = append(results, result)
 			}
 		}
-		printMsg(convertPerfResult(PerfTestResult{
+		r := PerfTestResult{
 			Type:        DrivePerfTest,
 			DriveResult: results,
 			Final:       true,
-		}))
+		}
+		printMsg(convertPerfResult(r))
+		if outCh != nil {
+			outCh <- r
+		}
 
 		return nil
 	}
@@ -62,19 +62,24 @@ func mainAdminSpeedTestNetperf(ctx *cli.Context, aliasedURL string, outCh chan<-
 	}()
 
 	if globalJSON {
+		var r PerfTestResult
 		select {
 		case e := <-errorCh:
-			printMsg(convertPerfResult(PerfTestResult{
+			r = PerfTestResult{
 				Type:  NetPerfTest,
 				Err:   e.Error(),
 				Final: true,
-			}))
+			}
 		case result := <-resultCh:
-			printMsg(convertPerfResult(PerfTestResult{
+			r = PerfTestResult{
 				Type:      NetPerfTest,
 				NetResult: &result,
 				Final:     true,
-			}))
+			}
+		}
+		printMsg(convertPerfResult(r))
+		if outCh != nil {
+			outCh <- r
 		}
 		return nil
 	}
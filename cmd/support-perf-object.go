@@ -95,11 +95,15 @@ func mainAdminSpeedTestObject(ctx *cli.Context, aliasedURL string, outCh chan<-
 
 	if globalJSON {
 		if e != nil {
-			printMsg(convertPerfResult(PerfTestResult{
+			r := PerfTestResult{
 				Type:  ObjectPerfTest,
 				Err:   e.Error(),
 				Final: true,
-			}))
+			}
+			printMsg(convertPerfResult(r))
+			if outCh != nil {
+				outCh <- r
+			}
 			return nil
 		}
 
@@ -110,11 +114,15 @@ func mainAdminSpeedTestObject(ctx *cli.Context, aliasedURL string, outCh chan<-
 			}
 		}
 
-		printMsg(convertPerfResult(PerfTestResult{
+		r := PerfTestResult{
 			Type:         ObjectPerfTest,
 			ObjectResult: &result,
 			Final:        true,
-		}))
+		}
+		printMsg(convertPerfResult(r))
+		if outCh != nil {
+			outCh <- r
+		}
 
 		return nil
 	}
@@ -62,19 +62,24 @@ func mainAdminSpeedTestSiteReplication(ctx *cli.Context, aliasedURL string, outC
 	}()
 
 	if globalJSON {
+		var r PerfTestResult
 		select {
 		case e := <-errorCh:
-			printMsg(convertPerfResult(PerfTestResult{
+			r = PerfTestResult{
 				Type:  SiteReplicationPerfTest,
 				Err:   e.Error(),
 				Final: true,
-			}))
+			}
 		case result := <-resultCh:
-			printMsg(convertPerfResult(PerfTestResult{
+			r = PerfTestResult{
 				Type:                  SiteReplicationPerfTest,
 				SiteReplicationResult: &result,
 				Final:                 true,
-			}))
+			}
+		}
+		printMsg(convertPerfResult(r))
+		if outCh != nil {
+			outCh <- r
 		}
 		return nil
 	}
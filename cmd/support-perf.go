@@ -26,6 +26,7 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/madmin-go/v3"
@@ -44,16 +45,14 @@ var supportPerfFlags = append([]cli.Flag{
 		Usage: "display per-server stats",
 	},
 	cli.StringFlag{
-		Name:   "duration",
-		Usage:  "maximum duration each perf tests are run",
-		Value:  "10s",
-		Hidden: true,
+		Name:  "duration",
+		Usage: "maximum duration each perf tests are run",
+		Value: "10s",
 	},
 	cli.IntFlag{
-		Name:   "concurrent",
-		Usage:  "number of concurrent requests per server",
-		Value:  32,
-		Hidden: true,
+		Name:  "concurrent",
+		Usage: "number of concurrent requests per server, disables autotuning",
+		Value: 32,
 	},
 	cli.StringFlag{
 		Name:   "bucket",
@@ -78,6 +77,19 @@ var supportPerfFlags = append([]cli.Flag{
 		Value:  "4MiB",
 		Hidden: true,
 	},
+	cli.DurationFlag{
+		Name:  "schedule",
+		Usage: "run the performance tests repeatedly at the given interval",
+	},
+	cli.StringFlag{
+		Name:  "compare",
+		Usage: "compare the results with a baseline file, the file is created with the results when missing",
+	},
+	cli.Float64Flag{
+		Name:  "threshold",
+		Usage: "percentage drop from the baseline reported as a regression",
+		Value: 10,
+	},
 	cli.BoolFlag{
 		Name:   "serial",
 		Usage:  "run tests on drive(s) one-by-one",
@@ -102,12 +114,27 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+NOTE:
+  Results of the runs with --schedule or --compare are kept in the local history
+  file 'perf-history/ALIAS.json' under the mc config directory, one run per line.
+
 EXAMPLES:
   1. Upload object storage, network, and drive performance analysis for cluster with alias 'myminio' to SUBNET
      {{.Prompt}} {{.HelpName}} myminio
 
   2. Run object storage, network, and drive performance tests on cluster with alias 'myminio', save and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} myminio --airgap
+
+  3. Run object storage performance test with 16MiB objects and 64 concurrent requests per server for 30 seconds
+     {{.Prompt}} {{.HelpName}} object myminio --size 16MiB --concurrent 64 --duration 30s --airgap
+
+  4. Run object storage performance test on cluster with alias 'myminio' and compare the results with a baseline,
+     the results are saved as the baseline by the first run
+     {{.Prompt}} {{.HelpName}} object myminio --compare myminio-baseline.json --airgap
+
+  5. Run object storage performance test on cluster with alias 'myminio' every 6 hours and report regressions
+     of more than 20% from the baseline
+     {{.Prompt}} {{.HelpName}} object myminio --schedule 6h --compare myminio-baseline.json --threshold 20 --airgap
 `,
 }
 
@@ -291,10 +318,15 @@ func mainSupportPerf(ctx *cli.Context) error {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 
-	// Main execution
-	execSupportPerf(ctx, aliasedURL, perfType)
+	if ctx.Duration("schedule") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "--schedule cannot be negative.")
+	}
+	if ctx.Float64("threshold") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "--threshold cannot be negative.")
+	}
 
-	return nil
+	// Main execution
+	return execSupportPerf(ctx, aliasedURL, perfType)
 }
 
 func convertDriveTestResult(dr madmin.DriveSpeedTestResult) DriveTestResult {
@@ -460,17 +492,61 @@ func convertPerfResults(results []PerfTestResult) PerfTestOutput {
 	return out
 }
 
-func execSupportPerf(ctx *cli.Context, aliasedURL, perfType string) {
+func execSupportPerf(ctx *cli.Context, aliasedURL, perfType string) error {
 	alias, apiKey := initSubnetConnectivity(ctx, aliasedURL, true)
 	if len(apiKey) == 0 {
 		// api key not passed as flag. Check that the cluster is registered.
 		apiKey = validateClusterRegistered(alias, true)
 	}
 
+	console.SetColor("PerfPass", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PerfFail", color.New(color.FgRed, color.Bold))
+
+	schedule := ctx.Duration("schedule")
+	for {
+		regression := runSupportPerf(ctx, aliasedURL, alias, apiKey, perfType)
+		if schedule == 0 {
+			if regression {
+				return exitStatus(globalErrorExitStatus)
+			}
+			return nil
+		}
+
+		select {
+		case <-globalContext.Done():
+			return nil
+		case <-time.After(schedule):
+		}
+	}
+}
+
+// runSupportPerf runs the performance tests once, records and compares
+// their results when requested and uploads them to SUBNET. It returns
+// true if the results regressed from the baseline.
+func runSupportPerf(ctx *cli.Context, aliasedURL, alias, apiKey, perfType string) (regression bool) {
 	results := runPerfTests(ctx, aliasedURL, perfType)
+
+	if len(results) > 0 && (ctx.IsSet("schedule") || ctx.IsSet("compare")) {
+		perfOutput := convertPerfResults(results)
+		e := recordPerfHistory(perfHistoryFile(alias), perfHistoryEntry{
+			Time:    UTCNow(),
+			Alias:   alias,
+			Test:    perfType,
+			Results: perfOutput,
+		})
+		errorIf(probe.NewError(e), "Unable to record the performance results in the local history")
+
+		if baseline := ctx.String("compare"); baseline != "" {
+			msg, e := comparePerfBaseline(baseline, perfOutput, ctx.Float64("threshold"))
+			fatalIf(probe.NewError(e), "Unable to compare the performance results with the baseline")
+			printMsg(msg)
+			regression = msg.regression()
+		}
+	}
+
 	if globalJSON {
 		// No file to be saved or uploaded to SUBNET in case of `--json`
-		return
+		return regression
 	}
 
 	// If results still not available, don't write anything
@@ -487,7 +563,7 @@ func execSupportPerf(ctx *cli.Context, aliasedURL, perfType string) {
 		if globalAirgapped {
 			console.Infoln()
 			savePerfResultFile(tmpFileName, resultFileNamePfx)
-			return
+			return regression
 		}
 
 		uploadURL := SubnetUploadURL("perf")
@@ -503,11 +579,12 @@ func execSupportPerf(ctx *cli.Context, aliasedURL, perfType string) {
 		if e != nil {
 			errorIf(probe.NewError(e), "Unable to upload performance results to SUBNET portal")
 			savePerfResultFile(tmpFileName, resultFileNamePfx)
-			return
+			return regression
 		}
 
 		console.Infoln("Uploaded performance report to SUBNET successfully")
 	}
+	return regression
 }
 
 func savePerfResultFile(tmpFileName, resultFileNamePfx string) {
@@ -518,7 +595,8 @@ func savePerfResultFile(tmpFileName, resultFileNamePfx string) {
 }
 
 func runPerfTests(ctx *cli.Context, aliasedURL, perfType string) []PerfTestResult {
-	resultCh := make(chan PerfTestResult)
+	// buffered as the results are sent before returning in JSON mode
+	resultCh := make(chan PerfTestResult, 1)
 	results := []PerfTestResult{}
 	defer close(resultCh)
 
@@ -544,9 +622,7 @@ func runPerfTests(ctx *cli.Context, aliasedURL, perfType string) []PerfTestResul
 			showCommandHelpAndExit(ctx, 1) // last argument is exit code
 		}
 
-		if !globalJSON {
-			results = append(results, <-resultCh)
-		}
+		results = append(results, <-resultCh)
 	}
 
 	return results
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -44,6 +45,10 @@ var (
 			Usage: "profiler type, possible values are 'cpu', 'cpuio', 'mem', 'block', 'mutex', 'trace', 'threads' and 'goroutines'",
 			Value: "cpu,mem,block,mutex,goroutines",
 		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "save profile data to a local file or directory instead of uploading to SUBNET",
+		},
 	}, subnetCommonFlags...)
 )
 
@@ -66,7 +71,7 @@ func (s supportProfileMessage) String() string {
 		return msg
 	}
 
-	if globalAirgapped {
+	if globalAirgapped || s.File != "" {
 		msg = fmt.Sprintf("Profiling data saved successfully at %s", s.File)
 	} else {
 		msg = "Profiling data uploaded to SUBNET successfully"
@@ -108,6 +113,9 @@ EXAMPLES:
 
   4. Profile CPU for 10 seconds on cluster with alias 'myminio', save and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} --type cpu --airgap myminio
+
+  5. Profile CPU and Memory for 30 seconds on cluster with alias 'myminio' and save results in the directory 'profiles'
+     {{.Prompt}} {{.HelpName}} --type cpu,mem --duration 30 --output profiles/ myminio
 `,
 }
 
@@ -168,11 +176,28 @@ func moveFile(sourcePath, destPath string) error {
 	return os.Remove(sourcePath)
 }
 
-func saveProfileFile(data io.ReadCloser) {
+// profileOutputFile returns the local file the profile data is saved to
+// for --output. When output is a directory, the file is named after the
+// alias, the profiler types and the current time.
+func profileOutputFile(output, alias, profilers string, now time.Time) string {
+	fi, e := os.Stat(output)
+	if (e == nil && fi.IsDir()) || strings.HasSuffix(output, string(os.PathSeparator)) || strings.HasSuffix(output, "/") {
+		name := fmt.Sprintf("profile-%s-%s-%s.zip", alias,
+			strings.ReplaceAll(strings.ToLower(profilers), ",", "-"), now.Format("20060102T150405"))
+		return filepath.Join(output, name)
+	}
+	return output
+}
+
+func saveProfileFile(data io.ReadCloser, file string) {
 	// Create profile zip file
 	tmpFile, e := os.CreateTemp("", "mc-profile-")
 	fatalIf(probe.NewError(e), "Unable to download profile data.")
 
+	if dir := filepath.Dir(file); dir != "" {
+		fatalIf(probe.NewError(os.MkdirAll(dir, 0o700)), "Unable to save profile data")
+	}
+
 	// Copy zip content to target download file
 	_, e = io.Copy(tmpFile, data)
 	fatalIf(probe.NewError(e), "Unable to download profile data.")
@@ -181,18 +206,18 @@ func saveProfileFile(data io.ReadCloser) {
 	data.Close()
 	tmpFile.Close()
 
-	downloadedFile := profileFile + "." + time.Now().Format(dateTimeFormatFilename)
+	downloadedFile := file + "." + time.Now().Format(dateTimeFormatFilename)
 
-	fi, e := os.Stat(profileFile)
+	fi, e := os.Stat(file)
 	if e == nil && !fi.IsDir() {
-		e = moveFile(profileFile, downloadedFile)
-		fatalIf(probe.NewError(e), "Unable to create a backup of profile.zip")
+		e = moveFile(file, downloadedFile)
+		fatalIf(probe.NewError(e), "Unable to create a backup of %s", file)
 	} else {
 		if !os.IsNotExist(e) {
 			fatal(probe.NewError(e), "Unable to save profile data")
 		}
 	}
-	fatalIf(probe.NewError(moveFile(tmpFile.Name(), profileFile)), "Unable to save profile data")
+	fatalIf(probe.NewError(moveFile(tmpFile.Name(), file)), "Unable to save profile data")
 }
 
 // mainSupportProfile is the handle for "mc support profile" command.
@@ -205,6 +230,14 @@ func mainSupportProfile(ctx *cli.Context) error {
 
 	// Get the alias parameter from cli
 	aliasedURL := ctx.Args().Get(0)
+
+	if ctx.IsSet("output") {
+		// Profile data is only saved locally, no SUBNET connectivity required.
+		alias, _ := url2Alias(aliasedURL)
+		execSupportProfile(ctx, getClient(aliasedURL), alias, "")
+		return nil
+	}
+
 	alias, apiKey := initSubnetConnectivity(ctx, aliasedURL, true)
 	if len(apiKey) == 0 {
 		// api key not passed as flag. Check that the cluster is registered.
@@ -225,7 +258,12 @@ func execSupportProfile(ctx *cli.Context, client *madmin.AdminClient, alias, api
 	profilers := ctx.String("type")
 	duration := ctx.Int("duration")
 
-	if !globalAirgapped {
+	output := profileFile
+	if ctx.IsSet("output") {
+		output = profileOutputFile(ctx.String("output"), alias, profilers, time.Now())
+	}
+
+	if !globalAirgapped && !ctx.IsSet("output") {
 		// Retrieve subnet credentials (login/license) beforehand as
 		// it can take a long time to fetch the profile data
 		uploadURL := SubnetUploadURL("profile")
@@ -238,7 +276,15 @@ func execSupportProfile(ctx *cli.Context, client *madmin.AdminClient, alias, api
 	data, e := client.Profile(globalContext, madmin.ProfilerType(profilers), time.Second*time.Duration(duration))
 	fatalIf(probe.NewError(e), "Unable to save profile data")
 
-	saveProfileFile(data)
+	saveProfileFile(data, output)
+
+	if ctx.IsSet("output") {
+		printMsg(supportProfileMessage{
+			Status: "success",
+			File:   output,
+		})
+		return
+	}
 
 	if !globalAirgapped {
 		_, e = (&SubnetFileUploader{
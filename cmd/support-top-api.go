@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/minio/cli"
@@ -43,6 +44,11 @@ var supportTopAPIFlags = []cli.Flag{
 		Name:  "errors, e",
 		Usage: "summarize current API calls throwing only errors",
 	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "duration to summarize API calls for when printing a --json snapshot",
+		Value: 5 * time.Second,
+	},
 }
 
 var supportTopAPICmd = cli.Command{
@@ -68,6 +74,9 @@ EXAMPLES:
 
    2. Display current in-progress all 's3.PutObject' API calls.
       {{.Prompt}} {{.HelpName}} --name s3.PutObject myminio/
+
+   3. Print a JSON summary of the API calls received during 10 seconds.
+      {{.Prompt}} {{.HelpName}} --json --interval 10s myminio/
 `,
 }
 
@@ -103,6 +112,32 @@ func mainSupportTopAPI(ctx *cli.Context) error {
 	// Start listening on all trace activity.
 	traceCh := client.ServiceTrace(ctxt, opts)
 
+	if globalJSON {
+		if ctx.Duration("interval") <= 0 {
+			fatalIf(errInvalidArgument().Trace(), "--interval must be greater than zero.")
+		}
+		stats := &statTrace{Calls: make(map[string]statItem, 20)}
+		timer := time.NewTimer(ctx.Duration("interval"))
+		defer timer.Stop()
+	loop:
+		for {
+			select {
+			case t, ok := <-traceCh:
+				if !ok {
+					break loop
+				}
+				fatalIf(probe.NewError(t.Err).Trace(aliasedURL), "Unable to fetch http trace statistics")
+				if mopts.matches(t) {
+					stats.add(t)
+				}
+			case <-timer.C:
+				break loop
+			}
+		}
+		printMsg(stats)
+		return nil
+	}
+
 	filteredTraces := make(chan madmin.ServiceTraceInfo, 1)
 	ui := tea.NewProgram(initTraceStatsUI(false, 30, filteredTraces))
 	var te error
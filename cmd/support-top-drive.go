@@ -33,6 +33,11 @@ var supportTopDriveFlags = []cli.Flag{
 		Usage: "show up to N drives",
 		Value: 10,
 	},
+	cli.StringFlag{
+		Name:  "sort-by",
+		Usage: "sort drives by 'name', 'used', 'tps', 'read', 'write', 'discard', 'await' or 'util'",
+		Value: "name",
+	},
 }
 
 var supportTopDriveCmd = cli.Command{
@@ -57,6 +62,12 @@ FLAGS:
 EXAMPLES:
    1. Display drive metrics
       {{.Prompt}} {{.HelpName}} myminio/
+
+   2. Display the 5 drives with the highest utilization
+      {{.Prompt}} {{.HelpName}} --sort-by util --count 5 myminio/
+
+   3. Print a JSON snapshot of the drive metrics, sorted by average wait time
+      {{.Prompt}} {{.HelpName}} --sort-by await --json myminio/
 `,
 }
 
@@ -65,6 +76,73 @@ func checkSupportTopDriveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if _, ok := parseDrivesSorter(ctx.String("sort-by")); !ok {
+		fatalIf(errInvalidArgument().Trace(ctx.String("sort-by")), "Unsupported sort key for drives.")
+	}
+}
+
+// topDriveStat is the JSON representation of the metrics of a drive.
+type topDriveStat struct {
+	Endpoint   string  `json:"endpoint"`
+	Pool       int     `json:"pool"`
+	Used       uint64  `json:"usedPercent"`
+	TPS        uint64  `json:"tps"`
+	ReadMBs    float64 `json:"readMiBps"`
+	WriteMBs   float64 `json:"writeMiBps"`
+	DiscardMBs float64 `json:"discardMiBps"`
+	Await      float64 `json:"awaitMs"`
+	Util       float64 `json:"utilPercent"`
+	Healing    bool    `json:"healing,omitempty"`
+	Scanning   bool    `json:"scanning,omitempty"`
+}
+
+// topDriveMessage container for a snapshot of the drive metrics.
+type topDriveMessage struct {
+	Status string         `json:"status"`
+	Drives []topDriveStat `json:"drives"`
+}
+
+func (m topDriveMessage) JSON() string {
+	return toJSON(m)
+}
+
+func (m topDriveMessage) String() string {
+	return m.JSON()
+}
+
+// topDriveSnapshot returns the drive metrics computed between two samples.
+func topDriveSnapshot(drivesInfo map[string]madmin.Disk, curr, prev map[string]madmin.DiskIOStats, sortBy drivesSorter, count int) topDriveMessage {
+	var data []driveIOStat
+	for disk := range curr {
+		info, ok := drivesInfo[disk]
+		if !ok {
+			continue
+		}
+		data = append(data, generateDriveStat(info, curr[disk], prev[disk], 1000))
+	}
+	sortDriveIOStat(sortBy, false, data)
+	if count > 0 && len(data) > count {
+		data = data[:count]
+	}
+
+	m := topDriveMessage{Status: "success", Drives: []topDriveStat{}}
+	for _, d := range data {
+		info := drivesInfo[d.endpoint]
+		m.Drives = append(m.Drives, topDriveStat{
+			Endpoint:   d.endpoint,
+			Pool:       info.PoolIndex + 1,
+			Used:       d.used,
+			TPS:        d.tps,
+			ReadMBs:    d.readMBs,
+			WriteMBs:   d.writeMBs,
+			DiscardMBs: d.discardMBs,
+			Await:      d.await,
+			Util:       d.util,
+			Healing:    info.Healing,
+			Scanning:   info.Scanning,
+		})
+	}
+	return m
 }
 
 func mainSupportTopDrive(ctx *cli.Context) error {
@@ -100,7 +178,39 @@ func mainSupportTopDrive(ctx *cli.Context) error {
 		N:        ctx.Int("count"),
 	}
 
-	p := tea.NewProgram(initTopDriveUI(disks, ctx.Int("count")))
+	sortBy, _ := parseDrivesSorter(ctx.String("sort-by"))
+
+	if globalJSON {
+		// Print a single snapshot computed from two consecutive samples.
+		drivesInfo := make(map[string]madmin.Disk, len(disks))
+		for _, disk := range disks {
+			drivesInfo[disk.Endpoint] = disk
+		}
+		// Fetch all drives, they are sorted and limited locally.
+		opts.N = 0
+		var samples int
+		prev := make(map[string]madmin.DiskIOStats)
+		curr := make(map[string]madmin.DiskIOStats)
+		e := client.Metrics(ctxt, opts, func(m madmin.RealtimeMetrics) {
+			if ctxt.Err() != nil {
+				return
+			}
+			for name, metric := range m.ByDisk {
+				prev[name] = curr[name]
+				curr[name] = metric.IOStats
+			}
+			if samples++; samples >= 2 {
+				printMsg(topDriveSnapshot(drivesInfo, curr, prev, sortBy, ctx.Int("count")))
+				cancel()
+			}
+		})
+		if e != nil && ctxt.Err() == nil {
+			fatalIf(probe.NewError(e), "Unable to fetch top drives events")
+		}
+		return nil
+	}
+
+	p := tea.NewProgram(initTopDriveUI(disks, ctx.Int("count"), sortBy))
 	go func() {
 		out := func(m madmin.RealtimeMetrics) {
 			for name, metric := range m.ByDisk {
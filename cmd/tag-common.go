@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cheggaaa/pb"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var tagParallelFlag = cli.IntFlag{
+	Name:  "parallel, P",
+	Usage: "number of objects to process in parallel with --recursive or --versions",
+	Value: 4,
+}
+
+// tagTarget is an object version listed by a recursive or versioned tag operation.
+type tagTarget struct {
+	url       string
+	versionID string
+}
+
+// processTagTargets calls fn for every listed target using parallel workers
+// and returns the number of failures. In the default console mode a progress
+// bar counting the processed objects replaces the message per object, like
+// mc cp does; --quiet and --json keep printing every message.
+func processTagTargets(ctx context.Context, parallel int, caption string, targetsCh <-chan tagTarget, fn func(ctx context.Context, target tagTarget) (message, *probe.Error)) (failed int64) {
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	var pg *progressBar
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(0)
+		pg.SetUnits(pb.U_NO)
+		pg.SetCaption(caption)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range targetsCh {
+				msg, err := fn(ctx, target)
+				if pg != nil {
+					pg.Increment()
+				}
+				if err != nil {
+					atomic.AddInt64(&failed, 1)
+					errorIf(err.Trace(target.url), "Unable to process `%s`.", target.url)
+					continue
+				}
+				if msg != nil && pg == nil {
+					printMsg(msg)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if pg != nil {
+		pg.Finish()
+	}
+	return failed
+}
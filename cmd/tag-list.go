@@ -71,6 +71,9 @@ FLAGS:
 DESCRIPTION:
    List tags assigned to a bucket or an object
 
+ENVIRONMENT VARIABLES:
+  MC_STAT_CONCURRENCY: number of objects to fetch tags for concurrently, defaults to 16.
+
 EXAMPLES:
   1. List the tags assigned to an object.
      {{.Prompt}} {{.HelpName}} myminio/testbucket/testobject
@@ -166,28 +169,37 @@ func parseTagListSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef
 	return
 }
 
-// showTags pretty prints tags of a bucket or a specified object/version
-func showTags(ctx context.Context, clnt Client, versionID string) {
-	targetName := clnt.GetURL().String()
-	if versionID != "" {
-		targetName += " (" + versionID + ")"
-	}
-
+// getTags fetches tags of a bucket or a specified object/version
+func getTags(ctx context.Context, clnt Client, versionID string) (tagListMessage, *probe.Error) {
 	tagsMap, err := clnt.GetTags(ctx, versionID)
-	if err != nil {
-		if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchTagSet" {
-			fatalIf(probe.NewError(errors.New("check 'mc tag set --help' on how to set tags")), "No tags found  for "+targetName)
-		}
-		fatalIf(err, "Unable to fetch tags for "+targetName)
-		return
-	}
-
-	printMsg(tagListMessage{
+	return tagListMessage{
 		Tags:      tagsMap,
 		Status:    "success",
 		URL:       clnt.GetURL().String(),
 		VersionID: versionID,
-	})
+	}, err
+}
+
+// fatalTagsIf exits on a failure to fetch tags of a bucket or object
+func fatalTagsIf(err *probe.Error, msg tagListMessage) {
+	if err == nil {
+		return
+	}
+	targetName := msg.URL
+	if msg.VersionID != "" {
+		targetName += " (" + msg.VersionID + ")"
+	}
+	if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchTagSet" {
+		fatalIf(probe.NewError(errors.New("check 'mc tag set --help' on how to set tags")), "No tags found  for "+targetName)
+	}
+	fatalIf(err, "Unable to fetch tags for "+targetName)
+}
+
+// showTags pretty prints tags of a bucket or a specified object/version
+func showTags(ctx context.Context, clnt Client, versionID string) {
+	msg, err := getTags(ctx, clnt, versionID)
+	fatalTagsIf(err, msg)
+	printMsg(msg)
 }
 
 func showTagsSingle(ctx context.Context, alias, url, versionID string) *probe.Error {
@@ -224,7 +236,27 @@ func mainListTag(cliCtx *cli.Context) error {
 		return nil
 	}
 
-	for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions, Recursive: recursive}) {
+	workers, err := getStatConcurrency()
+	fatalIf(err, "Unable to initialize tag listing")
+
+	// Fetch tags concurrently, a job without a result either was
+	// skipped or failed to initialize its client.
+	lookup := func(ctx context.Context, content *ClientContent) (interface{}, *probe.Error) {
+		if content.IsDeleteMarker {
+			return nil, nil
+		}
+		if !recursive && getStandardizedURL(alias+getKey(content)) != getStandardizedURL(targetURL) {
+			return nil, nil
+		}
+		newClnt, err := newClientFromAlias(alias, content.URL.String())
+		if err != nil {
+			return nil, err
+		}
+		return getTags(ctx, newClnt, content.VersionID)
+	}
+
+	for job := range newStatPipeline(workers).Run(ctx, clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions, Recursive: recursive}), lookup) {
+		content := job.content
 		if content.Err != nil {
 			fatalIf(content.Err.Trace(), "Unable to list target "+targetURL)
 			continue
@@ -239,11 +271,13 @@ func mainListTag(cliCtx *cli.Context) error {
 			break
 		}
 
-		err := showTagsSingle(ctx, alias, content.URL.String(), content.VersionID)
-		if err != nil {
-			errorIf(err.Trace(clnt.GetURL().String()), "Invalid URL")
+		if job.result == nil {
+			errorIf(job.err.Trace(clnt.GetURL().String()), "Invalid URL")
 			continue
 		}
+		msg := job.result.(tagListMessage)
+		fatalTagsIf(job.err, msg)
+		printMsg(msg)
 	}
 
 	return nil
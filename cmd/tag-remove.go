@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,7 +27,9 @@ import (
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/wildcard"
 )
 
 var tagRemoveFlags = []cli.Flag{
@@ -46,6 +49,11 @@ var tagRemoveFlags = []cli.Flag{
 		Name:  "recursive, r",
 		Usage: "recursivley remove tags for all objects",
 	},
+	cli.StringSliceFlag{
+		Name:  "key",
+		Usage: "only remove the tags whose key matches the wildcard pattern, can be repeated",
+	},
+	tagParallelFlag,
 }
 
 var tagRemoveCmd = cli.Command{
@@ -85,20 +93,41 @@ EXAMPLES:
 
   6. Remove the tags recursively for all versions of all objects of subdirs of bucket.
      {{.Prompt}} {{.HelpName}} --recursive --versions myminio/testbucket
+
+  7. Remove only the tags with a key starting with "temp-" from all the objects of a bucket, keeping the other tags.
+     {{.Prompt}} {{.HelpName}} --recursive --key "temp-*" myminio/testbucket
+
+  8. Remove the "owner" tag and the tags with a key starting with "tmp" from all versions of all objects, 16 objects at a time.
+     {{.Prompt}} {{.HelpName}} --recursive --versions --key owner --key "tmp*" --parallel 16 myminio/testbucket
 `,
 }
 
 // tagSetTagMessage structure will show message depending on the type of console.
 type tagRemoveMessage struct {
-	Status    string `json:"status"`
-	Name      string `json:"name"`
-	VersionID string `json:"versionID"`
+	Status    string   `json:"status"`
+	Name      string   `json:"name"`
+	VersionID string   `json:"versionID"`
+	Keys      []string `json:"keys,omitempty"`
+	DryRun    bool     `json:"dryRun,omitempty"`
+
+	byKey bool
 }
 
 // tagRemoveMessage console colorized output.
 func (t tagRemoveMessage) String() string {
 	var msg string
-	msg += "Tags removed for " + t.Name
+	switch {
+	case t.byKey && len(t.Keys) == 0:
+		msg += "No matching tags found for " + t.Name
+	case t.byKey && t.DryRun:
+		msg += "Would remove tags `" + strings.Join(t.Keys, "`, `") + "` for " + t.Name
+	case t.byKey:
+		msg += "Tags `" + strings.Join(t.Keys, "`, `") + "` removed for " + t.Name
+	case t.DryRun:
+		msg += "Would remove tags for " + t.Name
+	default:
+		msg += "Tags removed for " + t.Name
+	}
 	if strings.TrimSpace(t.VersionID) != "" {
 		msg += " (" + t.VersionID + ")"
 	}
@@ -113,7 +142,7 @@ func (t tagRemoveMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func parseRemoveTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef time.Time, withVersions, recursive bool) {
+func parseRemoveTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef time.Time, withVersions, recursive bool, keys []string) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, globalErrorExitStatus)
 	}
@@ -123,43 +152,98 @@ func parseRemoveTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRe
 	withVersions = ctx.Bool("versions")
 	rewind := ctx.String("rewind")
 	recursive = ctx.Bool("recursive")
+	keys = ctx.StringSlice("key")
 
 	if versionID != "" && (rewind != "" || withVersions) {
 		fatalIf(errDummy().Trace(), "You cannot specify both --version-id and --rewind or --versions flags at the same time")
 	}
 
+	for _, key := range keys {
+		if key == "" {
+			fatalIf(errInvalidArgument().Trace(keys...), "--key cannot be empty")
+		}
+	}
+
 	timeRef = parseRewindFlag(rewind)
 	return
 }
 
-// Delete tags of a bucket or a specified object/version
-func deleteTags(ctx context.Context, clnt Client, versionID string) {
-	targetName := clnt.GetURL().String()
-	if versionID != "" {
-		targetName += " (" + versionID + ")"
+// removeTagKeys returns the tags whose key does not match any of the
+// wildcard patterns along with the sorted keys of the removed tags.
+func removeTagKeys(tagMap map[string]string, patterns []string) (remaining map[string]string, removed []string) {
+	remaining = make(map[string]string, len(tagMap))
+	for key, value := range tagMap {
+		matched := false
+		for _, pattern := range patterns {
+			if wildcard.Match(pattern, key) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			removed = append(removed, key)
+			continue
+		}
+		remaining[key] = value
 	}
+	sort.Strings(removed)
+	return remaining, removed
+}
 
-	err := clnt.DeleteTags(ctx, versionID)
+// Delete tags of a bucket or a specified object/version, only the tags
+// matching the key patterns are removed if any is specified.
+func deleteTagsSingle(ctx context.Context, alias, url, versionID string, keys []string) (message, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, url)
 	if err != nil {
-		fatalIf(err, "Unable to remove tags for "+targetName)
-		return
+		return nil, err
 	}
 
-	printMsg(tagRemoveMessage{
+	msg := tagRemoveMessage{
 		Status:    "success",
 		Name:      clnt.GetURL().String(),
 		VersionID: versionID,
-	})
-}
+		DryRun:    globalDryRun,
+		byKey:     len(keys) > 0,
+	}
+	if len(keys) == 0 {
+		if globalDryRun {
+			return msg, nil
+		}
+		if err = clnt.DeleteTags(ctx, versionID); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
 
-func deleteTagsSingle(ctx context.Context, alias, url, versionID string) *probe.Error {
-	newClnt, err := newClientFromAlias(alias, url)
+	tagMap, err := clnt.GetTags(ctx, versionID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	deleteTags(ctx, newClnt, versionID)
-	return nil
+	remaining, removed := removeTagKeys(tagMap, keys)
+	if len(removed) == 0 {
+		return msg, nil
+	}
+	msg.Keys = removed
+
+	if globalDryRun {
+		return msg, nil
+	}
+	if len(remaining) == 0 {
+		err = clnt.DeleteTags(ctx, versionID)
+	} else {
+		// Tags read from the server are already valid, use the
+		// larger bucket limits to only build the tag string.
+		t, e := tags.NewTags(remaining, false)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		err = clnt.SetTags(ctx, versionID, t.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
 }
 
 func mainRemoveTag(cliCtx *cli.Context) error {
@@ -168,7 +252,7 @@ func mainRemoveTag(cliCtx *cli.Context) error {
 
 	console.SetColor("Remove", color.New(color.FgGreen))
 
-	targetURL, versionID, timeRef, withVersions, recursive := parseRemoveTagSyntax(cliCtx)
+	targetURL, versionID, timeRef, withVersions, recursive, keys := parseRemoveTagSyntax(cliCtx)
 	if timeRef.IsZero() && withVersions {
 		timeRef = time.Now().UTC()
 	}
@@ -178,29 +262,42 @@ func mainRemoveTag(cliCtx *cli.Context) error {
 
 	alias, urlStr, _ := mustExpandAlias(targetURL)
 	if timeRef.IsZero() && !withVersions && !recursive {
-		err := deleteTagsSingle(ctx, alias, urlStr, versionID)
+		msg, err := deleteTagsSingle(ctx, alias, urlStr, versionID, keys)
 		fatalIf(err.Trace(), "Unable to remove tags on `%s`", targetURL)
+		printMsg(msg)
 		return nil
 	}
-	for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions, Recursive: recursive}) {
-		if content.Err != nil {
-			fatalIf(content.Err.Trace(), "Unable to list target "+targetURL)
-		}
-
-		// Skip if its delete marker
-		if content.IsDeleteMarker {
-			continue
-		}
 
-		if !recursive && getStandardizedURL(alias+getKey(content)) != getStandardizedURL(targetURL) {
-			break
+	targetsCh := make(chan tagTarget)
+	go func() {
+		defer close(targetsCh)
+		for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions, Recursive: recursive}) {
+			if content.Err != nil {
+				fatalIf(content.Err.Trace(), "Unable to list target "+targetURL)
+			}
+
+			// Skip if its delete marker
+			if content.IsDeleteMarker {
+				continue
+			}
+
+			if !recursive && getStandardizedURL(alias+getKey(content)) != getStandardizedURL(targetURL) {
+				break
+			}
+
+			select {
+			case targetsCh <- tagTarget{url: content.URL.String(), versionID: content.VersionID}:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
 
-		err := deleteTagsSingle(ctx, alias, content.URL.String(), content.VersionID)
-		if err != nil {
-			errorIf(err.Trace(clnt.GetURL().String()), "Invalid URL")
-			continue
-		}
+	failed := processTagTargets(ctx, cliCtx.Int("parallel"), "Removing tags:", targetsCh, func(ctx context.Context, target tagTarget) (message, *probe.Error) {
+		return deleteTagsSingle(ctx, alias, target.url, target.versionID, keys)
+	})
+	if failed > 0 {
+		return exitStatus(globalErrorExitStatus)
 	}
 	return nil
 }
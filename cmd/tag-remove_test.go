@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRemoveTagKeys(t *testing.T) {
+	testCases := []struct {
+		tags      map[string]string
+		patterns  []string
+		remaining map[string]string
+		removed   []string
+	}{
+		{
+			tags:      map[string]string{"temp-a": "1", "temp-b": "2", "owner": "me"},
+			patterns:  []string{"temp-*"},
+			remaining: map[string]string{"owner": "me"},
+			removed:   []string{"temp-a", "temp-b"},
+		},
+		{
+			tags:      map[string]string{"temp-a": "1", "owner": "me", "project": "x"},
+			patterns:  []string{"owner", "temp-?"},
+			remaining: map[string]string{"project": "x"},
+			removed:   []string{"owner", "temp-a"},
+		},
+		{
+			tags:      map[string]string{"owner": "me"},
+			patterns:  []string{"temp-*"},
+			remaining: map[string]string{"owner": "me"},
+			removed:   nil,
+		},
+		{
+			tags:      map[string]string{"a": "1", "b": "2"},
+			patterns:  []string{"*"},
+			remaining: map[string]string{},
+			removed:   []string{"a", "b"},
+		},
+		{
+			tags:      nil,
+			patterns:  []string{"*"},
+			remaining: map[string]string{},
+			removed:   nil,
+		},
+	}
+
+	for i, testCase := range testCases {
+		remaining, removed := removeTagKeys(testCase.tags, testCase.patterns)
+		if !reflect.DeepEqual(remaining, testCase.remaining) {
+			t.Errorf("Test %d: expected remaining %v, got %v", i+1, testCase.remaining, remaining)
+		}
+		if !reflect.DeepEqual(removed, testCase.removed) {
+			t.Errorf("Test %d: expected removed %v, got %v", i+1, testCase.removed, removed)
+		}
+	}
+}
@@ -50,6 +50,7 @@ var tagSetFlags = []cli.Flag{
 		Name:  "exclude-folders",
 		Usage: "exclude setting tags on folder objects",
 	},
+	tagParallelFlag,
 }
 
 var tagSetCmd = cli.Command{
@@ -91,6 +92,9 @@ EXAMPLES:
 
   7. Assign tags to all the objects on a bucket, excluding folders
      {{.Prompt}} {{.HelpName}} myminio/testbucket --exclude-folders --recursive "key1=value1&key2=value2&key3=value3"
+
+  8. Assign tags recursively to all versions of all objects of a bucket, 16 objects at a time.
+     {{.Prompt}} {{.HelpName}} myminio/testbucket --recursive --versions --parallel 16 "key1=value1"
 `,
 }
 
@@ -99,12 +103,17 @@ type tagSetMessage struct {
 	Status    string `json:"status"`
 	Name      string `json:"name"`
 	VersionID string `json:"versionID"`
+	DryRun    bool   `json:"dryRun,omitempty"`
 }
 
 // tagSetMessage console colorized output.
 func (t tagSetMessage) String() string {
 	var msg string
-	msg += "Tags set for " + t.Name
+	if t.DryRun {
+		msg += "Would set tags for " + t.Name
+	} else {
+		msg += "Tags set for " + t.Name
+	}
 	if t.VersionID != "" {
 		msg += " (" + t.VersionID + ")"
 	}
@@ -145,32 +154,24 @@ func parseSetTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef t
 }
 
 // Set tags to a bucket or to a specified object/version
-func setTags(ctx context.Context, clnt Client, versionID, tags string) {
-	targetName := clnt.GetURL().String()
-	if versionID != "" {
-		targetName += " (" + versionID + ")"
+func setTagsSingle(ctx context.Context, alias, url, versionID, tags string) (message, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, url)
+	if err != nil {
+		return nil, err
 	}
 
-	err := clnt.SetTags(ctx, versionID, tags)
-	if err != nil {
-		fatalIf(err.Trace(tags), "Failed to set tags for "+targetName)
-		return
+	if !globalDryRun {
+		if err = clnt.SetTags(ctx, versionID, tags); err != nil {
+			return nil, err.Trace(tags)
+		}
 	}
-	printMsg(tagSetMessage{
+
+	return tagSetMessage{
 		Status:    "success",
 		Name:      clnt.GetURL().String(),
 		VersionID: versionID,
-	})
-}
-
-func setTagsSingle(ctx context.Context, alias, url, versionID, tags string) *probe.Error {
-	newClnt, err := newClientFromAlias(alias, url)
-	if err != nil {
-		return err
-	}
-
-	setTags(ctx, newClnt, versionID, tags)
-	return nil
+		DryRun:    globalDryRun,
+	}, nil
 }
 
 func mainSetTag(cliCtx *cli.Context) error {
@@ -189,37 +190,49 @@ func mainSetTag(cliCtx *cli.Context) error {
 
 	alias, urlStr, _ := mustExpandAlias(targetURL)
 	if timeRef.IsZero() && !withVersions && !recursive && !excludeFolders {
-		err := setTagsSingle(ctx, alias, urlStr, versionID, tags)
+		msg, err := setTagsSingle(ctx, alias, urlStr, versionID, tags)
 		fatalIf(err.Trace(), "Unable to set tags on `%s`", targetURL)
+		printMsg(msg)
 		return nil
 	}
-	for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions, Recursive: recursive}) {
-		if content.Err != nil {
-			fatalIf(content.Err.Trace(), "Unable to list target "+targetURL)
-			continue
-		}
-
-		// Dont set tag for the delete marker
-		if content.IsDeleteMarker {
-			continue
-		}
 
-		// if excludeFolders dont set tags for subdirs
-		_, objName := url2BucketAndObject(&content.URL)
-		if strings.Index(objName, string(content.URL.Separator)) > 0 && excludeFolders {
-			continue
+	targetsCh := make(chan tagTarget)
+	go func() {
+		defer close(targetsCh)
+		for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions, Recursive: recursive}) {
+			if content.Err != nil {
+				fatalIf(content.Err.Trace(), "Unable to list target "+targetURL)
+				continue
+			}
+
+			// Dont set tag for the delete marker
+			if content.IsDeleteMarker {
+				continue
+			}
+
+			// if excludeFolders dont set tags for subdirs
+			_, objName := url2BucketAndObject(&content.URL)
+			if strings.Index(objName, string(content.URL.Separator)) > 0 && excludeFolders {
+				continue
+			}
+
+			if !recursive && getStandardizedURL(alias+getKey(content)) != getStandardizedURL(targetURL) {
+				break
+			}
+
+			select {
+			case targetsCh <- tagTarget{url: content.URL.String(), versionID: content.VersionID}:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
 
-		if !recursive && getStandardizedURL(alias+getKey(content)) != getStandardizedURL(targetURL) {
-			break
-		}
-
-		err := setTagsSingle(ctx, alias, content.URL.String(), content.VersionID, tags)
-		if err != nil {
-			errorIf(err.Trace(clnt.GetURL().String()), "Invalid URL")
-			continue
-		}
+	failed := processTagTargets(ctx, cliCtx.Int("parallel"), "Setting tags:", targetsCh, func(ctx context.Context, target tagTarget) (message, *probe.Error) {
+		return setTagsSingle(ctx, alias, target.url, target.versionID, tags)
+	})
+	if failed > 0 {
+		return exitStatus(globalErrorExitStatus)
 	}
-
 	return nil
 }
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// themeConfig is the format of theme.json in the mc config folder, it
+// overrides the color of tags such as "Copy", "Error" or "Size" with a list
+// of attributes, e.g. {"version": "1", "colors": {"Copy": "blue,bold"}}.
+type themeConfig struct {
+	Version string            `json:"version"`
+	Colors  map[string]string `json:"colors"`
+}
+
+const themeConfigVersion = "1"
+
+// themeAttributes maps the names accepted in theme.json to colors and styles.
+var themeAttributes = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+	"blink":     color.BlinkSlow,
+	"reverse":   color.ReverseVideo,
+
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+
+	"hi-black":   color.FgHiBlack,
+	"hi-red":     color.FgHiRed,
+	"hi-green":   color.FgHiGreen,
+	"hi-yellow":  color.FgHiYellow,
+	"hi-blue":    color.FgHiBlue,
+	"hi-magenta": color.FgHiMagenta,
+	"hi-cyan":    color.FgHiCyan,
+	"hi-white":   color.FgHiWhite,
+
+	"bg-black":   color.BgBlack,
+	"bg-red":     color.BgRed,
+	"bg-green":   color.BgGreen,
+	"bg-yellow":  color.BgYellow,
+	"bg-blue":    color.BgBlue,
+	"bg-magenta": color.BgMagenta,
+	"bg-cyan":    color.BgCyan,
+	"bg-white":   color.BgWhite,
+}
+
+func getThemeFile() string {
+	return filepath.Join(mustGetMcConfigDir(), "theme.json")
+}
+
+// parseThemeColor parses a comma or space separated list of attributes,
+// "none" prints the tag without any color.
+func parseThemeColor(value string) (*color.Color, *probe.Error) {
+	c := color.New()
+	for _, name := range strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		if name == "none" {
+			continue
+		}
+		attr, ok := themeAttributes[name]
+		if !ok {
+			return nil, errInvalidArgument().Trace(value, name)
+		}
+		c.Add(attr)
+	}
+	return c, nil
+}
+
+// loadTheme reads the color overrides of a theme file, a missing file
+// has no overrides.
+func loadTheme(filename string) (map[string]*color.Color, *probe.Error) {
+	data, e := os.ReadFile(filename)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil, nil
+		}
+		return nil, probe.NewError(e)
+	}
+	var cfg themeConfig
+	if e = json.Unmarshal(data, &cfg); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if cfg.Version != "" && cfg.Version != themeConfigVersion {
+		return nil, errInvalidArgument().Trace("version", cfg.Version)
+	}
+	colors := make(map[string]*color.Color, len(cfg.Colors))
+	for tag, value := range cfg.Colors {
+		c, err := parseThemeColor(value)
+		if err != nil {
+			return nil, err.Trace(tag)
+		}
+		colors[tag] = c
+	}
+	return colors, nil
+}
+
+// applyTheme makes the colors of the theme take precedence over the ones
+// set by the commands with console.SetColor.
+func applyTheme(colors map[string]*color.Color) {
+	if len(colors) == 0 {
+		return
+	}
+	for tag, c := range colors {
+		console.SetColor(tag, c)
+	}
+	colorize := console.Colorize
+	console.Colorize = func(tag string, data interface{}) string {
+		if c, ok := colors[tag]; ok {
+			console.SetColor(tag, c)
+		}
+		return colorize(tag, data)
+	}
+}
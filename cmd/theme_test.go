@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestParseThemeColor(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    *color.Color
+		wantErr bool
+	}{
+		{"red", color.New(color.FgRed), false},
+		{"Blue,Bold", color.New(color.FgBlue, color.Bold), false},
+		{"hi-yellow bg-black underline", color.New(color.FgHiYellow, color.BgBlack, color.Underline), false},
+		{"none", color.New(), false},
+		{"", color.New(), false},
+		{"purple", nil, true},
+	}
+	for i, testCase := range testCases {
+		got, err := parseThemeColor(testCase.value)
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if err == nil && !got.Equals(testCase.want) {
+			t.Errorf("Test %d: unexpected color for %q", i+1, testCase.value)
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	colors, err := loadTheme(filepath.Join(dir, "missing.json"))
+	if err != nil || colors != nil {
+		t.Fatalf("expected no theme, got %v %v", colors, err)
+	}
+
+	testCases := []struct {
+		content string
+		tags    int
+		wantErr bool
+	}{
+		{`{"version": "1", "colors": {"Copy": "blue,bold", "Error": "red"}}`, 2, false},
+		{`{"colors": {"Size": "none"}}`, 1, false},
+		{`{"version": "2", "colors": {"Copy": "blue"}}`, 0, true},
+		{`{"version": "1", "colors": {"Copy": "sparkly"}}`, 0, true},
+		{`{"version": "1"`, 0, true},
+	}
+	for i, testCase := range testCases {
+		filename := filepath.Join(dir, "theme.json")
+		if e := os.WriteFile(filename, []byte(testCase.content), 0o600); e != nil {
+			t.Fatal(e)
+		}
+		colors, err := loadTheme(filename)
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if len(colors) != testCase.tags {
+			t.Fatalf("Test %d: expected %d colors, got %d", i+1, testCase.tags, len(colors))
+		}
+	}
+}
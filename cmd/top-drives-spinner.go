@@ -51,7 +51,7 @@ type topDriveResult struct {
 	stats    madmin.DiskIOStats
 }
 
-func initTopDriveUI(disks []madmin.Disk, count int) *topDriveUI {
+func initTopDriveUI(disks []madmin.Disk, count int, sortBy drivesSorter) *topDriveUI {
 	maxPool := 0
 	drivesInfo := make(map[string]madmin.Disk)
 	for i := range disks {
@@ -66,7 +66,7 @@ func initTopDriveUI(disks []madmin.Disk, count int) *topDriveUI {
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 	return &topDriveUI{
 		count:      count,
-		sortBy:     sortByName,
+		sortBy:     sortBy,
 		pool:       0,
 		maxPool:    maxPool,
 		drivesInfo: drivesInfo,
@@ -201,6 +201,16 @@ func (s drivesSorter) String() string {
 	return "unknown"
 }
 
+// parseDrivesSorter returns the drives sorter with the given name.
+func parseDrivesSorter(name string) (drivesSorter, bool) {
+	for s := sortByName; s <= sortByTps; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return sortByName, false
+}
+
 func sortDriveIOStat(sortBy drivesSorter, asc bool, data []driveIOStat) {
 	sort.SliceStable(data, func(i, j int) bool {
 		c := 0
@@ -68,6 +68,7 @@ func (m *traceStatsUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			for k, si := range m.current.Calls {
 				si.MaxDur, si.MinDur = 0, 0
 				si.MaxTTFB = 0
+				si.durations = nil
 				m.current.Calls[k] = si
 			}
 			return m, nil
@@ -112,6 +113,7 @@ func (m *traceStatsUI) View() string {
 	table.SetTablePadding("  ") // pad with tabs
 	table.SetNoWhiteSpace(true)
 	var entries []statItem
+	latencies := make(map[string][]time.Duration, len(m.current.Calls))
 
 	m.current.mu.Lock()
 	var (
@@ -123,6 +125,7 @@ func (m *traceStatsUI) View() string {
 		totalCnt += v.Count
 		totalRX += v.CallStats.Rx
 		totalTX += v.CallStats.Tx
+		latencies[v.Name] = v.percentiles(50, 99)
 		entries = append(entries, v)
 	}
 	m.current.mu.Unlock()
@@ -186,6 +189,8 @@ func (m *traceStatsUI) View() string {
 		console.Colorize("metrics-top-title", "Avg Time"),
 		console.Colorize("metrics-top-title", "Min Time"),
 		console.Colorize("metrics-top-title", "Max Time"),
+		console.Colorize("metrics-top-title", "P50 Time"),
+		console.Colorize("metrics-top-title", "P99 Time"),
 	}
 	if hasTTFB {
 		t = append(t,
@@ -207,7 +212,8 @@ func (m *traceStatsUI) View() string {
 		}
 		errs := "0"
 		if v.Errors > 0 {
-			errs = console.Colorize("metrics-error", strconv.Itoa(v.Errors))
+			errs = console.Colorize("metrics-error", strconv.Itoa(v.Errors)) +
+				console.Colorize("metrics-number-secondary", fmt.Sprintf(" (%0.1f%%)", float64(v.Errors)/float64(v.Count)*100))
 		}
 		avg := v.Duration / time.Duration(v.Count)
 		avgTTFB := v.TTFB / time.Duration(v.Count)
@@ -273,6 +279,8 @@ func (m *traceStatsUI) View() string {
 			console.Colorize(avgColor, fmt.Sprintf("%v", roundDur(avg))),
 			console.Colorize(minColor, roundDur(v.MinDur)),
 			console.Colorize(maxColor, roundDur(v.MaxDur)),
+			console.Colorize(durColor(latencies[v.Name][0]), roundDur(latencies[v.Name][0])),
+			console.Colorize(durColor(latencies[v.Name][1]), roundDur(latencies[v.Name][1])),
 		}
 		if hasTTFB {
 			if v.TTFB > 0 {
@@ -302,6 +310,17 @@ func (m *traceStatsUI) View() string {
 	return strings.Join(split, "\n")
 }
 
+// durColor returns the color used to display a call duration.
+func durColor(d time.Duration) string {
+	switch {
+	case d > 10*time.Second:
+		return "metrics-dur-high"
+	case d > 2*time.Second:
+		return "metrics-dur-med"
+	}
+	return "metrics-dur"
+}
+
 // ibytesShort returns a short un-padded version of the value from humanize.IBytes.
 func ibytesShort(v uint64) string {
 	return strings.ReplaceAll(strings.TrimSuffix(humanize.IBytes(v), "iB"), " ", "")
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/env"
+)
+
+// updateCheckConfig is the format of update.json in the mc config folder,
+// it turns off the update notice or changes how often mc looks for a new
+// release, e.g. {"version": "1", "check": "off"}.
+type updateCheckConfig struct {
+	Version      string `json:"version"`
+	Check        string `json:"check"`
+	IntervalDays int    `json:"intervalDays"`
+}
+
+// updateCheckState caches the latest release found by the last check.
+type updateCheckState struct {
+	CheckedAt     time.Time `json:"checkedAt"`
+	LatestRelease string    `json:"latestRelease"`
+	NotifiedAt    time.Time `json:"notifiedAt"`
+}
+
+const (
+	updateCheckConfigVersion   = "1"
+	defaultUpdateCheckInterval = 7 * 24 * time.Hour
+
+	envUpdateCheckInterval = "MC_UPDATE_CHECK_INTERVAL"
+)
+
+// updateCheckDone is closed once the update check started by notifyUpdate
+// is over.
+var updateCheckDone chan struct{}
+
+func getUpdateCheckConfigFile() string {
+	return filepath.Join(mustGetMcConfigDir(), "update.json")
+}
+
+func getUpdateCheckStateFile() string {
+	return filepath.Join(mustGetMcConfigDir(), "update-state.json")
+}
+
+// loadUpdateCheckConfig reads the update check settings, a missing file
+// keeps the defaults.
+func loadUpdateCheckConfig(filename string) (cfg updateCheckConfig, err *probe.Error) {
+	data, e := os.ReadFile(filename)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return cfg, nil
+		}
+		return cfg, probe.NewError(e)
+	}
+	if e = json.Unmarshal(data, &cfg); e != nil {
+		return cfg, probe.NewError(e)
+	}
+	if cfg.Version != "" && cfg.Version != updateCheckConfigVersion {
+		return cfg, errInvalidArgument().Trace("version", cfg.Version)
+	}
+	return cfg, nil
+}
+
+// isUpdateCheckEnabled returns false if the update check is turned off
+// with MC_UPDATE, MINIO_UPDATE or update.json.
+func isUpdateCheckEnabled(cfg updateCheckConfig) bool {
+	if env.Get("MC_UPDATE", madmin.EnableOn) != madmin.EnableOn || env.Get("MINIO_UPDATE", madmin.EnableOn) != madmin.EnableOn {
+		return false
+	}
+	return cfg.Check == "" || cfg.Check == madmin.EnableOn
+}
+
+// getUpdateCheckInterval returns how often to look for a new release,
+// MC_UPDATE_CHECK_INTERVAL takes precedence over update.json.
+func getUpdateCheckInterval(cfg updateCheckConfig) time.Duration {
+	days := cfg.IntervalDays
+	if v := env.Get(envUpdateCheckInterval, ""); v != "" {
+		if n, e := strconv.Atoi(v); e == nil {
+			days = n
+		}
+	}
+	if days <= 0 {
+		return defaultUpdateCheckInterval
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func loadUpdateCheckState(filename string) (state updateCheckState) {
+	data, e := os.ReadFile(filename)
+	if e == nil {
+		// A corrupted state is checked again.
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+func saveUpdateCheckState(filename string, state updateCheckState) error {
+	data, e := json.Marshal(state)
+	if e != nil {
+		return e
+	}
+	tmpFile := filename + ".tmp"
+	if e = os.WriteFile(tmpFile, data, 0o600); e != nil {
+		return e
+	}
+	return os.Rename(tmpFile, filename)
+}
+
+// isNewerRelease returns true if releaseTag is newer than the running release.
+func isNewerRelease(releaseTag string) bool {
+	releaseTime, err := releaseTagToReleaseTime(releaseTag)
+	if err != nil {
+		return false
+	}
+	currentReleaseTime, err := GetCurrentReleaseTime()
+	if err != nil {
+		return false
+	}
+	return releaseTime.After(currentReleaseTime)
+}
+
+// updateNotice returns the notice to print for a cached state, at most
+// once per interval, and whether the release info is due for a check.
+func updateNotice(state updateCheckState, interval time.Duration, now time.Time) (notice string, checkDue bool) {
+	checkDue = now.Sub(state.CheckedAt) >= interval
+	if state.LatestRelease == "" || now.Sub(state.NotifiedAt) < interval {
+		return "", checkDue
+	}
+	if !isNewerRelease(state.LatestRelease) {
+		return "", checkDue
+	}
+	return fmt.Sprintf("mc: a newer release %s is available, run `mc update` to update.", state.LatestRelease), checkDue
+}
+
+// notifyUpdate prints a one-line notice on stderr when the last update
+// check found a newer release, and refreshes the cached release info once
+// per interval while the command runs.
+func notifyUpdate(ctx *cli.Context) {
+	if ctx.Command.Name == "" || ctx.Command.Name == "update" || len(ctx.Command.Subcommands) > 0 {
+		return
+	}
+	if ctx.Bool("quiet-update") || ctx.GlobalBool("quiet-update") || globalQuiet || globalJSON || globalAirgapped {
+		return
+	}
+	if !strings.HasPrefix(ReleaseTag, "RELEASE.") || !isatty.IsTerminal(os.Stderr.Fd()) {
+		return
+	}
+	cfg, err := loadUpdateCheckConfig(getUpdateCheckConfigFile())
+	if err != nil || !isUpdateCheckEnabled(cfg) {
+		return
+	}
+
+	stateFile := getUpdateCheckStateFile()
+	state := loadUpdateCheckState(stateFile)
+	now := time.Now().UTC()
+	notice, checkDue := updateNotice(state, getUpdateCheckInterval(cfg), now)
+	if notice != "" {
+		fmt.Fprintln(os.Stderr, colorYellowBold("%s", notice))
+		state.NotifiedAt = now
+		saveUpdateCheckState(stateFile, state)
+	}
+	if !checkDue {
+		return
+	}
+
+	updateCheckDone = make(chan struct{})
+	go func() {
+		defer close(updateCheckDone)
+		channelURL, _ := getChannelURL(mcChannelStable)
+		// A failed check is not retried before the next interval either,
+		// hosts without Internet access would wait on every command.
+		if _, _, releaseTag, err := getLatestReleaseTime(releaseInfoURL(channelURL), 2*time.Second); err == nil {
+			state.LatestRelease = releaseTag
+		}
+		state.CheckedAt = time.Now().UTC()
+		saveUpdateCheckState(stateFile, state)
+	}()
+}
+
+// waitUpdateCheck waits for the update check started by notifyUpdate, if
+// any, to save its result.
+func waitUpdateCheck() {
+	if updateCheckDone != nil {
+		<-updateCheckDone
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadUpdateCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := loadUpdateCheckConfig(filepath.Join(dir, "missing.json"))
+	if err != nil || !isUpdateCheckEnabled(cfg) {
+		t.Fatalf("expected the default settings, got %v %v", cfg, err)
+	}
+
+	testCases := []struct {
+		content  string
+		enabled  bool
+		interval time.Duration
+		wantErr  bool
+	}{
+		{`{"version": "1", "check": "off"}`, false, defaultUpdateCheckInterval, false},
+		{`{"check": "on", "intervalDays": 1}`, true, 24 * time.Hour, false},
+		{`{"intervalDays": -3}`, true, defaultUpdateCheckInterval, false},
+		{`{"version": "2"}`, false, 0, true},
+		{`{`, false, 0, true},
+	}
+	for i, testCase := range testCases {
+		filename := filepath.Join(dir, "update.json")
+		if e := os.WriteFile(filename, []byte(testCase.content), 0o600); e != nil {
+			t.Fatal(e)
+		}
+		cfg, err := loadUpdateCheckConfig(filename)
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if enabled := isUpdateCheckEnabled(cfg); enabled != testCase.enabled {
+			t.Errorf("Test %d: expected enabled %v, got %v", i+1, testCase.enabled, enabled)
+		}
+		if interval := getUpdateCheckInterval(cfg); interval != testCase.interval {
+			t.Errorf("Test %d: expected interval %v, got %v", i+1, testCase.interval, interval)
+		}
+	}
+}
+
+func TestUpdateNotice(t *testing.T) {
+	now := time.Now().UTC()
+	interval := 24 * time.Hour
+	testCases := []struct {
+		state    updateCheckState
+		notice   bool
+		checkDue bool
+	}{
+		// Never checked.
+		{updateCheckState{}, false, true},
+		// Newer release, not notified yet.
+		{updateCheckState{CheckedAt: now, LatestRelease: "RELEASE.2099-01-01T00-00-00Z"}, true, false},
+		// Newer release, already notified.
+		{updateCheckState{CheckedAt: now, LatestRelease: "RELEASE.2099-01-01T00-00-00Z", NotifiedAt: now.Add(-time.Hour)}, false, false},
+		// Older release, due for a check.
+		{updateCheckState{CheckedAt: now.Add(-2 * interval), LatestRelease: "RELEASE.2000-01-01T00-00-00Z"}, false, true},
+		{updateCheckState{CheckedAt: now, LatestRelease: "invalid"}, false, false},
+	}
+	for i, testCase := range testCases {
+		notice, checkDue := updateNotice(testCase.state, interval, now)
+		if (notice != "") != testCase.notice {
+			t.Errorf("Test %d: expected a notice %v, got %q", i+1, testCase.notice, notice)
+		}
+		if checkDue != testCase.checkDue {
+			t.Errorf("Test %d: expected check due %v, got %v", i+1, testCase.checkDue, checkDue)
+		}
+	}
+}
@@ -30,7 +30,9 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -56,6 +58,36 @@ var updateCmd = cli.Command{
 			Name:  "json",
 			Usage: "enable JSON lines formatted output",
 		},
+		cli.BoolFlag{
+			Name:  "check",
+			Usage: "only report whether a newer release is available, without installing it",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only check for a newer release, same as --check",
+		},
+		cli.StringFlag{
+			Name:   "minisign-pubkey",
+			Usage:  "minisign public key to verify the release signature with, defaults to the key of the official releases",
+			EnvVar: envMinisignPubKey,
+		},
+		cli.StringFlag{
+			Name:  "channel",
+			Value: mcChannelStable,
+			Usage: "release channel to update from, 'stable' or 'experimental'",
+		},
+		cli.StringFlag{
+			Name:  "version",
+			Usage: "install this release instead of the latest one, e.g. to roll back to an older release",
+		},
+		cli.BoolFlag{
+			Name:  "list",
+			Usage: "list the releases available in the release channel",
+		},
+		cli.StringFlag{
+			Name:  "offline",
+			Usage: "update from a local mc binary, along with its .sha256sum and .minisig files, without Internet access",
+		},
 	},
 	CustomHelpTemplate: `Name:
    {{.HelpName}} - {{.Usage}}
@@ -66,28 +98,175 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  MC_UPDATE_URL:             URL of a mirror of https://dl.min.io/client/mc/ to update from
+  MC_UPDATE_MINISIGN_PUBKEY: minisign public key to verify the releases with
+
+NOTE:
+  Every release is verified against its SHA-256 checksum and its minisign signature before it
+  replaces the running binary, with the public key of the official releases unless
+  --minisign-pubkey is set, e.g. for a mirror of privately built releases.
+
 EXIT STATUS:
-  0 - you are already running the most recent version
+  0 - you are already running the most recent version, or no update was applied with --check
   1 - new update was applied successfully
- -1 - error in getting update information
+ -1 - error in getting or applying the update
 
 EXAMPLES:
-  1. Check and update mc:
+  1. Update mc in place, the downloaded binary is checked against its SHA-256 checksum and signature:
      {{.Prompt}} {{.HelpName}}
+
+  2. Check for a newer mc release without installing it:
+     {{.Prompt}} {{.HelpName}} --check
+
+  3. Update mc in place from releases signed with another minisign key:
+     {{.Prompt}} {{.HelpName}} --minisign-pubkey "$(tail -n 1 minisign.pub)" https://mirror.example.com/mc.sha256sum
+
+  4. List the available releases:
+     {{.Prompt}} {{.HelpName}} --list
+
+  5. Roll back to an older release:
+     {{.Prompt}} {{.HelpName}} --version RELEASE.2024-10-02T08-27-28Z
+
+  6. Update mc to the latest experimental release:
+     {{.Prompt}} {{.HelpName}} --channel experimental
+
+  7. Update mc from a local mirror of the mc releases:
+     {{.Prompt}} MC_UPDATE_URL=https://mirror.example.com/client/mc/ {{.HelpName}}
+
+  8. Update mc in an air-gapped site from a release copied along with its .sha256sum and .minisig files:
+     {{.Prompt}} {{.HelpName}} --offline ./mc.RELEASE.2024-10-02T08-27-28Z
 `,
 }
 
 const (
 	mcReleaseTagTimeLayout = "2006-01-02T15-04-05Z"
 	mcOSARCH               = runtime.GOOS + "-" + runtime.GOARCH
-	mcReleaseURL           = "https://dl.min.io/client/mc/release/" + mcOSARCH + "/"
+	mcDownloadURL          = "https://dl.min.io/client/mc/"
+	mcReleaseURL           = mcDownloadURL + "release/" + mcOSARCH + "/"
+
+	mcChannelStable       = "stable"
+	mcChannelExperimental = "experimental"
 
 	envMinisignPubKey = "MC_UPDATE_MINISIGN_PUBKEY"
+	envUpdateURL      = "MC_UPDATE_URL"
+
+	// mcReleasePublicKey is the minisign public key the official
+	// releases are signed with.
+	mcReleasePublicKey = "RWTx5Zr1tiHQLwG9keckT0c45M3AGeHD6IvimQHpyRywVWGbP1aVSGav"
 )
 
+// updatePublicKey returns the minisign public key to verify the releases
+// with, releases are never installed without verifying their signature.
+func updatePublicKey(ctx *cli.Context) string {
+	if pubkey := ctx.String("minisign-pubkey"); pubkey != "" {
+		return pubkey
+	}
+	return mcReleasePublicKey
+}
+
 // For windows our files have .exe additionally.
 var mcReleaseWindowsInfoURL = mcReleaseURL + "mc.exe.sha256sum"
 
+// mcChannelPaths maps the release channels to their download path.
+var mcChannelPaths = map[string]string{
+	mcChannelStable:       "release",
+	mcChannelExperimental: "experimental",
+}
+
+// getChannelURL returns the download URL of a release channel, on the
+// update mirror set with MC_UPDATE_URL if any.
+func getChannelURL(channel string) (channelURL string, ok bool) {
+	channelPath, ok := mcChannelPaths[channel]
+	if !ok {
+		return "", false
+	}
+	downloadURL := mcDownloadURL
+	if mirrorURL := env.Get(envUpdateURL, ""); mirrorURL != "" {
+		downloadURL = strings.TrimSuffix(mirrorURL, "/") + "/"
+	}
+	return downloadURL + channelPath + "/" + mcOSARCH + "/", true
+}
+
+// releaseInfoURL returns the URL of the latest release info of a channel,
+// empty for the default stable channel.
+func releaseInfoURL(channelURL string) string {
+	if channelURL == mcReleaseURL {
+		return ""
+	}
+	infoURL := mcReleaseInfoURL
+	if runtime.GOOS == "windows" {
+		infoURL = mcReleaseWindowsInfoURL
+	}
+	return channelURL + path.Base(infoURL)
+}
+
+// pinnedReleaseInfoURL returns the URL of the release info of a given
+// release tag, found in the archive of a channel.
+func pinnedReleaseInfoURL(channelURL, releaseTag string) string {
+	return channelURL + "archive/mc." + releaseTag + ".sha256sum"
+}
+
+var releaseTagRegexp = regexp.MustCompile(`mc\.(RELEASE\.\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}Z)`)
+
+// parseReleaseIndex returns the release tags found in the archive index
+// page of a channel, newest first.
+func parseReleaseIndex(index string) (releaseTags []string) {
+	seen := make(map[string]bool)
+	for _, m := range releaseTagRegexp.FindAllStringSubmatch(index, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		releaseTags = append(releaseTags, m[1])
+	}
+	// Release tags sort by their release time.
+	sort.Sort(sort.Reverse(sort.StringSlice(releaseTags)))
+	return releaseTags
+}
+
+// releaseListMessage is an available release.
+type releaseListMessage struct {
+	Status     string    `json:"status"`
+	Release    string    `json:"release"`
+	ReleasedAt time.Time `json:"releasedAt"`
+	Current    bool      `json:"current"`
+}
+
+func (r releaseListMessage) String() string {
+	if r.Current {
+		return colorGreenBold("%s (current)", r.Release)
+	}
+	return r.Release
+}
+
+func (r releaseListMessage) JSON() string {
+	r.Status = "success"
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// listReleases prints the releases available in a channel.
+func listReleases(channelURL string, timeout time.Duration) *probe.Error {
+	index, err := downloadReleaseURL(channelURL+"archive/", timeout)
+	if err != nil {
+		return err.Trace(channelURL)
+	}
+	for _, releaseTag := range parseReleaseIndex(index) {
+		releaseTime, err := releaseTagToReleaseTime(releaseTag)
+		if err != nil {
+			continue
+		}
+		printMsg(releaseListMessage{
+			Release:    releaseTag,
+			ReleasedAt: releaseTime,
+			Current:    releaseTag == ReleaseTag,
+		})
+	}
+	return nil
+}
+
 // mcVersionToReleaseTime - parses a standard official release
 // mc --version string.
 //
@@ -423,21 +602,15 @@ func getUpdateReaderFromURL(u *url.URL, transport http.RoundTripper) (io.ReadClo
 	return newProgressReader(resp.Body, "mc", resp.ContentLength), nil
 }
 
-func doUpdate(customReleaseURL, sha256Hex string, latestReleaseTime time.Time, releaseTag string, ok bool) (updateStatusMsg string, err *probe.Error) {
-	fmtReleaseTime := latestReleaseTime.Format(mcReleaseTagTimeLayout)
+func doUpdate(customReleaseURL, sha256Hex, minisignPubkey string, latestReleaseTime time.Time, releaseTag string, ok bool) (updateStatusMsg string, err *probe.Error) {
 	if !ok {
 		updateStatusMsg = colorGreenBold("mc update to version %s canceled.",
 			releaseTag)
 		return updateStatusMsg, nil
 	}
 
-	sha256Sum, e := hex.DecodeString(sha256Hex)
-	if e != nil {
-		return updateStatusMsg, probe.NewError(e)
-	}
-
 	u, e := url.Parse(getDownloadURL(customReleaseURL, releaseTag))
-	if err != nil {
+	if e != nil {
 		return updateStatusMsg, probe.NewError(e)
 	}
 
@@ -449,19 +622,48 @@ func doUpdate(customReleaseURL, sha256Hex string, latestReleaseTime time.Time, r
 	}
 	defer rc.Close()
 
+	verifier := selfupdate.NewVerifier()
+	u.Path = path.Dir(u.Path) + "/mc." + releaseTag + ".minisig"
+	if e = verifier.LoadFromURL(u.String(), minisignPubkey, transport); e != nil {
+		return updateStatusMsg, probe.NewError(e)
+	}
+
+	return applyUpdate(rc, sha256Hex, verifier, latestReleaseTime)
+}
+
+// doOfflineUpdate replaces the running binary with the release at
+// binPath, verified against the checksum of its .sha256sum file and the
+// signature of its .minisig file. The checksum is copied along with the
+// binary, the signature is what authenticates the release.
+func doOfflineUpdate(binPath, sha256Hex, minisignPubkey string, releaseTime time.Time) (updateStatusMsg string, err *probe.Error) {
+	f, e := os.Open(binPath)
+	if e != nil {
+		return updateStatusMsg, probe.NewError(e)
+	}
+	defer f.Close()
+
+	verifier := selfupdate.NewVerifier()
+	if e = verifier.LoadFromFile(binPath+".minisig", minisignPubkey); e != nil {
+		return updateStatusMsg, probe.NewError(e)
+	}
+
+	return applyUpdate(f, sha256Hex, verifier, releaseTime)
+}
+
+// applyUpdate verifies the release read from r and replaces the running
+// binary with it.
+func applyUpdate(r io.Reader, sha256Hex string, verifier *selfupdate.Verifier, latestReleaseTime time.Time) (updateStatusMsg string, err *probe.Error) {
+	fmtReleaseTime := latestReleaseTime.Format(mcReleaseTagTimeLayout)
+
+	sha256Sum, e := hex.DecodeString(sha256Hex)
+	if e != nil {
+		return updateStatusMsg, probe.NewError(e)
+	}
+
 	opts := selfupdate.Options{
 		Hash:     crypto.SHA256,
 		Checksum: sha256Sum,
-	}
-
-	minisignPubkey := env.Get(envMinisignPubKey, "")
-	if minisignPubkey != "" {
-		v := selfupdate.NewVerifier()
-		u.Path = path.Dir(u.Path) + "/mc." + releaseTag + ".minisig"
-		if e = v.LoadFromURL(u.String(), minisignPubkey, transport); e != nil {
-			return updateStatusMsg, probe.NewError(e)
-		}
-		opts.Verifier = v
+		Verifier: verifier,
 	}
 
 	if e := opts.CheckPermissions(); e != nil {
@@ -471,7 +673,7 @@ func doUpdate(customReleaseURL, sha256Hex string, latestReleaseTime time.Time, r
 		return updateStatusMsg, nil
 	}
 
-	if e = selfupdate.Apply(rc, opts); e != nil {
+	if e = selfupdate.Apply(r, opts); e != nil {
 		if re := selfupdate.RollbackError(e); re != nil {
 			rollBackErr := fmt.Sprintf("Failed to rollback from bad update: %v", re)
 			updateStatusMsg = colorYellowBold("mc update to version RELEASE.%s %s.", fmtReleaseTime, rollBackErr)
@@ -486,7 +688,9 @@ func doUpdate(customReleaseURL, sha256Hex string, latestReleaseTime time.Time, r
 			return updateStatusMsg, nil
 		}
 
-		return colorYellowBold(fmt.Sprintf("Error in mc update to version RELEASE.%s %v.", fmtReleaseTime, e)), nil
+		// The downloaded binary failed the checksum or signature
+		// verification, the running binary is left untouched.
+		return updateStatusMsg, probe.NewError(e)
 	}
 
 	return colorGreenBold("mc updated to version RELEASE.%s successfully.", fmtReleaseTime), nil
@@ -511,6 +715,40 @@ func (s updateMessage) JSON() string {
 	return string(updateJSONBytes)
 }
 
+// updateOffline updates mc from a release bundle copied to binPath and
+// exits, for sites without Internet access.
+func updateOffline(binPath, minisignPubkey string, apply bool) {
+	data, e := os.ReadFile(binPath + ".sha256sum")
+	fatalIf(probe.NewError(e).Trace(binPath), "Unable to read the release checksum of `%s`.", binPath)
+
+	sha256Hex, releaseTime, releaseTag, err := parseReleaseData(string(data))
+	fatalIf(err.Trace(binPath), "Unable to read the release checksum of `%s`.", binPath)
+
+	if releaseTag == ReleaseTag {
+		printMsg(updateMessage{
+			Status:  "success",
+			Message: colorGreenBold("You are already running ‘mc’ %s.", releaseTag),
+		})
+		os.Exit(0)
+	}
+
+	printMsg(updateMessage{
+		Status:  "success",
+		Message: colorYellowBold("Found release %s at %s.", releaseTag, binPath),
+	})
+	if !apply || IsDocker() {
+		os.Exit(0)
+	}
+
+	updateStatusMsg, err := doOfflineUpdate(binPath, sha256Hex, minisignPubkey, releaseTime)
+	if err != nil {
+		errorIf(err, "Unable to update ‘mc’.")
+		os.Exit(-1)
+	}
+	printMsg(updateMessage{Status: "success", Message: updateStatusMsg})
+	os.Exit(1)
+}
+
 func mainUpdate(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, -1)
@@ -520,6 +758,39 @@ func mainUpdate(ctx *cli.Context) {
 	globalJSON = ctx.Bool("json") || ctx.GlobalBool("json")
 
 	customReleaseURL := ctx.Args().Get(0)
+	apply := !ctx.Bool("check") && !ctx.Bool("dry-run") && !ctx.GlobalBool("dry-run")
+
+	if binPath := ctx.String("offline"); binPath != "" {
+		updateOffline(binPath, updatePublicKey(ctx), apply)
+	}
+
+	channel := ctx.String("channel")
+	channelURL, ok := getChannelURL(channel)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(channel), "Unknown release channel `%s`, use 'stable' or 'experimental'.", channel)
+	}
+
+	if ctx.Bool("list") {
+		if err := listReleases(channelURL, 10*time.Second); err != nil {
+			errorIf(err, "Unable to list the ‘mc’ releases.")
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	}
+
+	pinnedTag := ctx.String("version")
+	switch {
+	case pinnedTag != "":
+		if !strings.HasPrefix(pinnedTag, "RELEASE.") {
+			pinnedTag = "RELEASE." + pinnedTag
+		}
+		if _, err := releaseTagToReleaseTime(pinnedTag); err != nil {
+			fatalIf(errInvalidArgument().Trace(pinnedTag), "Invalid release `%s`.", ctx.String("version"))
+		}
+		customReleaseURL = pinnedReleaseInfoURL(channelURL, pinnedTag)
+	case customReleaseURL == "":
+		customReleaseURL = releaseInfoURL(channelURL)
+	}
 
 	updateMsg, sha256Hex, _, latestReleaseTime, releaseTag, err := getUpdateInfo(customReleaseURL, 10*time.Second)
 	if err != nil {
@@ -527,6 +798,12 @@ func mainUpdate(ctx *cli.Context) {
 		os.Exit(-1)
 	}
 
+	// A pinned release may be older than the running one, install it
+	// as well to roll back.
+	if pinnedTag != "" && updateMsg == "" && releaseTag != ReleaseTag {
+		updateMsg = colorYellowBold("Release %s is older than the running release %s.", releaseTag, ReleaseTag)
+	}
+
 	// Nothing to update running the latest release.
 	color.New(color.FgGreen, color.Bold)
 	if updateMsg == "" {
@@ -542,11 +819,23 @@ func mainUpdate(ctx *cli.Context) {
 		Message: updateMsg,
 	})
 
+	// Only check for updates with --check, a docker image is
+	// updated by pulling the new image instead.
+	if !apply || IsDocker() {
+		if !apply && !IsDocker() && !globalJSON {
+			printMsg(updateMessage{
+				Status:  "success",
+				Message: colorYellowBold("Run `mc update` to install it."),
+			})
+		}
+		os.Exit(0)
+	}
+
 	// Avoid updating mc development, source builds.
 	if updateMsg != "" {
 		var updateStatusMsg string
 		var err *probe.Error
-		updateStatusMsg, err = doUpdate(customReleaseURL, sha256Hex, latestReleaseTime, releaseTag, true)
+		updateStatusMsg, err = doUpdate(customReleaseURL, sha256Hex, updatePublicKey(ctx), latestReleaseTime, releaseTag, true)
 		if err != nil {
 			errorIf(err, "Unable to update ‘mc’.")
 			os.Exit(-1)
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/shlex"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// watchExecutor runs a command line for every received event, with
+// at most a fixed number of commands running at the same time.
+type watchExecutor struct {
+	args []string
+	sem  chan struct{}
+	wg   sync.WaitGroup
+
+	succeeded atomic.Int64
+	failed    atomic.Int64
+}
+
+// newWatchExecutor parses the command line and returns an executor
+// running up to 'parallel' commands concurrently.
+func newWatchExecutor(cmdline string, parallel int) (*watchExecutor, *probe.Error) {
+	args, e := shlex.Split(cmdline)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if len(args) == 0 {
+		return nil, errInvalidArgument().Trace(cmdline)
+	}
+	if parallel <= 0 {
+		parallel = 1
+	}
+	return &watchExecutor{
+		args: args,
+		sem:  make(chan struct{}, parallel),
+	}, nil
+}
+
+// watchExecReplace substitutes the event fields in a single command argument.
+func watchExecReplace(arg, target string, msg watchMessage) string {
+	return strings.NewReplacer(
+		"{url}", msg.Event.Path,
+		"{event}", string(msg.Event.Type),
+		"{size}", strconv.FormatInt(msg.Event.Size, 10),
+		"{time}", msg.Event.Time,
+		"{target}", target,
+	).Replace(arg)
+}
+
+// Run executes the command for an event received on target, blocking
+// while the maximum number of commands are already running. done, if
+// not nil, is called once the command exited, successfully or not.
+func (w *watchExecutor) Run(target string, msg watchMessage, done func()) {
+	args := make([]string, len(w.args))
+	for i, arg := range w.args {
+		args[i] = watchExecReplace(arg, target, msg)
+	}
+
+	w.sem <- struct{}{}
+	w.wg.Add(1)
+	go func() {
+		defer func() {
+			if done != nil {
+				done()
+			}
+			<-w.sem
+			w.wg.Done()
+		}()
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if e := cmd.Run(); e != nil {
+			w.failed.Add(1)
+			if stderr.Len() > 0 {
+				e = fmt.Errorf("%w: %s", e, strings.TrimSpace(stderr.String()))
+			}
+			errorIf(probe.NewError(e).Trace(args...), "Unable to execute command for `%s`.", msg.Event.Path)
+			return
+		}
+		w.succeeded.Add(1)
+		if !globalJSON && stdout.Len() > 0 {
+			console.PrintC(stdout.String())
+		}
+	}()
+}
+
+// Wait waits for all the running commands to finish and
+// returns the summary of executed commands.
+func (w *watchExecutor) Wait() watchExecMessage {
+	w.wg.Wait()
+	succeeded, failed := w.succeeded.Load(), w.failed.Load()
+	return watchExecMessage{
+		Total:     succeeded + failed,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+}
+
+// watchExecMessage container for the summary of commands executed on events
+type watchExecMessage struct {
+	Status    string `json:"status"`
+	Total     int64  `json:"total"`
+	Succeeded int64  `json:"succeeded"`
+	Failed    int64  `json:"failed"`
+}
+
+func (m watchExecMessage) JSON() string {
+	m.Status = "success"
+	if m.Failed > 0 {
+		m.Status = "error"
+	}
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m watchExecMessage) String() string {
+	return console.Colorize("ExecSummary", fmt.Sprintf("Executed %d command(s), %d succeeded, %d failed.", m.Total, m.Succeeded, m.Failed))
+}
@@ -20,8 +20,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"text/template"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -38,18 +42,52 @@ var watchFlags = []cli.Flag{
 		Value: "put,delete,get",
 		Usage: "filter specific types of events; defaults to all events by default",
 	},
-	cli.StringFlag{
+	cli.StringSliceFlag{
 		Name:  "prefix",
-		Usage: "filter events for a prefix",
+		Usage: "filter events for a prefix, can be specified multiple times",
 	},
-	cli.StringFlag{
+	cli.StringSliceFlag{
 		Name:  "suffix",
-		Usage: "filter events for a suffix",
+		Usage: "filter events for a suffix, can be specified multiple times",
 	},
 	cli.BoolFlag{
 		Name:  "recursive",
 		Usage: "recursively watch for events",
 	},
+	cli.StringFlag{
+		Name:  "exec",
+		Usage: "spawn an external process for each event, substituting {url}, {event}, {size}, {time} and {target}",
+	},
+	cli.IntFlag{
+		Name:  "exec-parallel",
+		Value: 4,
+		Usage: "maximum number of --exec processes running at the same time",
+	},
+	cli.StringFlag{
+		Name:  "state-file",
+		Usage: "persist the last processed event in a file to skip already processed events on restart",
+	},
+	cli.BoolFlag{
+		Name:  "replay",
+		Usage: "on startup, report objects created since the last event recorded in --state-file (MinIO server only)",
+	},
+	cli.StringSliceFlag{
+		Name:  "sink",
+		Usage: "publish each event as JSON to a sink of the form TYPE=ENDPOINT, where TYPE is 'webhook' or 'file'",
+	},
+	cli.IntFlag{
+		Name:  "sink-retries",
+		Value: 3,
+		Usage: "number of times to retry publishing an event to a sink",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "print each event using a Go template, e.g. '{{.Time}} {{.EventType}} {{.Key}} {{.Size}}'",
+	},
+	cli.BoolFlag{
+		Name:  "metadata",
+		Usage: "include object user metadata in the events",
+	},
 }
 
 var watchCmd = cli.Command{
@@ -63,11 +101,15 @@ var watchCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] TARGET
+  {{.HelpName}} [FLAGS] TARGET [TARGET...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+FORMAT:
+  Templates passed to --format can use the fields .Time, .EventType, .Target, .Path,
+  .Bucket, .Key, .Size, .Host, .Port, .UserAgent and .Metadata (requires --metadata).
+
 EXAMPLES:
   1. Watch new S3 operations on a MinIO server
      {{.Prompt}} {{.HelpName}} play/testbucket
@@ -86,30 +128,99 @@ EXAMPLES:
 
   6. Watch for events on local directory.
      {{.Prompt}} {{.HelpName}} /usr/share
+
+  7. Watch new events on multiple buckets in a single stream.
+     {{.Prompt}} {{.HelpName}} play/testbucket play/otherbucket
+
+  8. Watch new events for several prefixes and suffixes on MinIO server.
+     {{.Prompt}} {{.HelpName}} --prefix "photos/" --prefix "videos/" --suffix ".jpg" --suffix ".mp4" play/testbucket
+
+  9. Generate a thumbnail for each new image, running at most 8 conversions at a time.
+     {{.Prompt}} {{.HelpName}} --events put --suffix ".jpg" --exec "./thumbnail.sh {url}" --exec-parallel 8 play/testbucket
+
+  10. Resume watching from the last processed event, replaying objects created while watch was stopped.
+     {{.Prompt}} {{.HelpName}} --state-file ~/watch-state.json --replay play/testbucket
+
+  11. Forward all events to a webhook and keep a local copy in a file.
+     {{.Prompt}} {{.HelpName}} --sink webhook=https://hooks.example.com/minio --sink file=/var/log/mc-events.json play/testbucket
+
+  12. Print only the event type, object key and its 'content-owner' user metadata for each event.
+     {{.Prompt}} {{.HelpName}} --metadata --format '{{.EventType}} {{.Key}} {{index .Metadata "X-Amz-Meta-Content-Owner"}}' play/testbucket
 `,
 }
 
 // checkWatchSyntax - validate all the passed arguments
 func checkWatchSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
+	if len(ctx.Args()) < 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Bool("replay") && ctx.String("state-file") == "" {
+		fatalIf(errInvalidArgument().Trace(), "--replay requires --state-file.")
+	}
 }
 
 // watchMessage container to hold one event notification
 type watchMessage struct {
 	Status string `json:"status"`
+	Target string `json:"target,omitempty"`
 	Event  struct {
-		Time string                 `json:"time"`
-		Size int64                  `json:"size"`
-		Path string                 `json:"path"`
-		Type notification.EventType `json:"type"`
+		Time         string                 `json:"time"`
+		Size         int64                  `json:"size"`
+		Path         string                 `json:"path"`
+		Type         notification.EventType `json:"type"`
+		UserMetadata map[string]string      `json:"userMetadata,omitempty"`
 	} `json:"events"`
 	Source struct {
 		Host      string `json:"host,omitempty"`
 		Port      string `json:"port,omitempty"`
 		UserAgent string `json:"userAgent,omitempty"`
 	} `json:"source,omitempty"`
+
+	// format is the optional template used to print the message.
+	format *template.Template
+}
+
+// watchTemplateData holds the event fields available to --format templates.
+type watchTemplateData struct {
+	Time      string
+	EventType string
+	Target    string
+	Path      string
+	Bucket    string
+	Key       string
+	Size      int64
+	Host      string
+	Port      string
+	UserAgent string
+	Metadata  map[string]string
+}
+
+// watchEventBucketKey returns the bucket and the object key of an event path,
+// local filesystem events have no bucket and use their path as the key.
+func watchEventBucketKey(eventPath string) (bucket, key string) {
+	u := newClientURL(eventPath)
+	if u.Type != objectStorage {
+		return "", eventPath
+	}
+	bucket, key, _ = strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	return bucket, key
+}
+
+func (u watchMessage) templateData() watchTemplateData {
+	bucket, key := watchEventBucketKey(u.Event.Path)
+	return watchTemplateData{
+		Time:      u.Event.Time,
+		EventType: string(u.Event.Type),
+		Target:    u.Target,
+		Path:      u.Event.Path,
+		Bucket:    bucket,
+		Key:       key,
+		Size:      u.Event.Size,
+		Host:      u.Source.Host,
+		Port:      u.Source.Port,
+		UserAgent: u.Source.UserAgent,
+		Metadata:  u.Event.UserMetadata,
+	}
 }
 
 func (u watchMessage) JSON() string {
@@ -120,7 +231,17 @@ func (u watchMessage) JSON() string {
 }
 
 func (u watchMessage) String() string {
+	if u.format != nil {
+		var buf strings.Builder
+		if e := u.format.Execute(&buf, u.templateData()); e != nil {
+			fatalIf(probe.NewError(e), "Unable to format the event.")
+		}
+		return buf.String()
+	}
 	msg := console.Colorize("Time", fmt.Sprintf("[%s] ", u.Event.Time))
+	if u.Target != "" {
+		msg += console.Colorize("Target", fmt.Sprintf("%s ", u.Target))
+	}
 	if strings.HasPrefix(string(u.Event.Type), "s3:ObjectCreated:") {
 		msg += console.Colorize("Size", fmt.Sprintf("%6s ", humanize.IBytes(uint64(u.Event.Size))))
 	} else {
@@ -131,87 +252,273 @@ func (u watchMessage) String() string {
 	return msg
 }
 
+// watchPrefixes returns the list of prefixes to open a notification
+// stream for. Prefixes already covered by a shorter prefix are dropped
+// so that overlapping filters do not report the same event twice.
+func watchPrefixes(prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return []string{""}
+	}
+	sorted := make([]string, len(prefixes))
+	copy(sorted, prefixes)
+	sort.Strings(sorted)
+
+	uniq := []string{}
+	for _, prefix := range sorted {
+		if len(uniq) > 0 && strings.HasPrefix(prefix, uniq[len(uniq)-1]) {
+			continue
+		}
+		uniq = append(uniq, prefix)
+	}
+	return uniq
+}
+
+// matchWatchSuffix returns true if the path ends with any of the given suffixes.
+func matchWatchSuffix(path string, suffixes []string) bool {
+	if len(suffixes) == 0 {
+		return true
+	}
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchEvents is a batch of events along with the target it was received from.
+type watchEvents struct {
+	target string
+	events []EventInfo
+}
+
 func mainWatch(cliCtx *cli.Context) error {
 	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Target", color.New(color.FgMagenta))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("EventType", color.New(color.FgCyan, color.Bold))
 	console.SetColor("ObjectName", color.New(color.Bold))
+	console.SetColor("ExecSummary", color.New(color.FgGreen, color.Bold))
 
 	checkWatchSyntax(cliCtx)
 
-	args := cliCtx.Args()
-	path := args[0]
+	targets := cliCtx.Args()
 
-	prefix := cliCtx.String("prefix")
-	suffix := cliCtx.String("suffix")
+	prefixes := watchPrefixes(cliCtx.StringSlice("prefix"))
+	suffixes := cliCtx.StringSlice("suffix")
 	events := strings.Split(cliCtx.String("events"), ",")
 	recursive := cliCtx.Bool("recursive")
 
-	s3Client, pErr := newClient(path)
-	if pErr != nil {
-		fatalIf(pErr.Trace(), "Unable to parse the provided url.")
+	// A single suffix can be filtered on the server, multiple
+	// suffixes are matched locally on the received events.
+	var suffix string
+	if len(suffixes) == 1 {
+		suffix = suffixes[0]
 	}
 
-	options := WatchOptions{
-		Recursive: recursive,
-		Events:    events,
-		Prefix:    prefix,
-		Suffix:    suffix,
+	var executor *watchExecutor
+	if cmdline := cliCtx.String("exec"); cmdline != "" {
+		var err *probe.Error
+		executor, err = newWatchExecutor(cmdline, cliCtx.Int("exec-parallel"))
+		fatalIf(err.Trace(cmdline), "Unable to parse --exec.")
+		// Print the summary of executed commands when interrupted.
+		defer holdSignalExit()()
+	}
+
+	var format *template.Template
+	if formatStr := cliCtx.String("format"); formatStr != "" {
+		var e error
+		format, e = template.New("watch").Option("missingkey=zero").Parse(formatStr)
+		if e == nil {
+			// Catch references to unknown fields before receiving any event.
+			e = format.Execute(io.Discard, watchTemplateData{})
+		}
+		fatalIf(probe.NewError(e), "Unable to parse --format.")
+	}
+	withMetadata := cliCtx.Bool("metadata")
+
+	var sinks []watchSink
+	for _, spec := range cliCtx.StringSlice("sink") {
+		sink, err := newWatchSink(spec)
+		fatalIf(err.Trace(spec), "Unable to initialize the sink.")
+		sinks = append(sinks, sink)
+	}
+	defer func() {
+		for _, sink := range sinks {
+			sink.Close()
+		}
+	}()
+	sinkRetries := cliCtx.Int("sink-retries")
+
+	var state *watchState
+	if stateFile := cliCtx.String("state-file"); stateFile != "" {
+		var err *probe.Error
+		state, err = loadWatchState(stateFile)
+		fatalIf(err.Trace(stateFile), "Unable to load the watch state.")
 	}
 
 	ctx, cancelWatch := context.WithCancel(globalContext)
 	defer cancelWatch()
 
-	// Start watching on events
-	wo, err := s3Client.Watch(ctx, options)
-	fatalIf(err, "Unable to watch on the specified bucket.")
+	var publisher *watchPublisher
+	if len(sinks) > 0 {
+		publisher = newWatchPublisher(ctx, sinks, sinkRetries)
+		defer publisher.Close()
+	}
+
+	eventsCh := make(chan watchEvents)
+	errorsCh := make(chan *probe.Error)
 
-	// Initialize.. waitgroup to track the go-routine.
+	var watchers []*WatchObject
 	var wg sync.WaitGroup
+	for _, target := range targets {
+		s3Client, pErr := newClient(target)
+		if pErr != nil {
+			fatalIf(pErr.Trace(target), "Unable to parse the provided url.")
+		}
 
-	// Increment wait group to wait subsequent routine.
-	wg.Add(1)
+		for _, prefix := range prefixes {
+			options := WatchOptions{
+				Recursive: recursive,
+				Events:    events,
+				Prefix:    prefix,
+				Suffix:    suffix,
+			}
 
-	// Start routine to watching on events.
-	go func() {
-		defer wg.Done()
-
-		// Wait for all events.
-		for {
-			select {
-			case <-globalContext.Done():
-				// Signal received we are done.
-				close(wo.DoneChan)
-				return
-			case events, ok := <-wo.Events():
-				if !ok {
-					return
-				}
-				for _, event := range events {
-					msg := watchMessage{}
-					msg.Event.Path = event.Path
-					msg.Event.Size = event.Size
-					msg.Event.Time = event.Time
-					msg.Event.Type = event.Type
-					msg.Source.Host = event.Host
-					msg.Source.Port = event.Port
-					msg.Source.UserAgent = event.UserAgent
-					printMsg(msg)
-				}
-			case err, ok := <-wo.Errors():
-				if !ok {
-					return
-				}
-				if err != nil {
-					errorIf(err, "Unable to watch for events.")
-					return
+			// Start watching on events
+			wo, err := s3Client.Watch(ctx, options)
+			fatalIf(err.Trace(target), "Unable to watch on the specified bucket.")
+			watchers = append(watchers, wo)
+
+			// Forward events and errors of this stream to the combined channels.
+			wg.Add(1)
+			go func(target string, wo *WatchObject) {
+				defer wg.Done()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case events, ok := <-wo.Events():
+						if !ok {
+							return
+						}
+						select {
+						case eventsCh <- watchEvents{target: target, events: events}:
+						case <-ctx.Done():
+							return
+						}
+					case err, ok := <-wo.Errors():
+						if !ok {
+							return
+						}
+						select {
+						case errorsCh <- err.Trace(target):
+						case <-ctx.Done():
+							return
+						}
+					}
 				}
-			}
+			}(target, wo)
 		}
+	}
+
+	// Close the combined events channel once all the streams are done.
+	go func() {
+		wg.Wait()
+		close(eventsCh)
 	}()
 
-	// Wait on the routine to be finished or exit.
-	wg.Wait()
+	defer func() {
+		for _, wo := range watchers {
+			close(wo.DoneChan)
+		}
+	}()
 
+	// handleEvent reports the event and calls done once it is fully
+	// handled, published to the sinks and processed by --exec.
+	handleEvent := func(target string, event EventInfo, done func()) {
+		if done == nil {
+			done = func() {}
+		}
+		if publisher != nil && executor != nil {
+			done = watchDoneAfter(2, done)
+		}
+		if !matchWatchSuffix(event.Path, suffixes) {
+			done()
+			return
+		}
+		msg := watchMessage{format: format}
+		if len(targets) > 1 {
+			msg.Target = target
+		}
+		msg.Event.Path = event.Path
+		msg.Event.Size = event.Size
+		msg.Event.Time = event.Time
+		msg.Event.Type = event.Type
+		if withMetadata {
+			msg.Event.UserMetadata = event.UserMetadata
+		}
+		msg.Source.Host = event.Host
+		msg.Source.Port = event.Port
+		msg.Source.UserAgent = event.UserAgent
+		printMsg(msg)
+		if publisher != nil {
+			publisher.Publish(msg, done)
+		}
+		if executor != nil {
+			executor.Run(target, msg, done)
+		}
+		if publisher == nil && executor == nil {
+			done()
+		}
+	}
+
+	// Only object creations are replayed, skip replay if they are not watched.
+	if cliCtx.Bool("replay") && slices.Contains(events, "put") {
+		for _, target := range targets {
+			replayed, err := replayWatchEvents(ctx, state, target, prefixes)
+			fatalIf(err.Trace(target), "Unable to replay events.")
+			for _, event := range replayed {
+				handleEvent(target, event, nil)
+			}
+		}
+	}
+
+	// Wait for all events.
+loop:
+	for {
+		select {
+		case <-globalContext.Done():
+			// Signal received we are done.
+			break loop
+		case received, ok := <-eventsCh:
+			if !ok {
+				break loop
+			}
+			for _, event := range received.events {
+				var done func()
+				if state != nil {
+					if state.Seen(received.target, event) {
+						continue
+					}
+					done = state.Track(received.target, event)
+				}
+				handleEvent(received.target, event, done)
+			}
+		case err := <-errorsCh:
+			if err != nil {
+				errorIf(err, "Unable to watch for events.")
+				break loop
+			}
+		}
+	}
+
+	if executor != nil {
+		summary := executor.Wait()
+		printMsg(summary)
+		if summary.Failed > 0 {
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,232 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+)
+
+func TestWatchPrefixes(t *testing.T) {
+	testCases := []struct {
+		prefixes []string
+		expected []string
+	}{
+		{nil, []string{""}},
+		{[]string{"photos/"}, []string{"photos/"}},
+		{[]string{"videos/", "photos/"}, []string{"photos/", "videos/"}},
+		// Nested prefixes are covered by their parent.
+		{[]string{"photos/2024/", "photos/"}, []string{"photos/"}},
+		// An empty prefix covers everything.
+		{[]string{"photos/", ""}, []string{""}},
+	}
+
+	for i, testCase := range testCases {
+		got := watchPrefixes(testCase.prefixes)
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestMatchWatchSuffix(t *testing.T) {
+	testCases := []struct {
+		path     string
+		suffixes []string
+		match    bool
+	}{
+		{"play/bucket/a.jpg", nil, true},
+		{"play/bucket/a.jpg", []string{".jpg"}, true},
+		{"play/bucket/a.jpg", []string{".png", ".jpg"}, true},
+		{"play/bucket/a.mp4", []string{".png", ".jpg"}, false},
+	}
+
+	for i, testCase := range testCases {
+		if got := matchWatchSuffix(testCase.path, testCase.suffixes); got != testCase.match {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.match, got)
+		}
+	}
+}
+
+func TestWatchExecReplace(t *testing.T) {
+	msg := watchMessage{}
+	msg.Event.Path = "http://localhost:9000/bucket/photo.jpg"
+	msg.Event.Type = "s3:ObjectCreated:Put"
+	msg.Event.Size = 1024
+	msg.Event.Time = "2024-01-02T03:04:05.000Z"
+
+	testCases := []struct {
+		arg      string
+		expected string
+	}{
+		{"{url}", "http://localhost:9000/bucket/photo.jpg"},
+		{"--event={event}", "--event=s3:ObjectCreated:Put"},
+		{"{size}:{time}", "1024:2024-01-02T03:04:05.000Z"},
+		{"{target}", "play/bucket"},
+		{"static", "static"},
+	}
+
+	for i, testCase := range testCases {
+		if got := watchExecReplace(testCase.arg, "play/bucket", msg); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestWatchState(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.json")
+	state, err := loadWatchState(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := EventInfo{Time: "2024-01-02T03:04:05.000Z", Path: "bucket/a", Type: "s3:ObjectCreated:Put"}
+	second := EventInfo{Time: "2024-01-02T03:04:05.000Z", Path: "bucket/b", Type: "s3:ObjectCreated:Put"}
+	older := EventInfo{Time: "2024-01-02T03:04:04.000Z", Path: "bucket/c", Type: "s3:ObjectCreated:Put"}
+	newer := EventInfo{Time: "2024-01-02T03:04:06.000Z", Path: "bucket/d", Type: "s3:ObjectCreated:Put"}
+
+	state.Update("play/bucket", first)
+	if err = state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = loadWatchState(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		target string
+		event  EventInfo
+		seen   bool
+	}{
+		{"play/bucket", first, true},
+		{"play/bucket", second, false},
+		{"play/bucket", older, true},
+		{"play/bucket", newer, false},
+		{"play/other", first, false},
+	}
+	for i, testCase := range testCases {
+		if got := state.Seen(testCase.target, testCase.event); got != testCase.seen {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.seen, got)
+		}
+	}
+}
+
+func TestWatchStateTrack(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.json")
+	state, err := loadWatchState(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := EventInfo{Time: "2024-01-02T03:04:05.000Z", Path: "bucket/a", Type: "s3:ObjectCreated:Put"}
+	second := EventInfo{Time: "2024-01-02T03:04:07.000Z", Path: "bucket/b", Type: "s3:ObjectCreated:Put"}
+	late := EventInfo{Time: "2024-01-02T03:04:06.000Z", Path: "bucket/c", Type: "s3:ObjectCreated:Put"}
+
+	doneFirst := state.Track("play/bucket", first)
+	doneSecond := state.Track("play/bucket", second)
+
+	// A later event received during the run must not hide an out of order one.
+	if state.Seen("play/bucket", late) {
+		t.Fatal("expected out of order event not to be seen")
+	}
+
+	// The second event completes first, nothing is recorded until the first one completes.
+	doneSecond()
+	if ts := state.Targets["play/bucket"]; ts != nil {
+		t.Fatalf("expected no recorded event, got %v", ts.LastEventTime)
+	}
+	doneFirst()
+
+	state, err = loadWatchState(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, testCase := range []struct {
+		event EventInfo
+		seen  bool
+	}{
+		{first, true},
+		{second, true},
+	} {
+		if got := state.Seen("play/bucket", testCase.event); got != testCase.seen {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.seen, got)
+		}
+	}
+}
+
+func TestWatchMessageFormat(t *testing.T) {
+	msg := watchMessage{}
+	msg.Event.Path = "http://localhost:9000/bucket/dir/photo.jpg"
+	msg.Event.Type = "s3:ObjectCreated:Put"
+	msg.Event.Size = 1024
+	msg.Event.Time = "2024-01-02T03:04:05.000Z"
+	msg.Event.UserMetadata = map[string]string{"X-Amz-Meta-Owner": "alice"}
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"{{.Time}} {{.EventType}} {{.Key}} {{.Size}}", "2024-01-02T03:04:05.000Z s3:ObjectCreated:Put dir/photo.jpg 1024"},
+		{"{{.Bucket}}", "bucket"},
+		{`{{index .Metadata "X-Amz-Meta-Owner"}}`, "alice"},
+	}
+
+	for i, testCase := range testCases {
+		msg.format = template.Must(template.New("watch").Parse(testCase.format))
+		if got := msg.String(); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestWatchPublisher(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.json")
+	sink, err := newWatchSink("file=" + file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	publisher := newWatchPublisher(context.Background(), []watchSink{sink}, 0)
+	var done atomic.Int32
+	for _, path := range []string{"bucket/a", "bucket/b"} {
+		msg := watchMessage{}
+		msg.Event.Path = path
+		publisher.Publish(msg, func() { done.Add(1) })
+	}
+	publisher.Close()
+
+	if n := done.Load(); n != 2 {
+		t.Fatalf("expected 2 published events, got %d", n)
+	}
+	data, e := os.ReadFile(file)
+	if e != nil {
+		t.Fatal(e)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "bucket/a") || !strings.Contains(lines[1], "bucket/b") {
+		t.Fatalf("unexpected published events %q", lines)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// watchSink publishes watch events to an external destination.
+type watchSink interface {
+	// Send publishes a single JSON encoded event.
+	Send(ctx context.Context, data []byte) error
+	// Close releases the resources held by the sink.
+	Close() error
+	// String returns the sink description.
+	String() string
+}
+
+// newWatchSink creates a sink from a TYPE=ENDPOINT specification.
+func newWatchSink(spec string) (watchSink, *probe.Error) {
+	sinkType, endpoint, ok := strings.Cut(spec, "=")
+	if !ok || endpoint == "" {
+		return nil, probe.NewError(fmt.Errorf("sink `%s` must be of the form TYPE=ENDPOINT", spec))
+	}
+	switch sinkType {
+	case "webhook":
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			return nil, probe.NewError(fmt.Errorf("webhook endpoint `%s` must be an http or https URL", endpoint))
+		}
+		return &webhookSink{endpoint: endpoint, client: httpClient(10 * time.Second)}, nil
+	case "file":
+		f, e := os.OpenFile(endpoint, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		return &fileSink{f: f}, nil
+	default:
+		return nil, probe.NewError(fmt.Errorf("unsupported sink type `%s`, supported types are webhook and file", sinkType))
+	}
+}
+
+// webhookSink posts each event to an HTTP endpoint.
+type webhookSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (s *webhookSink) Send(ctx context.Context, data []byte) error {
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(data))
+	if e != nil {
+		return e
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, e := s.client.Do(req)
+	if e != nil {
+		return e
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func (s *webhookSink) String() string {
+	return "webhook=" + s.endpoint
+}
+
+// fileSink appends each event as a line of JSON to a local file.
+type fileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (s *fileSink) Send(_ context.Context, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, e := s.f.Write(append(data, '\n'))
+	return e
+}
+
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
+
+func (s *fileSink) String() string {
+	return "file=" + s.f.Name()
+}
+
+// watchSinkQueueSize is the number of events waiting to be published
+// to a sink, receiving events blocks once the queue of a sink is full.
+const watchSinkQueueSize = 1000
+
+// watchSinkEvent is an event waiting to be published.
+type watchSinkEvent struct {
+	path string
+	data []byte
+	done func()
+}
+
+// watchPublisher publishes events to the sinks in the background, each
+// sink has its own queue so that a slow sink does not delay the others.
+type watchPublisher struct {
+	queues []chan watchSinkEvent
+	wg     sync.WaitGroup
+}
+
+// newWatchPublisher starts publishing to the sinks, retrying failed
+// deliveries up to maxRetries times.
+func newWatchPublisher(ctx context.Context, sinks []watchSink, maxRetries int) *watchPublisher {
+	p := &watchPublisher{}
+	for _, sink := range sinks {
+		queue := make(chan watchSinkEvent, watchSinkQueueSize)
+		p.queues = append(p.queues, queue)
+		p.wg.Add(1)
+		go func(sink watchSink) {
+			defer p.wg.Done()
+			for event := range queue {
+				if ctx.Err() != nil {
+					// Interrupted, the event is not marked as handled.
+					continue
+				}
+				var err *probe.Error
+				newRetryManager(ctx, time.Second, maxRetries).retry(func(_ *retryManager) *probe.Error {
+					err = probe.NewError(sink.Send(ctx, event.data))
+					return err
+				})
+				errorIf(err.Trace(sink.String()), "Unable to publish event for `%s`.", event.path)
+				event.done()
+			}
+		}(sink)
+	}
+	return p
+}
+
+// Publish queues the event for all the sinks, done, if not nil, is
+// called once the event was delivered, or given up on, by every sink.
+func (p *watchPublisher) Publish(msg watchMessage, done func()) {
+	msg.Status = "success"
+	data, e := json.Marshal(msg)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	if done == nil {
+		done = func() {}
+	}
+	event := watchSinkEvent{
+		path: msg.Event.Path,
+		data: data,
+		done: watchDoneAfter(int32(len(p.queues)), done),
+	}
+	for _, queue := range p.queues {
+		queue <- event
+	}
+}
+
+// Close waits for the queued events to be published.
+func (p *watchPublisher) Close() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.wg.Wait()
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+const watchStateVersion = "1"
+
+// watchTargetState is the position of a single watched target.
+type watchTargetState struct {
+	// LastEventTime is the time of the last processed event.
+	LastEventTime time.Time `json:"lastEventTime"`
+	// LastEvents holds the events processed at LastEventTime, so
+	// that events sharing the same timestamp are not repeated.
+	LastEvents []string `json:"lastEvents,omitempty"`
+}
+
+// watchState is the persisted position of all the watched targets,
+// allowing a restarted watch to skip events already processed.
+type watchState struct {
+	Version string                       `json:"version"`
+	Targets map[string]*watchTargetState `json:"targets"`
+
+	file string
+
+	// checkpoint is the state loaded on startup, events are only
+	// skipped when they were processed by a previous run.
+	checkpoint map[string]watchTargetState
+
+	mu      sync.Mutex
+	pending map[string][]*watchPendingEvent
+}
+
+// watchPendingEvent is an event received on a target whose
+// handling has not completed yet.
+type watchPendingEvent struct {
+	event EventInfo
+	done  bool
+}
+
+// watchEventID uniquely identifies an event at a given timestamp.
+func watchEventID(event EventInfo) string {
+	return string(event.Type) + ":" + event.Path
+}
+
+// loadWatchState reads the watch state file, a missing file is
+// treated as an empty state.
+func loadWatchState(file string) (*watchState, *probe.Error) {
+	state := &watchState{
+		Version:    watchStateVersion,
+		Targets:    map[string]*watchTargetState{},
+		file:       file,
+		checkpoint: map[string]watchTargetState{},
+		pending:    map[string][]*watchPendingEvent{},
+	}
+	data, e := os.ReadFile(file)
+	if e != nil {
+		if errors.Is(e, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, probe.NewError(e)
+	}
+	if e = json.Unmarshal(data, state); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if state.Version != watchStateVersion {
+		return nil, probe.NewError(errors.New("unsupported watch state version " + state.Version))
+	}
+	if state.Targets == nil {
+		state.Targets = map[string]*watchTargetState{}
+	}
+	for target, ts := range state.Targets {
+		state.checkpoint[target] = *ts
+	}
+	return state, nil
+}
+
+// Save atomically writes the state to its file.
+func (s *watchState) Save() *probe.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.save()
+}
+
+func (s *watchState) save() *probe.Error {
+	data, e := json.MarshalIndent(s, "", " ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	tmpFile, e := os.CreateTemp(filepath.Dir(s.file), filepath.Base(s.file)+".tmp")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, e = tmpFile.Write(data); e != nil {
+		tmpFile.Close()
+		return probe.NewError(e)
+	}
+	if e = tmpFile.Sync(); e != nil {
+		tmpFile.Close()
+		return probe.NewError(e)
+	}
+	if e = tmpFile.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	return probe.NewError(os.Rename(tmpFile.Name(), s.file))
+}
+
+// Seen returns true if the event received on target was already
+// processed by a previous run.
+func (s *watchState) Seen(target string, event EventInfo) bool {
+	ts, ok := s.checkpoint[target]
+	if !ok {
+		return false
+	}
+	eventTime, e := time.Parse(time.RFC3339Nano, event.Time)
+	if e != nil {
+		return false
+	}
+	if eventTime.Before(ts.LastEventTime) {
+		return true
+	}
+	if eventTime.Equal(ts.LastEventTime) {
+		id := watchEventID(event)
+		for _, lastEvent := range ts.LastEvents {
+			if lastEvent == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Track registers an event received on target and returns the function
+// to call once the event is handled. The state only advances over events
+// whose handling completed, in the order they were received, and is saved
+// each time it advances.
+func (s *watchState) Track(target string, event EventInfo) func() {
+	pe := &watchPendingEvent{event: event}
+	s.mu.Lock()
+	s.pending[target] = append(s.pending[target], pe)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		pe.done = true
+		pending := s.pending[target]
+		var n int
+		for n < len(pending) && pending[n].done {
+			s.Update(target, pending[n].event)
+			n++
+		}
+		if n == 0 {
+			return
+		}
+		s.pending[target] = pending[n:]
+		errorIf(s.save().Trace(), "Unable to save the watch state.")
+	}
+}
+
+// watchDoneAfter returns a function calling done the n-th time it is called.
+func watchDoneAfter(n int32, done func()) func() {
+	var pending atomic.Int32
+	pending.Store(n)
+	return func() {
+		if pending.Add(-1) == 0 {
+			done()
+		}
+	}
+}
+
+// Update records the event received on target as processed.
+func (s *watchState) Update(target string, event EventInfo) {
+	eventTime, e := time.Parse(time.RFC3339Nano, event.Time)
+	if e != nil {
+		return
+	}
+	ts, ok := s.Targets[target]
+	if !ok {
+		ts = &watchTargetState{}
+		s.Targets[target] = ts
+	}
+	switch {
+	case eventTime.After(ts.LastEventTime):
+		ts.LastEventTime = eventTime
+		ts.LastEvents = []string{watchEventID(event)}
+	case eventTime.Equal(ts.LastEventTime):
+		ts.LastEvents = append(ts.LastEvents, watchEventID(event))
+	}
+}
+
+// replayWatchEvents lists the objects created under target since the
+// last processed event and returns them as put events, so that events
+// missed while watch was not running are delivered at least once.
+// Only object storage targets can be replayed, deletions are not replayed.
+// Replayed events are not recorded in the state since they may overlap
+// with the live notification stream.
+func replayWatchEvents(ctx context.Context, state *watchState, target string, prefixes []string) ([]EventInfo, *probe.Error) {
+	ts, ok := state.checkpoint[target]
+	if !ok {
+		return nil, nil
+	}
+
+	var events []EventInfo
+	for _, prefix := range prefixes {
+		urlStr := target
+		if prefix != "" {
+			urlStr = strings.TrimSuffix(target, "/") + "/" + prefix
+		}
+		clnt, err := newClient(urlStr)
+		if err != nil {
+			return nil, err.Trace(urlStr)
+		}
+		if _, ok := clnt.(*S3Client); !ok {
+			return nil, nil
+		}
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				return nil, content.Err.Trace(urlStr)
+			}
+			if content.Time.Before(ts.LastEventTime) {
+				continue
+			}
+			event := EventInfo{
+				Time: content.Time.UTC().Format(time.RFC3339Nano),
+				Size: content.Size,
+				Path: content.URL.String(),
+				Type: notification.ObjectCreatedPut,
+			}
+			if state.Seen(target, event) {
+				continue
+			}
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+	return events, nil
+}